@@ -24,6 +24,11 @@ var actionBuiltinCmd = cli.Command{
 	Short: "Manage CDS builtin action",
 }
 
+var actionRepositoryCmd = cli.Command{
+	Name:  "repository",
+	Short: "Manage CDS actions defined in repositories",
+}
+
 func action() *cobra.Command {
 	return cli.NewCommand(actionCmd, nil, []*cobra.Command{
 		cli.NewListCommand(actionListCmd, actionListRun, nil),
@@ -36,6 +41,9 @@ func action() *cobra.Command {
 			cli.NewListCommand(actionBuiltinListCmd, actionBuiltinListRun, nil),
 			cli.NewGetCommand(actionBuiltinShowCmd, actionBuiltinShowRun, nil),
 		}),
+		cli.NewCommand(actionRepositoryCmd, nil, []*cobra.Command{
+			cli.NewListCommand(actionRepositoryListCmd, actionRepositoryListRun, nil),
+		}),
 	})
 }
 
@@ -283,3 +291,47 @@ func actionBuiltinShowRun(v cli.Values) (interface{}, error) {
 
 	return newActionDisplay(*action), nil
 }
+
+var actionRepositoryListCmd = cli.Command{
+	Name:  "list",
+	Short: "List the versions of the actions defined in repositories used by a CDS project",
+	Long: `Actions defined in a repository in .cds/actions/<action-name>.yml are used in a pipeline step
+with repo@ref/action-name, where ref is a tag or a branch. List each version used by the project and
+the pipeline jobs that use it:
+
+	cdsctl action repository list MYPROJECT
+`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+}
+
+type actionRepositoryDisplay struct {
+	Action    string `cli:"action,key"`
+	Commit    string `cli:"commit"`
+	Updated   string `cli:"updated"`
+	Pipelines string `cli:"pipelines"`
+}
+
+func actionRepositoryListRun(v cli.Values) (cli.ListResult, error) {
+	ars, err := client.ActionRepositoryList(v.GetString(_ProjectKey))
+	if err != nil {
+		return nil, err
+	}
+
+	ads := make([]actionRepositoryDisplay, len(ars))
+	for i, ar := range ars {
+		jobs := make([]string, len(ar.Pipelines))
+		for j, u := range ar.Pipelines {
+			jobs[j] = fmt.Sprintf("%s/%s/%s", u.PipelineName, u.StageName, u.JobName)
+		}
+		ads[i] = actionRepositoryDisplay{
+			Action:    ar.ActionRepositoryRef().String(),
+			Commit:    ar.Commit,
+			Updated:   ar.LastModified.Format(time.RFC3339),
+			Pipelines: strings.Join(jobs, ","),
+		}
+	}
+
+	return cli.AsListResult(ads), nil
+}
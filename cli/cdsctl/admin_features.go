@@ -0,0 +1,121 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var adminFeaturesCmd = cli.Command{
+	Name:    "features",
+	Short:   "Manage CDS feature flags (when the features mode of the API is database)",
+	Aliases: []string{"feature"},
+}
+
+func adminFeatures() *cobra.Command {
+	return cli.NewCommand(adminFeaturesCmd, nil, []*cobra.Command{
+		cli.NewListCommand(adminFeatureListCmd, adminFeatureListRun, nil),
+		cli.NewGetCommand(adminFeatureShowCmd, adminFeatureShowRun, nil),
+		cli.NewCommand(adminFeatureSetCmd, adminFeatureSetRun, nil),
+		cli.NewCommand(adminFeatureDeleteCmd, adminFeatureDeleteRun, nil),
+	})
+}
+
+var adminFeatureListCmd = cli.Command{
+	Name:  "list",
+	Short: "List CDS feature flags",
+}
+
+func adminFeatureListRun(v cli.Values) (cli.ListResult, error) {
+	fs, err := client.AdminFeatureList()
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(fs), nil
+}
+
+var adminFeatureShowCmd = cli.Command{
+	Name:  "show",
+	Short: "Show a CDS feature flag",
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+}
+
+func adminFeatureShowRun(v cli.Values) (interface{}, error) {
+	return client.AdminFeatureGet(v.GetString("name"))
+}
+
+var adminFeatureSetCmd = cli.Command{
+	Name:  "set",
+	Short: "Create or update a CDS feature flag",
+	Long: `A feature is active for a project if it is enabled and if the project matches one of its rules: its key,
+one of its groups or the percentage rollout. A feature which is not in the store is active.`,
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "disabled",
+			Usage: "Disable the feature for all the projects",
+			Type:  cli.FlagBool,
+		},
+		{
+			Name:  "project-key",
+			Usage: "Keys of the projects with the feature active",
+			Type:  cli.FlagSlice,
+		},
+		{
+			Name:      "group",
+			ShortHand: "g",
+			Usage:     "Groups of the projects with the feature active",
+			Type:      cli.FlagSlice,
+		},
+		{
+			Name:    "percentage",
+			Usage:   "Percentage of the projects with the feature active",
+			Default: "0",
+			IsValid: func(s string) bool {
+				p, err := strconv.Atoi(s)
+				return err == nil && p >= 0 && p <= 100
+			},
+		},
+	},
+	Example: `enable a feature for all projects:
+
+	cdsctl admin features set cds:wnode --percentage 100
+
+enable a feature for two projects and the projects of a group:
+
+	cdsctl admin features set cds:tracing --project-key PROJ1,PROJ2 --group my-team
+	`,
+}
+
+func adminFeatureSetRun(v cli.Values) error {
+	percentage, err := strconv.Atoi(v.GetString("percentage"))
+	if err != nil {
+		return err
+	}
+	return client.AdminFeatureSet(sdk.Feature{
+		Name:        v.GetString("name"),
+		Enabled:     !v.GetBool("disabled"),
+		ProjectKeys: v.GetStringSlice("project-key"),
+		Groups:      v.GetStringSlice("group"),
+		Percentage:  percentage,
+	})
+}
+
+var adminFeatureDeleteCmd = cli.Command{
+	Name:  "delete",
+	Short: "Delete a CDS feature flag, the feature is then active for all the projects",
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+}
+
+func adminFeatureDeleteRun(v cli.Values) error {
+	return client.AdminFeatureDelete(v.GetString("name"))
+}
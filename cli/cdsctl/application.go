@@ -24,6 +24,7 @@ func application() *cobra.Command {
 		cli.NewDeleteCommand(applicationDeleteCmd, applicationDeleteRun, nil, withAllCommandModifiers()...),
 		applicationKey(),
 		applicationVariable(),
+		applicationRelease(),
 		cli.NewCommand(applicationExportCmd, applicationExportRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(applicationImportCmd, applicationImportRun, nil, withAllCommandModifiers()...),
 	})
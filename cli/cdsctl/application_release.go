@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var applicationReleaseCmd = cli.Command{
+	Name:  "release",
+	Short: "Manage CDS application releases",
+}
+
+func applicationRelease() *cobra.Command {
+	return cli.NewCommand(applicationReleaseCmd, nil, []*cobra.Command{
+		cli.NewListCommand(applicationReleaseListCmd, applicationReleaseListRun, nil, withAllCommandModifiers()...),
+		cli.NewGetCommand(applicationReleaseShowCmd, applicationReleaseShowRun, nil, withAllCommandModifiers()...),
+		cli.NewGetCommand(applicationReleasePromoteCmd, applicationReleasePromoteRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(applicationReleaseDownloadCmd, applicationReleaseDownloadRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var applicationReleaseListCmd = cli.Command{
+	Name:  "list",
+	Short: "List the releases of a CDS application, the latest version first",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _ApplicationName},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "range",
+			Usage: "Semantic version range of the releases, ie: \">=1.2.0 <2.0.0\"",
+		},
+	},
+}
+
+func applicationReleaseListRun(v cli.Values) (cli.ListResult, error) {
+	rs, err := client.ApplicationReleaseList(v.GetString(_ProjectKey), v.GetString(_ApplicationName), v.GetString("range"))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(rs), nil
+}
+
+var applicationReleaseShowCmd = cli.Command{
+	Name:  "show",
+	Short: "Show a release of a CDS application",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _ApplicationName},
+	},
+	Args: []cli.Arg{
+		{Name: "version"},
+	},
+}
+
+func applicationReleaseShowRun(v cli.Values) (interface{}, error) {
+	return client.ApplicationReleaseGet(v.GetString(_ProjectKey), v.GetString(_ApplicationName), v.GetString("version"))
+}
+
+var applicationReleasePromoteCmd = cli.Command{
+	Name:  "promote",
+	Short: "Promote the artifacts of a workflow run to a new release of a CDS application",
+	Long: `The artifacts uploaded by the application on a successful workflow run are copied to the release repository of the application.
+A release can't be modified and is kept when the workflow run is purged:
+
+	$ cdsctl application release promote MYPROJECT myapp 1.2.0 myworkflow 42
+	$ cdsctl application release promote MYPROJECT myapp 1.2.0 myworkflow 42 --node-name build --artifact myapp.tar.gz
+`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _ApplicationName},
+	},
+	Args: []cli.Arg{
+		{Name: "version"},
+		{Name: _WorkflowName},
+		{Name: "run-number"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "node-name",
+			Usage: "Node which uploaded the artifacts, mandatory if several nodes of the application uploaded artifacts",
+		},
+		{
+			Name:  "artifact",
+			Type:  cli.FlagArray,
+			Usage: "Name of an artifact to promote, all the artifacts of the node are promoted by default",
+		},
+	},
+}
+
+func applicationReleasePromoteRun(v cli.Values) (interface{}, error) {
+	number, err := v.GetInt64("run-number")
+	if err != nil {
+		return nil, err
+	}
+	promotion := sdk.ApplicationReleasePromotion{
+		Version:      v.GetString("version"),
+		WorkflowName: v.GetString(_WorkflowName),
+		Number:       number,
+		NodeName:     v.GetString("node-name"),
+		Artifacts:    v.GetStringArray("artifact"),
+	}
+	return client.ApplicationReleasePromote(v.GetString(_ProjectKey), v.GetString(_ApplicationName), promotion)
+}
+
+var applicationReleaseDownloadCmd = cli.Command{
+	Name:  "download",
+	Short: "Download the artifacts of a release of a CDS application",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _ApplicationName},
+	},
+	Args: []cli.Arg{
+		{Name: "version"},
+	},
+	OptionalArgs: []cli.Arg{
+		{Name: "artifact-name"},
+	},
+}
+
+func applicationReleaseDownloadRun(v cli.Values) error {
+	rel, err := client.ApplicationReleaseGet(v.GetString(_ProjectKey), v.GetString(_ApplicationName), v.GetString("version"))
+	if err != nil {
+		return err
+	}
+
+	var ok bool
+	for _, a := range rel.Artifacts {
+		if v.GetString("artifact-name") != "" && v.GetString("artifact-name") != a.Name {
+			continue
+		}
+		f, err := os.OpenFile(a.Name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(a.Perm))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Downloading %s...\n", a.Name)
+		if err := client.ApplicationReleaseArtifactDownload(v.GetString(_ProjectKey), v.GetString(_ApplicationName), rel.Version, a.Name, f); err != nil {
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+
+		sha512sum, err := sdk.FileSHA512sum(a.Name)
+		if err != nil {
+			return err
+		}
+		if sha512sum != a.SHA512sum {
+			return fmt.Errorf("Invalid sha512sum \ndownloaded file:%s\n%s:%s", sha512sum, f.Name(), a.SHA512sum)
+		}
+
+		fmt.Printf("File %s created, checksum OK\n", f.Name())
+		ok = true
+	}
+
+	if !ok {
+		return fmt.Errorf("No artifact downloaded")
+	}
+	return nil
+}
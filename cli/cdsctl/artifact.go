@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var artifactCmd = cli.Command{
+	Name:  "artifact",
+	Short: "Manage CDS artifacts",
+}
+
+func artifact() *cobra.Command {
+	return cli.NewCommand(artifactCmd, nil, []*cobra.Command{
+		cli.NewCommand(artifactVerifyCmd, artifactVerifyRun, nil, cli.CommandWithoutExtraFlags),
+	})
+}
+
+var artifactVerifyCmd = cli.Command{
+	Name:  "verify",
+	Short: "Verify offline that an artifact was built by CDS",
+	Long: `Check the signature of the provenance of an artifact and that the provenance describes the artifact.
+
+The provenance is downloaded with cdsctl workflow artifact provenance, it is signed with the key proj-cds-provenance of the project.
+The public key is displayed by cdsctl project keys provenance, save it in a file to verify artifacts offline.
+`,
+	Example: `cdsctl project keys provenance MYPROJ > proj-cds-provenance.pub
+cdsctl artifact verify myapp.tar.gz myapp.tar.gz.provenance.json --public-key proj-cds-provenance.pub`,
+	Args: []cli.Arg{
+		{Name: "artifact-file"},
+		{Name: "provenance-file"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "public-key",
+			Usage: "File containing the armored PGP public key of the project key " + sdk.ProvenanceKeyName,
+		},
+	},
+}
+
+func artifactVerifyRun(v cli.Values) error {
+	if v.GetString("public-key") == "" {
+		return fmt.Errorf("public-key flag is mandatory")
+	}
+	publicKey, err := ioutil.ReadFile(v.GetString("public-key"))
+	if err != nil {
+		return err
+	}
+
+	btes, err := ioutil.ReadFile(v.GetString("provenance-file"))
+	if err != nil {
+		return err
+	}
+	var envelope sdk.ProvenanceEnvelope
+	if err := json.Unmarshal(btes, &envelope); err != nil {
+		return fmt.Errorf("invalid provenance file: %v", err)
+	}
+
+	sha512sum, err := sdk.FileSHA512sum(v.GetString("artifact-file"))
+	if err != nil {
+		return err
+	}
+
+	s, err := sdk.VerifyProvenance(envelope, string(publicKey), sha512sum)
+	if err != nil {
+		return err
+	}
+
+	source := s.Predicate.Invocation.ConfigSource
+	fmt.Printf("Artifact %s verified\n", v.GetString("artifact-file"))
+	fmt.Printf("  built by %s on %s/%s #%d.%d, pipeline %s\n", s.Predicate.Builder.ID, source.Project, source.Workflow, source.Number, source.SubNumber, source.Pipeline)
+	for _, m := range s.Predicate.Materials {
+		fmt.Printf("  from %s at %s\n", m.URI, m.Digest["sha1"])
+	}
+	if s.Predicate.Builder.WorkerModel != "" {
+		fmt.Printf("  on worker model %s %s\n", s.Predicate.Builder.WorkerModel, s.Predicate.Builder.WorkerImage)
+	}
+	for _, n := range s.ProvenanceParametersNames() {
+		fmt.Printf("  %s=%s\n", n, s.Predicate.Invocation.Parameters[n])
+	}
+	return nil
+}
@@ -27,14 +27,56 @@ func events() *cobra.Command {
 var eventsListenCmd = cli.Command{
 	Name:  "listen",
 	Short: "Listen CDS events",
+	Long: `Listen CDS events, filtered by the API:
+
+	$ cdsctl events listen --project MY-PROJECT --workflow my-workflow --type EventRunWorkflow --status Fail
+
+Use --last-event-id to replay the recent events published after an event.
+`,
+	Flags: []cli.Flag{
+		{
+			Type:  cli.FlagArray,
+			Name:  "project",
+			Usage: "Listen only the events of the given project keys",
+		},
+		{
+			Type:  cli.FlagArray,
+			Name:  "workflow",
+			Usage: "Listen only the events of the given workflow names",
+		},
+		{
+			Type:  cli.FlagArray,
+			Name:  "type",
+			Usage: "Listen only the given event types like EventRunWorkflowNode",
+		},
+		{
+			Type:  cli.FlagArray,
+			Name:  "status",
+			Usage: "Listen only the events with the given status",
+		},
+		{
+			Name:  "last-event-id",
+			Usage: "Replay the recent events published after this event id",
+		},
+	},
 }
 
 func eventsListenRun(v cli.Values) error {
 	ctx := context.Background()
 	chanSSE := make(chan cdsclient.SSEvent)
 
+	opts := cdsclient.EventsListenOptions{
+		Filter: sdk.EventsStreamFilter{
+			ProjectKeys:   v.GetStringArray("project"),
+			WorkflowNames: v.GetStringArray("workflow"),
+			EventTypes:    v.GetStringArray("type"),
+			Status:        v.GetStringArray("status"),
+		},
+		LastEventID: v.GetString("last-event-id"),
+	}
+
 	sdk.GoRoutine(ctx, "EventsListenCmd", func(ctx context.Context) {
-		client.EventsListen(ctx, chanSSE)
+		client.EventsListenWithOptions(ctx, chanSSE, opts)
 	})
 
 	for {
@@ -48,7 +90,7 @@ func eventsListenRun(v cli.Values) error {
 			if e.EventType == "" {
 				continue
 			}
-			fmt.Printf("%s: %s %s %s\n", e.EventType, e.ProjectKey, e.WorkflowName, e.Status)
+			fmt.Printf("%d %s: %s %s %s\n", e.ID, e.EventType, e.ProjectKey, e.WorkflowName, e.Status)
 		}
 	}
 }
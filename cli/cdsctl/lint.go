@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+var lintCmd = cli.Command{
+	Name:  "lint",
+	Short: "Check the CDS files of a directory",
+	Long: `Check offline the CDS files (*.yml) of a directory, the .cds directory by default.
+
+The kind of each file is given by its name: *.pip.yml for pipelines, *.app.yml for applications,
+*.env.yml for environments, *.model.yml for worker models and *.yml for workflows. The linter checks:
+
+* the files against their JSON Schema (see cdsctl schema)
+* the pipelines used by the workflow nodes exist in the directory
+* there is no cycle in the depends_on of the workflow nodes
+* the hook models used by the workflows exist
+* the {{.cds.*}} and {{.git.*}} variables are defined
+`,
+	Example: `cdsctl lint .cds`,
+	OptionalArgs: []cli.Arg{
+		{Name: "directory"},
+	},
+}
+
+func lint() *cobra.Command {
+	return cli.NewCommand(lintCmd, lintRun, nil, cli.CommandWithoutExtraFlags)
+}
+
+func lintRun(v cli.Values) error {
+	dir := v.GetString("directory")
+	if dir == "" {
+		dir = ".cds"
+	}
+
+	msgs, err := exportentities.LintDirectory(dir)
+	if err != nil {
+		return err
+	}
+	for _, m := range msgs {
+		fmt.Println(filepath.Join(dir, m.File) + ": " + m.Message)
+	}
+	if len(msgs) > 0 {
+		return fmt.Errorf("%d problem(s) found", len(msgs))
+	}
+	return nil
+}
+
+var schemaCmd = cli.Command{
+	Name:  "schema",
+	Short: "Write the JSON Schemas of the CDS files",
+	Long: `Write the JSON Schemas of the CDS files, they can be used by editors to validate the .cds/*.yml files.
+
+Without argument, a schema is written for each kind of file in the output directory. With a kind, its schema
+is printed on the standard output.`,
+	Example: `cdsctl schema --output-dir .cds/schemas
+cdsctl schema workflow`,
+	OptionalArgs: []cli.Arg{
+		{Name: "kind"},
+	},
+	Flags: []cli.Flag{
+		{
+			Type:    cli.FlagString,
+			Name:    "output-dir",
+			Usage:   "Directory where the schemas are written",
+			Default: ".",
+		},
+	},
+}
+
+func schema() *cobra.Command {
+	return cli.NewCommand(schemaCmd, schemaRun, nil, cli.CommandWithoutExtraFlags)
+}
+
+func schemaRun(v cli.Values) error {
+	if kind := v.GetString("kind"); kind != "" {
+		s, err := exportentities.GetSchema(kind)
+		if err != nil {
+			return err
+		}
+		btes, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(btes))
+		return nil
+	}
+
+	dir := v.GetString("output-dir")
+	if err := os.MkdirAll(dir, os.FileMode(0755)); err != nil {
+		return err
+	}
+	for _, kind := range exportentities.SchemaKinds() {
+		s, err := exportentities.GetSchema(kind)
+		if err != nil {
+			return err
+		}
+		btes, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(dir, kind+".schema.json")
+		if err := ioutil.WriteFile(path, btes, os.FileMode(0644)); err != nil {
+			return err
+		}
+		fmt.Printf("%s written\n", path)
+	}
+	return nil
+}
@@ -27,6 +27,7 @@ func main() {
 		loginExperimental(), // experimental command to handle JWT
 		signup(),
 		application(),
+		artifact(),
 		environment(),
 		events(),
 		pipeline(),
@@ -41,6 +42,8 @@ func main() {
 		monitoring(),
 		version(),
 		encrypt(),
+		lint(),
+		schema(),
 		token(), // nearly deprecated
 		template(),
 		admin(),
@@ -69,6 +72,8 @@ func rootFromSubCommands(cmds []*cobra.Command) *cobra.Command {
 		if cmd.Name() == "login" ||
 			cmd.Name() == "signup" ||
 			cmd.Name() == "version" ||
+			cmd.Name() == "lint" ||
+			cmd.Name() == "schema" ||
 			cmd.Name() == "doc" || strings.HasPrefix(cmd.Use, "doc ") || (cmd.Run == nil && cmd.RunE == nil) {
 			return
 		}
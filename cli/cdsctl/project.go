@@ -25,6 +25,9 @@ func projectCommands() []*cobra.Command {
 		cli.NewCommand(projectCreateCmd, projectCreateRun, nil),
 		cli.NewDeleteCommand(projectDeleteCmd, projectDeleteRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(projectFavoriteCmd, projectFavoriteRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectExportCmd, projectExportRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectImportCmd, projectImportRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(projectDORACmd, projectDORARun, nil, withAllCommandModifiers()...),
 		projectKey(),
 		projectGroup(),
 		projectVariable(),
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/ovh/cds/cli"
+)
+
+var projectExportCmd = cli.Command{
+	Name:  "export",
+	Short: "Export a CDS project with all its entities in a tar bundle",
+	Long: `Export the workflows, pipelines, applications, environments, variables, keys, integrations and permissions of a project in a tar bundle.
+Secrets are encrypted with the bundle passphrase, the same passphrase is needed to import the bundle:
+
+	$ cdsctl project export MY-PROJECT --passphrase my-passphrase --file my-project.tar
+`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "passphrase",
+			Usage: "Passphrase used to encrypt the secrets of the bundle",
+		},
+		{
+			Name:      "file",
+			ShortHand: "f",
+			Usage:     "Bundle file, default is <project-key>.tar",
+		},
+	},
+}
+
+func projectExportRun(v cli.Values) error {
+	file := strings.TrimSpace(v.GetString("file"))
+	if file == "" {
+		file = v.GetString(_ProjectKey) + ".tar"
+	}
+
+	btes, err := client.ProjectBundleExport(v.GetString(_ProjectKey), v.GetString("passphrase"))
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(file, btes, os.FileMode(0600)); err != nil {
+		return fmt.Errorf("unable to write file %s: %v", file, err)
+	}
+	fmt.Printf("Project %s exported in %s\n", v.GetString(_ProjectKey), file)
+	return nil
+}
+
+var projectImportCmd = cli.Command{
+	Name:  "import",
+	Short: "Import a tar bundle in a CDS project",
+	Long: `Import a bundle created by cdsctl project export in an existing project.
+Use --dry-run to check the bundle, it reports the entities which already exist in the project and the missing groups and integration models.
+Existing entities are overridden with --force. Groups can be renamed with --group old-group=new-group:
+
+	$ cdsctl project import MY-PROJECT my-project.tar --passphrase my-passphrase --dry-run
+	$ cdsctl project import MY-PROJECT my-project.tar --passphrase my-passphrase --group devs=my-team
+`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "file"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "passphrase",
+			Usage: "Passphrase used to export the bundle",
+		},
+		{
+			Type:    cli.FlagBool,
+			Name:    "dry-run",
+			Usage:   "Check the bundle and report conflicts without importing it",
+			Default: "false",
+		},
+		{
+			Type:    cli.FlagBool,
+			Name:    "force",
+			Usage:   "Override the existing entities",
+			Default: "false",
+		},
+		{
+			Type:  cli.FlagArray,
+			Name:  "group",
+			Usage: "Rename a group of the bundle like --group old-group=new-group",
+		},
+	},
+}
+
+func projectImportRun(v cli.Values) error {
+	f, err := os.Open(v.GetString("file"))
+	if err != nil {
+		return fmt.Errorf("unable to open file %s: %v", v.GetString("file"), err)
+	}
+	defer f.Close() // nolint
+
+	report, err := client.ProjectBundleImport(v.GetString(_ProjectKey), v.GetString("passphrase"), f,
+		v.GetBool("dry-run"), v.GetBool("force"), v.GetStringArray("group"))
+	if err != nil {
+		return err
+	}
+
+	for _, c := range report.Conflicts {
+		fmt.Printf("conflict: %s already exists\n", c)
+	}
+	for _, e := range report.Errors {
+		fmt.Printf("error: %s\n", e)
+	}
+	if report.DryRun {
+		if len(report.Errors) > 0 {
+			return fmt.Errorf("bundle can't be imported: %d errors", len(report.Errors))
+		}
+		fmt.Printf("Bundle can be imported (%d conflicts)\n", len(report.Conflicts))
+		return nil
+	}
+	for _, i := range report.Imported {
+		fmt.Printf("imported: %s\n", i)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ovh/cds/cli"
+)
+
+var projectDORACmd = cli.Command{
+	Name:  "dora",
+	Short: "Display the DORA metrics of the applications of a CDS project",
+	Long: `Display the deployment frequency, lead time for changes, change failure rate and time to restore
+of each application deployed on each production environment of a project, a deployment being a run of a pipeline
+bound to an application and an environment flagged as production:
+
+	cdsctl project dora MYPROJECT --environment production --days 90
+`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "days",
+			Usage: "Number of days used to compute the metrics (default 30)",
+		},
+		{
+			Name:  "application",
+			Usage: "Filter on an application name",
+		},
+		{
+			Name:  "environment",
+			Usage: "Filter on an environment name",
+		},
+	},
+}
+
+type projectDORADisplay struct {
+	Application         string `cli:"application,key"`
+	Environment         string `cli:"environment"`
+	Deployments         int    `cli:"deployments"`
+	DeploymentFrequency string `cli:"deployment_frequency"`
+	LeadTimeForChanges  string `cli:"lead_time_for_changes"`
+	ChangeFailureRate   string `cli:"change_failure_rate"`
+	TimeToRestore       string `cli:"time_to_restore"`
+}
+
+func projectDORARun(v cli.Values) (cli.ListResult, error) {
+	days, err := v.GetInt64("days")
+	if err != nil {
+		return nil, err
+	}
+
+	ms, err := client.ProjectDORAMetrics(v.GetString(_ProjectKey), days, v.GetString("application"), v.GetString("environment"))
+	if err != nil {
+		return nil, err
+	}
+
+	duration := func(s float64) string {
+		if s == 0 {
+			return "-"
+		}
+		return (time.Duration(s) * time.Second).String()
+	}
+	ds := make([]projectDORADisplay, len(ms))
+	for i, m := range ms {
+		ds[i] = projectDORADisplay{
+			Application:         m.ApplicationName,
+			Environment:         m.EnvironmentName,
+			Deployments:         m.Deployments,
+			DeploymentFrequency: fmt.Sprintf("%.2f/day", m.DeploymentFrequency),
+			LeadTimeForChanges:  duration(m.LeadTimeForChanges),
+			ChangeFailureRate:   fmt.Sprintf("%.0f%%", m.ChangeFailureRate*100),
+			TimeToRestore:       duration(m.TimeToRestore),
+		}
+	}
+	return cli.AsListResult(ds), nil
+}
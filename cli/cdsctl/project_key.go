@@ -19,6 +19,7 @@ func projectKey() *cobra.Command {
 		cli.NewCommand(projectKeyCreateCmd, projectCreateKeyRun, nil, withAllCommandModifiers()...),
 		cli.NewListCommand(projectKeyListCmd, projectListKeyRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(projectKeyDeleteCmd, projectDeleteKeyRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectKeyProvenanceCmd, projectProvenanceKeyRun, nil, withAllCommandModifiers()...),
 	})
 }
 
@@ -80,3 +81,23 @@ var projectKeyDeleteCmd = cli.Command{
 func projectDeleteKeyRun(v cli.Values) error {
 	return client.ProjectKeysDelete(v.GetString(_ProjectKey), v.GetString("key-name"))
 }
+
+var projectKeyProvenanceCmd = cli.Command{
+	Name:  "provenance",
+	Short: "Display the public key used to sign the provenance of the artifacts of the project",
+	Long: `Display the armored PGP public key of the builtin key ` + sdk.ProvenanceKeyName + ` of the project.
+Save it in a file to verify artifacts offline with cdsctl artifact verify.`,
+	Example: `cdsctl project keys provenance MYPROJ > proj-cds-provenance.pub`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+}
+
+func projectProvenanceKeyRun(v cli.Values) error {
+	k, err := client.ProjectProvenanceKeyGet(v.GetString(_ProjectKey))
+	if err != nil {
+		return err
+	}
+	fmt.Println(k.Public)
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ovh/cds/cli"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
 	"github.com/ovh/cds/sdk/exportentities"
 )
 
@@ -23,6 +24,9 @@ func workerModel() *cobra.Command {
 		cli.NewDeleteCommand(workerModelDeleteCmd, workerModelDeleteRun, nil),
 		cli.NewCommand(workerModelImportCmd, workerModelImportRun, nil),
 		cli.NewCommand(workerModelExportCmd, workerModelExportRun, nil, withAllCommandModifiers()...),
+		workerModelVersion(),
+		cli.NewCommand(workerModelRollbackCmd, workerModelRollbackRun, nil, withAllCommandModifiers()...),
+		workerModelCanary(),
 	})
 }
 
@@ -93,11 +97,23 @@ For admin:
 			Default: "false",
 			Type:    cli.FlagBool,
 		},
+		{
+			Name:  "canary",
+			Usage: "Import the worker model as a new version sent to this percentage of the jobs, the current version is not changed",
+		},
 	},
 }
 
 func workerModelImportRun(c cli.Values) error {
 	force := c.GetBool("force")
+	canary, err := c.GetInt64("canary")
+	if err != nil {
+		return err
+	}
+	var mods []cdsclient.RequestModifier
+	if canary > 0 {
+		mods = append(mods, cdsclient.WithWorkerModelCanary(canary))
+	}
 	if c.GetString("path") == "" {
 		return fmt.Errorf("path for worker model is mandatory")
 	}
@@ -110,12 +126,16 @@ func workerModelImportRun(c cli.Values) error {
 		}
 		formatStr, _ := exportentities.GetFormatStr(format)
 
-		wm, err := client.WorkerModelImport(contentFile, formatStr, force)
+		wm, err := client.WorkerModelImport(contentFile, formatStr, force, mods...)
 		if err != nil {
 			_ = contentFile.Close()
 			return err
 		}
-		fmt.Printf("Worker model %s imported with success\n", wm.Name)
+		if canary > 0 {
+			fmt.Printf("Worker model %s imported with success as canary version %d for %d%% of the jobs\n", wm.Name, wm.CanaryVersion, wm.CanaryPercent)
+		} else {
+			fmt.Printf("Worker model %s imported with success\n", wm.Name)
+		}
 		_ = contentFile.Close()
 	}
 
@@ -169,6 +189,10 @@ var workerModelExportCmd = cli.Command{
 			Usage:   "Specify export format (json or yaml)",
 			Default: "yaml",
 		},
+		{
+			Name:  "version",
+			Usage: "Export a version of the worker model instead of its current version",
+		},
 	},
 }
 
@@ -178,7 +202,15 @@ func workerModelExportRun(c cli.Values) error {
 	if err != nil {
 		return sdk.WrapError(err, "cannot load worker model %s", wmName)
 	}
-	btes, err := client.WorkerModelExport(wm.ID, c.GetString("format"))
+	version, err := c.GetInt64("version")
+	if err != nil {
+		return err
+	}
+	var mods []cdsclient.RequestModifier
+	if version > 0 {
+		mods = append(mods, cdsclient.WithWorkerModelVersion(version))
+	}
+	btes, err := client.WorkerModelExport(wm.ID, c.GetString("format"), mods...)
 	if err != nil {
 		return err
 	}
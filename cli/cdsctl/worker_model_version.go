@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workerModelVersionCmd = cli.Command{
+	Name:  "version",
+	Short: "Manage versions of a Worker Model",
+}
+
+func workerModelVersion() *cobra.Command {
+	return cli.NewCommand(workerModelVersionCmd, nil, []*cobra.Command{
+		cli.NewListCommand(workerModelVersionListCmd, workerModelVersionListRun, nil),
+	})
+}
+
+var workerModelVersionListCmd = cli.Command{
+	Name:    "list",
+	Short:   "List versions of a worker model",
+	Example: "cdsctl worker model version list myModel",
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+}
+
+func workerModelVersionListRun(v cli.Values) (cli.ListResult, error) {
+	wm, err := client.WorkerModel(v.GetString("name"))
+	if err != nil {
+		return nil, err
+	}
+	vs, err := client.WorkerModelVersions(wm.ID)
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(vs), nil
+}
+
+var workerModelRollbackCmd = cli.Command{
+	Name:  "rollback",
+	Short: "Restore a version of a worker model",
+	Long: `Restore a version as the current version of a worker model, the canary rollout of the model is stopped.
+Jobs with a model requirement pinned to a version (ie: myModel@3) are not affected.`,
+	Example: "cdsctl worker model rollback myModel 3",
+	Args: []cli.Arg{
+		{Name: "name"},
+		{Name: "version"},
+	},
+}
+
+func workerModelRollbackRun(v cli.Values) error {
+	version, err := v.GetInt64("version")
+	if err != nil {
+		return err
+	}
+	wm, err := client.WorkerModel(v.GetString("name"))
+	if err != nil {
+		return err
+	}
+	m, err := client.WorkerModelRollback(wm.ID, version)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Worker model %s rolled back to version %d\n", m.Name, m.Version)
+	return nil
+}
+
+var workerModelCanaryCmd = cli.Command{
+	Name:  "canary",
+	Short: "Manage canary rollout of a Worker Model",
+	Long: `A canary rollout sends a percentage of the jobs to a new version of a worker model.
+A new canary version can be imported with: cdsctl worker model import --canary 10 myModel.yml`,
+}
+
+func workerModelCanary() *cobra.Command {
+	return cli.NewCommand(workerModelCanaryCmd, nil, []*cobra.Command{
+		cli.NewCommand(workerModelCanaryStartCmd, workerModelCanaryStartRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workerModelCanaryStopCmd, workerModelCanaryStopRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workerModelCanaryPromoteCmd, workerModelCanaryPromoteRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var workerModelCanaryStartCmd = cli.Command{
+	Name:    "start",
+	Short:   "Send a percentage of the jobs to a version of a worker model",
+	Example: "cdsctl worker model canary start myModel 4 10",
+	Args: []cli.Arg{
+		{Name: "name"},
+		{Name: "version"},
+		{Name: "percent"},
+	},
+}
+
+func workerModelCanaryStartRun(v cli.Values) error {
+	version, err := v.GetInt64("version")
+	if err != nil {
+		return err
+	}
+	percent, err := v.GetInt64("percent")
+	if err != nil {
+		return err
+	}
+	wm, err := client.WorkerModel(v.GetString("name"))
+	if err != nil {
+		return err
+	}
+	m, err := client.WorkerModelCanary(wm.ID, sdk.ModelCanary{Version: version, Percent: percent})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Version %d of worker model %s receives %d%% of the jobs\n", m.CanaryVersion, m.Name, m.CanaryPercent)
+	return nil
+}
+
+var workerModelCanaryStopCmd = cli.Command{
+	Name:    "stop",
+	Short:   "Stop the canary rollout of a worker model, all the jobs are sent to its current version",
+	Example: "cdsctl worker model canary stop myModel",
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+}
+
+func workerModelCanaryStopRun(v cli.Values) error {
+	wm, err := client.WorkerModel(v.GetString("name"))
+	if err != nil {
+		return err
+	}
+	if _, err := client.WorkerModelCanaryDelete(wm.ID); err != nil {
+		return err
+	}
+	fmt.Printf("Canary rollout of worker model %s stopped\n", wm.Name)
+	return nil
+}
+
+var workerModelCanaryPromoteCmd = cli.Command{
+	Name:    "promote",
+	Short:   "Promote the canary version of a worker model as its current version",
+	Example: "cdsctl worker model canary promote myModel",
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+}
+
+func workerModelCanaryPromoteRun(v cli.Values) error {
+	wm, err := client.WorkerModel(v.GetString("name"))
+	if err != nil {
+		return err
+	}
+	if wm.CanaryPercent <= 0 {
+		return fmt.Errorf("no canary rollout in progress for worker model %s", wm.Name)
+	}
+	m, err := client.WorkerModelRollback(wm.ID, wm.CanaryVersion)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Version %d of worker model %s promoted\n", m.Version, m.Name)
+	return nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowApproveCmd = cli.Command{
+	Name:  "approve",
+	Short: "Approve or reject a CDS workflow node run waiting for approval",
+	Long:  "Approve or reject a CDS workflow node run waiting for approval",
+	Example: `cdsctl workflow approve MYPROJECT myworkflow 5 deploy # To approve the node deploy on workflow run 5
+cdsctl workflow approve MYPROJECT myworkflow 5 deploy --reject --comment "not during the sales" # To reject it
+	`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "run-number"},
+		{Name: "node-name"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "reject",
+			Usage: "if true, reject the node run instead of approving it",
+			IsValid: func(s string) bool {
+				return s == "true" || s == "false"
+			},
+			Default: "false",
+			Type:    cli.FlagBool,
+		},
+		{
+			Name:  "comment",
+			Usage: "Comment stored in the audit trail of the workflow",
+		},
+	},
+}
+
+func workflowApproveRun(v cli.Values) error {
+	runNumber, err := v.GetInt64("run-number")
+	if err != nil {
+		return err
+	}
+
+	wr, err := client.WorkflowRunGet(v.GetString(_ProjectKey), v.GetString(_WorkflowName), runNumber)
+	if err != nil {
+		return err
+	}
+
+	var nodeRunID int64
+	for _, wnrs := range wr.WorkflowNodeRuns {
+		if wnrs[0].WorkflowNodeName == v.GetString("node-name") {
+			nodeRunID = wnrs[0].ID
+			break
+		}
+	}
+	if nodeRunID == 0 {
+		return fmt.Errorf("Node not found")
+	}
+
+	a, err := client.WorkflowNodeRunApprove(v.GetString(_ProjectKey), v.GetString(_WorkflowName), runNumber, nodeRunID, sdk.WorkflowNodeRunApprovalRequest{
+		Approved: !v.GetBool("reject"),
+		Comment:  v.GetString("comment"),
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Workflow node %s from workflow %s #%d: %s (%d/%d approvals)\n", a.WorkflowNodeName, v.GetString(_WorkflowName), runNumber, a.Status, a.Approvals(), a.Gate.RequiredApprovals())
+	return nil
+}
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"regexp"
 	"strconv"
@@ -21,6 +23,7 @@ func workflowArtifact() *cobra.Command {
 	return cli.NewCommand(workflowArtifactCmd, nil, []*cobra.Command{
 		cli.NewListCommand(workflowArtifactListCmd, workflowArtifactListRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowArtifactDownloadCmd, workflowArtifactDownloadRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowArtifactProvenanceCmd, workflowArtifactProvenanceRun, nil, withAllCommandModifiers()...),
 	})
 }
 
@@ -139,3 +142,51 @@ func workflowArtifactDownloadRun(v cli.Values) error {
 	}
 	return nil
 }
+
+var workflowArtifactProvenanceCmd = cli.Command{
+	Name:  "provenance",
+	Short: "Download the signed provenance of an artifact of one Workflow Run",
+	Long: `Download the signed provenance of an artifact in the file <artifact-name>.provenance.json.
+It can be verified offline with the command cdsctl artifact verify.`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "number"},
+		{Name: "artefact-name"},
+	},
+}
+
+func workflowArtifactProvenanceRun(v cli.Values) error {
+	number, err := strconv.ParseInt(v.GetString("number"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("number parameter have to be an integer")
+	}
+
+	artifacts, err := client.WorkflowRunArtifacts(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range artifacts {
+		if a.Name != v.GetString("artefact-name") {
+			continue
+		}
+		envelope, err := client.WorkflowNodeRunArtifactProvenance(v.GetString(_ProjectKey), v.GetString(_WorkflowName), a.ID)
+		if err != nil {
+			return err
+		}
+		btes, err := json.MarshalIndent(envelope, "", "  ")
+		if err != nil {
+			return err
+		}
+		fileName := a.Name + sdk.ArtifactProvenanceSuffix
+		if err := ioutil.WriteFile(fileName, btes, 0644); err != nil {
+			return err
+		}
+		fmt.Printf("File %s created\n", fileName)
+		return nil
+	}
+	return fmt.Errorf("artifact %s not found", v.GetString("artefact-name"))
+}
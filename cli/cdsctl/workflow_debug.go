@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/crypto/ssh/terminal"
+	"golang.org/x/net/websocket"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowDebugCmd = cli.Command{
+	Name:  "debug",
+	Short: "Attach a terminal to a failed job kept alive for debugging",
+	Long: `Attach a terminal to the worker of a failed job, in its workspace and with its environment variables.
+The job must set keep_on_failure, the worker is kept alive after a failure for the given number of minutes.
+Exit the shell to end the debug session and release the worker.
+
+The shell gives access to the secrets of the job, the write permission (RWX) on the workflow or on its project is required.`,
+	Example: `cdsctl workflow debug MYPROJECT myworkflow 5 compile # Attach to the job compile of workflow run 5`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "run-number"},
+		{Name: "job-name"},
+	},
+}
+
+func workflowDebugRun(v cli.Values) error {
+	runNumber, err := v.GetInt64("run-number")
+	if err != nil {
+		return err
+	}
+
+	wr, err := client.WorkflowRunGet(v.GetString(_ProjectKey), v.GetString(_WorkflowName), runNumber)
+	if err != nil {
+		return err
+	}
+
+	var nodeRunID, jobID int64
+	for _, wnrs := range wr.WorkflowNodeRuns {
+		if len(wnrs) == 0 {
+			continue
+		}
+		for _, s := range wnrs[0].Stages {
+			for _, rj := range s.RunJobs {
+				if rj.Job.Action.Name == v.GetString("job-name") && rj.Status == sdk.StatusBuilding.String() {
+					nodeRunID, jobID = wnrs[0].ID, rj.ID
+				}
+			}
+		}
+	}
+	if jobID == 0 {
+		return fmt.Errorf("no building job %s found on workflow %s #%d", v.GetString("job-name"), v.GetString(_WorkflowName), runNumber)
+	}
+
+	ws, err := client.WorkflowNodeRunJobDebug(v.GetString(_ProjectKey), v.GetString(_WorkflowName), runNumber, nodeRunID, jobID)
+	if err != nil {
+		return fmt.Errorf("unable to attach to job %s, check that the job failed and that keep_on_failure is set: %v", v.GetString("job-name"), err)
+	}
+	defer ws.Close()
+
+	fd := int(os.Stdin.Fd())
+	if terminal.IsTerminal(fd) {
+		state, err := terminal.MakeRaw(fd)
+		if err != nil {
+			return err
+		}
+		defer terminal.Restore(fd, state) // nolint
+
+		if cols, rows, err := terminal.GetSize(fd); err == nil {
+			if err := websocket.JSON.Send(ws, sdk.DebugSessionMessage{Type: sdk.DebugSessionMessageResize, Cols: uint16(cols), Rows: uint16(rows)}); err != nil {
+				return err
+			}
+		}
+	}
+
+	// the worker starts the shell on the first message, send an empty input if the terminal size is unknown
+	if err := websocket.JSON.Send(ws, sdk.DebugSessionMessage{Type: sdk.DebugSessionMessageData}); err != nil {
+		return err
+	}
+
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if n > 0 {
+				if err := websocket.JSON.Send(ws, sdk.DebugSessionMessage{Type: sdk.DebugSessionMessageData, Data: buf[:n]}); err != nil {
+					return
+				}
+			}
+			if err != nil {
+				_ = websocket.JSON.Send(ws, sdk.DebugSessionMessage{Type: sdk.DebugSessionMessageClose})
+				return
+			}
+		}
+	}()
+
+	for {
+		var msg sdk.DebugSessionMessage
+		if err := websocket.JSON.Receive(ws, &msg); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		switch msg.Type {
+		case sdk.DebugSessionMessageData:
+			if _, err := os.Stdout.Write(msg.Data); err != nil {
+				return err
+			}
+		case sdk.DebugSessionMessageClose:
+			return nil
+		}
+	}
+}
@@ -85,6 +85,11 @@ var workflowRunManualCmd = cli.Command{
 			Usage:     "Synchronise your pipelines with your last editions. Must be used with flag run-number",
 			Type:      cli.FlagBool,
 		},
+		{
+			Name:  "override-freeze",
+			Usage: "Run the nodes blocked by a freeze window, you must be a member of one of its override groups",
+			Type:  cli.FlagBool,
+		},
 	},
 }
 
@@ -93,7 +98,7 @@ func workflowRunManualRun(v cli.Values) error {
 		return fmt.Errorf("Could not use flag --sync without flag --run-number")
 	}
 
-	manual := sdk.WorkflowNodeRunManual{}
+	manual := sdk.WorkflowNodeRunManual{OverrideFreeze: v.GetBool("override-freeze")}
 	if strings.TrimSpace(v.GetString("data")) != "" {
 		data := map[string]interface{}{}
 		if err := json.Unmarshal([]byte(v.GetString("data")), &data); err != nil {
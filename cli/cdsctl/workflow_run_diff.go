@@ -0,0 +1,266 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowRunDiffCmd = cli.Command{
+	Name:  "diff",
+	Short: "Compare two runs of a CDS workflow",
+	Long: `Compare the build parameters, the node statuses and durations, the tests which changed status and the commits between two workflow runs:
+
+	$ cdsctl workflow run diff MYPROJECT myworkflow 41 42
+`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "from-run-number"},
+		{Name: "to-run-number"},
+	},
+}
+
+func workflowRunDiffRun(v cli.Values) error {
+	from, err := v.GetInt64("from-run-number")
+	if err != nil {
+		return err
+	}
+	to, err := v.GetInt64("to-run-number")
+	if err != nil {
+		return err
+	}
+
+	diff, err := client.WorkflowRunDiff(v.GetString(_ProjectKey), v.GetString(_WorkflowName), from, to)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Comparing run #%d (%s) with run #%d (%s)\n", diff.FromNumber, diff.FromHash, diff.ToNumber, diff.ToHash)
+
+	fmt.Printf("\nCommits (%d):\n", len(diff.Commits))
+	for _, c := range diff.Commits {
+		fmt.Printf("  %s %s: %s\n", shortHash(c.Hash), c.Author.Name, firstLine(c.Message))
+	}
+
+	fmt.Printf("\nParameters (%d changed):\n", len(diff.Parameters))
+	for _, p := range diff.Parameters {
+		fmt.Printf("  %s: %q -> %q\n", p.Name, p.From, p.To)
+	}
+
+	fmt.Printf("\nNodes:\n")
+	for _, n := range diff.Nodes {
+		fmt.Printf("  %s: %s (%s) -> %s (%s)", n.Name, statusOrNone(n.FromStatus), time.Duration(n.FromDuration)*time.Second,
+			statusOrNone(n.ToStatus), time.Duration(n.ToDuration)*time.Second)
+		if strings.Join(n.FromWorkerModels, ",") != strings.Join(n.ToWorkerModels, ",") {
+			fmt.Printf(" worker models: %v -> %v", n.FromWorkerModels, n.ToWorkerModels)
+		}
+		fmt.Println()
+	}
+
+	fmt.Printf("\nTests (%d changed):\n", len(diff.Tests))
+	for _, t := range diff.Tests {
+		fmt.Printf("  %s %s/%s: %s -> %s\n", t.Node, t.Suite, t.Name, t.FromStatus, t.ToStatus)
+	}
+	return nil
+}
+
+var workflowBisectCmd = cli.Command{
+	Name:  "bisect",
+	Short: "Find the first commit making a CDS workflow fail",
+	Long: `Launch the workflow on the commits between a successful run and a failed run to find the first failing commit.
+Each tested commit is run with a new workflow run, the status of the given node is checked (the status of the whole run by default).
+Workflows with deployment nodes that are not the checked node or one of its ancestors are refused, the run is stopped once the node status is known:
+
+	$ cdsctl workflow bisect MYPROJECT myworkflow 41 42 --node-name build
+`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "good-run-number"},
+		{Name: "bad-run-number"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "node-name",
+			Usage: "Node checked on each tested commit",
+		},
+		{
+			Name:    "interval",
+			Usage:   "Interval in seconds between two checks of a run status",
+			Default: "10",
+		},
+	},
+}
+
+func workflowBisectRun(v cli.Values) error {
+	projectKey, workflowName := v.GetString(_ProjectKey), v.GetString(_WorkflowName)
+	good, err := v.GetInt64("good-run-number")
+	if err != nil {
+		return err
+	}
+	bad, err := v.GetInt64("bad-run-number")
+	if err != nil {
+		return err
+	}
+	interval, err := v.GetInt64("interval")
+	if err != nil || interval <= 0 {
+		return fmt.Errorf("invalid interval %s", v.GetString("interval"))
+	}
+
+	wf, err := client.WorkflowGet(projectKey, workflowName)
+	if err != nil {
+		return err
+	}
+	if err := bisectCheckDeployments(wf, v.GetString("node-name")); err != nil {
+		return err
+	}
+
+	diff, err := client.WorkflowRunDiff(projectKey, workflowName, good, bad)
+	if err != nil {
+		return err
+	}
+	badRun, err := client.WorkflowRunGet(projectKey, workflowName, bad)
+	if err != nil {
+		return err
+	}
+	var branch string
+	if r := badRun.RootRun(); r != nil {
+		branch = r.VCSBranch
+	}
+
+	// Commits from the oldest to the failing one, the failing commit is known as bad
+	commits := diff.Commits
+	sort.SliceStable(commits, func(i, j int) bool { return commits[i].Timestamp < commits[j].Timestamp })
+	if len(commits) == 0 || commits[len(commits)-1].Hash != diff.ToHash {
+		return fmt.Errorf("no commits found between run #%d and run #%d", good, bad)
+	}
+
+	lo, hi := 0, len(commits)-1
+	for lo < hi {
+		mid := (lo + hi) / 2
+		c := commits[mid]
+		fmt.Printf("Testing %s %s (%d commits left)\n", shortHash(c.Hash), firstLine(c.Message), hi-lo)
+
+		status, err := bisectTestCommit(projectKey, workflowName, branch, c.Hash, v.GetString("node-name"), time.Duration(interval)*time.Second)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("  %s: %s\n", shortHash(c.Hash), status)
+
+		if status == sdk.StatusSuccess.String() {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+
+	c := commits[lo]
+	fmt.Printf("First failing commit is %s by %s: %s\n", c.Hash, c.Author.Name, firstLine(c.Message))
+	if c.URL != "" {
+		fmt.Println(c.URL)
+	}
+	return nil
+}
+
+// bisectCheckDeployments refuses workflows which would deploy the tested commits on other nodes than
+// the checked node and its ancestors, because each tested commit is run with a new workflow run
+func bisectCheckDeployments(wf *sdk.Workflow, nodeName string) error {
+	allowed := map[string]bool{}
+	if nodeName != "" {
+		n := wf.WorkflowData.NodeByName(nodeName)
+		if n == nil {
+			return fmt.Errorf("node %s not found in workflow %s", nodeName, wf.Name)
+		}
+		// the ancestors of the checked node have to run to reach it
+		todo := []string{n.Name}
+		for len(todo) > 0 {
+			name := todo[0]
+			todo = todo[1:]
+			if allowed[name] {
+				continue
+			}
+			allowed[name] = true
+			if a := wf.WorkflowData.NodeByName(name); a != nil {
+				todo = append(todo, wf.WorkflowData.AncestorsNames(*a)...)
+			}
+		}
+	}
+
+	for _, n := range wf.WorkflowData.Array() {
+		if n.Context == nil || n.Context.EnvironmentID == 0 || allowed[n.Name] {
+			continue
+		}
+		if nodeName == "" {
+			return fmt.Errorf("workflow %s deploys on node %s, use --node-name to check a node which is not followed by deployments", wf.Name, n.Name)
+		}
+		return fmt.Errorf("workflow %s deploys on node %s which is not an ancestor of node %s, bisect can't run it", wf.Name, n.Name, nodeName)
+	}
+	return nil
+}
+
+// bisectTestCommit runs the workflow on a commit and waits for the status of the node, or of the run if no node is given
+func bisectTestCommit(projectKey, workflowName, branch, hash, nodeName string, interval time.Duration) (string, error) {
+	manual := sdk.WorkflowNodeRunManual{Payload: map[string]string{"git.branch": branch, "git.hash": hash}}
+	wr, err := client.WorkflowRunFromManual(projectKey, workflowName, manual, 0, 0)
+	if err != nil {
+		return "", err
+	}
+	fmt.Printf("  workflow %s #%d launched\n", workflowName, wr.Number)
+
+	for {
+		time.Sleep(interval)
+		wr, err = client.WorkflowRunGet(projectKey, workflowName, wr.Number)
+		if err != nil {
+			return "", err
+		}
+
+		if nodeName == "" {
+			if sdk.StatusIsTerminated(wr.Status) {
+				return wr.Status, nil
+			}
+			continue
+		}
+
+		for _, nrs := range wr.WorkflowNodeRuns {
+			if len(nrs) > 0 && nrs[0].WorkflowNodeName == nodeName && sdk.StatusIsTerminated(nrs[0].Status) {
+				// the following nodes are not needed to know the status of the commit
+				if !sdk.StatusIsTerminated(wr.Status) {
+					if _, err := client.WorkflowStop(projectKey, workflowName, wr.Number); err != nil {
+						return "", err
+					}
+				}
+				return nrs[0].Status, nil
+			}
+		}
+		if sdk.StatusIsTerminated(wr.Status) {
+			return "", fmt.Errorf("node %s was not run by workflow run #%d", nodeName, wr.Number)
+		}
+	}
+}
+
+func shortHash(h string) string {
+	if len(h) > 7 {
+		return h[:7]
+	}
+	return h
+}
+
+func firstLine(s string) string {
+	return strings.SplitN(s, "\n", 2)[0]
+}
+
+func statusOrNone(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
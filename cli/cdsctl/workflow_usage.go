@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowUsageCmd = cli.Command{
+	Name:  "usage",
+	Short: "Display the resource usage of the jobs of a CDS workflow",
+	Long: `Display the percentiles of the CPU and memory used by each job of the last runs of a workflow,
+with a suggestion when the memory requirement of the job is over or under provisioned:
+
+	cdsctl workflow usage MYPROJECT myworkflow --runs 20
+`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "runs",
+			Usage: "Number of runs of each job used to compute the percentiles",
+		},
+	},
+}
+
+type workflowUsageDisplay struct {
+	Pipeline   string `cli:"pipeline,key"`
+	Job        string `cli:"job"`
+	Runs       int    `cli:"runs"`
+	CPU        string `cli:"cpu_p50_p90_p99"`
+	Memory     string `cli:"memory_p50_p90_p99"`
+	Requested  string `cli:"requested_memory"`
+	Suggestion string `cli:"suggestion"`
+}
+
+func workflowUsageRun(v cli.Values) (cli.ListResult, error) {
+	runs, err := v.GetInt64("runs")
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := client.WorkflowResourceUsage(v.GetString(_ProjectKey), v.GetString(_WorkflowName), runs)
+	if err != nil {
+		return nil, err
+	}
+
+	mb := func(b float64) string { return fmt.Sprintf("%.0fMB", b/(1024*1024)) }
+	ds := make([]workflowUsageDisplay, len(stats))
+	for i, s := range stats {
+		ds[i] = workflowUsageDisplay{
+			Pipeline:  s.WorkflowNodeName,
+			Job:       s.JobName,
+			Runs:      s.Runs,
+			CPU:       fmt.Sprintf("%.1fs/%.1fs/%.1fs", s.CPUSeconds.P50, s.CPUSeconds.P90, s.CPUSeconds.P99),
+			Memory:    fmt.Sprintf("%s/%s/%s", mb(s.MaxMemory.P50), mb(s.MaxMemory.P90), mb(s.MaxMemory.P99)),
+			Requested: "-",
+		}
+		if s.RequestedMemory > 0 {
+			ds[i].Requested = fmt.Sprintf("%dMB", s.RequestedMemory)
+		}
+		switch s.Suggestion {
+		case sdk.ResourceSuggestionIncreaseMemory:
+			ds[i].Suggestion = fmt.Sprintf("under-provisioned, increase memory to %dMB", s.SuggestedMemory)
+		case sdk.ResourceSuggestionDecreaseMemory:
+			ds[i].Suggestion = fmt.Sprintf("over-provisioned, decrease memory to %dMB", s.SuggestedMemory)
+		case sdk.ResourceSuggestionSetMemory:
+			ds[i].Suggestion = fmt.Sprintf("no memory requirement, set memory to %dMB", s.SuggestedMemory)
+		}
+	}
+	return cli.AsListResult(ds), nil
+}
@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 
 	"github.com/ovh/cds/sdk"
 )
 
-// SendVulnerabilityReport call worker to send vulnerabiliry report to API
+// SendVulnerabilityReport call worker to send vulnerabiliry report to API, it returns an error when the
+// security policy of the project is not respected and fails the node
 func SendVulnerabilityReport(workerHTTPPort int32, report sdk.VulnerabilityWorkerReport) error {
 	if workerHTTPPort == 0 {
 		return nil
@@ -30,11 +32,24 @@ func SendVulnerabilityReport(workerHTTPPort int32, report sdk.VulnerabilityWorke
 	if err != nil {
 		return fmt.Errorf("cannot send report to worker /vulnerability: %v", err)
 	}
+	defer resp.Body.Close()
 
 	if resp.StatusCode >= 300 {
 		return fmt.Errorf("cannot send report to worker /vulnerability: HTTP %d", resp.StatusCode)
 	}
 
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("cannot read security policy report from worker /vulnerability: %v", err)
+	}
+	var policyReport sdk.SecurityPolicyReport
+	if err := json.Unmarshal(body, &policyReport); err != nil {
+		return fmt.Errorf("cannot read security policy report from worker /vulnerability: %v", err)
+	}
+	if policyReport.FailNode {
+		return fmt.Errorf("security policy of the project is not respected: %s", strings.Join(policyReport.Violations, ", "))
+	}
+
 	return nil
 }
 
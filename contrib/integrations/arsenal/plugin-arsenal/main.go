@@ -200,6 +200,10 @@ func (e *arsenalDeploymentPlugin) DeployStatus(ctx context.Context, q *integrati
 	}, nil
 }
 
+func (e *arsenalDeploymentPlugin) Teardown(ctx context.Context, q *integrationplugin.TeardownQuery) (*integrationplugin.DeployResult, error) {
+	return fail("Error: ephemeral environments are not supported by Arsenal")
+}
+
 func main() {
 	e := arsenalDeploymentPlugin{}
 	if err := integrationplugin.Start(context.Background(), &e); err != nil {
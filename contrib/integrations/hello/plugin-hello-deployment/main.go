@@ -175,6 +175,13 @@ func (e *helloDeploymentPlugin) DeployStatus(ctx context.Context, q *integration
 	}, nil
 }
 
+func (e *helloDeploymentPlugin) Teardown(ctx context.Context, q *integrationplugin.TeardownQuery) (*integrationplugin.DeployResult, error) {
+	fmt.Printf("Hello, tearing down ephemeral environment %s\n", q.GetOptions()[sdk.EphemeralEnvironmentNameParam])
+	return &integrationplugin.DeployResult{
+		Status: sdk.StatusSuccess.String(),
+	}, nil
+}
+
 func main() {
 	e := helloDeploymentPlugin{}
 	if err := integrationplugin.Start(context.Background(), &e); err != nil {
@@ -156,6 +156,44 @@ func (k8sPlugin *kubernetesDeploymentPlugin) DeployStatus(ctx context.Context, q
 	}, nil
 }
 
+// Teardown deletes the namespace of an ephemeral environment
+func (k8sPlugin *kubernetesDeploymentPlugin) Teardown(ctx context.Context, q *integrationplugin.TeardownQuery) (*integrationplugin.DeployResult, error) {
+	k8sAPIURL := q.GetOptions()["cds.integration.api_url"]
+	k8sToken := q.GetOptions()["cds.integration.token"]
+	k8sCaCertificate := q.GetOptions()["cds.integration.ca_certificate"]
+	timeoutStr := q.GetOptions()["cds.integration.timeout"]
+	namespace := q.GetOptions()[sdk.EphemeralEnvironmentNameParam]
+
+	if k8sToken == "" {
+		return fail("Kubernetes token should not be empty")
+	}
+	if namespace == "" || namespace == apiv1.NamespaceDefault {
+		return fail("Invalid ephemeral environment name %q", namespace)
+	}
+
+	configK8s, err := clientcmd.BuildConfigFromKubeconfigGetter(k8sAPIURL, getStartingConfig(k8sToken, timeoutStr))
+	if err != nil {
+		return fail("Cannot build kubernetes config from config getter : %v", err)
+	}
+	configK8s.TLSClientConfig = rest.TLSClientConfig{
+		CAData: []byte(k8sCaCertificate),
+	}
+
+	clientset, errCl := kubernetes.NewForConfig(configK8s)
+	if errCl != nil {
+		return fail("Cannot create new config for kubernetes: %v", errCl)
+	}
+
+	fmt.Printf("Deleting namespace %s...\n", namespace)
+	if err := clientset.CoreV1().Namespaces().Delete(namespace, &metav1.DeleteOptions{}); err != nil {
+		return fail("Cannot delete namespace %s in kubernetes: %v", namespace, err)
+	}
+
+	return &integrationplugin.DeployResult{
+		Status: sdk.StatusSuccess.String(),
+	}, nil
+}
+
 func main() {
 	e := kubernetesDeploymentPlugin{}
 	if err := integrationplugin.Start(context.Background(), &e); err != nil {
@@ -181,6 +219,9 @@ func executeK8s(q *integrationplugin.DeployQuery) error {
 	timeoutStr := q.GetOptions()["cds.integration.timeout"]
 	project := q.GetOptions()["cds.project"]
 	workflow := q.GetOptions()["cds.workflow"]
+	if ephemeralName := q.GetOptions()[sdk.EphemeralEnvironmentNameParam]; ephemeralName != "" {
+		namespace = ephemeralName
+	}
 	if namespace == "" {
 		namespace = "default"
 	}
@@ -284,6 +325,9 @@ func executeHelm(q *integrationplugin.DeployQuery) error {
 	project := q.GetOptions()["cds.project"]
 	workflow := q.GetOptions()["cds.workflow"]
 	application := q.GetOptions()["cds.application"]
+	if ephemeralName := q.GetOptions()[sdk.EphemeralEnvironmentNameParam]; ephemeralName != "" {
+		namespace = ephemeralName
+	}
 	if namespace == "" {
 		namespace = "default"
 	}
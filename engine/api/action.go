@@ -81,6 +81,26 @@ func (api *API) getActionsForProjectHandler() service.Handler {
 	}
 }
 
+// getActionsRepositoryForProjectHandler returns the versions of the actions defined in repositories that are used by
+// the pipelines of a project, with the jobs that use each version.
+func (api *API) getActionsRepositoryForProjectHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		key := mux.Vars(r)[permProjectKey]
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key, deprecatedGetUser(ctx))
+		if err != nil {
+			return sdk.WrapError(err, "unable to load projet %s", key)
+		}
+
+		ars, err := action.LoadAllRepositoryByProjectID(api.mustDB(), proj.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, ars, http.StatusOK)
+	}
+}
+
 func (api *API) getActionsForGroupHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		groupID, err := requestVarInt(r, "groupID")
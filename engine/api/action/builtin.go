@@ -134,6 +134,44 @@ The public key have to be granted on your repository`,
 		Type:        sdk.StringParameter,
 		Advanced:    true,
 	})
+	gitclone.Parameter(sdk.Parameter{
+		Name:        "filter",
+		Description: "Make a partial clone with the given filter, for example 'blob:none' to download the content of the files on demand",
+		Type:        sdk.StringParameter,
+		Advanced:    true,
+	})
+	gitclone.Parameter(sdk.Parameter{
+		Name:        "sparseCheckout",
+		Description: "Only check out the given directories (comma separated) and the files at the root of the repository, in sparse-checkout cone mode",
+		Type:        sdk.StringParameter,
+		Advanced:    true,
+	})
+	gitclone.Parameter(sdk.Parameter{
+		Name:        "lfs",
+		Description: "Fetch the Git LFS files, git-lfs must be installed on the worker",
+		Value:       "false",
+		Type:        sdk.BooleanParameter,
+		Advanced:    true,
+	})
+	gitclone.Parameter(sdk.Parameter{
+		Name:        "lfsInclude",
+		Description: "Only fetch the Git LFS files matching the given patterns (comma separated)",
+		Type:        sdk.StringParameter,
+		Advanced:    true,
+	})
+	gitclone.Parameter(sdk.Parameter{
+		Name:        "lfsExclude",
+		Description: "Do not fetch the Git LFS files matching the given patterns (comma separated)",
+		Type:        sdk.StringParameter,
+		Advanced:    true,
+	})
+	gitclone.Parameter(sdk.Parameter{
+		Name:        "mirror",
+		Description: "Clone the repository of the application from its mirror on the CDS repositories service when it exists, then fetch the latest changes from the repository",
+		Value:       "true",
+		Type:        sdk.BooleanParameter,
+		Advanced:    true,
+	})
 	gitclone.Requirement("git", sdk.BinaryRequirement, "git")
 
 	if err := checkBuiltinAction(db, gitclone); err != nil {
@@ -149,6 +187,7 @@ Checkout a repository into a new directory.
 This action use the configuration from application to git clone the repository.
 The clone will be done with a depth of 50 and with submodules.
 If you want to modify theses options, you have to use gitClone action.
+Git LFS files, sparse checkout and partial clone can be set with the advanced parameters.
 `
 
 	checkoutApplication.Parameter(sdk.Parameter{
@@ -157,6 +196,44 @@ If you want to modify theses options, you have to use gitClone action.
 		Value:       "{{.cds.workspace}}",
 		Type:        sdk.StringParameter,
 	})
+	checkoutApplication.Parameter(sdk.Parameter{
+		Name:        "filter",
+		Description: "Make a partial clone with the given filter, for example 'blob:none' to download the content of the files on demand",
+		Type:        sdk.StringParameter,
+		Advanced:    true,
+	})
+	checkoutApplication.Parameter(sdk.Parameter{
+		Name:        "sparseCheckout",
+		Description: "Only check out the given directories (comma separated) and the files at the root of the repository, in sparse-checkout cone mode",
+		Type:        sdk.StringParameter,
+		Advanced:    true,
+	})
+	checkoutApplication.Parameter(sdk.Parameter{
+		Name:        "lfs",
+		Description: "Fetch the Git LFS files, git-lfs must be installed on the worker",
+		Value:       "false",
+		Type:        sdk.BooleanParameter,
+		Advanced:    true,
+	})
+	checkoutApplication.Parameter(sdk.Parameter{
+		Name:        "lfsInclude",
+		Description: "Only fetch the Git LFS files matching the given patterns (comma separated)",
+		Type:        sdk.StringParameter,
+		Advanced:    true,
+	})
+	checkoutApplication.Parameter(sdk.Parameter{
+		Name:        "lfsExclude",
+		Description: "Do not fetch the Git LFS files matching the given patterns (comma separated)",
+		Type:        sdk.StringParameter,
+		Advanced:    true,
+	})
+	checkoutApplication.Parameter(sdk.Parameter{
+		Name:        "mirror",
+		Description: "Clone the repository of the application from its mirror on the CDS repositories service when it exists, then fetch the latest changes from the repository",
+		Value:       "true",
+		Type:        sdk.BooleanParameter,
+		Advanced:    true,
+	})
 	checkoutApplication.Requirement("git", sdk.BinaryRequirement, "git")
 
 	if err := checkBuiltinAction(db, checkoutApplication); err != nil {
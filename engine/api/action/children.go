@@ -16,13 +16,15 @@ func insertActionChild(db gorp.SqlExecutor, child sdk.Action, actionID int64, ex
 	}
 
 	ae := actionEdge{
-		ParentID:       actionID,
-		ChildID:        child.ID,
-		ExecOrder:      int64(execOrder), // TODO exec order can be int 64
-		StepName:       child.StepName,
-		Optional:       child.Optional,
-		AlwaysExecuted: child.AlwaysExecuted,
-		Enabled:        child.Enabled,
+		ParentID:        actionID,
+		ChildID:         child.ID,
+		ExecOrder:       int64(execOrder), // TODO exec order can be int 64
+		StepName:        child.StepName,
+		Optional:        child.Optional,
+		AlwaysExecuted:  child.AlwaysExecuted,
+		ContinueOnError: child.ContinueOnError,
+		Condition:       child.Condition,
+		Enabled:         child.Enabled,
 	}
 	if err := insertEdge(db, &ae); err != nil {
 		return err
@@ -68,12 +70,83 @@ func CheckChildrenForGroupIDs(db gorp.SqlExecutor, a *sdk.Action, groupIDs []int
 	return handleChildrenError(a, children)
 }
 
+// CheckChildrenForProject returns an error if given children not found, children can also be actions defined in
+// repositories resolved for given project.
+func CheckChildrenForProject(db gorp.SqlExecutor, a *sdk.Action, projectID int64, groupIDs []int64) error {
+	if len(a.Actions) == 0 {
+		return nil
+	}
+
+	children, err := LoadAllByIDsWithTypeBuiltinOrPluginOrDefaultInGroupIDsOrRepositoryInProjectID(db, a.ToUniqueChildrenIDs(), groupIDs, projectID)
+	if err != nil {
+		return err
+	}
+	return handleChildrenError(a, children)
+}
+
+// LoadStepsForProject replaces the steps of given job, that only reference their actions by id, with the actions
+// usable by the project, as if the job was loaded from database. The requirements of the job are computed from its steps.
+func LoadStepsForProject(db gorp.SqlExecutor, job *sdk.Action, projectID int64, groupIDs []int64) error {
+	if len(job.Actions) == 0 {
+		return nil
+	}
+
+	children, err := LoadAllByIDsWithTypeBuiltinOrPluginOrDefaultInGroupIDsOrRepositoryInProjectID(db, job.ToUniqueChildrenIDs(), groupIDs, projectID,
+		LoadOptions.WithParameters,
+		LoadOptions.WithRequirements,
+		LoadOptions.WithGroup,
+		LoadOptions.WithChildren,
+	)
+	if err != nil {
+		return err
+	}
+	if err := handleChildrenError(job, children); err != nil {
+		return err
+	}
+
+	m := make(map[int64]sdk.Action, len(children))
+	for i := range children {
+		m[children[i].ID] = children[i]
+	}
+	steps := make([]sdk.Action, len(job.Actions))
+	for i, s := range job.Actions {
+		child := m[s.ID]
+		e := actionEdge{
+			StepName:        s.StepName,
+			Optional:        s.Optional,
+			AlwaysExecuted:  s.AlwaysExecuted,
+			ContinueOnError: s.ContinueOnError,
+			Condition:       s.Condition,
+			Enabled:         s.Enabled,
+			Child:           &child,
+		}
+		for _, p := range s.Parameters {
+			e.Parameters = append(e.Parameters, actionEdgeParameter{Name: p.Name, Type: p.Type, Value: p.Value})
+		}
+		steps[i] = newStepFromEdge(e)
+	}
+	job.Actions = steps
+	job.Requirements = job.FlattenRequirements()
+
+	return nil
+}
+
 // CheckChildrenForGroupIDsWithLoop return an error if given children not found or tree loop detected.
 func CheckChildrenForGroupIDsWithLoop(db gorp.SqlExecutor, a *sdk.Action, groupIDs []int64) error {
-	return checkChildrenForGroupIDsWithLoopStep(db, a, a, groupIDs)
+	return checkChildrenWithLoopStep(a, a, func(ids []int64) ([]sdk.Action, error) {
+		return LoadAllByIDsWithTypeBuiltinOrPluginOrDefaultInGroupIDs(db, ids, groupIDs, LoadOptions.WithChildren)
+	})
 }
 
-func checkChildrenForGroupIDsWithLoopStep(db gorp.SqlExecutor, root, current *sdk.Action, groupIDs []int64) error {
+// CheckChildrenForProjectWithLoop return an error if given children not found or tree loop detected, children can
+// also be actions defined in repositories resolved for given project.
+func CheckChildrenForProjectWithLoop(db gorp.SqlExecutor, a *sdk.Action, projectID int64, groupIDs []int64) error {
+	return checkChildrenWithLoopStep(a, a, func(ids []int64) ([]sdk.Action, error) {
+		return LoadAllByIDsWithTypeBuiltinOrPluginOrDefaultInGroupIDsOrRepositoryInProjectID(db, ids, groupIDs, projectID, LoadOptions.WithChildren)
+	})
+}
+
+func checkChildrenWithLoopStep(root, current *sdk.Action, loadChildren func(ids []int64) ([]sdk.Action, error)) error {
 	if len(current.Actions) == 0 {
 		return nil
 	}
@@ -87,7 +160,7 @@ func checkChildrenForGroupIDsWithLoopStep(db gorp.SqlExecutor, root, current *sd
 		}
 	}
 
-	children, err := LoadAllByIDsWithTypeBuiltinOrPluginOrDefaultInGroupIDs(db, childrenIDs, groupIDs, LoadOptions.WithChildren)
+	children, err := loadChildren(childrenIDs)
 	if err != nil {
 		return err
 	}
@@ -96,7 +169,7 @@ func checkChildrenForGroupIDsWithLoopStep(db gorp.SqlExecutor, root, current *sd
 	}
 
 	for i := range children {
-		if err := checkChildrenForGroupIDsWithLoopStep(db, root, &children[i], groupIDs); err != nil {
+		if err := checkChildrenWithLoopStep(root, &children[i], loadChildren); err != nil {
 			return err
 		}
 	}
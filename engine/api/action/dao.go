@@ -111,6 +111,33 @@ func LoadAllByIDsWithTypeBuiltinOrPluginOrDefaultInGroupIDs(db gorp.SqlExecutor,
 	return getAll(db, query, opts...)
 }
 
+// LoadAllByIDsWithTypeBuiltinOrPluginOrDefaultInGroupIDsOrRepositoryInProjectID returns all actions for given ids.
+// Action should be of type builtin, plugin, default or repository. Default action should be in given group ids list
+// and repository action should be resolved for given project.
+func LoadAllByIDsWithTypeBuiltinOrPluginOrDefaultInGroupIDsOrRepositoryInProjectID(db gorp.SqlExecutor, ids, groupIDs []int64, projectID int64, opts ...LoadOptionFunc) ([]sdk.Action, error) {
+	query := gorpmapping.NewQuery(`
+    SELECT *
+    FROM action
+    WHERE
+      id = ANY(string_to_array($1, ',')::int[])
+      AND (
+        type = $2
+        OR type = $3
+        OR (type = $4 AND group_id = ANY(string_to_array($5, ',')::int[]))
+        OR (type = $6 AND id IN (SELECT action_id FROM action_repository WHERE project_id = $7))
+      )
+  `).Args(
+		gorpmapping.IDsToQueryString(ids),
+		sdk.BuiltinAction,
+		sdk.PluginAction,
+		sdk.DefaultAction,
+		gorpmapping.IDsToQueryString(groupIDs),
+		sdk.RepositoryAction,
+		projectID,
+	)
+	return getAll(db, query, opts...)
+}
+
 // LoadTypeDefaultByNameAndGroupID returns an action from database with given name and group id.
 func LoadTypeDefaultByNameAndGroupID(db gorp.SqlExecutor, name string, groupID int64, opts ...LoadOptionFunc) (*sdk.Action, error) {
 	query := gorpmapping.NewQuery(
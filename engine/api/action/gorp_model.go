@@ -31,14 +31,16 @@ func actionParametersToParameters(aps []actionParameter) []sdk.Parameter {
 }
 
 type actionEdge struct {
-	ID             int64  `db:"id"`
-	ParentID       int64  `db:"parent_id"`
-	ChildID        int64  `db:"child_id"`
-	ExecOrder      int64  `db:"exec_order"`
-	Enabled        bool   `db:"enabled"`
-	Optional       bool   `db:"optional"`
-	AlwaysExecuted bool   `db:"always_executed"`
-	StepName       string `db:"step_name"`
+	ID              int64  `db:"id"`
+	ParentID        int64  `db:"parent_id"`
+	ChildID         int64  `db:"child_id"`
+	ExecOrder       int64  `db:"exec_order"`
+	Enabled         bool   `db:"enabled"`
+	Optional        bool   `db:"optional"`
+	AlwaysExecuted  bool   `db:"always_executed"`
+	ContinueOnError bool   `db:"continue_on_error"`
+	Condition       string `db:"condition"`
+	StepName        string `db:"step_name"`
 	// aggregates
 	Parameters []actionEdgeParameter `db:"-"`
 	Child      *sdk.Action           `db:"-"`
@@ -149,27 +149,7 @@ func loadChildren(db gorp.SqlExecutor, as ...*sdk.Action) error {
 
 		children := make([]sdk.Action, len(edges))
 		for i := range edges {
-			// init child from edge child then override with edge attributes and parameters
-			child := *edges[i].Child
-			child.StepName = edges[i].StepName
-			child.Optional = edges[i].Optional
-			child.AlwaysExecuted = edges[i].AlwaysExecuted
-			child.Enabled = edges[i].Enabled
-
-			// replace action parameter with value configured by user when he created the child action
-			params := make([]sdk.Parameter, len(child.Parameters))
-			for j := range child.Parameters {
-				params[j] = child.Parameters[j]
-				for k := range edges[i].Parameters {
-					if edges[i].Parameters[k].Name == params[j].Name {
-						params[j].Value = edges[i].Parameters[k].Value
-						break
-					}
-				}
-			}
-			child.Parameters = params
-
-			children[i] = child
+			children[i] = newStepFromEdge(edges[i])
 		}
 
 		actionsNotBuiltIn[i].Actions = children
@@ -183,6 +163,32 @@ func loadChildren(db gorp.SqlExecutor, as ...*sdk.Action) error {
 	return nil
 }
 
+// newStepFromEdge inits a step from the edge child then overrides it with edge attributes and parameters.
+func newStepFromEdge(e actionEdge) sdk.Action {
+	child := *e.Child
+	child.StepName = e.StepName
+	child.Optional = e.Optional
+	child.AlwaysExecuted = e.AlwaysExecuted
+	child.ContinueOnError = e.ContinueOnError
+	child.Condition = e.Condition
+	child.Enabled = e.Enabled
+
+	// replace action parameter with value configured by user when he created the child action
+	params := make([]sdk.Parameter, len(child.Parameters))
+	for j := range child.Parameters {
+		params[j] = child.Parameters[j]
+		for k := range e.Parameters {
+			if e.Parameters[k].Name == params[j].Name {
+				params[j].Value = e.Parameters[k].Value
+				break
+			}
+		}
+	}
+	child.Parameters = params
+
+	return child
+}
+
 func loadGroup(db gorp.SqlExecutor, as ...*sdk.Action) error {
 	gs := []sdk.Group{}
 
@@ -0,0 +1,98 @@
+package action
+
+import (
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+func init() {
+	gorpmapping.Register(gorpmapping.New(sdk.ActionRepository{}, "action_repository", true, "id"))
+}
+
+// LoadRepositoryByRef returns the action defined in a repository resolved for given project and reference.
+func LoadRepositoryByRef(db gorp.SqlExecutor, projectID int64, ref sdk.ActionRepositoryRef) (*sdk.ActionRepository, error) {
+	query := gorpmapping.NewQuery(`
+    SELECT *
+    FROM action_repository
+    WHERE project_id = $1 AND repository = $2 AND ref = $3 AND name = $4
+  `).Args(projectID, ref.Repository, ref.Ref, ref.Name)
+
+	var ar sdk.ActionRepository
+	found, err := gorpmapping.Get(db, query, &ar)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot get repository action %s", ref)
+	}
+	if !found {
+		return nil, nil
+	}
+	return &ar, nil
+}
+
+// LoadAllRepositoryByProjectID returns all the versions of the actions defined in repositories resolved for given
+// project, with the jobs of the pipelines that use each version.
+func LoadAllRepositoryByProjectID(db gorp.SqlExecutor, projectID int64) ([]sdk.ActionRepository, error) {
+	query := gorpmapping.NewQuery(`
+    SELECT *
+    FROM action_repository
+    WHERE project_id = $1
+    ORDER BY repository, name, ref
+  `).Args(projectID)
+
+	ars := []sdk.ActionRepository{}
+	if err := gorpmapping.GetAll(db, query, &ars); err != nil {
+		return nil, sdk.WrapError(err, "cannot get repository actions")
+	}
+
+	for i := range ars {
+		us, err := loadRepositoryUsages(db, ars[i].ActionID)
+		if err != nil {
+			return nil, err
+		}
+		ars[i].Pipelines = us
+	}
+
+	return ars, nil
+}
+
+func loadRepositoryUsages(db gorp.SqlExecutor, actionID int64) ([]sdk.ActionRepositoryUsage, error) {
+	rows, err := db.Query(`
+    SELECT DISTINCT pipeline.name, pipeline_stage.name, job.name
+    FROM action_edge
+    JOIN action AS job ON job.id = action_edge.parent_id
+    JOIN pipeline_action ON pipeline_action.action_id = job.id
+    JOIN pipeline_stage ON pipeline_stage.id = pipeline_action.pipeline_stage_id
+    JOIN pipeline ON pipeline.id = pipeline_stage.pipeline_id
+    WHERE action_edge.child_id = $1
+    ORDER BY pipeline.name, pipeline_stage.name, job.name
+  `, actionID)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load pipeline usages for action with id %d", actionID)
+	}
+	defer rows.Close()
+
+	us := []sdk.ActionRepositoryUsage{}
+	for rows.Next() {
+		var u sdk.ActionRepositoryUsage
+		if err := rows.Scan(&u.PipelineName, &u.StageName, &u.JobName); err != nil {
+			return nil, sdk.WrapError(err, "cannot scan sql rows")
+		}
+		us = append(us, u)
+	}
+
+	return us, nil
+}
+
+func insertRepository(db gorp.SqlExecutor, ar *sdk.ActionRepository) error {
+	ar.Created = time.Now()
+	ar.LastModified = ar.Created
+	return sdk.WrapError(gorpmapping.Insert(db, ar), "unable to insert repository action %s", ar.ActionRepositoryRef())
+}
+
+func updateRepository(db gorp.SqlExecutor, ar *sdk.ActionRepository) error {
+	ar.LastModified = time.Now()
+	return sdk.WrapError(gorpmapping.Update(db, ar), "unable to update repository action %s", ar.ActionRepositoryRef())
+}
@@ -0,0 +1,233 @@
+package action
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/application"
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/engine/api/services"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// ResolveRepositoryChildren resolves the children of given action that reference actions defined in repositories,
+// and sets their ids. Each reference is resolved once per call.
+func ResolveRepositoryChildren(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, a *sdk.Action, groupIDs []int64) error {
+	resolved := map[string]int64{}
+	for i := range a.Actions {
+		if a.Actions[i].Type != sdk.RepositoryAction {
+			continue
+		}
+		if id, ok := resolved[a.Actions[i].Name]; ok {
+			a.Actions[i].ID = id
+			continue
+		}
+		ref, err := sdk.ParseActionRepositoryRef(a.Actions[i].Name)
+		if err != nil {
+			return err
+		}
+		ra, err := ResolveRepository(ctx, db, store, proj, ref, groupIDs)
+		if err != nil {
+			return err
+		}
+		a.Actions[i].ID = ra.ID
+		resolved[a.Actions[i].Name] = ra.ID
+	}
+	return nil
+}
+
+// ResolveRepository returns the action defined in a repository for given reference. An action resolved for a tag is
+// loaded from the project cache, otherwise its file is loaded from the repository with the repositories service
+// then the cache is updated. The repository should be the one of an application of the project.
+func ResolveRepository(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, ref sdk.ActionRepositoryRef, groupIDs []int64) (*sdk.Action, error) {
+	ar, err := LoadRepositoryByRef(db, proj.ID, ref)
+	if err != nil {
+		return nil, err
+	}
+	if ar != nil && ref.IsTag() {
+		return LoadByID(db, ar.ActionID, LoadOptions.WithParameters, LoadOptions.WithRequirements)
+	}
+
+	ope, err := loadRepositoryActionFile(ctx, db, store, proj, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	btes, ok := ope.LoadFiles.Results[ref.FilePath()]
+	if !ok {
+		return nil, sdk.NewErrorFrom(sdk.ErrNoAction, "file %s not found in repository %s at %s", ref.FilePath(), ref.Repository, ref.Ref)
+	}
+	var ea exportentities.Action
+	if err := exportentities.Unmarshal(btes, exportentities.FormatYAML, &ea); err != nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "cannot parse action %s: %v", ref, err)
+	}
+	if ea.Name != "" && ea.Name != ref.Name {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "action %s is named %s in file %s", ref, ea.Name, ref.FilePath())
+	}
+
+	a, err := ea.Action()
+	if err != nil {
+		return nil, err
+	}
+	a.Name = ref.String()
+	a.Type = sdk.RepositoryAction
+	a.Group = nil
+	a.Enabled = true
+
+	// an action defined in a repository can only use builtin, plugin and default actions
+	for i := range a.Actions {
+		if a.Actions[i].Type == sdk.RepositoryAction {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "action %s cannot use action %s defined in a repository", ref, a.Actions[i].Name)
+		}
+		child, err := RetrieveForGroupAndName(db, a.Actions[i].Group, a.Actions[i].Name)
+		if err != nil {
+			return nil, err
+		}
+		a.Actions[i].ID = child.ID
+	}
+	if err := a.IsValid(); err != nil {
+		return nil, err
+	}
+	if err := CheckChildrenForGroupIDs(db, &a, groupIDs); err != nil {
+		return nil, err
+	}
+
+	if ar == nil {
+		if err := Insert(db, &a); err != nil {
+			return nil, err
+		}
+		ar = &sdk.ActionRepository{
+			ActionID:   a.ID,
+			ProjectID:  proj.ID,
+			Repository: ref.Repository,
+			Ref:        ref.Ref,
+			Name:       ref.Name,
+		}
+	} else {
+		a.ID = ar.ActionID
+		if err := Update(db, &a); err != nil {
+			return nil, err
+		}
+	}
+	ar.VCSServer = ope.VCSServer
+	ar.Commit = ope.Setup.Checkout.Commit
+	if ar.ID == 0 {
+		err = insertRepository(db, ar)
+	} else {
+		err = updateRepository(db, ar)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("ResolveRepository> action %s resolved for project %s", ref, proj.Key)
+	return &a, nil
+}
+
+// loadRepositoryActionFile loads the file of an action from the repository of an application of the project.
+func loadRepositoryActionFile(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, ref sdk.ActionRepositoryRef) (*sdk.Operation, error) {
+	apps, err := application.LoadAll(db, store, proj.Key, application.LoadOptions.WithClearKeys)
+	if err != nil {
+		return nil, err
+	}
+	var app *sdk.Application
+	for i := range apps {
+		if apps[i].VCSServer != "" && apps[i].RepositoryFullname == ref.Repository {
+			app = &apps[i]
+			break
+		}
+	}
+	if app == nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrNoAction, "no application of project %s is linked to repository %s used by action %s", proj.Key, ref.Repository, ref)
+	}
+
+	vcsServer := repositoriesmanager.GetProjectVCSServer(&proj, app.VCSServer)
+	client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, vcsServer)
+	if err != nil {
+		return nil, sdk.NewErrorWithStack(err, sdk.ErrNoReposManagerClientAuth)
+	}
+	repo, err := client.RepoByFullname(ctx, ref.Repository)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot get repository %s", ref.Repository)
+	}
+
+	vcsStrategy := app.RepositoryStrategy
+	url := repo.HTTPCloneURL
+	if vcsStrategy.ConnectionType == "ssh" {
+		url = repo.SSHCloneURL
+		// The key can be an application key, or a project key
+		for _, k := range app.Keys {
+			if k.Name == vcsStrategy.SSHKey {
+				vcsStrategy.SSHKeyContent = k.Private
+			}
+		}
+		for _, k := range proj.Keys {
+			if vcsStrategy.SSHKeyContent == "" && k.Name == vcsStrategy.SSHKey && k.Private != sdk.PasswordPlaceholder {
+				vcsStrategy.SSHKeyContent = k.Private
+			}
+		}
+		if vcsStrategy.SSHKeyContent == "" {
+			return nil, sdk.NewErrorFrom(sdk.ErrNoAction, "ssh key %s of application %s not found to load action %s", vcsStrategy.SSHKey, app.Name, ref)
+		}
+		vcsStrategy.User = ""
+		vcsStrategy.Password = ""
+	} else {
+		if err := application.DecryptVCSStrategyPassword(app); err != nil {
+			return nil, sdk.WrapError(err, "unable to decrypt vcs strategy")
+		}
+		vcsStrategy = app.RepositoryStrategy
+		vcsStrategy.SSHKey = ""
+	}
+
+	ope := sdk.Operation{
+		VCSServer:          app.VCSServer,
+		RepoFullName:       ref.Repository,
+		URL:                url,
+		RepositoryStrategy: vcsStrategy,
+		LoadFiles: sdk.OperationLoadFiles{
+			Pattern: ref.FilePath(),
+		},
+	}
+	if ref.IsTag() {
+		ope.Setup.Checkout.Tag = ref.Ref
+	} else {
+		ope.Setup.Checkout.Branch = ref.Ref
+	}
+
+	srvs, err := services.FindByType(db, services.TypeRepositories)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to found repositories service")
+	}
+	if _, err := services.DoJSONRequest(ctx, srvs, http.MethodPost, "/operations", ope, &ope); err != nil {
+		return nil, sdk.WrapError(err, "unable to perform operation")
+	}
+
+	tickTimeout := time.NewTimer(5 * time.Minute)
+	defer tickTimeout.Stop()
+	tickPoll := time.NewTicker(2 * time.Second)
+	defer tickPoll.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, sdk.WithStack(ctx.Err())
+		case <-tickTimeout.C:
+			return nil, sdk.WrapError(sdk.ErrRepoOperationTimeout, "timeout loading action %s", ref)
+		case <-tickPoll.C:
+			if _, err := services.DoJSONRequest(ctx, srvs, http.MethodGet, "/operations/"+ope.UUID, nil, &ope); err != nil {
+				return nil, sdk.WrapError(err, "unable to get operation")
+			}
+			switch ope.Status {
+			case sdk.OperationStatusError:
+				return nil, sdk.NewErrorFrom(sdk.ErrNoAction, "cannot load action %s from repository: %s", ref, ope.Error)
+			case sdk.OperationStatusDone:
+				return &ope, nil
+			}
+		}
+	}
+}
@@ -143,6 +143,7 @@ type Configuration struct {
 		} `toml:"kafka" json:"kafka"`
 	} `toml:"events" comment:"#######################\n CDS Events Settings \n######################" json:"events"`
 	Features struct {
+		Mode    string `toml:"mode" default:"izanami" comment:"Feature flipping backend: izanami or database. With database, features are managed with cdsctl admin feature" json:"mode"`
 		Izanami struct {
 			APIURL       string `toml:"apiurl" json:"apiurl"`
 			ClientID     string `toml:"clientid" json:"-"`
@@ -356,6 +357,12 @@ func (a *API) CheckConfiguration(config interface{}) error {
 		}
 	}
 
+	switch aConfig.Features.Mode {
+	case "", "izanami", "database":
+	default:
+		return fmt.Errorf("Invalid feature flipping mode")
+	}
+
 	if len(aConfig.Secrets.Key) != 32 {
 		return fmt.Errorf("Invalid secret key. It should be 32 bits (%d)", len(aConfig.Secrets.Key))
 	}
@@ -545,10 +552,16 @@ func (a *API) Serve(ctx context.Context) error {
 		a.Config.SMTP.Disable)
 
 	// Initialize feature packages
-	log.Info("Initializing feature flipping with izanami %s", a.Config.Features.Izanami.APIURL)
-	if a.Config.Features.Izanami.APIURL != "" {
-		if err := feature.Init(a.Config.Features.Izanami.APIURL, a.Config.Features.Izanami.ClientID, a.Config.Features.Izanami.ClientSecret); err != nil {
-			return fmt.Errorf("Feature flipping not enabled with izanami: %v", err)
+	switch a.Config.Features.Mode {
+	case "database":
+		log.Info("Initializing feature flipping with database")
+		feature.InitDatabase(a.mustDB)
+	default:
+		log.Info("Initializing feature flipping with izanami %s", a.Config.Features.Izanami.APIURL)
+		if a.Config.Features.Izanami.APIURL != "" {
+			if err := feature.Init(a.Config.Features.Izanami.APIURL, a.Config.Features.Izanami.ClientID, a.Config.Features.Izanami.ClientSecret); err != nil {
+				return fmt.Errorf("Feature flipping not enabled with izanami: %v", err)
+			}
 		}
 	}
 
@@ -764,6 +777,9 @@ func (a *API) Serve(ctx context.Context) error {
 	sdk.GoRoutine(ctx, "api.serviceAPIHeartbeat", func(ctx context.Context) {
 		a.serviceAPIHeartbeat(ctx)
 	}, a.PanicDump())
+	sdk.GoRoutine(ctx, "api.checkWorkerModelsRegistryUpdates", func(ctx context.Context) {
+		a.checkWorkerModelsRegistryUpdates(ctx)
+	}, a.PanicDump())
 
 	//Temporary migration code
 	//DEPRECATED Migrations
@@ -873,6 +889,14 @@ func (a *API) Serve(ctx context.Context) error {
 		func(ctx context.Context) {
 			purge.Initialize(ctx, a.Cache, a.DBConnectionFactory.GetDBMap, a.Metrics.WorkflowRunsMarkToDelete, a.Metrics.WorkflowRunsDeleted)
 		}, a.PanicDump())
+	sdk.GoRoutine(ctx, "api.ephemeralEnvironmentsRoutine",
+		func(ctx context.Context) {
+			a.ephemeralEnvironmentsRoutine(ctx)
+		}, a.PanicDump())
+	sdk.GoRoutine(ctx, "api.workflowNodeRunApprovalsRoutine",
+		func(ctx context.Context) {
+			a.workflowNodeRunApprovalsRoutine(ctx)
+		}, a.PanicDump())
 
 	s := &http.Server{
 		Addr:           fmt.Sprintf("%s:%d", a.Config.HTTP.Addr, a.Config.HTTP.Port),
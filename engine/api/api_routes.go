@@ -48,6 +48,7 @@ func (api *API) InitRouter() {
 	r.Handle("/action/{groupName}/{permActionName}/audit/{auditID}/rollback", r.POST(api.postActionAuditRollbackHandler))
 	r.Handle("/action/requirement", r.GET(api.getActionsRequirements, Auth(false))) // FIXME add auth used by hatcheries
 	r.Handle("/project/{permProjectKey}/action", r.GET(api.getActionsForProjectHandler))
+	r.Handle("/project/{permProjectKey}/action/repository", r.GET(api.getActionsRepositoryForProjectHandler))
 	r.Handle("/group/{groupID}/action", r.GET(api.getActionsForGroupHandler))
 	r.Handle("/actionBuiltin", r.GET(api.getActionsBuiltinHandler))
 	r.Handle("/actionBuiltin/{permActionBuiltinName}", r.GET(api.getActionBuiltinHandler))
@@ -56,6 +57,8 @@ func (api *API) InitRouter() {
 	// Admin
 	r.Handle("/admin/maintenance", r.POST(api.postMaintenanceHandler, NeedAdmin(true)))
 	r.Handle("/admin/warning", r.DELETE(api.adminTruncateWarningsHandler, NeedAdmin(true)))
+	r.Handle("/admin/feature", r.GET(api.getAdminFeaturesHandler, NeedAdmin(true)))
+	r.Handle("/admin/feature/{name}", r.GET(api.getAdminFeatureHandler, NeedAdmin(true)), r.PUT(api.putAdminFeatureHandler, NeedAdmin(true)), r.DELETE(api.deleteAdminFeatureHandler, NeedAdmin(true)))
 	r.Handle("/admin/cds/migration", r.GET(api.getAdminMigrationsHandler, NeedAdmin(true)))
 	r.Handle("/admin/cds/migration/{id}/cancel", r.POST(api.postAdminMigrationCancelHandler, NeedAdmin(true)))
 	r.Handle("/admin/cds/migration/{id}/todo", r.POST(api.postAdminMigrationTodoHandler, NeedAdmin(true)))
@@ -145,6 +148,12 @@ func (api *API) InitRouter() {
 	r.Handle("/project/{permProjectKey}/all/keys", r.GET(api.getAllKeysProjectHandler))
 	r.Handle("/project/{permProjectKey}/keys", r.GET(api.getKeysInProjectHandler), r.POST(api.addKeyInProjectHandler))
 	r.Handle("/project/{permProjectKey}/keys/{name}", r.DELETE(api.deleteKeyInProjectHandler))
+	r.Handle("/project/{permProjectKey}/provenance/key", r.GET(api.getProvenanceKeyInProjectHandler))
+	r.Handle("/project/{permProjectKey}/freeze", r.GET(api.getFreezeWindowsHandler), r.POST(api.postFreezeWindowHandler))
+	r.Handle("/project/{permProjectKey}/freeze/calendar", r.GET(api.getFreezeCalendarHandler))
+	r.Handle("/project/{permProjectKey}/freeze/{id}", r.PUT(api.putFreezeWindowHandler), r.DELETE(api.deleteFreezeWindowHandler))
+	r.Handle("/project/{permProjectKey}/security/policy", r.GET(api.getProjectSecurityPolicyHandler), r.PUT(api.putProjectSecurityPolicyHandler))
+	r.Handle("/project/{permProjectKey}/bundle", r.GET(api.getProjectBundleHandler), r.POST(api.postProjectBundleHandler))
 	// Import Application
 	r.Handle("/project/{permProjectKey}/import/application", r.POST(api.postApplicationImportHandler))
 	// Export Application
@@ -156,6 +165,7 @@ func (api *API) InitRouter() {
 	// Application
 	r.Handle("/project/{permProjectKey}/application/{applicationName}", r.GET(api.getApplicationHandler), r.PUT(api.updateApplicationHandler), r.DELETE(api.deleteApplicationHandler))
 	r.Handle("/project/{permProjectKey}/application/{applicationName}/metrics/{metricName}", r.GET(api.getApplicationMetricHandler))
+	r.Handle("/project/{permProjectKey}/metrics/dora", r.GET(api.getProjectDORAMetricsHandler))
 	r.Handle("/project/{permProjectKey}/application/{applicationName}/keys", r.GET(api.getKeysInApplicationHandler), r.POST(api.addKeyInApplicationHandler))
 	r.Handle("/project/{permProjectKey}/application/{applicationName}/keys/{name}", r.DELETE(api.deleteKeyInApplicationHandler))
 	r.Handle("/project/{permProjectKey}/application/{applicationName}/vcsinfos", r.GET(api.getApplicationVCSInfosHandler))
@@ -165,6 +175,10 @@ func (api *API) InitRouter() {
 	r.Handle("/project/{permProjectKey}/application/{applicationName}/variable/{name}", r.GET(api.getVariableInApplicationHandler), r.POST(api.addVariableInApplicationHandler), r.PUT(api.updateVariableInApplicationHandler), r.DELETE(api.deleteVariableFromApplicationHandler))
 	r.Handle("/project/{permProjectKey}/application/{applicationName}/variable/{name}/audit", r.GET(api.getVariableAuditInApplicationHandler))
 	r.Handle("/project/{permProjectKey}/application/{applicationName}/vulnerability/{id}", r.POST(api.postVulnerabilityHandler))
+	r.Handle("/project/{permProjectKey}/application/{applicationName}/components", r.GET(api.getApplicationComponentsHandler))
+	r.Handle("/project/{permProjectKey}/application/{applicationName}/release", r.GET(api.getApplicationReleasesHandler), r.POST(api.postApplicationReleaseHandler))
+	r.Handle("/project/{permProjectKey}/application/{applicationName}/release/{version}", r.GET(api.getApplicationReleaseHandler))
+	r.Handle("/project/{permProjectKey}/application/{applicationName}/release/{version}/artifact/{artifactName}/download", r.GET(api.getApplicationReleaseArtifactDownloadHandler))
 	// Application deployment
 	r.Handle("/project/{permProjectKey}/application/{applicationName}/deployment/config/{integration}", r.POST(api.postApplicationDeploymentStrategyConfigHandler, AllowProvider(true)), r.GET(api.getApplicationDeploymentStrategyConfigHandler), r.DELETE(api.deleteApplicationDeploymentStrategyConfigHandler))
 	r.Handle("/project/{permProjectKey}/application/{applicationName}/deployment/config", r.GET(api.getApplicationDeploymentStrategiesConfigHandler))
@@ -194,6 +208,7 @@ func (api *API) InitRouter() {
 
 	// Workflows
 	r.Handle("/workflow/artifact/{hash}", r.GET(api.downloadworkflowArtifactDirectHandler, Auth(false)))
+	r.Handle("/workflow/approval/{token}", r.POST(api.postWorkflowNodeRunApprovalCallbackHandler))
 
 	r.Handle("/project/{permProjectKey}/workflows", r.POST(api.postWorkflowHandler, EnableTracing()), r.GET(api.getWorkflowsHandler, AllowProvider(true), EnableTracing()))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}", r.GET(api.getWorkflowHandler, AllowProvider(true), EnableTracing()), r.PUT(api.putWorkflowHandler, EnableTracing()), r.DELETE(api.deleteWorkflowHandler))
@@ -228,22 +243,28 @@ func (api *API) InitRouter() {
 	// Workflows run
 	r.Handle("/project/{permProjectKey}/runs", r.GET(api.getWorkflowAllRunsHandler, EnableTracing()))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/artifact/{artifactId}", r.GET(api.getDownloadArtifactHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/artifact/{artifactId}/provenance", r.GET(api.getArtifactProvenanceHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs", r.GET(api.getWorkflowRunsHandler, EnableTracing()), r.POSTEXECUTE(api.postWorkflowRunHandler, AllowServices(true), EnableTracing()))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/usage", r.GET(api.getWorkflowResourceUsageHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/latest", r.GET(api.getLatestWorkflowRunHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/tags", r.GET(api.getWorkflowRunTagsHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/num", r.GET(api.getWorkflowRunNumHandler), r.POST(api.postWorkflowRunNumHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}", r.GET(api.getWorkflowRunHandler, AllowServices(true)))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/stop", r.POSTEXECUTE(api.stopWorkflowRunHandler, EnableTracing()))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/diff/{otherNumber}", r.GET(api.getWorkflowRunDiffHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/usage", r.GET(api.getWorkflowRunResourceUsageHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/vcs/resync", r.POSTEXECUTE(api.postResyncVCSWorkflowRunHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/resync", r.POST(api.resyncWorkflowRunHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/artifacts", r.GET(api.getWorkflowRunArtifactsHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}", r.GET(api.getWorkflowNodeRunHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/stop", r.POSTEXECUTE(api.stopWorkflowNodeRunHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/approval", r.GET(api.getWorkflowNodeRunApprovalHandler), r.POSTEXECUTE(api.postWorkflowNodeRunApprovalHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeID}/history", r.GET(api.getWorkflowNodeRunHistoryHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/{nodeName}/commits", r.GET(api.getWorkflowCommitsHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/job/{runJobId}/info", r.GET(api.getWorkflowNodeRunJobSpawnInfosHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/job/{runJobId}/log/service", r.GET(api.getWorkflowNodeRunJobServiceLogsHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/job/{runJobId}/step/{stepOrder}", r.GET(api.getWorkflowNodeRunJobStepHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/job/{runJobId}/debug", r.GET(api.getWorkflowNodeRunJobDebugHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/node/{nodeID}/triggers/condition", r.GET(api.getWorkflowTriggerConditionHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/release", r.POST(api.releaseApplicationWorkflowHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/hooks/{hookRunID}/callback", r.POST(api.postWorkflowJobHookCallbackHandler, AllowServices(true)))
@@ -286,6 +307,9 @@ func (api *API) InitRouter() {
 	r.Handle("/queue/workflows/{id}/attempt", r.POST(api.postIncWorkflowJobAttemptHandler, NeedHatchery(), EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{id}/infos", r.GET(api.getWorkflowJobHandler, NeedWorker(), NeedHatchery(), EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permID}/vulnerability", r.POSTEXECUTE(api.postVulnerabilityReportHandler, NeedWorker(), EnableTracing(), MaintenanceAware()))
+	r.Handle("/queue/workflows/{permID}/sbom", r.POSTEXECUTE(api.postSBOMReportHandler, NeedWorker(), EnableTracing(), MaintenanceAware()))
+	r.Handle("/queue/workflows/{permID}/stages", r.POSTEXECUTE(api.postWorkflowJobStagesHandler, NeedWorker(), EnableTracing(), MaintenanceAware()))
+	r.Handle("/queue/workflows/{permID}/debug", r.GET(api.getWorkflowJobDebugHandler, NeedWorker(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{id}/spawn/infos", r.POST(r.Asynchronous(api.postSpawnInfosWorkflowJobHandler, 1), NeedHatchery(), EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permID}/result", r.POSTEXECUTE(api.postWorkflowJobResultHandler, NeedWorker(), EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permID}/log", r.POSTEXECUTE(r.Asynchronous(api.postWorkflowJobLogsHandler, 1), NeedWorker(), MaintenanceAware()))
@@ -295,6 +319,8 @@ func (api *API) InitRouter() {
 	r.Handle("/queue/workflows/{permID}/tag", r.POSTEXECUTE(api.postWorkflowJobTagsHandler, NeedWorker(), EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permID}/variable", r.POSTEXECUTE(api.postWorkflowJobVariableHandler, NeedWorker(), EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permID}/step", r.POSTEXECUTE(api.postWorkflowJobStepStatusHandler, NeedWorker(), EnableTracing(), MaintenanceAware()))
+	r.Handle("/queue/workflows/{permID}/usage", r.POSTEXECUTE(api.postWorkflowJobResourceUsageHandler, NeedWorker(), EnableTracing(), MaintenanceAware()))
+	r.Handle("/queue/workflows/{permID}/mirror/{path:.*}", r.GET(api.workflowJobMirrorHandler, NeedWorker(), MaintenanceAware()), r.POSTEXECUTE(api.workflowJobMirrorHandler, NeedWorker(), MaintenanceAware()))
 
 	r.Handle("/variable/type", r.GET(api.getVariableTypeHandler))
 	r.Handle("/parameter/type", r.GET(api.getParameterTypeHandler))
@@ -332,6 +358,10 @@ func (api *API) InitRouter() {
 	r.Handle("/config/user", r.GET(api.ConfigUserHandler, Auth(false)))
 	r.Handle("/config/vcs", r.GET(api.ConfigVCShandler))
 
+	// JSON Schemas of CDS files
+	r.Handle("/schema", r.GET(api.getSchemasHandler, Auth(false)))
+	r.Handle("/schema/{kind}", r.GET(api.getSchemaHandler, Auth(false)))
+
 	// Users
 	r.Handle("/user", r.GET(api.getUsersHandler))
 	r.Handle("/user/logged", r.GET(api.getUserLoggedHandler, Auth(false)))
@@ -370,6 +400,10 @@ func (api *API) InitRouter() {
 	r.Handle("/worker/model/{permModelID}", r.PUT(api.updateWorkerModelHandler), r.DELETE(api.deleteWorkerModelHandler))
 	r.Handle("/worker/model/{modelID}/export", r.GET(api.getWorkerModelExportHandler))
 	r.Handle("/worker/model/{modelID}/usage", r.GET(api.getWorkerModelUsageHandler))
+	r.Handle("/worker/model/{permModelID}/version", r.GET(api.getWorkerModelVersionsHandler))
+	r.Handle("/worker/model/{permModelID}/version/{version}", r.GET(api.getWorkerModelVersionHandler))
+	r.Handle("/worker/model/{permModelID}/rollback", r.POST(api.postWorkerModelRollbackHandler))
+	r.Handle("/worker/model/{permModelID}/canary", r.PUT(api.putWorkerModelCanaryHandler), r.DELETE(api.deleteWorkerModelCanaryHandler))
 	r.Handle("/worker/model/capability/type", r.GET(api.getRequirementTypesHandler))
 
 	// Workflows
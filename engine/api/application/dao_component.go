@@ -0,0 +1,54 @@
+package application
+
+import (
+	"encoding/json"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadComponents loads the components of an application, found in the SBOM of the default branch
+func LoadComponents(db gorp.SqlExecutor, appID int64) ([]sdk.Component, error) {
+	rows, err := db.Query(`SELECT id, application_id, name, version, type, purl, licenses
+	FROM application_component WHERE application_id = $1 ORDER BY name`, appID)
+	if err != nil {
+		return nil, sdk.WrapError(err, "Cannot load components of application %d", appID)
+	}
+	defer rows.Close()
+
+	cs := []sdk.Component{}
+	for rows.Next() {
+		var c sdk.Component
+		var licenses []byte
+		if err := rows.Scan(&c.ID, &c.ApplicationID, &c.Name, &c.Version, &c.Type, &c.PURL, &licenses); err != nil {
+			return nil, sdk.WrapError(err, "Cannot scan component")
+		}
+		if len(licenses) > 0 {
+			if err := json.Unmarshal(licenses, &c.Licenses); err != nil {
+				return nil, sdk.WrapError(err, "Cannot unmarshal licenses of component %s", c.Name)
+			}
+		}
+		cs = append(cs, c)
+	}
+	return cs, nil
+}
+
+// InsertComponents replaces the components of an application
+func InsertComponents(db gorp.SqlExecutor, cs []sdk.Component, appID int64) error {
+	if _, err := db.Exec("DELETE FROM application_component WHERE application_id = $1", appID); err != nil {
+		return sdk.WrapError(err, "Unable to remove old components")
+	}
+	for i := range cs {
+		licenses, err := json.Marshal(cs[i].Licenses)
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		cs[i].ApplicationID = appID
+		if err := db.QueryRow(`INSERT INTO application_component (application_id, name, version, type, purl, licenses)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`, appID, cs[i].Name, cs[i].Version, cs[i].Type, cs[i].PURL, licenses).Scan(&cs[i].ID); err != nil {
+			return sdk.WrapError(err, "Unable to insert component %s", cs[i].Name)
+		}
+	}
+	return nil
+}
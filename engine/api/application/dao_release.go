@@ -0,0 +1,99 @@
+package application
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+const releaseFields = `id, application_id, version, created, created_by, workflow_name, workflow_run_id, num,
+	workflow_node_run_id, node_name, pipeline_name, repository, branch, vcs_hash`
+
+// LoadReleases loads the releases of an application sorted by semantic version, without their artifacts
+func LoadReleases(db gorp.SqlExecutor, appID int64) ([]sdk.ApplicationRelease, error) {
+	rows, err := db.Query(`SELECT `+releaseFields+` FROM application_release WHERE application_id = $1`, appID)
+	if err != nil {
+		return nil, sdk.WrapError(err, "Cannot load releases of application %d", appID)
+	}
+	defer rows.Close()
+
+	rs := []sdk.ApplicationRelease{}
+	for rows.Next() {
+		var r sdk.ApplicationRelease
+		if err := rows.Scan(&r.ID, &r.ApplicationID, &r.Version, &r.Created, &r.CreatedBy, &r.WorkflowName, &r.WorkflowRunID, &r.Number,
+			&r.WorkflowNodeRunID, &r.NodeName, &r.PipelineName, &r.Repository, &r.Branch, &r.Commit); err != nil {
+			return nil, sdk.WrapError(err, "Cannot scan release")
+		}
+		rs = append(rs, r)
+	}
+	sdk.SortApplicationReleases(rs)
+	return rs, nil
+}
+
+// LoadReleaseByVersion loads a release of an application with its artifacts
+func LoadReleaseByVersion(db gorp.SqlExecutor, appID int64, version string) (*sdk.ApplicationRelease, error) {
+	var r sdk.ApplicationRelease
+	if err := db.QueryRow(`SELECT `+releaseFields+` FROM application_release WHERE application_id = $1 AND version = $2`, appID, version).Scan(
+		&r.ID, &r.ApplicationID, &r.Version, &r.Created, &r.CreatedBy, &r.WorkflowName, &r.WorkflowRunID, &r.Number,
+		&r.WorkflowNodeRunID, &r.NodeName, &r.PipelineName, &r.Repository, &r.Branch, &r.Commit); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sdk.WithStack(sdk.ErrNotFound)
+		}
+		return nil, sdk.WrapError(err, "Cannot load release %s of application %d", version, appID)
+	}
+
+	rows, err := db.Query(`SELECT id, application_release_id, name, size, perm, md5sum, sha512sum, object_path, project_integration_id, created
+	FROM application_release_artifact WHERE application_release_id = $1 ORDER BY name`, r.ID)
+	if err != nil {
+		return nil, sdk.WrapError(err, "Cannot load artifacts of release %s", version)
+	}
+	defer rows.Close()
+
+	r.Artifacts = []sdk.ApplicationReleaseArtifact{}
+	for rows.Next() {
+		a := sdk.ApplicationReleaseArtifact{ApplicationID: r.ApplicationID, Version: r.Version}
+		var integrationID sql.NullInt64
+		if err := rows.Scan(&a.ID, &a.ReleaseID, &a.Name, &a.Size, &a.Perm, &a.MD5sum, &a.SHA512sum, &a.ObjectPath, &integrationID, &a.Created); err != nil {
+			return nil, sdk.WrapError(err, "Cannot scan release artifact")
+		}
+		if integrationID.Valid {
+			a.ProjectIntegrationID = &integrationID.Int64
+		}
+		r.Artifacts = append(r.Artifacts, a)
+	}
+	return &r, nil
+}
+
+// InsertRelease inserts a release without its artifacts, a release can't be updated once inserted.
+// The unique index on the version locks the release until the end of the transaction
+func InsertRelease(db gorp.SqlExecutor, r *sdk.ApplicationRelease) error {
+	r.Created = time.Now()
+	if err := db.QueryRow(`INSERT INTO application_release (application_id, version, created, created_by, workflow_name, workflow_run_id, num,
+	workflow_node_run_id, node_name, pipeline_name, repository, branch, vcs_hash)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id`,
+		r.ApplicationID, r.Version, r.Created, r.CreatedBy, r.WorkflowName, r.WorkflowRunID, r.Number,
+		r.WorkflowNodeRunID, r.NodeName, r.PipelineName, r.Repository, r.Branch, r.Commit).Scan(&r.ID); err != nil {
+		if errPG, ok := err.(*pq.Error); ok && errPG.Code == gorpmapping.ViolateUniqueKeyPGCode {
+			return sdk.NewErrorFrom(sdk.ErrConflict, "release %s already exists", r.Version)
+		}
+		return sdk.WrapError(err, "Unable to insert release %s", r.Version)
+	}
+	return nil
+}
+
+// InsertReleaseArtifact inserts an artifact of an inserted release
+func InsertReleaseArtifact(db gorp.SqlExecutor, r *sdk.ApplicationRelease, a *sdk.ApplicationReleaseArtifact) error {
+	a.ReleaseID = r.ID
+	a.Created = r.Created
+	if err := db.QueryRow(`INSERT INTO application_release_artifact (application_release_id, name, size, perm, md5sum, sha512sum, object_path, project_integration_id, created)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`,
+		a.ReleaseID, a.Name, a.Size, a.Perm, a.MD5sum, a.SHA512sum, a.ObjectPath, a.ProjectIntegrationID, a.Created).Scan(&a.ID); err != nil {
+		return sdk.WrapError(err, "Unable to insert artifact %s of release %s", a.Name, r.Version)
+	}
+	return nil
+}
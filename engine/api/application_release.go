@@ -0,0 +1,332 @@
+package api
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/application"
+	"github.com/ovh/cds/engine/api/integration"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// getApplicationReleasesHandler returns the releases of an application sorted by semantic version,
+// they can be filtered with a semantic version range (ie. ?range=>=1.2.0 <2.0.0)
+func (api *API) getApplicationReleasesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+		appName := vars["applicationName"]
+
+		app, err := application.LoadByName(api.mustDB(), api.Cache, key, appName)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to load application")
+		}
+
+		rs, err := application.LoadReleases(api.mustDB(), app.ID)
+		if err != nil {
+			return err
+		}
+
+		rs, err = sdk.FilterApplicationReleases(rs, r.FormValue("range"))
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, rs, http.StatusOK)
+	}
+}
+
+func (api *API) getApplicationReleaseHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+		appName := vars["applicationName"]
+
+		app, err := application.LoadByName(api.mustDB(), api.Cache, key, appName)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to load application")
+		}
+
+		rel, err := application.LoadReleaseByVersion(api.mustDB(), app.ID, vars["version"])
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, rel, http.StatusOK)
+	}
+}
+
+// postApplicationReleaseHandler promotes the artifacts of a workflow run to a new release of the application.
+// The artifacts are copied in the release repository, so the release is not removed with the workflow runs
+func (api *API) postApplicationReleaseHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+		appName := vars["applicationName"]
+
+		var promotion sdk.ApplicationReleasePromotion
+		if err := service.UnmarshalBody(r, &promotion); err != nil {
+			return sdk.WrapError(err, "Unable to read body")
+		}
+		if err := promotion.IsValid(); err != nil {
+			return err
+		}
+
+		app, err := application.LoadByName(api.mustDB(), api.Cache, key, appName)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to load application")
+		}
+
+		wr, err := workflow.LoadRun(api.mustDB(), key, promotion.WorkflowName, promotion.Number, workflow.LoadRunOptions{WithArtifacts: true})
+		if err != nil {
+			return sdk.WrapError(err, "Unable to load workflow run")
+		}
+
+		nr, err := releaseNodeRun(wr, app.ID, promotion.NodeName)
+		if err != nil {
+			return err
+		}
+
+		arts, err := releaseArtifacts(nr, promotion.Artifacts)
+		if err != nil {
+			return err
+		}
+
+		rel := sdk.ApplicationRelease{
+			ApplicationID:     app.ID,
+			Version:           promotion.Version,
+			CreatedBy:         deprecatedGetUser(ctx).Username,
+			WorkflowName:      wr.Workflow.Name,
+			WorkflowRunID:     wr.ID,
+			Number:            wr.Number,
+			WorkflowNodeRunID: nr.ID,
+			NodeName:          nr.WorkflowNodeName,
+			Repository:        nr.VCSRepository,
+			Branch:            nr.VCSBranch,
+			Commit:            nr.VCSHash,
+		}
+		if n := wr.Workflow.WorkflowData.NodeByID(nr.WorkflowNodeID); n != nil && n.Context != nil {
+			rel.PipelineName = wr.Workflow.Pipelines[n.Context.PipelineID].Name
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WrapError(err, "Cannot start transaction")
+		}
+		defer tx.Rollback() // nolint
+
+		// the release is inserted before copying the artifacts, so a concurrent promotion of the same version
+		// waits on the unique index then fails without overwriting the copied artifacts
+		if err := application.InsertRelease(tx, &rel); err != nil {
+			return err
+		}
+
+		for i := range arts {
+			a, err := api.copyArtifactToRelease(key, app.ID, promotion.Version, &arts[i])
+			if err != nil {
+				api.deleteReleaseArtifacts(key, rel.Artifacts)
+				return err
+			}
+			rel.Artifacts = append(rel.Artifacts, *a)
+			if err := application.InsertReleaseArtifact(tx, &rel, &rel.Artifacts[len(rel.Artifacts)-1]); err != nil {
+				api.deleteReleaseArtifacts(key, rel.Artifacts)
+				return err
+			}
+		}
+
+		if err := tx.Commit(); err != nil {
+			api.deleteReleaseArtifacts(key, rel.Artifacts)
+			return sdk.WrapError(err, "Cannot commit transaction")
+		}
+
+		log.Info("postApplicationReleaseHandler> release %s of %s/%s promoted from %s #%d", rel.Version, key, appName, rel.WorkflowName, rel.Number)
+		return service.WriteJSON(w, rel, http.StatusCreated)
+	}
+}
+
+func (api *API) getApplicationReleaseArtifactDownloadHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+		appName := vars["applicationName"]
+
+		app, err := application.LoadByName(api.mustDB(), api.Cache, key, appName)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to load application")
+		}
+
+		rel, err := application.LoadReleaseByVersion(api.mustDB(), app.ID, vars["version"])
+		if err != nil {
+			return err
+		}
+
+		art := rel.ArtifactByName(vars["artifactName"])
+		if art == nil {
+			return sdk.WithStack(sdk.ErrNotFound)
+		}
+
+		integrationName, err := api.artifactIntegrationName(art.ProjectIntegrationID)
+		if err != nil {
+			return err
+		}
+		storageDriver, err := api.getStorageDriver(key, integrationName)
+		if err != nil {
+			return err
+		}
+
+		f, err := storageDriver.Fetch(art)
+		if err != nil {
+			return sdk.WrapError(err, "Cannot fetch artifact")
+		}
+		defer f.Close()
+
+		w.Header().Add("Content-Type", "application/octet-stream")
+		w.Header().Add("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", art.Name))
+		if _, err := io.Copy(w, f); err != nil {
+			return sdk.WrapError(err, "Cannot stream artifact")
+		}
+		return nil
+	}
+}
+
+// copyArtifactToRelease copies a workflow run artifact in the release repository, its checksums are verified during the copy
+func (api *API) copyArtifactToRelease(projectKey string, appID int64, version string, art *sdk.WorkflowNodeRunArtifact) (*sdk.ApplicationReleaseArtifact, error) {
+	integrationName, err := api.artifactIntegrationName(art.ProjectIntegrationID)
+	if err != nil {
+		return nil, err
+	}
+	storageDriver, err := api.getStorageDriver(projectKey, integrationName)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := storageDriver.Fetch(art)
+	if err != nil {
+		return nil, sdk.WrapError(err, "Cannot fetch artifact %s", art.Name)
+	}
+	defer f.Close()
+
+	md5Hash := md5.New()
+	sha512Hash := sha512.New()
+	relArt := sdk.ApplicationReleaseArtifact{
+		ApplicationID:        appID,
+		Version:              version,
+		Name:                 art.Name,
+		Size:                 art.Size,
+		Perm:                 art.Perm,
+		ProjectIntegrationID: art.ProjectIntegrationID,
+	}
+	objectPath, err := storageDriver.Store(&relArt, ioutil.NopCloser(io.TeeReader(f, io.MultiWriter(md5Hash, sha512Hash))))
+	if err != nil {
+		return nil, sdk.WrapError(err, "Cannot store artifact %s in release %s", art.Name, version)
+	}
+	relArt.ObjectPath = objectPath
+	relArt.MD5sum = hex.EncodeToString(md5Hash.Sum(nil))
+	relArt.SHA512sum = hex.EncodeToString(sha512Hash.Sum(nil))
+
+	if (art.MD5sum != "" && art.MD5sum != relArt.MD5sum) || (art.SHA512sum != "" && art.SHA512sum != relArt.SHA512sum) {
+		if err := storageDriver.Delete(&relArt); err != nil {
+			log.Error("copyArtifactToRelease> unable to delete artifact %s of release %s: %v", art.Name, version, err)
+		}
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "checksum of artifact %s does not match", art.Name)
+	}
+	return &relArt, nil
+}
+
+// deleteReleaseArtifacts removes the copied artifacts of a release which was not inserted
+func (api *API) deleteReleaseArtifacts(projectKey string, arts []sdk.ApplicationReleaseArtifact) {
+	for i := range arts {
+		integrationName, err := api.artifactIntegrationName(arts[i].ProjectIntegrationID)
+		if err != nil {
+			log.Error("deleteReleaseArtifacts> %v", err)
+			continue
+		}
+		storageDriver, err := api.getStorageDriver(projectKey, integrationName)
+		if err != nil {
+			log.Error("deleteReleaseArtifacts> %v", err)
+			continue
+		}
+		if err := storageDriver.Delete(&arts[i]); err != nil {
+			log.Error("deleteReleaseArtifacts> unable to delete artifact %s of release %s: %v", arts[i].Name, arts[i].Version, err)
+		}
+	}
+}
+
+func (api *API) artifactIntegrationName(projectIntegrationID *int64) (string, error) {
+	if projectIntegrationID == nil || *projectIntegrationID <= 0 {
+		return sdk.DefaultStorageIntegrationName, nil
+	}
+	projectIntegration, err := integration.LoadProjectIntegrationByID(api.mustDB(), *projectIntegrationID, false)
+	if err != nil {
+		return "", sdk.WrapError(err, "Cannot load project integration %d", *projectIntegrationID)
+	}
+	return projectIntegration.Name, nil
+}
+
+// releaseNodeRun returns the successful node run of the application with artifacts, the node name is mandatory
+// if several nodes of the application uploaded artifacts
+func releaseNodeRun(wr *sdk.WorkflowRun, appID int64, nodeName string) (*sdk.WorkflowNodeRun, error) {
+	var res []*sdk.WorkflowNodeRun
+	for _, nrs := range wr.WorkflowNodeRuns {
+		if len(nrs) == 0 {
+			continue
+		}
+		last := &nrs[0]
+		for i := range nrs {
+			if nrs[i].SubNumber > last.SubNumber {
+				last = &nrs[i]
+			}
+		}
+		if last.ApplicationID != appID || len(last.Artifacts) == 0 {
+			continue
+		}
+		if nodeName != "" && last.WorkflowNodeName != nodeName {
+			continue
+		}
+		res = append(res, last)
+	}
+
+	switch len(res) {
+	case 0:
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "no artifact of the application found on workflow run %d", wr.Number)
+	case 1:
+	default:
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "several nodes of workflow run %d uploaded artifacts of the application, a node name is mandatory", wr.Number)
+	}
+
+	if res[0].Status != sdk.StatusSuccess.String() {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "node %s is %s, only successful node runs can be promoted", res[0].WorkflowNodeName, res[0].Status)
+	}
+	return res[0], nil
+}
+
+// releaseArtifacts returns the artifacts of the node run to promote, all the artifacts if no name is given
+func releaseArtifacts(nr *sdk.WorkflowNodeRun, names []string) ([]sdk.WorkflowNodeRunArtifact, error) {
+	if len(names) == 0 {
+		return nr.Artifacts, nil
+	}
+	arts := make([]sdk.WorkflowNodeRunArtifact, 0, len(names))
+	for _, name := range names {
+		var found bool
+		for _, a := range nr.Artifacts {
+			if a.Name == name {
+				arts = append(arts, a)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "artifact %s not found on node %s", name, nr.WorkflowNodeName)
+		}
+	}
+	return arts, nil
+}
@@ -50,3 +50,23 @@ func (api *API) postVulnerabilityHandler() service.Handler {
 		return service.WriteJSON(w, vulnDB, http.StatusOK)
 	}
 }
+
+// getApplicationComponentsHandler returns the components of the application found in the SBOM of the default branch
+func (api *API) getApplicationComponentsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+		appName := vars["applicationName"]
+
+		app, err := application.LoadByName(api.mustDB(), api.Cache, key, appName)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to load application")
+		}
+
+		cs, err := application.LoadComponents(api.mustDB(), app.ID)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, cs, http.StatusOK)
+	}
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/keys"
+	"github.com/ovh/cds/engine/api/objectstore"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/worker"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// artifactProvenanceObject is the signed provenance of an artifact, stored next to the artifact
+type artifactProvenanceObject struct {
+	art *sdk.WorkflowNodeRunArtifact
+}
+
+func (o artifactProvenanceObject) GetName() string {
+	return o.art.GetName() + sdk.ArtifactProvenanceSuffix
+}
+
+func (o artifactProvenanceObject) GetPath() string {
+	return o.art.GetPath()
+}
+
+// storeArtifactProvenance signs the provenance of an uploaded artifact with the provenance key of the project
+// and stores it next to the artifact
+func (api *API) storeArtifactProvenance(storageDriver objectstore.Driver, art *sdk.WorkflowNodeRunArtifact, nodeRun *sdk.WorkflowNodeRun, nodeJobRun *sdk.WorkflowNodeJobRun) error {
+	var workerImage string
+	if nodeJobRun.Model != "" {
+		model, err := worker.LoadWorkerModelByName(api.mustDB(), nodeJobRun.Model)
+		if err != nil {
+			log.Warning("storeArtifactProvenance> unable to load worker model %s: %v", nodeJobRun.Model, err)
+		} else if model.Type == sdk.Docker {
+			workerImage = model.ModelDocker.Image
+		} else {
+			workerImage = model.ModelVirtualMachine.Image
+		}
+	}
+
+	k, err := project.LoadOrCreateProvenanceKey(api.mustDB(), nodeJobRun.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	statement := sdk.NewProvenanceStatement(api.Config.URL.API, *art, *nodeRun, *nodeJobRun, workerImage)
+	envelope, err := keys.SignProvenance(k.Key, statement)
+	if err != nil {
+		return err
+	}
+
+	btes, err := json.Marshal(envelope)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	if _, err := storageDriver.Store(artifactProvenanceObject{art: art}, ioutil.NopCloser(bytes.NewReader(btes))); err != nil {
+		return sdk.WrapError(err, "Cannot store provenance of artifact %s", art.Name)
+	}
+	return nil
+}
+
+// getArtifactProvenanceHandler returns the signed provenance of an artifact,
+// it can be verified offline with the public key of the project key proj-cds-provenance
+func (api *API) getArtifactProvenanceHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		id, err := requestVarInt(r, "artifactId")
+		if err != nil {
+			return sdk.WrapError(sdk.ErrInvalidID, "Invalid artifact ID")
+		}
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key, deprecatedGetUser(ctx))
+		if err != nil {
+			return sdk.WrapError(err, "unable to load projet")
+		}
+
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, proj, name, deprecatedGetUser(ctx), workflow.LoadOptions{WithoutNode: true})
+		if err != nil {
+			return sdk.WrapError(err, "Cannot load workflow")
+		}
+
+		art, err := workflow.LoadArtifactByIDs(api.mustDB(), wf.ID, id)
+		if err != nil {
+			return sdk.WrapError(err, "Cannot load artifact")
+		}
+
+		integrationName, err := api.artifactIntegrationName(art.ProjectIntegrationID)
+		if err != nil {
+			return err
+		}
+		storageDriver, err := api.getStorageDriver(proj.Key, integrationName)
+		if err != nil {
+			return err
+		}
+
+		f, err := storageDriver.Fetch(artifactProvenanceObject{art: art})
+		if err != nil {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "no provenance found for artifact %s", art.Name)
+		}
+		defer f.Close()
+
+		var envelope sdk.ProvenanceEnvelope
+		if err := json.NewDecoder(f).Decode(&envelope); err != nil {
+			return sdk.WrapError(err, "Cannot read provenance of artifact %s", art.Name)
+		}
+		return service.WriteJSON(w, envelope, http.StatusOK)
+	}
+}
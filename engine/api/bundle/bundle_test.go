@@ -0,0 +1,100 @@
+package bundle
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/engine/api/permission"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+func TestPassphraseCipher(t *testing.T) {
+	c, enc, err := newEncryption("my passphrase")
+	assert.NoError(t, err)
+
+	token, err := c.encrypt("my secret")
+	assert.NoError(t, err)
+	assert.NotContains(t, token, "my secret")
+
+	c2, err := openEncryption("my passphrase", enc)
+	assert.NoError(t, err)
+	clear, err := c2.decrypt(token)
+	assert.NoError(t, err)
+	assert.Equal(t, "my secret", clear)
+
+	_, err = openEncryption("another passphrase", enc)
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrInvalidBundlePassphrase))
+
+	_, _, err = newEncryption("")
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrInvalidBundlePassphrase))
+}
+
+func TestParseBundle(t *testing.T) {
+	c, enc, err := newEncryption("my passphrase")
+	assert.NoError(t, err)
+
+	proj := sdk.Project{
+		Key:  "KEY",
+		Name: "my project",
+		Variable: []sdk.Variable{
+			{Name: "foo", Type: sdk.StringVariable, Value: "bar"},
+			{Name: "password", Type: sdk.SecretVariable, Value: "secret"},
+		},
+		ProjectGroups: []sdk.GroupPermission{
+			{Group: sdk.Group{Name: "old-group"}, Permission: permission.PermissionReadWriteExecute},
+		},
+		Integrations: []sdk.ProjectIntegration{
+			{
+				Name:  "my-kafka",
+				Model: sdk.IntegrationModel{Name: "Kafka"},
+				Config: sdk.IntegrationConfig{
+					"password": sdk.IntegrationConfigValue{Type: sdk.IntegrationConfigTypePassword, Value: "kafka-secret"},
+				},
+			},
+			{
+				Name:  "public",
+				Model: sdk.IntegrationModel{Name: "public-model", Public: true},
+			},
+		},
+	}
+	manifest, err := exportProject(proj, c)
+	assert.NoError(t, err)
+	manifest.Encryption = enc
+	assert.Equal(t, "bar", manifest.Variables["foo"].Value)
+	assert.NotEqual(t, "secret", manifest.Variables["password"].Value)
+	assert.NotEqual(t, "kafka-secret", manifest.Integrations["my-kafka"].Config["password"].Value)
+	assert.NotContains(t, manifest.Integrations, "public")
+
+	b := exportentities.NewProjectBundle()
+	b.Project, err = exportentities.Marshal(manifest, exportentities.FormatYAML)
+	assert.NoError(t, err)
+	b.Workflows["my-workflow"] = []byte(`name: my-workflow
+pipeline: my-pipeline
+permissions:
+  old-group: 7
+  other-group: 4
+`)
+	b.Pipelines["my-pipeline"] = []byte("name: my-pipeline\n")
+
+	buf := new(bytes.Buffer)
+	assert.NoError(t, b.Tar(buf))
+
+	read, err := exportentities.ReadProjectBundle(buf)
+	assert.NoError(t, err)
+
+	_, err = parseBundle(read, "wrong passphrase", nil)
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrInvalidBundlePassphrase))
+
+	e, err := parseBundle(read, "my passphrase", map[string]string{"old-group": "new-group"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"new-group": permission.PermissionReadWriteExecute}, e.project.Permissions)
+	assert.Equal(t, map[string]int{"new-group": 7, "other-group": 4}, e.workflows["my-workflow"].Permissions)
+	assert.Equal(t, "my-pipeline", e.pipelines["my-pipeline"].Name)
+
+	clear, err := e.cipher.decrypt(e.project.Variables["password"].Value)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", clear)
+}
@@ -0,0 +1,119 @@
+package bundle
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+
+	"github.com/go-gorp/gorp"
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+const (
+	saltLength    = 16
+	keyIterations = 10000
+	keyLength     = 32
+	checkValue    = "cds-project-bundle"
+)
+
+// passphraseCipher encrypts the secrets of a bundle with AES-GCM, the key is derived from the bundle passphrase
+type passphraseCipher struct {
+	aead cipher.AEAD
+}
+
+// newEncryption generates a salt and returns the cipher and the encryption section of the manifest
+func newEncryption(passphrase string) (*passphraseCipher, exportentities.ProjectEncryption, error) {
+	var enc exportentities.ProjectEncryption
+	salt := make([]byte, saltLength)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, enc, sdk.WrapError(err, "unable to generate salt")
+	}
+
+	c, err := newPassphraseCipher(passphrase, salt)
+	if err != nil {
+		return nil, enc, err
+	}
+
+	enc.Salt = base64.StdEncoding.EncodeToString(salt)
+	enc.Check, err = c.encrypt(checkValue)
+	if err != nil {
+		return nil, enc, err
+	}
+	return c, enc, nil
+}
+
+// openEncryption returns the cipher of a bundle, it fails if the passphrase is not the one used to export the bundle
+func openEncryption(passphrase string, enc exportentities.ProjectEncryption) (*passphraseCipher, error) {
+	salt, err := base64.StdEncoding.DecodeString(enc.Salt)
+	if err != nil || len(salt) != saltLength {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid bundle salt")
+	}
+
+	c, err := newPassphraseCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	if v, err := c.decrypt(enc.Check); err != nil || v != checkValue {
+		return nil, sdk.WithStack(sdk.ErrInvalidBundlePassphrase)
+	}
+	return c, nil
+}
+
+func newPassphraseCipher(passphrase string, salt []byte) (*passphraseCipher, error) {
+	if passphrase == "" {
+		return nil, sdk.NewErrorFrom(sdk.ErrInvalidBundlePassphrase, "bundle passphrase is mandatory")
+	}
+	key := pbkdf2.Key([]byte(passphrase), salt, keyIterations, keyLength, sha256.New)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return &passphraseCipher{aead: aead}, nil
+}
+
+// encrypt returns the base64 of the nonce followed by the encrypted value
+func (c *passphraseCipher) encrypt(s string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", sdk.WrapError(err, "unable to generate nonce")
+	}
+	btes := c.aead.Seal(nonce, nonce, []byte(s), nil)
+	return base64.StdEncoding.EncodeToString(btes), nil
+}
+
+func (c *passphraseCipher) decrypt(s string) (string, error) {
+	btes, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", sdk.WithStack(sdk.ErrInvalidBundlePassphrase)
+	}
+	if len(btes) < c.aead.NonceSize() {
+		return "", sdk.WithStack(sdk.ErrInvalidBundlePassphrase)
+	}
+	nonce, btes := btes[:c.aead.NonceSize()], btes[c.aead.NonceSize():]
+	clear, err := c.aead.Open(nil, nonce, btes, nil)
+	if err != nil {
+		return "", sdk.WithStack(sdk.ErrInvalidBundlePassphrase)
+	}
+	return string(clear), nil
+}
+
+// encryptFunc is used by the entities exporters to encrypt secrets with the bundle passphrase
+func (c *passphraseCipher) encryptFunc(_ gorp.SqlExecutor, _ int64, _, content string) (string, error) {
+	return c.encrypt(content)
+}
+
+// decryptFunc is used by the entities importers to decrypt secrets with the bundle passphrase
+func (c *passphraseCipher) decryptFunc(_ gorp.SqlExecutor, _ int64, token string) (string, error) {
+	return c.decrypt(token)
+}
@@ -0,0 +1,142 @@
+package bundle
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/application"
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/environment"
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/engine/api/pipeline"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// Export writes a tar bundle with all the entities of a project. Secret variables, keys and integrations
+// passwords are encrypted with the passphrase so the bundle can be imported on another CDS instance.
+func Export(ctx context.Context, db gorp.SqlExecutor, store cache.Store, key, passphrase string, u *sdk.User, w io.Writer) error {
+	ctx, end := observability.Span(ctx, "bundle.Export")
+	defer end()
+
+	c, enc, err := newEncryption(passphrase)
+	if err != nil {
+		return err
+	}
+
+	proj, err := project.Load(db, store, key, u,
+		project.LoadOptions.WithVariablesWithClearPassword,
+		project.LoadOptions.WithClearKeys,
+		project.LoadOptions.WithClearIntegrations,
+		project.LoadOptions.WithGroups,
+		project.LoadOptions.WithApplicationNames,
+		project.LoadOptions.WithPipelineNames,
+		project.LoadOptions.WithEnvironments,
+		project.LoadOptions.WithWorkflowNames,
+	)
+	if err != nil {
+		return sdk.WrapError(err, "cannot load project %s", key)
+	}
+
+	manifest, err := exportProject(*proj, c)
+	if err != nil {
+		return err
+	}
+	manifest.Encryption = enc
+
+	b := exportentities.NewProjectBundle()
+	b.Project, err = exportentities.Marshal(manifest, exportentities.FormatYAML)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+
+	for _, a := range proj.ApplicationNames {
+		buff := new(bytes.Buffer)
+		if _, err := application.Export(db, store, proj.Key, a.Name, exportentities.FormatYAML, c.encryptFunc, buff); err != nil {
+			return sdk.WrapError(err, "unable to export application %s", a.Name)
+		}
+		b.Applications[a.Name] = buff.Bytes()
+	}
+
+	for _, e := range proj.Environments {
+		buff := new(bytes.Buffer)
+		if _, err := environment.Export(db, store, proj.Key, e.Name, exportentities.FormatYAML, u, c.encryptFunc, buff); err != nil {
+			return sdk.WrapError(err, "unable to export environment %s", e.Name)
+		}
+		b.Environments[e.Name] = buff.Bytes()
+	}
+
+	for _, p := range proj.PipelineNames {
+		buff := new(bytes.Buffer)
+		if _, err := pipeline.Export(db, store, proj.Key, p.Name, exportentities.FormatYAML, buff); err != nil {
+			return sdk.WrapError(err, "unable to export pipeline %s", p.Name)
+		}
+		b.Pipelines[p.Name] = buff.Bytes()
+	}
+
+	for _, wf := range proj.WorkflowNames {
+		buff := new(bytes.Buffer)
+		if _, err := workflow.Export(ctx, db, store, proj, wf.Name, exportentities.FormatYAML, u, buff, exportentities.WorkflowWithPermissions); err != nil {
+			return sdk.WrapError(err, "unable to export workflow %s", wf.Name)
+		}
+		b.Workflows[wf.Name] = buff.Bytes()
+	}
+
+	return b.Tar(w)
+}
+
+// exportProject returns the manifest of the project with its secrets encrypted
+func exportProject(proj sdk.Project, c *passphraseCipher) (exportentities.Project, error) {
+	vars := make([]sdk.Variable, 0, len(proj.Variable))
+	for _, v := range proj.Variable {
+		switch v.Type {
+		case sdk.KeyVariable:
+			return exportentities.Project{}, sdk.NewErrorFrom(sdk.ErrUnknownError, "variable %s: variable of type key are deprecated. Please use the standard keys from your project", v.Name)
+		case sdk.SecretVariable:
+			content, err := c.encrypt(v.Value)
+			if err != nil {
+				return exportentities.Project{}, sdk.WrapError(err, "unable to encrypt variable %s", v.Name)
+			}
+			v.Value = content
+		}
+		vars = append(vars, v)
+	}
+	proj.Variable = vars
+
+	ks := make([]exportentities.EncryptedKey, 0, len(proj.Keys))
+	for _, k := range proj.Keys {
+		if k.Builtin {
+			continue
+		}
+		content, err := c.encrypt(k.Private)
+		if err != nil {
+			return exportentities.Project{}, sdk.WrapError(err, "unable to encrypt key %s", k.Name)
+		}
+		ks = append(ks, exportentities.EncryptedKey{
+			Type:    k.Type,
+			Name:    k.Name,
+			Content: content,
+		})
+	}
+
+	// integrations from public models are created by the target CDS instance
+	integrations := make([]sdk.ProjectIntegration, 0, len(proj.Integrations))
+	for _, i := range proj.Integrations {
+		if i.Model.Public {
+			continue
+		}
+		i.Config = i.Config.Clone()
+		if err := i.Config.EncryptSecrets(c.encrypt); err != nil {
+			return exportentities.Project{}, sdk.WrapError(err, "unable to encrypt integration %s", i.Name)
+		}
+		integrations = append(integrations, i)
+	}
+	proj.Integrations = integrations
+
+	return exportentities.NewProject(proj, ks), nil
+}
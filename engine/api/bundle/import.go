@@ -0,0 +1,448 @@
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/application"
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/environment"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/integration"
+	"github.com/ovh/cds/engine/api/keys"
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/engine/api/pipeline"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// ImportOptions are the options of a bundle import
+type ImportOptions struct {
+	DryRun bool
+	Force  bool
+	// GroupMapping renames the groups of the bundle, the groups must exist on the target CDS instance
+	GroupMapping map[string]string
+}
+
+// bundleEntities are the parsed entities of a bundle
+type bundleEntities struct {
+	project      exportentities.Project
+	applications map[string]exportentities.Application
+	environments map[string]exportentities.Environment
+	pipelines    map[string]exportentities.PipelineV1
+	workflows    map[string]exportentities.Workflow
+	cipher       *passphraseCipher
+}
+
+// Import imports a tar bundle in an existing project. The bundle is checked first, entities which already
+// exist in the project are reported as conflicts and fail the import unless it is forced. Missing groups
+// and integration models always fail the import. In dry run mode the report is returned without any change.
+func Import(ctx context.Context, db *gorp.DbMap, store cache.Store, key, passphrase string, u *sdk.User, r io.Reader, opts ImportOptions) (*sdk.ProjectBundleImportReport, error) {
+	ctx, end := observability.Span(ctx, "bundle.Import")
+	defer end()
+
+	b, err := exportentities.ReadProjectBundle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := parseBundle(b, passphrase, opts.GroupMapping)
+	if err != nil {
+		return nil, err
+	}
+
+	proj, err := project.Load(db, store, key, u,
+		project.LoadOptions.WithGroups,
+		project.LoadOptions.WithVariables,
+		project.LoadOptions.WithKeys,
+		project.LoadOptions.WithApplications,
+		project.LoadOptions.WithEnvironments,
+		project.LoadOptions.WithPipelines,
+		project.LoadOptions.WithApplicationWithDeploymentStrategies,
+		project.LoadOptions.WithIntegrations,
+		project.LoadOptions.WithWorkflowNames,
+	)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load project %s", key)
+	}
+
+	report, err := checkBundle(db, proj, e)
+	if err != nil {
+		return nil, err
+	}
+	report.DryRun = opts.DryRun
+	if opts.DryRun {
+		return report, nil
+	}
+
+	if len(report.Errors) > 0 {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to import bundle: %s", strings.Join(report.Errors, ", "))
+	}
+	if len(report.Conflicts) > 0 && !opts.Force {
+		return nil, sdk.NewErrorFrom(sdk.ErrConflict, "bundle conflicts with existing %s", strings.Join(report.Conflicts, ", "))
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to start transaction")
+	}
+	defer tx.Rollback() // nolint
+
+	if err := importBundle(ctx, tx, store, proj, e, u, opts, report); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, sdk.WrapError(err, "unable to commit transaction")
+	}
+	return report, nil
+}
+
+// parseBundle checks the passphrase, parses the files of the bundle and renames the groups
+func parseBundle(b *exportentities.ProjectBundle, passphrase string, groupMapping map[string]string) (*bundleEntities, error) {
+	e := &bundleEntities{
+		applications: make(map[string]exportentities.Application, len(b.Applications)),
+		environments: make(map[string]exportentities.Environment, len(b.Environments)),
+		pipelines:    make(map[string]exportentities.PipelineV1, len(b.Pipelines)),
+		workflows:    make(map[string]exportentities.Workflow, len(b.Workflows)),
+	}
+
+	if err := exportentities.Unmarshal(b.Project, exportentities.FormatYAML, &e.project); err != nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to read %s: %v", exportentities.BundleProjectName, err)
+	}
+
+	var err error
+	e.cipher, err = openEncryption(passphrase, e.project.Encryption)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, btes := range b.Applications {
+		var a exportentities.Application
+		if err := exportentities.Unmarshal(btes, exportentities.FormatYAML, &a); err != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to read application %s: %v", name, err)
+		}
+		e.applications[name] = a
+	}
+	for name, btes := range b.Environments {
+		var env exportentities.Environment
+		if err := exportentities.Unmarshal(btes, exportentities.FormatYAML, &env); err != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to read environment %s: %v", name, err)
+		}
+		e.environments[name] = env
+	}
+	for name, btes := range b.Pipelines {
+		var p exportentities.PipelineV1
+		if err := exportentities.Unmarshal(btes, exportentities.FormatYAML, &p); err != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to read pipeline %s: %v", name, err)
+		}
+		e.pipelines[name] = p
+	}
+	for name, btes := range b.Workflows {
+		var w exportentities.Workflow
+		if err := exportentities.Unmarshal(btes, exportentities.FormatYAML, &w); err != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to read workflow %s: %v", name, err)
+		}
+		w.Permissions = mapGroups(w.Permissions, groupMapping)
+		for n, entry := range w.Workflow {
+			entry.Permissions = mapGroups(entry.Permissions, groupMapping)
+			w.Workflow[n] = entry
+		}
+		e.workflows[name] = w
+	}
+	e.project.Permissions = mapGroups(e.project.Permissions, groupMapping)
+
+	return e, nil
+}
+
+func mapGroups(perms map[string]int, groupMapping map[string]string) map[string]int {
+	if len(perms) == 0 || len(groupMapping) == 0 {
+		return perms
+	}
+	res := make(map[string]int, len(perms))
+	for g, p := range perms {
+		if newName, ok := groupMapping[g]; ok {
+			g = newName
+		}
+		res[g] = p
+	}
+	return res
+}
+
+// checkBundle lists the entities of the bundle which already exist in the project and the missing dependencies
+func checkBundle(db gorp.SqlExecutor, proj *sdk.Project, e *bundleEntities) (*sdk.ProjectBundleImportReport, error) {
+	report := &sdk.ProjectBundleImportReport{
+		Imported:  []string{},
+		Conflicts: []string{},
+		Errors:    []string{},
+	}
+
+	existing := newExistingEntities(proj)
+
+	for _, name := range sortedKeys(e.project.Variables) {
+		if sdk.VariableFind(proj.Variable, name) != nil {
+			report.Conflicts = append(report.Conflicts, "variable "+name)
+		}
+	}
+	for _, name := range sortedKeys(e.project.Keys) {
+		if existing.keys[name] {
+			report.Conflicts = append(report.Conflicts, "key "+name)
+		}
+	}
+
+	groups := map[string]bool{}
+	for _, name := range sortedKeys(e.project.Permissions) {
+		groups[name] = true
+	}
+	for _, w := range e.workflows {
+		for name := range w.Permissions {
+			groups[name] = true
+		}
+		for _, entry := range w.Workflow {
+			for name := range entry.Permissions {
+				groups[name] = true
+			}
+		}
+	}
+	for _, name := range sortedKeys(groups) {
+		if _, err := group.LoadGroup(db, name); err != nil {
+			if !sdk.ErrorIs(err, sdk.ErrGroupNotFound) {
+				return nil, err
+			}
+			report.Errors = append(report.Errors, fmt.Sprintf("group %s not found", name))
+		}
+	}
+
+	for _, name := range sortedKeys(e.project.Integrations) {
+		if _, err := integration.LoadModelByName(db, e.project.Integrations[name].Model, false); err != nil {
+			if !sdk.ErrorIs(err, sdk.ErrNotFound) {
+				return nil, err
+			}
+			report.Errors = append(report.Errors, fmt.Sprintf("integration model %s not found", e.project.Integrations[name].Model))
+		}
+		if _, ok := proj.GetIntegration(name); ok {
+			report.Conflicts = append(report.Conflicts, "integration "+name)
+		}
+	}
+
+	for _, name := range sortedKeys(e.applications) {
+		if existing.applications[name] {
+			report.Conflicts = append(report.Conflicts, "application "+name)
+		}
+	}
+	for _, name := range sortedKeys(e.environments) {
+		if existing.environments[name] {
+			report.Conflicts = append(report.Conflicts, "environment "+name)
+		}
+	}
+	for _, name := range sortedKeys(e.pipelines) {
+		if existing.pipelines[name] {
+			report.Conflicts = append(report.Conflicts, "pipeline "+name)
+		}
+	}
+	for _, name := range sortedKeys(e.workflows) {
+		if existing.workflows[name] {
+			report.Conflicts = append(report.Conflicts, "workflow "+name)
+		}
+	}
+
+	return report, nil
+}
+
+// importBundle inserts or updates the entities of the bundle, the dependencies are imported first
+func importBundle(ctx context.Context, tx gorp.SqlExecutor, store cache.Store, proj *sdk.Project, e *bundleEntities, u *sdk.User, opts ImportOptions, report *sdk.ProjectBundleImportReport) error {
+	existing := newExistingEntities(proj)
+
+	for _, name := range sortedKeys(e.project.Variables) {
+		v := sdk.Variable{
+			Name:  name,
+			Type:  e.project.Variables[name].Type,
+			Value: e.project.Variables[name].Value,
+		}
+		if v.Type == "" {
+			v.Type = sdk.StringVariable
+		}
+		if v.Type == sdk.SecretVariable {
+			var err error
+			v.Value, err = e.cipher.decrypt(v.Value)
+			if err != nil {
+				return sdk.WrapError(err, "unable to decrypt variable %s", name)
+			}
+		}
+		if old := sdk.VariableFind(proj.Variable, name); old != nil {
+			v.ID = old.ID
+			if err := project.UpdateVariable(tx, proj, &v, old, u); err != nil {
+				return sdk.WrapError(err, "unable to update variable %s", name)
+			}
+		} else if err := project.InsertVariable(tx, proj, &v, u); err != nil {
+			return sdk.WrapError(err, "unable to insert variable %s", name)
+		}
+		report.Imported = append(report.Imported, "variable "+name)
+	}
+
+	for _, name := range sortedKeys(e.project.Keys) {
+		k, err := keys.Parse(tx, proj.ID, name, e.project.Keys[name], e.cipher.decryptFunc)
+		if err != nil {
+			return sdk.WrapError(err, "unable to parse key %s", name)
+		}
+		if existing.keys[name] {
+			if err := project.DeleteProjectKey(tx, proj.ID, name); err != nil {
+				return sdk.WrapError(err, "unable to delete key %s", name)
+			}
+		}
+		if err := project.InsertKey(tx, &sdk.ProjectKey{Key: *k, ProjectID: proj.ID}); err != nil {
+			return sdk.WrapError(err, "unable to insert key %s", name)
+		}
+		report.Imported = append(report.Imported, "key "+name)
+	}
+
+	for _, name := range sortedKeys(e.project.Permissions) {
+		g, err := group.LoadGroup(tx, name)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load group %s", name)
+		}
+		exists, err := group.CheckGroupInProject(tx, proj.ID, g.ID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			err = group.UpdateGroupRoleInProject(tx, proj.ID, g.ID, e.project.Permissions[name])
+		} else {
+			err = group.InsertGroupInProject(tx, proj.ID, g.ID, e.project.Permissions[name])
+		}
+		if err != nil {
+			return sdk.WrapError(err, "unable to set permission of group %s", name)
+		}
+		report.Imported = append(report.Imported, "permission "+name)
+	}
+
+	for _, name := range sortedKeys(e.project.Integrations) {
+		ei := e.project.Integrations[name]
+		m, err := integration.LoadModelByName(tx, ei.Model, false)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load integration model %s", ei.Model)
+		}
+		pi := sdk.ProjectIntegration{
+			Name:               name,
+			ProjectID:          proj.ID,
+			IntegrationModelID: m.ID,
+			Model:              m,
+			Config:             ei.Config.Clone(),
+		}
+		if err := pi.Config.DecryptSecrets(e.cipher.decrypt); err != nil {
+			return sdk.WrapError(err, "unable to decrypt integration %s", name)
+		}
+		if old, ok := proj.GetIntegration(name); ok {
+			pi.ID = old.ID
+			if err := integration.UpdateIntegration(tx, pi); err != nil {
+				return err
+			}
+		} else {
+			if err := integration.InsertIntegration(tx, &pi); err != nil {
+				return err
+			}
+			proj.Integrations = append(proj.Integrations, pi)
+		}
+		report.Imported = append(report.Imported, "integration "+name)
+	}
+
+	for _, name := range sortedKeys(e.applications) {
+		a := e.applications[name]
+		app, _, err := application.ParseAndImport(tx, store, proj, &a, application.ImportOptions{Force: opts.Force}, e.cipher.decryptFunc, u)
+		if err != nil {
+			return sdk.ErrorWithFallback(err, sdk.ErrWrongRequest, "unable to import application %s", name)
+		}
+		proj.SetApplication(*app)
+		report.Imported = append(report.Imported, "application "+name)
+	}
+
+	for _, name := range sortedKeys(e.environments) {
+		env := e.environments[name]
+		envDB, _, err := environment.ParseAndImport(tx, proj, &env, environment.ImportOptions{Force: opts.Force}, e.cipher.decryptFunc, u)
+		if err != nil {
+			return sdk.ErrorWithFallback(err, sdk.ErrWrongRequest, "unable to import environment %s", name)
+		}
+		proj.SetEnvironment(*envDB)
+		report.Imported = append(report.Imported, "environment "+name)
+	}
+
+	for _, name := range sortedKeys(e.pipelines) {
+		p := e.pipelines[name]
+		pip, _, err := pipeline.ParseAndImport(ctx, tx, store, proj, &p, u, pipeline.ImportOptions{Force: opts.Force})
+		if err != nil {
+			return sdk.ErrorWithFallback(err, sdk.ErrWrongRequest, "unable to import pipeline %s", name)
+		}
+		proj.SetPipeline(*pip)
+		report.Imported = append(report.Imported, "pipeline "+name)
+	}
+
+	for _, name := range sortedKeys(e.workflows) {
+		w := e.workflows[name]
+		var oldW *sdk.Workflow
+		if existing.workflows[name] {
+			var err error
+			oldW, err = workflow.Load(ctx, tx, store, proj, name, u, workflow.LoadOptions{WithIcon: true})
+			if err != nil {
+				return sdk.WrapError(err, "unable to load workflow %s", name)
+			}
+		}
+		if _, _, err := workflow.ParseAndImport(ctx, tx, store, proj, oldW, &w, u, workflow.ImportOptions{Force: opts.Force}); err != nil {
+			return sdk.ErrorWithFallback(err, sdk.ErrWrongRequest, "unable to import workflow %s", name)
+		}
+		report.Imported = append(report.Imported, "workflow "+name)
+	}
+
+	log.Info("bundle.Import> %d entities imported in project %s", len(report.Imported), proj.Key)
+	return nil
+}
+
+// existingEntities are the names of the entities of the target project, by kind
+type existingEntities struct {
+	keys, applications, environments, pipelines, workflows map[string]bool
+}
+
+func newExistingEntities(proj *sdk.Project) existingEntities {
+	e := existingEntities{
+		keys:         make(map[string]bool, len(proj.Keys)),
+		applications: make(map[string]bool, len(proj.Applications)),
+		environments: make(map[string]bool, len(proj.Environments)),
+		pipelines:    make(map[string]bool, len(proj.Pipelines)),
+		workflows:    make(map[string]bool, len(proj.WorkflowNames)),
+	}
+	for _, k := range proj.Keys {
+		e.keys[k.Name] = true
+	}
+	for _, a := range proj.Applications {
+		e.applications[a.Name] = true
+	}
+	for _, env := range proj.Environments {
+		e.environments[env.Name] = true
+	}
+	for _, p := range proj.Pipelines {
+		e.pipelines[p.Name] = true
+	}
+	for _, w := range proj.WorkflowNames {
+		e.workflows[w.Name] = true
+	}
+	return e
+}
+
+// sortedKeys returns the sorted keys of a map indexed by name, so the import order is stable
+func sortedKeys(m interface{}) []string {
+	keys := reflect.ValueOf(m).MapKeys()
+	res := make([]string, len(keys))
+	for i := range keys {
+		res[i] = keys[i].String()
+	}
+	sort.Strings(res)
+	return res
+}
@@ -41,6 +41,9 @@ type Store interface {
 	SetRemove(rootKey string, memberKey string, member interface{})
 	SetCard(key string) int
 	SetScan(key string, members ...interface{}) error
+	Increment(key string) (int64, error)
+	EnqueueCapped(queueName string, value interface{}, size int)
+	QueueScan(queueName string) ([]string, error)
 	Lock(key string, expiration time.Duration, retryWaitDurationMillisecond int, retryCount int) bool
 	Unlock(key string)
 }
@@ -200,6 +200,52 @@ func (s *RedisStore) Enqueue(queueName string, value interface{}) {
 	}
 }
 
+// EnqueueCapped pushes to a queue and removes the oldest values to keep only size values
+func (s *RedisStore) EnqueueCapped(queueName string, value interface{}, size int) {
+	if s.Client == nil {
+		log.Error("redis> cannot get redis client")
+		return
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		log.Warning("redis> Error queueing %s:%s", queueName, err)
+		return
+	}
+	pipe := s.Client.TxPipeline()
+	pipe.LPush(queueName, string(b))
+	pipe.LTrim(queueName, 0, int64(size-1))
+	if _, err := pipe.Exec(); err != nil {
+		log.Warning("redis> Error while LPUSH to %s: %s", queueName, err)
+	}
+}
+
+// QueueScan returns all the values of a queue without dequeuing them, oldest first
+func (s *RedisStore) QueueScan(queueName string) ([]string, error) {
+	if s.Client == nil {
+		return nil, sdk.WithStack(fmt.Errorf("redis> cannot get redis client"))
+	}
+	values, err := s.Client.LRange(queueName, 0, -1).Result()
+	if err != nil {
+		return nil, sdk.WrapError(err, "redis lrange error")
+	}
+	for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+		values[i], values[j] = values[j], values[i]
+	}
+	return values, nil
+}
+
+// Increment increments the integer value of a key and returns it
+func (s *RedisStore) Increment(key string) (int64, error) {
+	if s.Client == nil {
+		return 0, sdk.WithStack(fmt.Errorf("redis> cannot get redis client"))
+	}
+	i, err := s.Client.Incr(key).Result()
+	if err != nil {
+		return 0, sdk.WrapError(err, "redis incr error")
+	}
+	return i, nil
+}
+
 //Dequeue gets from queue This is blocking while there is nothing in the queue
 func (s *RedisStore) Dequeue(queueName string, value interface{}) {
 	if s.Client == nil {
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.opencensus.io/tag"
+
+	"github.com/ovh/cds/engine/api/metrics"
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func (api *API) getProjectDORAMetricsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		key := mux.Vars(r)[permProjectKey]
+
+		days := sdk.DORAMetricsDefaultDays
+		if r.FormValue("days") != "" {
+			var err error
+			days, err = FormInt(r, "days")
+			if err != nil {
+				return err
+			}
+			if days <= 0 || days > 365 {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "days must be between 1 and 365")
+			}
+		}
+
+		ms, err := metrics.DORA(api.mustDB(), days, metrics.DeploymentsFilter{
+			ProjectKey:      key,
+			ApplicationName: FormString(r, "application"),
+			EnvironmentName: FormString(r, "environment"),
+		})
+		if err != nil {
+			return sdk.WrapError(err, "cannot compute DORA metrics of project %s", key)
+		}
+		return service.WriteJSON(w, ms, http.StatusOK)
+	}
+}
+
+// computeDORAMetrics records the DORA metrics of all the applications and production environments for prometheus
+func (api *API) computeDORAMetrics(ctx context.Context) {
+	tagCDSInstance, _ := tag.NewKey("cds")
+	tagProjectKey, _ := tag.NewKey("project_key")
+	tagApplication, _ := tag.NewKey("application")
+	tagEnvironment, _ := tag.NewKey("environment")
+	tags := []tag.Key{tagCDSInstance, tagProjectKey, tagApplication, tagEnvironment}
+
+	sdk.GoRoutine(ctx, "api.computeDORAMetrics", func(ctx context.Context) {
+		tick := time.NewTicker(15 * time.Minute)
+		defer tick.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				if ctx.Err() != nil {
+					log.Error("Exiting computeDORAMetrics: %v", ctx.Err())
+					return
+				}
+			case <-tick.C:
+				ms, err := metrics.DORA(api.mustDB(), sdk.DORAMetricsDefaultDays, metrics.DeploymentsFilter{})
+				if err != nil {
+					log.Warning("computeDORAMetrics> unable to compute DORA metrics: %v", err)
+					continue
+				}
+				for _, m := range ms {
+					ctx, _ := tag.New(ctx, tag.Upsert(tagProjectKey, m.ProjectKey),
+						tag.Upsert(tagApplication, m.ApplicationName), tag.Upsert(tagEnvironment, m.EnvironmentName))
+					for name, value := range map[string]float64{
+						"dora_deployment_frequency":          m.DeploymentFrequency,
+						"dora_lead_time_for_changes_seconds": m.LeadTimeForChanges,
+						"dora_change_failure_rate":           m.ChangeFailureRate,
+						"dora_time_to_restore_seconds":       m.TimeToRestore,
+					} {
+						v, err := observability.FindAndRegisterViewLastFloat64(name, tags)
+						if err != nil {
+							log.Warning("computeDORAMetrics> unable to register view %s: %v", name, err)
+							continue
+						}
+						observability.RecordFloat64(ctx, v.Measure, value)
+					}
+				}
+			}
+		}
+	})
+}
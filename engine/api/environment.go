@@ -225,6 +225,7 @@ func (api *API) updateEnvironmentHandler() service.Handler {
 
 		oldEnv := env
 		env.Name = envPost.Name
+		env.Production = envPost.Production
 
 		tx, errBegin := api.mustDB().Begin()
 		if errBegin != nil {
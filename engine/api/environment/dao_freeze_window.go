@@ -0,0 +1,138 @@
+package environment
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+const freezeWindowFields = `freeze_window.id, freeze_window.project_id, freeze_window.environment_id, COALESCE(environment.name, ''),
+	freeze_window.name, freeze_window.reason, freeze_window.cron, freeze_window.duration, freeze_window.timezone,
+	freeze_window.start_date, freeze_window.end_date, freeze_window.override_groups, freeze_window.created`
+
+// InsertFreezeWindow inserts a freeze window in database
+func InsertFreezeWindow(db gorp.SqlExecutor, f *sdk.FreezeWindow) error {
+	groups, err := json.Marshal(f.OverrideGroups)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	f.Created = time.Now()
+
+	query := `INSERT INTO freeze_window (project_id, environment_id, name, reason, cron, duration, timezone, start_date, end_date, override_groups, created)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id`
+	if err := db.QueryRow(query, f.ProjectID, nullEnvironmentID(f.EnvironmentID), f.Name, f.Reason, f.Cron, f.Duration, f.Timezone,
+		nullTime(f.Start), nullTime(f.End), groups, f.Created).Scan(&f.ID); err != nil {
+		if errPG, ok := err.(*pq.Error); ok && errPG.Code == gorpmapping.ViolateUniqueKeyPGCode {
+			return sdk.NewErrorFrom(sdk.ErrInvalidFreezeWindow, "freeze window %s already exists", f.Name)
+		}
+		return sdk.WrapError(err, "Cannot insert freeze window %s", f.Name)
+	}
+	return nil
+}
+
+// UpdateFreezeWindow updates a freeze window in database
+func UpdateFreezeWindow(db gorp.SqlExecutor, f *sdk.FreezeWindow) error {
+	groups, err := json.Marshal(f.OverrideGroups)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+
+	query := `UPDATE freeze_window SET environment_id = $3, name = $4, reason = $5, cron = $6, duration = $7, timezone = $8,
+	start_date = $9, end_date = $10, override_groups = $11
+	WHERE project_id = $1 AND id = $2`
+	if _, err := db.Exec(query, f.ProjectID, f.ID, nullEnvironmentID(f.EnvironmentID), f.Name, f.Reason, f.Cron, f.Duration, f.Timezone,
+		nullTime(f.Start), nullTime(f.End), groups); err != nil {
+		return sdk.WrapError(err, "Cannot update freeze window %d", f.ID)
+	}
+	return nil
+}
+
+// DeleteFreezeWindow deletes a freeze window from database
+func DeleteFreezeWindow(db gorp.SqlExecutor, projectID, id int64) error {
+	if _, err := db.Exec("DELETE FROM freeze_window WHERE project_id = $1 AND id = $2", projectID, id); err != nil {
+		return sdk.WrapError(err, "Cannot delete freeze window %d", id)
+	}
+	return nil
+}
+
+// LoadFreezeWindow loads a freeze window of a project
+func LoadFreezeWindow(db gorp.SqlExecutor, projectID, id int64) (*sdk.FreezeWindow, error) {
+	fs, err := loadFreezeWindows(db, `SELECT `+freezeWindowFields+` FROM freeze_window
+	LEFT JOIN environment ON environment.id = freeze_window.environment_id
+	WHERE freeze_window.project_id = $1 AND freeze_window.id = $2`, projectID, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(fs) == 0 {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return &fs[0], nil
+}
+
+// LoadFreezeWindowsByProject loads all the freeze windows of a project
+func LoadFreezeWindowsByProject(db gorp.SqlExecutor, projectID int64) ([]sdk.FreezeWindow, error) {
+	return loadFreezeWindows(db, `SELECT `+freezeWindowFields+` FROM freeze_window
+	LEFT JOIN environment ON environment.id = freeze_window.environment_id
+	WHERE freeze_window.project_id = $1
+	ORDER BY freeze_window.name`, projectID)
+}
+
+// LoadFreezeWindowsByEnvironment loads the freeze windows which apply to an environment:
+// the windows of the environment and the windows of its project
+func LoadFreezeWindowsByEnvironment(db gorp.SqlExecutor, projectID, environmentID int64) ([]sdk.FreezeWindow, error) {
+	return loadFreezeWindows(db, `SELECT `+freezeWindowFields+` FROM freeze_window
+	LEFT JOIN environment ON environment.id = freeze_window.environment_id
+	WHERE freeze_window.project_id = $1
+	AND (freeze_window.environment_id IS NULL OR freeze_window.environment_id = $2)
+	ORDER BY freeze_window.name`, projectID, environmentID)
+}
+
+func loadFreezeWindows(db gorp.SqlExecutor, query string, args ...interface{}) ([]sdk.FreezeWindow, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, sdk.WrapError(err, "Cannot load freeze windows")
+	}
+	defer rows.Close()
+
+	fs := []sdk.FreezeWindow{}
+	for rows.Next() {
+		var f sdk.FreezeWindow
+		var envID sql.NullInt64
+		var reason, cron, timezone sql.NullString
+		var duration sql.NullInt64
+		var start, end pq.NullTime
+		var groups []byte
+		if err := rows.Scan(&f.ID, &f.ProjectID, &envID, &f.EnvironmentName, &f.Name, &reason, &cron, &duration, &timezone,
+			&start, &end, &groups, &f.Created); err != nil {
+			return nil, sdk.WrapError(err, "Cannot scan freeze window")
+		}
+		f.EnvironmentID = envID.Int64
+		f.Reason = reason.String
+		f.Cron = cron.String
+		f.Duration = duration.Int64
+		f.Timezone = timezone.String
+		f.Start = start.Time
+		f.End = end.Time
+		if len(groups) > 0 {
+			if err := json.Unmarshal(groups, &f.OverrideGroups); err != nil {
+				return nil, sdk.WrapError(err, "Cannot unmarshal override groups of freeze window %s", f.Name)
+			}
+		}
+		fs = append(fs, f)
+	}
+	return fs, nil
+}
+
+func nullEnvironmentID(id int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: id, Valid: id != 0}
+}
+
+func nullTime(t time.Time) pq.NullTime {
+	return pq.NullTime{Time: t, Valid: !t.IsZero()}
+}
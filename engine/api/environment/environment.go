@@ -19,7 +19,7 @@ import (
 func LoadEnvironments(db gorp.SqlExecutor, projectKey string, loadDeps bool, u *sdk.User) ([]sdk.Environment, error) {
 	var envs []sdk.Environment
 
-	query := `SELECT environment.id, environment.name, environment.last_modified, 7 as "perm", environment.from_repository
+	query := `SELECT environment.id, environment.name, environment.last_modified, 7 as "perm", environment.from_repository, environment.production
 		  FROM environment
 		  JOIN project ON project.id = environment.project_id
 		  WHERE project.projectKey = $1
@@ -36,7 +36,7 @@ func LoadEnvironments(db gorp.SqlExecutor, projectKey string, loadDeps bool, u *
 	for rows.Next() {
 		var env sdk.Environment
 		var lastModified time.Time
-		if err := rows.Scan(&env.ID, &env.Name, &lastModified, &env.Permission, &env.FromRepository); err != nil {
+		if err := rows.Scan(&env.ID, &env.Name, &lastModified, &env.Permission, &env.FromRepository, &env.Production); err != nil {
 			return envs, sdk.WithStack(err)
 		}
 		env.LastModified = lastModified.Unix()
@@ -93,10 +93,10 @@ func LoadEnvironmentByID(db gorp.SqlExecutor, ID int64) (*sdk.Environment, error
 		return &sdk.DefaultEnv, nil
 	}
 	var env sdk.Environment
-	query := `SELECT environment.id, environment.name, environment.project_id, environment.from_repository
+	query := `SELECT environment.id, environment.name, environment.project_id, environment.from_repository, environment.production
 		  	FROM environment
 		 	WHERE id = $1`
-	if err := db.QueryRow(query, ID).Scan(&env.ID, &env.Name, &env.ProjectID, &env.FromRepository); err != nil {
+	if err := db.QueryRow(query, ID).Scan(&env.ID, &env.Name, &env.ProjectID, &env.FromRepository, &env.Production); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, sdk.ErrNoEnvironment
 		}
@@ -112,11 +112,11 @@ func LoadEnvironmentByName(db gorp.SqlExecutor, projectKey, envName string) (*sd
 	}
 
 	var env sdk.Environment
-	query := `SELECT environment.id, environment.name,  environment.project_id, environment.from_repository
+	query := `SELECT environment.id, environment.name,  environment.project_id, environment.from_repository, environment.production
 		  FROM environment
 		  JOIN project ON project.id = environment.project_id
 		  WHERE project.projectKey = $1 AND environment.name = $2`
-	if err := db.QueryRow(query, projectKey, envName).Scan(&env.ID, &env.Name, &env.ProjectID, &env.FromRepository); err != nil {
+	if err := db.QueryRow(query, projectKey, envName).Scan(&env.ID, &env.Name, &env.ProjectID, &env.FromRepository, &env.Production); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, sdk.ErrNoEnvironment
 		}
@@ -195,7 +195,7 @@ func loadDependencies(db gorp.SqlExecutor, env *sdk.Environment) error {
 
 // InsertEnvironment Insert new environment
 func InsertEnvironment(db gorp.SqlExecutor, env *sdk.Environment) error {
-	query := `INSERT INTO environment (name, project_id, from_repository) VALUES($1, $2, $3) RETURNING id, last_modified`
+	query := `INSERT INTO environment (name, project_id, from_repository, production) VALUES($1, $2, $3, $4) RETURNING id, last_modified`
 
 	rx := sdk.NamePatternRegex
 	if !rx.MatchString(env.Name) {
@@ -203,7 +203,7 @@ func InsertEnvironment(db gorp.SqlExecutor, env *sdk.Environment) error {
 	}
 
 	var lastModified time.Time
-	err := db.QueryRow(query, env.Name, env.ProjectID, env.FromRepository).Scan(&env.ID, &lastModified)
+	err := db.QueryRow(query, env.Name, env.ProjectID, env.FromRepository, env.Production).Scan(&env.ID, &lastModified)
 	if err != nil {
 		pqerr, ok := err.(*pq.Error)
 		if ok {
@@ -224,8 +224,8 @@ func UpdateEnvironment(db gorp.SqlExecutor, environment *sdk.Environment) error
 		return sdk.NewError(sdk.ErrInvalidName, fmt.Errorf("Invalid environment name. It should match %s", sdk.NamePattern))
 	}
 
-	query := `UPDATE environment SET name=$1, from_repository=$3 WHERE id=$2`
-	if _, err := db.Exec(query, environment.Name, environment.ID, environment.FromRepository); err != nil {
+	query := `UPDATE environment SET name=$1, from_repository=$3, production=$4 WHERE id=$2`
+	if _, err := db.Exec(query, environment.Name, environment.ID, environment.FromRepository, environment.Production); err != nil {
 		return err
 	}
 	return nil
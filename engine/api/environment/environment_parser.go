@@ -50,6 +50,7 @@ func ParseAndImport(db gorp.SqlExecutor, proj *sdk.Project, eenv *exportentities
 
 	env := new(sdk.Environment)
 	env.Name = eenv.Name
+	env.Production = eenv.Production
 	env.FromRepository = opts.FromRepository
 	if exist {
 		env.ID = oldEnv.ID
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// ephemeralEnvironmentsRoutine tears down the ephemeral environments which have
+// expired or whose pull request has been merged or closed
+func (api *API) ephemeralEnvironmentsRoutine(ctx context.Context) {
+	tick := time.NewTicker(time.Minute).C
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error("Exiting ephemeralEnvironmentsRoutine: %v", ctx.Err())
+			}
+			return
+		case <-tick:
+			envs, err := workflow.LoadActiveEphemeralEnvironments(api.mustDB())
+			if err != nil {
+				log.Warning("ephemeralEnvironmentsRoutine> %v", err)
+				continue
+			}
+			for i := range envs {
+				if err := api.checkEphemeralEnvironment(ctx, &envs[i]); err != nil {
+					log.Warning("ephemeralEnvironmentsRoutine> unable to check ephemeral environment %s: %v", envs[i].Name, err)
+				}
+			}
+		}
+	}
+}
+
+func (api *API) checkEphemeralEnvironment(ctx context.Context, env *sdk.EphemeralEnvironment) error {
+	// Lock the environment to not start the teardown twice from several API instances
+	lockKey := cache.Key("workflow", "ephemeral", strconv.FormatInt(env.ID, 10))
+	if !api.Cache.Lock(lockKey, 5*time.Minute, 0, 1) {
+		log.Debug("checkEphemeralEnvironment> ephemeral environment %s is locked", env.Name)
+		return nil
+	}
+	defer api.Cache.Unlock(lockKey)
+
+	db := api.mustDB()
+
+	wr, err := workflow.LoadRunByID(db, env.WorkflowRunID, workflow.LoadRunOptions{})
+	if err != nil {
+		return sdk.WrapError(err, "unable to load workflow run %d", env.WorkflowRunID)
+	}
+
+	proj, err := project.LoadByID(db, api.Cache, env.ProjectID, nil,
+		project.LoadOptions.WithVariables,
+		project.LoadOptions.WithFeatures,
+		project.LoadOptions.WithIntegrations,
+		project.LoadOptions.WithApplicationVariables,
+		project.LoadOptions.WithApplicationWithDeploymentStrategies,
+		project.LoadOptions.WithEnvironments,
+		project.LoadOptions.WithPipelines,
+	)
+	if err != nil {
+		return sdk.WrapError(err, "unable to load project %d", env.ProjectID)
+	}
+
+	teardown := env.IsExpired(time.Now())
+	if !teardown && env.PullRequestID > 0 {
+		app := wr.Workflow.Applications[env.ApplicationID]
+		vcsServer := repositoriesmanager.GetProjectVCSServer(proj, app.VCSServer)
+		if vcsServer != nil {
+			client, err := repositoriesmanager.AuthorizedClient(ctx, db, api.Cache, vcsServer)
+			if err != nil {
+				return sdk.WrapError(err, "cannot get vcs client")
+			}
+			merged, closed, err := workflow.CheckPullRequestStatus(ctx, client, app.RepositoryFullname, env.PullRequestID)
+			if err != nil {
+				return err
+			}
+			teardown = merged || closed
+		}
+	}
+	if !teardown {
+		return nil
+	}
+
+	nodeRun, err := workflow.LoadNodeRunByID(db, env.WorkflowNodeRunID, workflow.LoadRunOptions{})
+	if err != nil {
+		return sdk.WrapError(err, "unable to load workflow node run %d", env.WorkflowNodeRunID)
+	}
+
+	// Keep the payload of the deployment to run the teardown on the same branch
+	payload := map[string]interface{}{}
+	if p, ok := nodeRun.Payload.(map[string]interface{}); ok {
+		for k, v := range p {
+			payload[k] = v
+		}
+	}
+	payload[sdk.EphemeralEnvironmentTeardownParam] = "true"
+
+	u := &sdk.User{Username: "cds.ephemeral", Admin: true}
+	if env.TriggeredBy != "" {
+		u, err = user.LoadUserWithoutAuth(db, env.TriggeredBy)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load user %s", env.TriggeredBy)
+		}
+		if err := loadUserPermissions(db, api.Cache, u); err != nil {
+			return sdk.WrapError(err, "unable to load permissions of user %s", env.TriggeredBy)
+		}
+	}
+
+	log.Info("checkEphemeralEnvironment> tearing down ephemeral environment %s of workflow %s/%s", env.Name, proj.Key, wr.Workflow.Name)
+
+	if err := workflow.MigrateWorkflowRun(ctx, db, wr); err != nil {
+		return sdk.WrapError(err, "unable to migrate workflow run")
+	}
+	wr.Status = sdk.StatusWaiting.String()
+	opts := &sdk.WorkflowRunPostHandlerOption{
+		Number:      &wr.Number,
+		FromNodeIDs: []int64{env.WorkflowNodeID},
+		Manual: &sdk.WorkflowNodeRunManual{
+			Payload: payload,
+		},
+	}
+	api.initWorkflowRun(ctx, db, api.Cache, proj, &wr.Workflow, wr, opts, u)
+	if wr.Status == sdk.StatusFail.String() {
+		// The environment stays active, the teardown will be retried on next check
+		return sdk.WithStack(fmt.Errorf("unable to start the teardown of ephemeral environment %s", env.Name))
+	}
+
+	// The teardown node run updates the environment, reload it before marking it as tore down
+	env, err = workflow.LoadEphemeralEnvironmentByName(db, env.WorkflowID, env.WorkflowNodeID, env.Name)
+	if err != nil {
+		return err
+	}
+	if env.Status == sdk.EphemeralEnvironmentStatusTeardown {
+		return nil
+	}
+	env.Status = sdk.EphemeralEnvironmentStatusTeardown
+	return workflow.UpdateEphemeralEnvironment(db, env)
+}
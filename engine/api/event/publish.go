@@ -14,12 +14,28 @@ import (
 
 var store cache.Store
 
+const (
+	eventsSequenceKey = "events:sequence"
+	eventsBufferKey   = "events:buffer"
+	// EventsBufferSize is the number of events kept in cache to replay the SSE stream to reconnecting clients
+	EventsBufferSize = 1000
+)
+
 func publishEvent(e sdk.Event) {
 	if store == nil {
 		return
 	}
 
+	id, err := store.Increment(eventsSequenceKey)
+	if err != nil {
+		log.Warning("publishEvent> unable to get event id: %v", err)
+	}
+	e.ID = id
+
 	store.Enqueue("events", e)
+	if e.ID != 0 && e.EventType != fmt.Sprintf("%T", sdk.EventJob{}) {
+		store.EnqueueCapped(eventsBufferKey, e, EventsBufferSize)
+	}
 
 	// send to cache for cds repositories manager
 	var toSkipSendReposManager bool
@@ -81,3 +97,26 @@ func Publish(payload interface{}, u *sdk.User) {
 	}
 	publishEvent(event)
 }
+
+// LoadBufferedEvents returns the events published after the given event id that are still in the buffer, oldest first
+func LoadBufferedEvents(lastEventID int64) ([]sdk.Event, error) {
+	if store == nil {
+		return nil, nil
+	}
+	values, err := store.QueueScan(eventsBufferKey)
+	if err != nil {
+		return nil, err
+	}
+	es := make([]sdk.Event, 0, len(values))
+	for _, v := range values {
+		var e sdk.Event
+		if err := json.Unmarshal([]byte(v), &e); err != nil {
+			log.Warning("LoadBufferedEvents> unable to unmarshal event: %v", err)
+			continue
+		}
+		if e.ID > lastEventID {
+			es = append(es, e)
+		}
+	}
+	return es, nil
+}
@@ -79,3 +79,17 @@ func PublishWorkflowPermissionDelete(projKey string, w sdk.Workflow, gp sdk.Grou
 	}
 	publishWorkflowEvent(e, projKey, w.Name, u)
 }
+
+// PublishWorkflowNodeRunApproval publishes an event when a user reviews the approval request of a workflow node run
+func PublishWorkflowNodeRunApproval(projKey, workflowName string, number int64, a sdk.WorkflowNodeRunApproval, approved bool, comment string, u *sdk.User) {
+	e := sdk.EventWorkflowNodeRunApproval{
+		WorkflowID:        a.WorkflowID,
+		WorkflowRunNumber: number,
+		WorkflowNodeRunID: a.WorkflowNodeRunID,
+		WorkflowNodeName:  a.WorkflowNodeName,
+		Status:            a.Status,
+		Approved:          approved,
+		Comment:           comment,
+	}
+	publishWorkflowEvent(e, projKey, workflowName, u)
+}
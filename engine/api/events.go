@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -16,6 +17,7 @@ import (
 	"github.com/go-gorp/gorp"
 
 	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/event"
 	"github.com/ovh/cds/engine/api/group"
 	"github.com/ovh/cds/engine/api/observability"
 	"github.com/ovh/cds/engine/api/permission"
@@ -31,6 +33,9 @@ type eventsBrokerSubscribe struct {
 	isAlive *abool.AtomicBool
 	w       http.ResponseWriter
 	mutex   sync.Mutex
+	filter  sdk.EventsStreamFilter
+	// lastEventID is the id of the last replayed event, live events already replayed are skipped
+	lastEventID int64
 }
 
 // lastUpdateBroker keeps connected client of the current route,
@@ -187,14 +192,26 @@ func (b *eventsBroker) ServeHTTP() service.Handler {
 			return sdk.WrapError(err, "eventsBroker.Serve Cannot load user permission")
 		}
 
+		var lastEventID int64
+		if h := r.Header.Get(sdk.EventsLastEventIDHeader); h != "" {
+			lastEventID, err = strconv.ParseInt(h, 10, 64)
+			if err != nil {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid %s header: %s", sdk.EventsLastEventIDHeader, h)
+			}
+		}
+
 		uuid := sdk.UUID()
 		client := &eventsBrokerSubscribe{
 			UUID:    uuid,
 			User:    user,
 			isAlive: abool.NewBool(true),
 			w:       w,
+			filter:  sdk.NewEventsStreamFilter(r.URL.Query()),
 		}
 
+		// The client is locked until the missed events are replayed, so live events are sent after them
+		client.mutex.Lock()
+
 		// Add this client to the map of those that should receive updates
 		b.chanAddClient <- client
 
@@ -205,10 +222,17 @@ func (b *eventsBroker) ServeHTTP() service.Handler {
 		w.Header().Set("X-Accel-Buffering", "no")
 
 		if _, err := w.Write([]byte(fmt.Sprintf("data: ACK: %s \n\n", uuid))); err != nil {
+			client.mutex.Unlock()
+			b.chanRemoveClient <- client.UUID
 			return sdk.WrapError(err, "Unable to send ACK to client")
 		}
 		f.Flush()
 
+		if lastEventID > 0 {
+			client.replay(lastEventID)
+		}
+		client.mutex.Unlock()
+
 		tick := time.NewTicker(time.Second)
 		defer tick.Stop()
 
@@ -281,11 +305,30 @@ func (client *eventsBrokerSubscribe) manageEvent(event sdk.Event) bool {
 	return false
 }
 
+// replay sends the buffered events published after the last event received by the client, the client must be locked
+func (client *eventsBrokerSubscribe) replay(lastEventID int64) {
+	es, err := event.LoadBufferedEvents(lastEventID)
+	if err != nil {
+		log.Warning("eventsBroker> unable to load buffered events for %s: %v", client.UUID, err)
+		return
+	}
+	for _, e := range es {
+		if err := client.send(e); err != nil {
+			log.Warning("eventsBroker> unable to replay event %d to %s: %v", e.ID, client.UUID, err)
+			return
+		}
+		client.lastEventID = e.ID
+	}
+}
+
 // Send an event to a client
 func (client *eventsBrokerSubscribe) Send(event sdk.Event) (err error) {
 	client.mutex.Lock()
 	defer client.mutex.Unlock()
+	return client.send(event)
+}
 
+func (client *eventsBrokerSubscribe) send(event sdk.Event) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("eventsBrokerSubscribe.Send recovered %v", r)
@@ -304,14 +347,23 @@ func (client *eventsBrokerSubscribe) Send(event sdk.Event) (err error) {
 
 	var buffer bytes.Buffer
 	if event.EventType != "" {
+		if event.ID != 0 && event.ID <= client.lastEventID {
+			return nil
+		}
 		if ok := client.manageEvent(event); !ok {
 			return nil
 		}
+		if !client.filter.Match(event) {
+			return nil
+		}
 
 		msg, err := json.Marshal(event)
 		if err != nil {
 			return sdk.WrapError(err, "Unable to marshall event")
 		}
+		if event.ID != 0 {
+			buffer.WriteString(fmt.Sprintf("id: %d\n", event.ID))
+		}
 		buffer.WriteString("data: ")
 		buffer.Write(msg)
 		buffer.WriteString("\n\n")
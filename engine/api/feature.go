@@ -4,8 +4,11 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/gorilla/mux"
+
 	"github.com/ovh/cds/engine/api/feature"
 	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
 )
 
 func (api *API) cleanFeatureHandler() service.Handler {
@@ -14,3 +17,54 @@ func (api *API) cleanFeatureHandler() service.Handler {
 		return nil
 	}
 }
+
+func (api *API) getAdminFeaturesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		fs, err := feature.LoadAll(api.mustDB())
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, fs, http.StatusOK)
+	}
+}
+
+func (api *API) getAdminFeatureHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		name := mux.Vars(r)["name"]
+		f, err := feature.LoadByName(api.mustDB(), name)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load feature %s", name)
+		}
+		return service.WriteJSON(w, f, http.StatusOK)
+	}
+}
+
+func (api *API) putAdminFeatureHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var f sdk.Feature
+		if err := service.UnmarshalBody(r, &f); err != nil {
+			return sdk.WrapError(err, "cannot unmarshal body")
+		}
+		f.Name = mux.Vars(r)["name"]
+
+		if err := feature.Save(api.mustDB(), &f); err != nil {
+			return err
+		}
+		feature.Clean(api.Cache)
+		return service.WriteJSON(w, f, http.StatusOK)
+	}
+}
+
+func (api *API) deleteAdminFeatureHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		name := mux.Vars(r)["name"]
+		if _, err := feature.LoadByName(api.mustDB(), name); err != nil {
+			return sdk.WrapError(err, "unable to load feature %s", name)
+		}
+		if err := feature.Delete(api.mustDB(), name); err != nil {
+			return err
+		}
+		feature.Clean(api.Cache)
+		return nil
+	}
+}
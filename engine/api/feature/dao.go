@@ -0,0 +1,132 @@
+package feature
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const featureFields = `id, name, enabled, project_keys, groups, percentage, last_modified`
+
+// LoadAll loads all the features of the database store
+func LoadAll(db gorp.SqlExecutor) ([]sdk.Feature, error) {
+	rows, err := db.Query(`SELECT ` + featureFields + ` FROM feature_flag ORDER BY name`)
+	if err != nil {
+		return nil, sdk.WrapError(err, "Unable to load features")
+	}
+	defer rows.Close() // nolint
+
+	var fs []sdk.Feature
+	for rows.Next() {
+		f, err := scanFeature(rows)
+		if err != nil {
+			return nil, err
+		}
+		fs = append(fs, *f)
+	}
+	return fs, sdk.WithStack(rows.Err())
+}
+
+// LoadByName loads a feature of the database store
+func LoadByName(db gorp.SqlExecutor, name string) (*sdk.Feature, error) {
+	f, err := scanFeature(db.QueryRow(`SELECT `+featureFields+` FROM feature_flag WHERE name = $1`, name))
+	if err != nil {
+		if sdk.Cause(err) == sql.ErrNoRows {
+			return nil, sdk.WithStack(sdk.ErrNotFound)
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
+// Save inserts or updates a feature of the database store
+func Save(db gorp.SqlExecutor, f *sdk.Feature) error {
+	if err := f.IsValid(); err != nil {
+		return err
+	}
+	projectKeys, err := json.Marshal(f.ProjectKeys)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	groups, err := json.Marshal(f.Groups)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	f.LastModified = time.Now()
+	query := `INSERT INTO feature_flag (name, enabled, project_keys, groups, percentage, last_modified)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (name) DO UPDATE SET enabled = $2, project_keys = $3, groups = $4, percentage = $5, last_modified = $6
+	RETURNING id`
+	if err := db.QueryRow(query, f.Name, f.Enabled, projectKeys, groups, f.Percentage, f.LastModified).Scan(&f.ID); err != nil {
+		return sdk.WrapError(err, "Unable to save feature %s", f.Name)
+	}
+	return nil
+}
+
+// Delete deletes a feature of the database store
+func Delete(db gorp.SqlExecutor, name string) error {
+	if _, err := db.Exec(`DELETE FROM feature_flag WHERE name = $1`, name); err != nil {
+		return sdk.WrapError(err, "Unable to delete feature %s", name)
+	}
+	return nil
+}
+
+func scanFeature(s interface {
+	Scan(dest ...interface{}) error
+}) (*sdk.Feature, error) {
+	var f sdk.Feature
+	var projectKeys, groups []byte
+	if err := s.Scan(&f.ID, &f.Name, &f.Enabled, &projectKeys, &groups, &f.Percentage, &f.LastModified); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	if len(projectKeys) > 0 {
+		if err := json.Unmarshal(projectKeys, &f.ProjectKeys); err != nil {
+			return nil, sdk.WrapError(err, "Unable to unmarshal project keys of feature %s", f.Name)
+		}
+	}
+	if len(groups) > 0 {
+		if err := json.Unmarshal(groups, &f.Groups); err != nil {
+			return nil, sdk.WrapError(err, "Unable to unmarshal groups of feature %s", f.Name)
+		}
+	}
+	return &f, nil
+}
+
+func loadProjectGroupNames(db gorp.SqlExecutor, projectKey string) ([]string, error) {
+	var names []string
+	if _, err := db.Select(&names, `SELECT "group".name FROM "group"
+	JOIN project_group ON project_group.group_id = "group".id
+	JOIN project ON project.id = project_group.project_id
+	WHERE project.projectkey = $1`, projectKey); err != nil {
+		return nil, sdk.WrapError(err, "Unable to load groups of project %s", projectKey)
+	}
+	return names, nil
+}
+
+// getStatusFromDatabase returns the state of a feature for a project, as with Izanami a feature unknown
+// in the store is active
+func getStatusFromDatabase(db gorp.SqlExecutor, featureID string, projectKey string) bool {
+	f, err := LoadByName(db, featureID)
+	if err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return true
+		}
+		log.Warning("Feature.IsEnabled > Cannot load feature %s: %v", featureID, err)
+		return false
+	}
+
+	var groups []string
+	if len(f.Groups) > 0 {
+		groups, err = loadProjectGroupNames(db, projectKey)
+		if err != nil {
+			log.Warning("Feature.IsEnabled > Cannot check feature %s: %v", featureID, err)
+			return false
+		}
+	}
+	return f.IsActive(projectKey, groups)
+}
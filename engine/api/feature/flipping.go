@@ -3,6 +3,7 @@ package feature
 import (
 	"strings"
 
+	"github.com/go-gorp/gorp"
 	client "github.com/ovhlabs/izanami-go-client"
 
 	"github.com/ovh/cds/engine/api/cache"
@@ -21,6 +22,9 @@ const (
 
 var izanami *client.Client
 
+// dbFunc is set when the features are stored in CDS database instead of Izanami
+var dbFunc func() *gorp.DbMap
+
 // CheckContext represents the context send to Izanami to check if the feature is enabled
 type CheckContext struct {
 	Key string `json:"key"`
@@ -49,12 +53,17 @@ func SetClient(c *client.Client) {
 	izanami = c
 }
 
-// GetFeatures tree for the given project from cache, if not found in cache init from Izanami.
+// InitDatabase uses the features stored in CDS database instead of Izanami
+func InitDatabase(f func() *gorp.DbMap) {
+	dbFunc = f
+}
+
+// GetFeatures tree for the given project from cache, if not found in cache init from Izanami or the database.
 func GetFeatures(store cache.Store, projectKey string) map[string]bool {
 	projFeats := ProjectFeatures{}
 
 	if store.Get(cacheFeatureKey+projectKey, &projFeats) {
-		// if missing features, invalidate cache and rebuild data from Izanami
+		// if missing features, invalidate cache and rebuild data
 		var missingFeature bool
 		for _, f := range List() {
 			if _, ok := projFeats.Features[f]; !ok {
@@ -67,13 +76,13 @@ func GetFeatures(store cache.Store, projectKey string) map[string]bool {
 		}
 	}
 
-	// get all features from Izanami and store in cache
+	// get all features and store in cache
 	projFeats = ProjectFeatures{Key: projectKey, Features: make(map[string]bool)}
 	for _, f := range List() {
-		projFeats.Features[f] = getStatusFromIzanami(f, projectKey)
+		projFeats.Features[f] = getStatus(f, projectKey)
 	}
 
-	// no expiration delay is set, the cache is cleared by Izanami calls on /feature/clean
+	// no expiration delay is set, the cache is cleared by Izanami calls on /feature/clean or by database features updates
 	store.Set(cacheFeatureKey+projectKey, projFeats)
 
 	return projFeats.Features
@@ -88,7 +97,14 @@ func IsEnabled(store cache.Store, featureID string, projectKey string) bool {
 	}
 
 	// if features not in cache, it means that it's not a key from listed in List() func
-	// try to get a value from Izanami
+	// try to get a value from Izanami or the database
+	return getStatus(featureID, projectKey)
+}
+
+func getStatus(featureID string, projectKey string) bool {
+	if dbFunc != nil {
+		return getStatusFromDatabase(dbFunc(), featureID, projectKey)
+	}
 	return getStatusFromIzanami(featureID, projectKey)
 }
 
@@ -0,0 +1,187 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/environment"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) getFreezeWindowsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		p, err := project.Load(api.mustDB(), api.Cache, key, deprecatedGetUser(ctx))
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", key)
+		}
+
+		fs, err := environment.LoadFreezeWindowsByProject(api.mustDB(), p.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, fs, http.StatusOK)
+	}
+}
+
+// getFreezeCalendarHandler returns the freeze periods of the project for the next days
+func (api *API) getFreezeCalendarHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		days := 30
+		if d := r.FormValue("days"); d != "" {
+			var err error
+			days, err = strconv.Atoi(d)
+			if err != nil || days <= 0 {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid number of days %s", d)
+			}
+		}
+
+		p, err := project.Load(api.mustDB(), api.Cache, key, deprecatedGetUser(ctx))
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", key)
+		}
+
+		fs, err := environment.LoadFreezeWindowsByProject(api.mustDB(), p.ID)
+		if err != nil {
+			return err
+		}
+
+		from := time.Now()
+		to := from.AddDate(0, 0, days)
+		periods := []sdk.FreezePeriod{}
+		for _, f := range fs {
+			ps, err := f.Periods(from, to)
+			if err != nil {
+				return err
+			}
+			periods = append(periods, ps...)
+		}
+		sort.Slice(periods, func(i, j int) bool { return periods[i].Start.Before(periods[j].Start) })
+
+		return service.WriteJSON(w, periods, http.StatusOK)
+	}
+}
+
+func (api *API) postFreezeWindowHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		var f sdk.FreezeWindow
+		if err := service.UnmarshalBody(r, &f); err != nil {
+			return err
+		}
+
+		p, err := project.Load(api.mustDB(), api.Cache, key, deprecatedGetUser(ctx))
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", key)
+		}
+		f.ProjectID = p.ID
+
+		if err := checkFreezeWindow(api.mustDB(), p, &f); err != nil {
+			return err
+		}
+
+		if err := environment.InsertFreezeWindow(api.mustDB(), &f); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, f, http.StatusOK)
+	}
+}
+
+func (api *API) putFreezeWindowHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+		id, err := requestVarInt(r, "id")
+		if err != nil {
+			return err
+		}
+
+		var f sdk.FreezeWindow
+		if err := service.UnmarshalBody(r, &f); err != nil {
+			return err
+		}
+
+		p, err := project.Load(api.mustDB(), api.Cache, key, deprecatedGetUser(ctx))
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", key)
+		}
+
+		old, err := environment.LoadFreezeWindow(api.mustDB(), p.ID, id)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load freeze window %d", id)
+		}
+		f.ID = old.ID
+		f.ProjectID = p.ID
+		f.Created = old.Created
+
+		if err := checkFreezeWindow(api.mustDB(), p, &f); err != nil {
+			return err
+		}
+
+		if err := environment.UpdateFreezeWindow(api.mustDB(), &f); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, f, http.StatusOK)
+	}
+}
+
+func (api *API) deleteFreezeWindowHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+		id, err := requestVarInt(r, "id")
+		if err != nil {
+			return err
+		}
+
+		p, err := project.Load(api.mustDB(), api.Cache, key, deprecatedGetUser(ctx))
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", key)
+		}
+
+		if _, err := environment.LoadFreezeWindow(api.mustDB(), p.ID, id); err != nil {
+			return sdk.WrapError(err, "cannot load freeze window %d", id)
+		}
+
+		if err := environment.DeleteFreezeWindow(api.mustDB(), p.ID, id); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+// checkFreezeWindow validates the window and resolves its environment
+func checkFreezeWindow(db gorp.SqlExecutor, p *sdk.Project, f *sdk.FreezeWindow) error {
+	if err := f.IsValid(); err != nil {
+		return err
+	}
+
+	f.EnvironmentID = 0
+	if f.EnvironmentName != "" {
+		env, err := environment.LoadEnvironmentByName(db, p.Key, f.EnvironmentName)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load environment %s", f.EnvironmentName)
+		}
+		f.EnvironmentID = env.ID
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package integration
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+var registryHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// registryManifestTypes are the accepted manifests, the digest of a multi-arch image is the digest of its manifest list
+var registryManifestTypes = []string{
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+}
+
+// RegistryURL returns the base URL of the registry of an image, the URL of the registry integration if given
+func RegistryURL(cfg sdk.IntegrationConfig, img sdk.DockerImage) string {
+	if u := strings.TrimSuffix(cfg["url"].Value, "/"); u != "" {
+		return u
+	}
+	if img.Registry == sdk.DockerHubRegistry {
+		return "https://registry-1.docker.io"
+	}
+	return "https://" + img.Registry
+}
+
+// ResolveImageDigest returns the digest of an image tag with the registry API, using the credentials of a docker registry integration
+func ResolveImageDigest(ctx context.Context, cfg sdk.IntegrationConfig, image string) (string, error) {
+	img := sdk.ParseDockerImage(image)
+	manifestURL := fmt.Sprintf("%s/v2/%s/manifests/%s", RegistryURL(cfg, img), img.Repository, img.Reference())
+	username, password := cfg["username"].Value, cfg["password"].Value
+
+	resp, err := registryRequest(ctx, manifestURL, "")
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+
+		var authorization string
+		if strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+			token, err := registryToken(ctx, challenge, username, password)
+			if err != nil {
+				return "", err
+			}
+			authorization = "Bearer " + token
+		} else {
+			req, _ := http.NewRequest(http.MethodGet, manifestURL, nil)
+			req.SetBasicAuth(username, password)
+			authorization = req.Header.Get("Authorization")
+		}
+
+		resp, err = registryRequest(ctx, manifestURL, authorization)
+		if err != nil {
+			return "", err
+		}
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "image %s not found on registry %s", image, img.Registry)
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "access to image %s denied by registry %s", image, img.Registry)
+	case resp.StatusCode >= 300:
+		return "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to get image %s from registry %s: HTTP %d", image, img.Registry, resp.StatusCode)
+	}
+
+	if d := resp.Header.Get("Docker-Content-Digest"); d != "" {
+		return d, nil
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", sdk.WithStack(err)
+	}
+	sum := sha256.Sum256(body)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+func registryRequest(ctx context.Context, u, authorization string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", strings.Join(registryManifestTypes, ", "))
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	resp, err := registryHTTPClient.Do(req)
+	if err != nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to reach registry: %v", err)
+	}
+	return resp, nil
+}
+
+// registryToken gets a token from the authorization server given by a Bearer challenge
+// (ie: Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/golang:pull")
+func registryToken(ctx context.Context, challenge, username, password string) (string, error) {
+	params := map[string]string{}
+	for _, p := range strings.Split(challenge[len("bearer "):], ",") {
+		kv := strings.SplitN(strings.TrimSpace(p), "=", 2)
+		if len(kv) == 2 {
+			params[strings.ToLower(kv[0])] = strings.Trim(kv[1], `"`)
+		}
+	}
+	if params["realm"] == "" {
+		return "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid registry authentication challenge %s", challenge)
+	}
+
+	q := url.Values{}
+	if params["service"] != "" {
+		q.Set("service", params["service"])
+	}
+	if params["scope"] != "" {
+		q.Set("scope", params["scope"])
+	}
+	req, err := http.NewRequest(http.MethodGet, params["realm"]+"?"+q.Encode(), nil)
+	if err != nil {
+		return "", sdk.WithStack(err)
+	}
+	req = req.WithContext(ctx)
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+
+	resp, err := registryHTTPClient.Do(req)
+	if err != nil {
+		return "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to reach registry authorization server: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "registry authentication failed: HTTP %d", resp.StatusCode)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", sdk.WrapError(err, "Cannot read registry token")
+	}
+	if token.Token != "" {
+		return token.Token, nil
+	}
+	return token.AccessToken, nil
+}
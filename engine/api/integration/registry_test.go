@@ -0,0 +1,55 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestResolveImageDigest(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			u, p, ok := r.BasicAuth()
+			if !ok || u != "foo" || p != "bar" || r.URL.Query().Get("scope") != "repository:team/model:pull" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"token":"mytoken"}`)) // nolint
+		case "/v2/team/model/manifests/1.0":
+			if r.Header.Get("Authorization") != "Bearer mytoken" {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="`+srv.URL+`/token",service="registry",scope="repository:team/model:pull"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Docker-Content-Digest", "sha256:abcd")
+			w.Write([]byte(`{}`)) // nolint
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	cfg := sdk.IntegrationConfig{
+		"url":      sdk.IntegrationConfigValue{Value: srv.URL},
+		"username": sdk.IntegrationConfigValue{Value: "foo"},
+		"password": sdk.IntegrationConfigValue{Value: "bar"},
+	}
+
+	d, err := ResolveImageDigest(context.Background(), cfg, "registry.local/team/model:1.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "sha256:abcd", d)
+
+	_, err = ResolveImageDigest(context.Background(), cfg, "registry.local/team/model:2.0")
+	assert.Error(t, err)
+
+	cfg["password"] = sdk.IntegrationConfigValue{Value: "wrong"}
+	_, err = ResolveImageDigest(context.Background(), cfg, "registry.local/team/model:1.0")
+	assert.Error(t, err)
+}
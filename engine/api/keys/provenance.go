@@ -0,0 +1,43 @@
+package keys
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"golang.org/x/crypto/openpgp"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// SignProvenance signs a provenance statement with a PGP key, it returns a DSSE envelope
+func SignProvenance(k sdk.Key, s sdk.ProvenanceStatement) (*sdk.ProvenanceEnvelope, error) {
+	if k.Type != sdk.KeyTypePGP {
+		return nil, sdk.WrapError(sdk.ErrUnknownKeyType, "Cannot sign provenance with key %s of type %s", k.Name, k.Type)
+	}
+	entity, err := GetOpenPGPEntity(strings.NewReader(k.Private))
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(s)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+
+	sig := new(bytes.Buffer)
+	if err := openpgp.ArmoredDetachSign(sig, entity, bytes.NewReader(sdk.ProvenancePAE(sdk.InTotoPayloadType, payload)), nil); err != nil {
+		return nil, sdk.WrapError(err, "Cannot sign provenance")
+	}
+
+	return &sdk.ProvenanceEnvelope{
+		PayloadType: sdk.InTotoPayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures: []sdk.ProvenanceSignature{{
+			KeyID:   k.KeyID,
+			KeyName: k.Name,
+			Sig:     sig.String(),
+		}},
+	}, nil
+}
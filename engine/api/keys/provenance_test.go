@@ -0,0 +1,48 @@
+package keys
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestSignProvenance(t *testing.T) {
+	k, err := GeneratePGPKeyPair(sdk.ProvenanceKeyName)
+	test.NoError(t, err)
+
+	art := sdk.WorkflowNodeRunArtifact{Name: "myapp.tar.gz", SHA512sum: "abcd", MD5sum: "ef01"}
+	nr := sdk.WorkflowNodeRun{
+		ID:            1,
+		WorkflowRunID: 2,
+		Number:        3,
+		VCSHash:       "1234567",
+		VCSRepository: "ovh/cds",
+		BuildParameters: []sdk.Parameter{
+			{Name: "cds.project", Value: "PROJ"},
+			{Name: "cds.pipeline", Value: "build"},
+			{Name: "cds.proj.password", Value: "secret"},
+		},
+	}
+	s := sdk.NewProvenanceStatement("https://cds.local", art, nr, sdk.WorkflowNodeJobRun{ID: 4, Model: "go-official"}, "golang:1.11")
+	assert.Equal(t, "build", s.Predicate.Invocation.ConfigSource.Pipeline)
+	assert.Equal(t, []string{"cds.pipeline", "cds.project"}, s.ProvenanceParametersNames())
+
+	env, err := SignProvenance(k, s)
+	test.NoError(t, err)
+
+	verified, err := sdk.VerifyProvenance(*env, k.Public, "abcd")
+	test.NoError(t, err)
+	assert.Equal(t, "go-official", verified.Predicate.Builder.WorkerModel)
+	assert.Equal(t, "1234567", verified.Predicate.Materials[0].Digest["sha1"])
+
+	_, err = sdk.VerifyProvenance(*env, k.Public, "other")
+	assert.Error(t, err)
+
+	other, err := GeneratePGPKeyPair("other")
+	test.NoError(t, err)
+	_, err = sdk.VerifyProvenance(*env, other.Public, "abcd")
+	assert.Error(t, err)
+}
@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// DeploymentsFilter filters the deployments used to compute DORA metrics, empty fields are ignored
+type DeploymentsFilter struct {
+	ProjectKey      string
+	ApplicationName string
+	EnvironmentName string
+}
+
+// LoadDeployments loads the finished runs of the workflow nodes bound to an application and a production environment,
+// done after given date and sorted by done date. The application and the environment are the ones of the node when it ran
+func LoadDeployments(db gorp.SqlExecutor, since time.Time, filter DeploymentsFilter) ([]sdk.Deployment, error) {
+	query := `
+		SELECT project.projectkey, application.name, environment.name, workflow.name, workflow_node_run.num,
+			workflow_node_run.status, workflow_node_run.done, workflow_node_run.commits
+		FROM workflow_node_run
+		JOIN workflow_run ON workflow_run.id = workflow_node_run.workflow_run_id
+		JOIN workflow ON workflow.id = workflow_run.workflow_id
+		JOIN project ON project.id = workflow.project_id
+		JOIN application ON application.id = workflow_node_run.application_id
+		JOIN environment ON environment.id = workflow_node_run.environment_id
+		WHERE workflow_node_run.done > $1 AND workflow_node_run.status IN ($2, $3) AND environment.production = true`
+	args := []interface{}{since, sdk.StatusSuccess.String(), sdk.StatusFail.String()}
+	if filter.ProjectKey != "" {
+		args = append(args, filter.ProjectKey)
+		query += fmt.Sprintf(" AND project.projectkey = $%d", len(args))
+	}
+	if filter.ApplicationName != "" {
+		args = append(args, filter.ApplicationName)
+		query += fmt.Sprintf(" AND application.name = $%d", len(args))
+	}
+	if filter.EnvironmentName != "" {
+		args = append(args, filter.EnvironmentName)
+		query += fmt.Sprintf(" AND environment.name = $%d", len(args))
+	}
+	query += " ORDER BY workflow_node_run.done"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, sdk.WrapError(err, "Unable to load deployments")
+	}
+	defer rows.Close() // nolint
+
+	var ds []sdk.Deployment
+	for rows.Next() {
+		var d sdk.Deployment
+		var commits []byte
+		if err := rows.Scan(&d.ProjectKey, &d.ApplicationName, &d.EnvironmentName, &d.WorkflowName, &d.Number,
+			&d.Status, &d.Done, &commits); err != nil {
+			return nil, sdk.WrapError(err, "Unable to scan deployment")
+		}
+		if len(commits) > 0 {
+			if err := json.Unmarshal(commits, &d.Commits); err != nil {
+				return nil, sdk.WrapError(err, "Unable to unmarshal commits of %s/%s #%d", d.ProjectKey, d.WorkflowName, d.Number)
+			}
+		}
+		ds = append(ds, d)
+	}
+	return ds, sdk.WithStack(rows.Err())
+}
+
+// DORA computes the DORA metrics of the deployments done in the last given days
+func DORA(db gorp.SqlExecutor, days int, filter DeploymentsFilter) ([]sdk.DORAMetrics, error) {
+	to := time.Now()
+	from := to.Add(-time.Duration(days) * 24 * time.Hour)
+	ds, err := LoadDeployments(db, from, filter)
+	if err != nil {
+		return nil, err
+	}
+	return sdk.ComputeDORAMetrics(ds, from, to), nil
+}
@@ -2,6 +2,7 @@ package pipeline
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -114,7 +115,17 @@ func CheckJob(db gorp.SqlExecutor, job *sdk.Job) error {
 		step := &job.Action.Actions[i]
 		log.Debug("CheckJob> Checking step %s", step.Name)
 
-		a, err := action.RetrieveForGroupAndName(db, step.Group, step.Name)
+		var a *sdk.Action
+		var err error
+		if step.Type == sdk.RepositoryAction {
+			// actions defined in repositories are resolved before the import
+			a, err = action.LoadByID(db, step.ID, action.LoadOptions.WithParameters)
+			if err == nil && a == nil {
+				err = sdk.WithStack(sdk.ErrNoAction)
+			}
+		} else {
+			a, err = action.RetrieveForGroupAndName(db, step.Group, step.Name)
+		}
 		if err != nil {
 			if sdk.ErrorIs(err, sdk.ErrNoAction) {
 				errs = append(errs, sdk.NewMessage(sdk.MsgJobNotValidActionNotFound, job.Action.Name, step.Name, i+1))
@@ -133,6 +144,10 @@ func CheckJob(db gorp.SqlExecutor, job *sdk.Job) error {
 				ap := a.Parameters[y]
 				if strings.ToLower(sp.Name) == strings.ToLower(ap.Name) {
 					found = true
+					// inputs of actions defined in repositories are typed
+					if a.Type == sdk.RepositoryAction && !isValidParameterValue(ap.Type, sp.Value) {
+						errs = append(errs, sdk.NewMessage(sdk.MsgJobNotValidActionParameterType, job.Action.Name, sp.Name, i+1, step.Name, ap.Type))
+					}
 					break
 				}
 			}
@@ -149,6 +164,23 @@ func CheckJob(db gorp.SqlExecutor, job *sdk.Job) error {
 	return nil
 }
 
+// isValidParameterValue returns false if the value of a number or boolean parameter can't be parsed, values
+// containing variables are only known at run time.
+func isValidParameterValue(parameterType, value string) bool {
+	if value == "" || strings.Contains(value, "{{") || strings.Contains(value, "$") {
+		return true
+	}
+	switch parameterType {
+	case sdk.NumberParameter:
+		_, err := strconv.ParseFloat(value, 64)
+		return err == nil
+	case sdk.BooleanParameter:
+		_, err := strconv.ParseBool(value)
+		return err == nil
+	}
+	return true
+}
+
 // CountInPipelineData represents the result of CountInVarValue function
 type CountInPipelineData struct {
 	PipName   string
@@ -64,7 +64,7 @@ func ImportUpdate(db gorp.SqlExecutor, proj *sdk.Project, pip *sdk.Pipeline, msg
 					log.Debug("CheckJob > %s", errs)
 					return errs
 				}
-				if err := action.CheckChildrenForGroupIDs(db, &jobAction.Action, groupIDs); err != nil {
+				if err := action.CheckChildrenForProject(db, &jobAction.Action, proj.ID, groupIDs); err != nil {
 					return err
 				}
 				jobAction.PipelineStageID = s.ID
@@ -92,7 +92,7 @@ func ImportUpdate(db gorp.SqlExecutor, proj *sdk.Project, pip *sdk.Pipeline, msg
 					log.Debug(">> CheckJob > %s", errs)
 					return errs
 				}
-				if err := action.CheckChildrenForGroupIDs(db, &jobAction.Action, groupIDs); err != nil {
+				if err := action.CheckChildrenForProject(db, &jobAction.Action, proj.ID, groupIDs); err != nil {
 					return err
 				}
 			}
@@ -264,7 +264,7 @@ func importNew(db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, pip *s
 				log.Warning("pipeline.importNew.CheckJob > %s", errs)
 				return errs
 			}
-			if err := action.CheckChildrenForGroupIDs(db, &jobAction.Action, groupIDs); err != nil {
+			if err := action.CheckChildrenForProject(db, &jobAction.Action, proj.ID, groupIDs); err != nil {
 				return err
 			}
 
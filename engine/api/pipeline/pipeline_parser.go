@@ -1,11 +1,14 @@
 package pipeline
 
 import (
+	"context"
 	"sync"
 
 	"github.com/go-gorp/gorp"
 
+	"github.com/ovh/cds/engine/api/action"
 	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/group"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/exportentities"
 	"github.com/ovh/cds/sdk/log"
@@ -19,7 +22,7 @@ type ImportOptions struct {
 }
 
 // ParseAndImport parse an exportentities.pipeline and insert or update the pipeline in database
-func ParseAndImport(db gorp.SqlExecutor, cache cache.Store, proj *sdk.Project, epip exportentities.Pipeliner, u *sdk.User, opts ImportOptions) (*sdk.Pipeline, []sdk.Message, error) {
+func ParseAndImport(ctx context.Context, db gorp.SqlExecutor, cache cache.Store, proj *sdk.Project, epip exportentities.Pipeliner, u *sdk.User, opts ImportOptions) (*sdk.Pipeline, []sdk.Message, error) {
 	//Transform payload to a sdk.Pipeline
 	pip, errP := epip.Pipeline()
 	if errP != nil {
@@ -32,6 +35,24 @@ func ParseAndImport(db gorp.SqlExecutor, cache cache.Store, proj *sdk.Project, e
 		return nil, nil, sdk.WithStack(sdk.ErrPipelineNameImport)
 	}
 
+	if err := pip.CheckJobOutputs(); err != nil {
+		return pip, nil, err
+	}
+
+	// Resolve the steps that use actions defined in repositories
+	groupIDs := make([]int64, 0, len(proj.ProjectGroups)+1)
+	groupIDs = append(groupIDs, group.SharedInfraGroup.ID)
+	for i := range proj.ProjectGroups {
+		groupIDs = append(groupIDs, proj.ProjectGroups[i].Group.ID)
+	}
+	for i := range pip.Stages {
+		for j := range pip.Stages[i].Jobs {
+			if err := action.ResolveRepositoryChildren(ctx, db, cache, *proj, &pip.Stages[i].Jobs[j].Action, groupIDs); err != nil {
+				return pip, nil, sdk.WrapError(err, "unable to resolve actions of job %s", pip.Stages[i].Jobs[j].Action.Name)
+			}
+		}
+	}
+
 	// Check if pipeline exists
 	exist, errE := ExistPipeline(db, proj.ID, pip.Name)
 	if errE != nil {
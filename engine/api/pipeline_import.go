@@ -68,6 +68,7 @@ func (api *API) importPipelineHandler() service.Handler {
 		proj, errp := project.Load(api.mustDB(), api.Cache, key, deprecatedGetUser(ctx),
 			project.LoadOptions.Default,
 			project.LoadOptions.WithGroups,
+			project.LoadOptions.WithClearKeys,
 		)
 		if errp != nil {
 			return sdk.WrapError(errp, "Unable to load project %s", key)
@@ -90,7 +91,7 @@ func (api *API) importPipelineHandler() service.Handler {
 		}
 		defer tx.Rollback()
 
-		_, allMsg, globalError := pipeline.ParseAndImport(tx, api.Cache, proj, payload, deprecatedGetUser(ctx),
+		_, allMsg, globalError := pipeline.ParseAndImport(ctx, tx, api.Cache, proj, payload, deprecatedGetUser(ctx),
 			pipeline.ImportOptions{Force: forceUpdate})
 		msgListString := translate(r, allMsg)
 		if globalError != nil {
@@ -122,6 +123,7 @@ func (api *API) putImportPipelineHandler() service.Handler {
 		proj, errp := project.Load(api.mustDB(), api.Cache, key, deprecatedGetUser(ctx),
 			project.LoadOptions.Default,
 			project.LoadOptions.WithGroups,
+			project.LoadOptions.WithClearKeys,
 		)
 		if errp != nil {
 			return sdk.WrapError(errp, "Unable to load project %s", key)
@@ -151,7 +153,7 @@ func (api *API) putImportPipelineHandler() service.Handler {
 			_ = tx.Rollback()
 		}()
 
-		_, allMsg, globalError := pipeline.ParseAndImport(tx, api.Cache, proj, payload, deprecatedGetUser(ctx), pipeline.ImportOptions{Force: true, PipelineName: pipelineName})
+		_, allMsg, globalError := pipeline.ParseAndImport(ctx, tx, api.Cache, proj, payload, deprecatedGetUser(ctx), pipeline.ImportOptions{Force: true, PipelineName: pipelineName})
 		msgListString := translate(r, allMsg)
 		if globalError != nil {
 			return sdk.WrapError(sdk.NewError(sdk.ErrInvalidPipeline, globalError), "unable to parse and import pipeline")
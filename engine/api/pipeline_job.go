@@ -63,6 +63,16 @@ func (api *API) addJobToStageHandler() service.Handler {
 			return sdk.WrapError(sdk.ErrNotFound, "addJobToStageHandler>Stage not found")
 		}
 
+		// check that the job only uses outputs of jobs of previous stages
+		for i := range pip.Stages {
+			if pip.Stages[i].ID == stageID {
+				pip.Stages[i].Jobs = append(pip.Stages[i].Jobs, job)
+			}
+		}
+		if err := pip.CheckJobOutputs(); err != nil {
+			return err
+		}
+
 		tx, errb := api.mustDB().Begin()
 		if errb != nil {
 			return errb
@@ -79,7 +89,7 @@ func (api *API) addJobToStageHandler() service.Handler {
 		for i := range project.ProjectGroups {
 			groupIDs = append(groupIDs, project.ProjectGroups[i].Group.ID)
 		}
-		if err := action.CheckChildrenForGroupIDs(tx, &job.Action, groupIDs); err != nil {
+		if err := action.CheckChildrenForProject(tx, &job.Action, project.ID, groupIDs); err != nil {
 			return err
 		}
 
@@ -181,6 +191,18 @@ func (api *API) updateJobHandler() service.Handler {
 			return sdk.WrapError(sdk.ErrNotFound, "job not found in pipeline")
 		}
 
+		// check that the outputs used in the pipeline are still declared by jobs of previous stages
+		for i := range pipelineData.Stages {
+			for j := range pipelineData.Stages[i].Jobs {
+				if pipelineData.Stages[i].Jobs[j].PipelineActionID == jobID {
+					pipelineData.Stages[i].Jobs[j] = job
+				}
+			}
+		}
+		if err := pipelineData.CheckJobOutputs(); err != nil {
+			return err
+		}
+
 		tx, err := api.mustDB().Begin()
 		if err != nil {
 			return sdk.WrapError(err, "cannot start transaction")
@@ -197,7 +219,7 @@ func (api *API) updateJobHandler() service.Handler {
 		for i := range project.ProjectGroups {
 			groupIDs = append(groupIDs, project.ProjectGroups[i].Group.ID)
 		}
-		if err := action.CheckChildrenForGroupIDs(tx, &job.Action, groupIDs); err != nil {
+		if err := action.CheckChildrenForProject(tx, &job.Action, project.ID, groupIDs); err != nil {
 			return err
 		}
 
@@ -280,6 +302,22 @@ func (api *API) deleteJobHandler() service.Handler {
 			return sdk.WrapError(sdk.ErrNotFound, "deleteJobHandler>Job not found")
 		}
 
+		// check that the outputs of the job are not used in the pipeline
+		pipWithoutJob := *pipelineData
+		pipWithoutJob.Stages = make([]sdk.Stage, len(pipelineData.Stages))
+		for i, s := range pipelineData.Stages {
+			pipWithoutJob.Stages[i] = s
+			pipWithoutJob.Stages[i].Jobs = make([]sdk.Job, 0, len(s.Jobs))
+			for _, j := range s.Jobs {
+				if j.PipelineActionID != jobID {
+					pipWithoutJob.Stages[i].Jobs = append(pipWithoutJob.Stages[i].Jobs, j)
+				}
+			}
+		}
+		if err := pipWithoutJob.CheckJobOutputs(); err != nil {
+			return err
+		}
+
 		tx, errb := api.mustDB().Begin()
 		if errb != nil {
 			return sdk.WrapError(errb, "deleteJobHandler> Cannot begin transaction")
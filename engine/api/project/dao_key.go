@@ -7,6 +7,7 @@ import (
 	"github.com/lib/pq"
 
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/api/keys"
 	"github.com/ovh/cds/engine/api/secret"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
@@ -120,3 +121,50 @@ func loadBuildinKey(db gorp.SqlExecutor, projectID int64) (sdk.ProjectKey, error
 
 	return k, nil
 }
+
+// LoadOrCreateProvenanceKey loads the decrypted PGP key used to sign the provenance of the artifacts of a project,
+// the key is generated the first time. It is stored as a builtin key, so it is never given to the jobs nor
+// listed or edited with the project keys
+func LoadOrCreateProvenanceKey(db gorp.SqlExecutor, projectID int64) (*sdk.ProjectKey, error) {
+	k, err := loadProvenanceKey(db, projectID)
+	if err == nil {
+		return k, nil
+	}
+	if !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return nil, err
+	}
+
+	kp, err := keys.GeneratePGPKeyPair(sdk.ProvenanceKeyName)
+	if err != nil {
+		return nil, sdk.WrapError(err, "Cannot generate provenance key")
+	}
+	key := sdk.ProjectKey{Key: kp, ProjectID: projectID, Builtin: true}
+	private := key.Private
+	if err := InsertKey(db, &key); err != nil {
+		if !sdk.ErrorIs(err, sdk.ErrKeyAlreadyExist) {
+			return nil, err
+		}
+		// The key has been created by a concurrent upload
+		return loadProvenanceKey(db, projectID)
+	}
+	key.Private = private
+	return &key, nil
+}
+
+func loadProvenanceKey(db gorp.SqlExecutor, projectID int64) (*sdk.ProjectKey, error) {
+	var res dbProjectKey
+	if err := db.SelectOne(&res, "SELECT * FROM project_key WHERE project_id = $1 AND builtin = true AND name = $2", projectID, sdk.ProvenanceKeyName); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sdk.WithStack(sdk.ErrNotFound)
+		}
+		return nil, sdk.WrapError(err, "Cannot load provenance key")
+	}
+
+	k := sdk.ProjectKey(res)
+	decrypted, err := secret.Decrypt([]byte(k.Private))
+	if err != nil {
+		return nil, sdk.WrapError(err, "Unable to decrypt provenance key")
+	}
+	k.Private = string(decrypted)
+	return &k, nil
+}
@@ -0,0 +1,48 @@
+package project
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadSecurityPolicy loads the security policy of a project, an empty policy is returned if none was set
+func LoadSecurityPolicy(db gorp.SqlExecutor, projectID int64) (sdk.ProjectSecurityPolicy, error) {
+	p := sdk.ProjectSecurityPolicy{ProjectID: projectID, ForbiddenLicenses: []string{}}
+	var licenses []byte
+	query := `SELECT max_severity, forbidden_licenses, fail_node, comment_pull_request
+	FROM project_security_policy WHERE project_id = $1`
+	if err := db.QueryRow(query, projectID).Scan(&p.MaxSeverity, &licenses, &p.FailNode, &p.CommentPullRequest); err != nil {
+		if err == sql.ErrNoRows {
+			return p, nil
+		}
+		return p, sdk.WrapError(err, "Cannot load security policy of project %d", projectID)
+	}
+	if len(licenses) > 0 {
+		if err := json.Unmarshal(licenses, &p.ForbiddenLicenses); err != nil {
+			return p, sdk.WrapError(err, "Cannot unmarshal forbidden licenses")
+		}
+	}
+	return p, nil
+}
+
+// UpsertSecurityPolicy saves the security policy of a project
+func UpsertSecurityPolicy(db gorp.SqlExecutor, p sdk.ProjectSecurityPolicy) error {
+	if p.ForbiddenLicenses == nil {
+		p.ForbiddenLicenses = []string{}
+	}
+	licenses, err := json.Marshal(p.ForbiddenLicenses)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	query := `INSERT INTO project_security_policy (project_id, max_severity, forbidden_licenses, fail_node, comment_pull_request)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (project_id) DO UPDATE SET max_severity = $2, forbidden_licenses = $3, fail_node = $4, comment_pull_request = $5`
+	if _, err := db.Exec(query, p.ProjectID, p.MaxSeverity, licenses, p.FailNode, p.CommentPullRequest); err != nil {
+		return sdk.WrapError(err, "Cannot save security policy of project %d", p.ProjectID)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/bundle"
+	"github.com/ovh/cds/engine/api/permission"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// getProjectBundleHandler exports all the entities of a project in a tar bundle, secrets are
+// encrypted with the passphrase given in header
+func (api *API) getProjectBundleHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		// the bundle contains the project secrets, exporting it requires the same permission as editing the project
+		u := deprecatedGetUser(ctx)
+		if !u.Admin {
+			if err := api.checkProjectPermissions(ctx, key, permission.PermissionReadWriteExecute, nil); err != nil {
+				return sdk.NewErrorFrom(sdk.ErrForbidden, "write permission on project required to export a bundle")
+			}
+		}
+
+		buf := new(bytes.Buffer)
+		if err := bundle.Export(ctx, api.mustDB(), api.Cache, key, r.Header.Get(sdk.ProjectBundlePassphraseHeader), u, buf); err != nil {
+			return sdk.WrapError(err, "unable to export project %s", key)
+		}
+
+		w.Header().Add("Content-Type", "application/tar")
+		w.WriteHeader(http.StatusOK)
+		_, err := io.Copy(w, buf)
+		return sdk.WrapError(err, "unable to copy content buffer in the response writer")
+	}
+}
+
+// postProjectBundleHandler imports a tar bundle in a project, with dryRun the conflicts are
+// reported without importing anything
+func (api *API) postProjectBundleHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		if r.Body == nil {
+			return sdk.WithStack(sdk.ErrWrongRequest)
+		}
+		defer r.Body.Close()
+
+		groupMapping, err := sdk.ProjectBundleGroupMapping(r.URL.Query()["group"])
+		if err != nil {
+			return err
+		}
+
+		opts := bundle.ImportOptions{
+			DryRun:       FormBool(r, "dryRun"),
+			Force:        FormBool(r, "force"),
+			GroupMapping: groupMapping,
+		}
+		report, err := bundle.Import(ctx, api.mustDB(), api.Cache, key, r.Header.Get(sdk.ProjectBundlePassphraseHeader), deprecatedGetUser(ctx), r.Body, opts)
+		if err != nil {
+			return sdk.WrapError(err, "unable to import bundle in project %s", key)
+		}
+
+		return service.WriteJSON(w, report, http.StatusOK)
+	}
+}
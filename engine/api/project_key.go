@@ -84,6 +84,36 @@ func (api *API) getKeysInProjectHandler() service.Handler {
 	}
 }
 
+func (api *API) getProvenanceKeyInProjectHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		p, err := project.Load(api.mustDB(), api.Cache, key, deprecatedGetUser(ctx))
+		if err != nil {
+			return sdk.WrapError(err, "Cannot load project")
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WrapError(err, "Cannot start transaction")
+		}
+		defer tx.Rollback()
+
+		k, err := project.LoadOrCreateProvenanceKey(tx, p.ID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return sdk.WrapError(err, "Cannot commit transaction")
+		}
+
+		// Only the public key is returned, the private key never leaves the API
+		k.Private = ""
+		return service.WriteJSON(w, k, http.StatusOK)
+	}
+}
+
 func (api *API) deleteKeyInProjectHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		vars := mux.Vars(r)
@@ -146,6 +176,10 @@ func (api *API) addKeyInProjectHandler() service.Handler {
 		if !strings.HasPrefix(newKey.Name, "proj-") {
 			newKey.Name = "proj-" + newKey.Name
 		}
+		// the provenance key is internal to CDS
+		if newKey.Name == sdk.ProvenanceKeyName {
+			return sdk.WrapError(sdk.ErrKeyAlreadyExist, "addKeyInProjectHandler> Key name %s is reserved", newKey.Name)
+		}
 
 		switch newKey.Type {
 		case sdk.KeyTypeSSH:
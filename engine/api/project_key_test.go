@@ -212,6 +212,43 @@ func Test_getKeysInProjectHandler(t *testing.T) {
 	assert.Equal(t, len(keys), 1)
 }
 
+func Test_getProvenanceKeyInProjectHandler(t *testing.T) {
+	api, db, router, end := newTestAPI(t)
+	defer end()
+
+	//Create admin user
+	u, pass := assets.InsertAdminUser(api.mustDB())
+
+	//Insert Project
+	pkey := sdk.RandomString(10)
+	proj := assets.InsertTestProject(t, db, api.Cache, pkey, pkey, u)
+
+	vars := map[string]string{
+		"permProjectKey": proj.Key,
+	}
+
+	uri := router.GetRoute("GET", api.getProvenanceKeyInProjectHandler, vars)
+	req, err := http.NewRequest("GET", uri, nil)
+	test.NoError(t, err)
+	assets.AuthentifyRequest(t, req, u, pass)
+
+	// Do the request
+	w := httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, req)
+	assert.Equal(t, 200, w.Code)
+
+	var k sdk.ProjectKey
+	test.NoError(t, json.Unmarshal(w.Body.Bytes(), &k))
+	assert.Equal(t, sdk.ProvenanceKeyName, k.Name)
+	assert.NotEmpty(t, k.Public)
+	assert.Empty(t, k.Private)
+
+	// The key is the one used to sign the provenances
+	provenanceKey, err := project.LoadOrCreateProvenanceKey(db, proj.ID)
+	test.NoError(t, err)
+	assert.Equal(t, provenanceKey.Public, k.Public)
+}
+
 func Test_deleteKeyInProjectHandler(t *testing.T) {
 	api, db, router, end := newTestAPI(t)
 	defer end()
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// getProjectSecurityPolicyHandler returns the security policy checked on the vulnerability reports and SBOMs of the project
+func (api *API) getProjectSecurityPolicyHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		p, err := project.Load(api.mustDB(), api.Cache, key, deprecatedGetUser(ctx))
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", key)
+		}
+
+		policy, err := project.LoadSecurityPolicy(api.mustDB(), p.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, policy, http.StatusOK)
+	}
+}
+
+// putProjectSecurityPolicyHandler updates the security policy of the project
+func (api *API) putProjectSecurityPolicyHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		var policy sdk.ProjectSecurityPolicy
+		if err := service.UnmarshalBody(r, &policy); err != nil {
+			return err
+		}
+		if err := policy.IsValid(); err != nil {
+			return err
+		}
+
+		p, err := project.Load(api.mustDB(), api.Cache, key, deprecatedGetUser(ctx))
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", key)
+		}
+		policy.ProjectID = p.ID
+
+		if err := project.UpsertSecurityPolicy(api.mustDB(), policy); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, policy, http.StatusOK)
+	}
+}
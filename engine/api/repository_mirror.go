@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/application"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/services"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// workflowJobMirrorHandler serves to the worker of a job the mirror of the repository of its application,
+// with the git smart HTTP protocol. The mirror is given by the application of the node of the job, so a job
+// can only fetch the repository of its own project
+func (api *API) workflowJobMirrorHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, err := requestVarInt(r, "permID")
+		if err != nil {
+			return err
+		}
+		if getWorker(ctx).ActionBuildID != id {
+			return sdk.WrapError(sdk.ErrForbidden, "worker %s is not running job %d", getWorker(ctx).Name, id)
+		}
+
+		proj, err := project.LoadProjectByNodeJobRunID(ctx, api.mustDB(), api.Cache, id, deprecatedGetUser(ctx))
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project of job %d", id)
+		}
+		nodeRun, err := workflow.LoadNodeRunByNodeJobID(api.mustDB(), id, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load node run of job %d", id)
+		}
+		wr, err := workflow.LoadRunByID(api.mustDB(), nodeRun.WorkflowRunID, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow run of job %d", id)
+		}
+		n := wr.Workflow.WorkflowData.NodeByID(nodeRun.WorkflowNodeID)
+		if n == nil || n.Context == nil || n.Context.ApplicationID == 0 {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "no application for job %d", id)
+		}
+		app, has := wr.Workflow.Applications[n.Context.ApplicationID]
+		if !has || app.VCSServer == "" || app.RepositoryFullname == "" {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "no repository for job %d", id)
+		}
+
+		srvs, err := services.FindByType(api.mustDB(), services.TypeRepositories)
+		if err != nil {
+			return sdk.WrapError(err, "unable to found repositories service")
+		}
+		if len(srvs) == 0 {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "no repositories service")
+		}
+
+		proxy, err := services.NewReverseProxy(srvs[0], "/mirrors/"+sdk.RepositoryMirrorID(proj.Key, app.VCSServer, app.RepositoryFullname)+"/"+mux.Vars(r)["path"])
+		if err != nil {
+			return err
+		}
+		proxy.ServeHTTP(w, r)
+		return nil
+	}
+}
+
+// refreshRepositoryMirror asks the repositories service to create or refresh the mirror of the repository
+// of the root application of a workflow run
+func (api *API) refreshRepositoryMirror(ctx context.Context, db gorp.SqlExecutor, p sdk.Project, wf *sdk.Workflow, wfRun *sdk.WorkflowRun) error {
+	rootRun := wfRun.RootRun()
+	if rootRun == nil || wf.WorkflowData.Node.Context == nil || wf.WorkflowData.Node.Context.ApplicationID == 0 {
+		return nil
+	}
+	app, has := wf.Applications[wf.WorkflowData.Node.Context.ApplicationID]
+	if !has || app.VCSServer == "" || app.RepositoryFullname == "" {
+		return nil
+	}
+	cloneURL := sdk.RepositoryCloneURL(rootRun.BuildParameters)
+	if cloneURL == "" {
+		return nil
+	}
+
+	vcsStrategy := app.RepositoryStrategy
+	if vcsStrategy.ConnectionType == "ssh" {
+		// The key can be a project key, or an application key
+		if err := project.LoadAllDecryptedKeys(db, &p); err != nil {
+			return sdk.WrapError(err, "cannot load keys of project %s", p.Key)
+		}
+		if err := application.LoadAllDecryptedKeys(db, &app); err != nil {
+			return sdk.WrapError(err, "cannot load keys of application %s", app.Name)
+		}
+		for _, k := range app.Keys {
+			if k.Name == vcsStrategy.SSHKey {
+				vcsStrategy.SSHKeyContent = k.Private
+			}
+		}
+	} else {
+		if err := application.DecryptVCSStrategyPassword(&app); err != nil {
+			return sdk.WrapError(err, "unable to decrypt vcs strategy")
+		}
+		vcsStrategy = app.RepositoryStrategy
+	}
+
+	ope := sdk.Operation{
+		VCSServer:          app.VCSServer,
+		RepoFullName:       app.RepositoryFullname,
+		URL:                cloneURL,
+		RepositoryStrategy: vcsStrategy,
+		Setup: sdk.OperationSetup{
+			Mirror: sdk.OperationMirror{ID: sdk.RepositoryMirrorID(p.Key, app.VCSServer, app.RepositoryFullname)},
+		},
+	}
+	if err := workflow.PostRepositoryOperation(ctx, db, p, &ope, nil); err != nil {
+		return sdk.WrapError(err, "unable to post mirror operation for %s", app.RepositoryFullname)
+	}
+	log.Debug("refreshRepositoryMirror> operation %s posted for %s", ope.UUID, app.RepositoryFullname)
+	return nil
+}
@@ -0,0 +1,29 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// getSchemasHandler returns the kinds of CDS files which have a JSON Schema
+func (api *API) getSchemasHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		return service.WriteJSON(w, exportentities.SchemaKinds(), http.StatusOK)
+	}
+}
+
+// getSchemaHandler returns the JSON Schema of a kind of CDS file, it can be used by editors to validate .cds files
+func (api *API) getSchemaHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		s, err := exportentities.GetSchema(mux.Vars(r)["kind"])
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, s, http.StatusOK)
+	}
+}
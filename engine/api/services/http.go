@@ -10,6 +10,7 @@ import (
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
+	"net/http/httputil"
 	"net/textproto"
 	"net/url"
 	"time"
@@ -182,6 +183,29 @@ func PostMultipart(ctx context.Context, srvs []sdk.Service, path string, filenam
 	return lastCode, lastErr
 }
 
+// NewReverseProxy returns a proxy that forwards the requests to the given path of a service, they are authenticated
+// with the hash of the service
+func NewReverseProxy(srv sdk.Service, path string) (*httputil.ReverseProxy, error) {
+	u, err := url.Parse(srv.HTTPURL)
+	if err != nil {
+		return nil, sdk.WrapError(err, "invalid url %s of service %s", srv.HTTPURL, srv.Name)
+	}
+	basedHash := base64.StdEncoding.EncodeToString([]byte(srv.Hash))
+	return &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			req.URL.Scheme = u.Scheme
+			req.URL.Host = u.Host
+			req.URL.Path = u.Path + path
+			req.Host = u.Host
+			// The response is compressed by the router of the API
+			req.Header.Del("Accept-Encoding")
+			req.Header.Del("Authorization")
+			req.Header.Del(sdk.SessionTokenHeader)
+			req.Header.Set(sdk.AuthHeader, basedHash)
+		},
+	}, nil
+}
+
 // DoRequest performs an http request on a service
 func DoRequest(ctx context.Context, srvs []sdk.Service, method, path string, args []byte, mods ...sdk.RequestModifier) ([]byte, int, error) {
 	var lastErr error
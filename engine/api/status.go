@@ -268,6 +268,7 @@ func (api *API) initMetrics(ctx context.Context) error {
 	tagsServiceAvailability = []tag.Key{tagCDSInstance, tagService}
 
 	api.computeMetrics(ctx)
+	api.computeDORAMetrics(ctx)
 
 	err := observability.RegisterView(
 		observability.NewViewLast("nb_users", api.Metrics.nbUsers, tags),
@@ -0,0 +1,42 @@
+package warning
+
+import (
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// AddWorkerModelImageUpdated adds a warning on the project of the registry integration of a worker model
+// when its pinned image has been updated upstream
+func AddWorkerModelImageUpdated(db gorp.SqlExecutor, key string, m sdk.Model, newDigest string) error {
+	nb, err := db.SelectInt("SELECT COUNT(1) FROM warning WHERE type = $1 AND element = $2 AND project_key = $3",
+		sdk.WarningWorkerModelImageUpdated, m.Name, key)
+	if err != nil {
+		return sdk.WrapError(err, "Unable to count warnings of worker model %s", m.Name)
+	}
+	if nb > 0 {
+		return nil
+	}
+
+	w := sdk.Warning{
+		Key:     key,
+		Element: m.Name,
+		Created: time.Now(),
+		Type:    sdk.WarningWorkerModelImageUpdated,
+		MessageParams: map[string]string{
+			"ModelName":       m.Name,
+			"Image":           m.ModelDocker.Image,
+			"IntegrationName": m.ModelDocker.RegistryIntegration,
+			"Digest":          m.ModelDocker.Digest,
+			"NewDigest":       newDigest,
+		},
+	}
+	return Insert(db, w)
+}
+
+// RemoveWorkerModelImageUpdated removes the upstream update warning of a worker model
+func RemoveWorkerModelImageUpdated(db gorp.SqlExecutor, key string, modelName string) error {
+	return removeProjectWarning(db, sdk.WarningWorkerModelImageUpdated, modelName, key)
+}
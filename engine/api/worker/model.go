@@ -38,6 +38,9 @@ const modelColumns = `
 	worker_model.nb_spawn_err,
 	worker_model.date_last_spawn_err,
 	worker_model.is_deprecated,
+	worker_model.version,
+	worker_model.canary_version,
+	worker_model.canary_percent,
 	"group".name as groupname`
 
 const (
@@ -81,26 +84,34 @@ func InsertWorkerModel(db gorp.SqlExecutor, model *sdk.Model) error {
 	dbmodel := WorkerModel(*model)
 	dbmodel.NeedRegistration = true
 	model.UserLastModified = time.Now()
+	dbmodel.Version = 0
+	dbmodel.CanaryVersion = 0
+	dbmodel.CanaryPercent = 0
 	if err := db.Insert(&dbmodel); err != nil {
 		return err
 	}
 	*model = sdk.Model(dbmodel)
-	return nil
+	return insertWorkerModelVersion(db, model)
 }
 
-// UpdateWorkerModel update a worker model. If worker model have SpawnErr -> clear them
+// UpdateWorkerModel update a worker model. If worker model have SpawnErr -> clear them.
+// A new version of the worker model is created if its definition has changed
 func UpdateWorkerModel(db gorp.SqlExecutor, model *sdk.Model) error {
 	model.UserLastModified = time.Now()
 	model.NeedRegistration = true
 	model.NbSpawnErr = 0
 	model.LastSpawnErr = ""
 	model.LastSpawnErrLogs = nil
+	if err := db.QueryRow("SELECT version, canary_version, canary_percent FROM worker_model WHERE id = $1", model.ID).
+		Scan(&model.Version, &model.CanaryVersion, &model.CanaryPercent); err != nil {
+		return sdk.WrapError(err, "Cannot load version of worker model %d", model.ID)
+	}
 	dbmodel := WorkerModel(*model)
 	if _, err := db.Update(&dbmodel); err != nil {
 		return err
 	}
 	*model = sdk.Model(dbmodel)
-	return nil
+	return insertWorkerModelVersion(db, model)
 }
 
 // UpdateWorkerModelWithoutRegistration update a worker model. If worker model have SpawnErr -> clear them
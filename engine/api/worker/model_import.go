@@ -13,8 +13,16 @@ import (
 	"github.com/ovh/cds/sdk/exportentities"
 )
 
+// ImportOptions are options to import a worker model
+type ImportOptions struct {
+	Force bool
+	// CanaryPercent imports the worker model as a new version sent to the given percentage of the jobs,
+	// the current version of the existing worker model is not changed
+	CanaryPercent int64
+}
+
 // ParseAndImport parse and import an exportentities.WorkerModel
-func ParseAndImport(db gorp.SqlExecutor, store cache.Store, eWorkerModel *exportentities.WorkerModel, force bool, u *sdk.User) (*sdk.Model, error) {
+func ParseAndImport(db gorp.SqlExecutor, store cache.Store, eWorkerModel *exportentities.WorkerModel, opts ImportOptions, u *sdk.User) (*sdk.Model, error) {
 	sdkWm, errInvalidModel := eWorkerModel.GetWorkerModel()
 	gr, err := group.LoadGroupByName(db, sdkWm.Group.Name)
 	if err != nil {
@@ -95,7 +103,29 @@ currentUGroup:
 		sdkWm.Provision = 0
 	}
 
-	if force {
+	if opts.CanaryPercent > 0 {
+		existingWm, err := LoadWorkerModelByName(db, sdkWm.Name)
+		if err != nil {
+			return nil, sdk.WrapError(err, "cannot find worker model %s", sdkWm.Name)
+		}
+		if asSimpleUser && modelPattern == nil && existingWm.Type == sdk.Docker && sdkWm.Type == sdk.Docker {
+			img := sdkWm.ModelDocker.Image
+			sdkWm.ModelDocker = existingWm.ModelDocker
+			sdkWm.ModelDocker.Image = img
+		} else if asSimpleUser && modelPattern == nil {
+			return nil, sdk.ErrWorkerModelNoPattern
+		} else if badRequestError != nil {
+			return nil, badRequestError
+		}
+		sdkWm.CreatedBy = *u
+		if err := InsertWorkerModelCanary(db, existingWm, sdkWm, opts.CanaryPercent); err != nil {
+			return nil, err
+		}
+		store.DeleteAll(cache.Key("api:workermodels:*"))
+		return existingWm, nil
+	}
+
+	if opts.Force {
 		if existingWm, err := LoadWorkerModelByName(db, sdkWm.Name); err != nil {
 			if sdk.Cause(err) == sql.ErrNoRows {
 				if asSimpleUser && modelPattern == nil {
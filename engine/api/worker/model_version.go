@@ -0,0 +1,221 @@
+package worker
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadWorkerModelVersions returns all the versions of a worker model, last version first
+func LoadWorkerModelVersions(db gorp.SqlExecutor, modelID int64) ([]sdk.ModelVersion, error) {
+	var vs []workerModelVersion
+	if _, err := db.Select(&vs, "SELECT * FROM worker_model_version WHERE worker_model_id = $1 ORDER BY version DESC", modelID); err != nil {
+		return nil, sdk.WrapError(err, "Cannot load versions of worker model %d", modelID)
+	}
+	res := make([]sdk.ModelVersion, len(vs))
+	for i := range vs {
+		res[i] = sdk.ModelVersion(vs[i])
+	}
+	return res, nil
+}
+
+// LoadWorkerModelVersion returns a version of a worker model
+func LoadWorkerModelVersion(db gorp.SqlExecutor, modelID, version int64) (*sdk.ModelVersion, error) {
+	var v workerModelVersion
+	if err := db.SelectOne(&v, "SELECT * FROM worker_model_version WHERE worker_model_id = $1 AND version = $2", modelID, version); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "version %d of worker model not found", version)
+		}
+		return nil, sdk.WrapError(err, "Cannot load version %d of worker model %d", version, modelID)
+	}
+	res := sdk.ModelVersion(v)
+	return &res, nil
+}
+
+// LoadWorkerModelsCanaries sets the canary version on the worker models with a canary rollout in progress
+func LoadWorkerModelsCanaries(db gorp.SqlExecutor, models []sdk.Model) error {
+	for i := range models {
+		if models[i].CanaryPercent <= 0 || models[i].CanaryVersion <= 0 {
+			continue
+		}
+		v, err := LoadWorkerModelVersion(db, models[i].ID, models[i].CanaryVersion)
+		if err != nil {
+			return err
+		}
+		models[i].Canary = v
+	}
+	return nil
+}
+
+// insertWorkerModelVersion saves the definition of a worker model as a new version if it is different from its current version
+func insertWorkerModelVersion(db gorp.SqlExecutor, model *sdk.Model) error {
+	if model.Version > 0 {
+		current, err := LoadWorkerModelVersion(db, model.ID, model.Version)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+		if current != nil && current.SameDefinition(sdk.NewModelVersion(*model)) {
+			return nil
+		}
+	}
+
+	v, err := newWorkerModelVersion(db, *model)
+	if err != nil {
+		return err
+	}
+	if _, err := db.Exec("UPDATE worker_model SET version = $2 WHERE id = $1", model.ID, v.Version); err != nil {
+		return sdk.WrapError(err, "Cannot update version of worker model %d", model.ID)
+	}
+	model.Version = v.Version
+	return nil
+}
+
+func newWorkerModelVersion(db gorp.SqlExecutor, model sdk.Model) (*sdk.ModelVersion, error) {
+	last, err := db.SelectInt("SELECT COALESCE(MAX(version), 0) FROM worker_model_version WHERE worker_model_id = $1", model.ID)
+	if err != nil {
+		return nil, sdk.WrapError(err, "Cannot load last version of worker model %d", model.ID)
+	}
+
+	v := sdk.NewModelVersion(model)
+	v.Version = last + 1
+	v.Created = time.Now()
+	v.CreatedBy = model.CreatedBy.Username
+	dbv := workerModelVersion(v)
+	if err := db.Insert(&dbv); err != nil {
+		return nil, sdk.WrapError(err, "Cannot insert version of worker model %d", model.ID)
+	}
+	res := sdk.ModelVersion(dbv)
+	return &res, nil
+}
+
+// InsertWorkerModelCanary saves the given definition as a new version of a worker model, without changing its current version.
+// The given percentage of the jobs will be sent to this version
+func InsertWorkerModelCanary(db gorp.SqlExecutor, model *sdk.Model, definition sdk.Model, percent int64) error {
+	current, err := LoadWorkerModelVersion(db, model.ID, model.Version)
+	if err != nil {
+		return err
+	}
+	definition.ID = model.ID
+	if current.SameDefinition(sdk.NewModelVersion(definition)) {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "definition of worker model %s is the same as its current version %d", model.Name, model.Version)
+	}
+	if definition.Type != model.Type {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "type of worker model %s can't be changed with a canary", model.Name)
+	}
+
+	v, err := newWorkerModelVersion(db, definition)
+	if err != nil {
+		return err
+	}
+	return UpdateWorkerModelCanary(db, model, sdk.ModelCanary{Version: v.Version, Percent: percent})
+}
+
+// UpdateWorkerModelCanary starts or updates the canary rollout of a version of a worker model
+func UpdateWorkerModelCanary(db gorp.SqlExecutor, model *sdk.Model, canary sdk.ModelCanary) error {
+	if err := canary.IsValid(); err != nil {
+		return err
+	}
+	if canary.Version == model.Version {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "version %d is the current version of worker model %s", canary.Version, model.Name)
+	}
+	v, err := LoadWorkerModelVersion(db, model.ID, canary.Version)
+	if err != nil {
+		return err
+	}
+	if _, err := db.Exec("UPDATE worker_model SET canary_version = $2, canary_percent = $3 WHERE id = $1", model.ID, canary.Version, canary.Percent); err != nil {
+		return sdk.WrapError(err, "Cannot update canary of worker model %d", model.ID)
+	}
+	model.CanaryVersion = canary.Version
+	model.CanaryPercent = canary.Percent
+	model.Canary = v
+	return nil
+}
+
+// DeleteWorkerModelCanary stops the canary rollout of a worker model, all the jobs are sent to its current version
+func DeleteWorkerModelCanary(db gorp.SqlExecutor, model *sdk.Model) error {
+	if _, err := db.Exec("UPDATE worker_model SET canary_version = 0, canary_percent = 0 WHERE id = $1", model.ID); err != nil {
+		return sdk.WrapError(err, "Cannot delete canary of worker model %d", model.ID)
+	}
+	model.CanaryVersion = 0
+	model.CanaryPercent = 0
+	model.Canary = nil
+	return nil
+}
+
+// RollbackWorkerModel restores a version as the current version of a worker model and stops its canary rollout.
+// It is also used to promote the canary version of a worker model
+func RollbackWorkerModel(db gorp.SqlExecutor, model *sdk.Model, version int64) error {
+	v, err := LoadWorkerModelVersion(db, model.ID, version)
+	if err != nil {
+		return err
+	}
+	if v.Type != model.Type {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "version %d of worker model %s has type %s", version, model.Name, v.Type)
+	}
+
+	m := model.WithVersion(*v)
+	m.UserLastModified = time.Now()
+	m.NeedRegistration = true
+	m.NbSpawnErr = 0
+	m.LastSpawnErr = ""
+	m.LastSpawnErrLogs = nil
+	m.CanaryVersion = 0
+	m.CanaryPercent = 0
+	dbmodel := WorkerModel(m)
+	if _, err := db.Update(&dbmodel); err != nil {
+		return sdk.WrapError(err, "Cannot rollback worker model %d to version %d", model.ID, version)
+	}
+	*model = sdk.Model(dbmodel)
+	return nil
+}
+
+// workerModelVersion is a gorp wrapper around sdk.ModelVersion
+type workerModelVersion sdk.ModelVersion
+
+//PostInsert is a DB Hook on workerModelVersion
+func (v *workerModelVersion) PostInsert(s gorp.SqlExecutor) error {
+	def, err := v.definition()
+	if err != nil {
+		return err
+	}
+	if _, err := s.Exec("UPDATE worker_model_version SET model = $2 WHERE id = $1", v.ID, def); err != nil {
+		return sdk.WrapError(err, "Cannot update model of worker model version")
+	}
+	return nil
+}
+
+//PostGet load the definition of the version
+func (v *workerModelVersion) PostGet(s gorp.SqlExecutor) error {
+	model, err := s.SelectNullStr("SELECT model FROM worker_model_version WHERE id = $1", v.ID)
+	if err != nil {
+		return sdk.WrapError(err, "Cannot load model of worker model version %d", v.ID)
+	}
+	switch v.Type {
+	case sdk.Docker:
+		if err := gorpmapping.JSONNullString(model, &v.ModelDocker); err != nil {
+			return sdk.WrapError(err, "cannot unmarshall for docker model")
+		}
+		v.Image = v.ModelDocker.Image
+	default:
+		if err := gorpmapping.JSONNullString(model, &v.ModelVirtualMachine); err != nil {
+			return sdk.WrapError(err, "cannot unmarshall for vm model")
+		}
+		v.Image = v.ModelVirtualMachine.Image
+	}
+	return nil
+}
+
+func (v *workerModelVersion) definition() (sql.NullString, error) {
+	if v.Type == sdk.Docker {
+		return gorpmapping.JSONToNullString(v.ModelDocker)
+	}
+	return gorpmapping.JSONToNullString(v.ModelVirtualMachine)
+}
+
+func init() {
+	gorpmapping.Register(gorpmapping.New(workerModelVersion{}, "worker_model_version", true, "id"))
+}
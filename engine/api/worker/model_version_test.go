@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/engine/api/bootstrap"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestWorkerModelVersions(t *testing.T) {
+	db, _, end := test.SetupPG(t, bootstrap.InitiliazeDB)
+	defer end()
+	deleteAllWorkerModel(t, db)
+
+	g := insertGroup(t, db)
+	defer insertGroup(t, db)
+
+	m := insertWorkerModel(t, db, sdk.RandomString(10), g.ID)
+	assert.Equal(t, int64(1), m.Version)
+
+	// Updating the model without changing its definition doesn't create a new version
+	m.Description = "my model"
+	test.NoError(t, UpdateWorkerModel(db, m))
+	assert.Equal(t, int64(1), m.Version)
+
+	m.ModelDocker.Image = "foo/bar:3.5"
+	test.NoError(t, UpdateWorkerModel(db, m))
+	assert.Equal(t, int64(2), m.Version)
+
+	vs, err := LoadWorkerModelVersions(db, m.ID)
+	test.NoError(t, err)
+	assert.Len(t, vs, 2)
+	assert.Equal(t, "foo/bar:3.5", vs[0].ModelDocker.Image)
+	assert.Equal(t, "foo/bar:3.4", vs[1].ModelDocker.Image)
+
+	// Canary version
+	canary := *m
+	canary.ModelDocker.Image = "foo/bar:4.0"
+	test.NoError(t, InsertWorkerModelCanary(db, m, canary, 10))
+	assert.Equal(t, int64(3), m.CanaryVersion)
+	assert.Equal(t, int64(10), m.CanaryPercent)
+
+	m1, err := LoadWorkerModelByID(db, m.ID)
+	test.NoError(t, err)
+	assert.Equal(t, int64(2), m1.Version)
+	assert.Equal(t, "foo/bar:3.5", m1.ModelDocker.Image)
+	models := []sdk.Model{*m1}
+	test.NoError(t, LoadWorkerModelsCanaries(db, models))
+	if assert.NotNil(t, models[0].Canary) {
+		assert.Equal(t, "foo/bar:4.0", models[0].Canary.ModelDocker.Image)
+	}
+
+	// Rollback to the first version stops the canary
+	test.NoError(t, RollbackWorkerModel(db, m1, 1))
+	m2, err := LoadWorkerModelByID(db, m.ID)
+	test.NoError(t, err)
+	assert.Equal(t, int64(1), m2.Version)
+	assert.Equal(t, int64(0), m2.CanaryPercent)
+	assert.Equal(t, "foo/bar:3.4", m2.ModelDocker.Image)
+
+	_, err = LoadWorkerModelVersion(db, m.ID, 12)
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+}
@@ -33,8 +33,8 @@ func DeleteWorker(db gorp.SqlExecutor, id string) error {
 
 // InsertWorker inserts worker representation into database
 func InsertWorker(db gorp.SqlExecutor, w *sdk.Worker, groupID int64) error {
-	query := `INSERT INTO worker (id, name, last_beat, model, status, hatchery_name, group_id) VALUES ($1, $2, $3, $4, $5, $6, $7)`
-	_, err := db.Exec(query, w.ID, w.Name, time.Now(), w.ModelID, w.Status.String(), w.HatcheryName, groupID)
+	query := `INSERT INTO worker (id, name, last_beat, model, status, hatchery_name, group_id, model_version) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := db.Exec(query, w.ID, w.Name, time.Now(), w.ModelID, w.Status.String(), w.HatcheryName, groupID, w.ModelVersion)
 	return err
 }
 
@@ -44,9 +44,9 @@ func LoadWorker(db gorp.SqlExecutor, id string) (*sdk.Worker, error) {
 	var statusS string
 	var pbJobID sql.NullInt64
 	var jobType sql.NullString
-	query := `SELECT id, action_build_id, job_type, name, last_beat, group_id, model, status, hatchery_name, group_id FROM worker WHERE worker.id = $1 FOR UPDATE`
+	query := `SELECT id, action_build_id, job_type, name, last_beat, group_id, model, status, hatchery_name, group_id, model_version FROM worker WHERE worker.id = $1 FOR UPDATE`
 
-	if err := db.QueryRow(query, id).Scan(&w.ID, &pbJobID, &jobType, &w.Name, &w.LastBeat, &w.GroupID, &w.ModelID, &statusS, &w.HatcheryName, &w.GroupID); err != nil {
+	if err := db.QueryRow(query, id).Scan(&w.ID, &pbJobID, &jobType, &w.Name, &w.LastBeat, &w.GroupID, &w.ModelID, &statusS, &w.HatcheryName, &w.GroupID, &w.ModelVersion); err != nil {
 		return nil, err
 	}
 	w.Status = sdk.StatusFromString(statusS)
@@ -195,7 +195,7 @@ type TakeForm struct {
 }
 
 // RegisterWorker  Register new worker
-func RegisterWorker(db *gorp.DbMap, store cache.Store, name string, key string, modelID int64, hatchery *sdk.Service, binaryCapabilities []string, OS, arch string) (*sdk.Worker, error) {
+func RegisterWorker(db *gorp.DbMap, store cache.Store, name string, key string, modelID, modelVersion int64, hatchery *sdk.Service, binaryCapabilities []string, OS, arch string) (*sdk.Worker, error) {
 	if name == "" {
 		return nil, fmt.Errorf("cannot register worker with empty name")
 	}
@@ -235,6 +235,17 @@ func RegisterWorker(db *gorp.DbMap, store cache.Store, name string, key string,
 			log.Warning("RegisterWorker> worker %s (%d) cannot be spawned as %s (%d)", name, t.GroupID, m.Name, m.GroupID)
 			return nil, sdk.ErrForbidden
 		}
+
+		// the worker can be spawned with a pinned or canary version of the model
+		if modelVersion > 0 && modelVersion != m.Version {
+			v, err := LoadWorkerModelVersion(db, m.ID, modelVersion)
+			if err != nil {
+				log.Warning("RegisterWorker> Cannot load version %d of model %s: %s", modelVersion, m.Name, err)
+				return nil, err
+			}
+			*m = m.WithVersion(*v)
+		}
+		modelVersion = m.Version
 	}
 
 	//generate an ID
@@ -246,12 +257,13 @@ func RegisterWorker(db *gorp.DbMap, store cache.Store, name string, key string,
 
 	//Instanciate a new worker
 	w := &sdk.Worker{
-		ID:      id,
-		Name:    name,
-		ModelID: modelID,
-		Model:   m,
-		Status:  sdk.StatusWaiting,
-		GroupID: t.GroupID,
+		ID:           id,
+		Name:         name,
+		ModelID:      modelID,
+		ModelVersion: modelVersion,
+		Model:        m,
+		Status:       sdk.StatusWaiting,
+		GroupID:      t.GroupID,
 	}
 
 	if hatchery != nil {
@@ -120,6 +120,10 @@ func (api *API) addWorkerModelHandler() service.Handler {
 			Origin:   currentUser.Origin,
 		}
 
+		if err := api.pinWorkerModelImage(ctx, currentUser, &model); err != nil {
+			return err
+		}
+
 		// Insert model in db
 		if err := worker.InsertWorkerModel(api.mustDB(), &model); err != nil {
 			return sdk.WrapError(err, "cannot add worker model")
@@ -337,6 +341,10 @@ func (api *API) updateWorkerModelHandler() service.Handler {
 			return sdk.WrapError(sdk.ErrInvalidID, "wrong ID")
 		}
 
+		if err := api.pinWorkerModelImage(ctx, user, &model); err != nil {
+			return err
+		}
+
 		tx, errtx := api.mustDB().Begin()
 		if errtx != nil {
 			return sdk.WrapError(errtx, "unable to start transaction")
@@ -436,7 +444,10 @@ func (api *API) getWorkerModelsEnabledHandler() service.Handler {
 		if errgroup != nil {
 			return sdk.WrapError(errgroup, "getWorkerModelsEnabled> cannot load worker models for hatchery %d with group %d", h.ID, *h.GroupID)
 		}
-		return service.WriteJSON(w, models, http.StatusOK)
+		if err := worker.LoadWorkerModelsCanaries(api.mustDB(), models); err != nil {
+			return err
+		}
+		return service.WriteJSON(w, api.setWorkerModelsRegistryCredentials(models), http.StatusOK)
 	}
 }
 
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"net/http"
+	"strconv"
 
 	"github.com/ovh/cds/engine/api/worker"
 	"github.com/ovh/cds/engine/service"
@@ -28,6 +29,18 @@ func (api *API) getWorkerModelExportHandler() service.Handler {
 			return sdk.WrapError(err, "cannot load worker model id %d", workerModelID)
 		}
 
+		if version := FormString(r, "version"); version != "" {
+			v, err := strconv.ParseInt(version, 10, 64)
+			if err != nil {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid version %s", version)
+			}
+			mv, err := worker.LoadWorkerModelVersion(api.mustDB(), wm.ID, v)
+			if err != nil {
+				return err
+			}
+			*wm = wm.WithVersion(*mv)
+		}
+
 		// Export
 		f, err := exportentities.GetFormat(format)
 		if err != nil {
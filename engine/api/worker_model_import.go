@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 
 	yaml "gopkg.in/yaml.v2"
 
@@ -18,9 +19,17 @@ import (
 // @title import a worker model yml/json file
 // @description import a worker model yml/json file with `cdsctl worker model import mywm.yml`
 // @params force=true or false. If false and if the worker model already exists, raise an error
+// @params canary=percent. Import the worker model as a new version sent to this percentage of the jobs
 func (api *API) postWorkerModelImportHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
-		force := FormBool(r, "force")
+		opts := worker.ImportOptions{Force: FormBool(r, "force")}
+		if canary := FormString(r, "canary"); canary != "" {
+			percent, err := strconv.ParseInt(canary, 10, 64)
+			if err != nil {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid canary percentage %s", canary)
+			}
+			opts.CanaryPercent = percent
+		}
 
 		body, errr := ioutil.ReadAll(r.Body)
 		if errr != nil {
@@ -53,7 +62,7 @@ func (api *API) postWorkerModelImportHandler() service.Handler {
 		}
 		defer tx.Rollback() //nolint
 
-		wm, err := worker.ParseAndImport(tx, api.Cache, &eWorkerModel, force, deprecatedGetUser(ctx))
+		wm, err := worker.ParseAndImport(tx, api.Cache, &eWorkerModel, opts, deprecatedGetUser(ctx))
 		if err != nil {
 			return sdk.WrapError(err, "cannot parse and import worker model")
 		}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/integration"
+	"github.com/ovh/cds/engine/api/permission"
+	"github.com/ovh/cds/engine/api/warning"
+	"github.com/ovh/cds/engine/api/worker"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// workerModelRegistryCheckInterval is the interval between two checks of upstream updates of pinned worker model images
+const workerModelRegistryCheckInterval = time.Hour
+
+// loadRegistryIntegration loads the docker registry integration of a worker model with its decrypted config
+func loadRegistryIntegration(db gorp.SqlExecutor, m sdk.ModelDocker) (*sdk.ProjectIntegration, error) {
+	pi, err := integration.LoadProjectIntegrationByName(db, m.RegistryProjectKey, m.RegistryIntegration, true)
+	if err != nil {
+		return nil, sdk.WrapError(err, "Cannot load registry integration %s/%s", m.RegistryProjectKey, m.RegistryIntegration)
+	}
+	if pi.Model.Name != sdk.DockerRegistryIntegrationModel {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "integration %s is not a %s integration", m.RegistryIntegration, sdk.DockerRegistryIntegrationModel)
+	}
+	return &pi, nil
+}
+
+// pinWorkerModelImage resolves the image of a docker worker model to its digest with the registry integration of the model.
+// Models without registry integration are not pinned
+func (api *API) pinWorkerModelImage(ctx context.Context, u *sdk.User, model *sdk.Model) error {
+	model.ModelDocker.Registry = ""
+	model.ModelDocker.Username = ""
+	model.ModelDocker.Password = ""
+	model.ModelDocker.Digest = ""
+	if model.Type != sdk.Docker || model.ModelDocker.RegistryIntegration == "" {
+		model.ModelDocker.RegistryProjectKey = ""
+		model.ModelDocker.RegistryIntegration = ""
+		return nil
+	}
+
+	if !permission.AccessToProject(model.ModelDocker.RegistryProjectKey, u, permission.PermissionReadWriteExecute) {
+		return sdk.WrapError(sdk.ErrForbidden, "Cannot use registry integration of project %s", model.ModelDocker.RegistryProjectKey)
+	}
+
+	pi, err := loadRegistryIntegration(api.mustDB(), model.ModelDocker)
+	if err != nil {
+		return err
+	}
+
+	digest, err := integration.ResolveImageDigest(ctx, pi.Config, model.ModelDocker.Image)
+	if err != nil {
+		return err
+	}
+	model.ModelDocker.Digest = digest
+
+	if err := warning.RemoveWorkerModelImageUpdated(api.mustDB(), model.ModelDocker.RegistryProjectKey, model.Name); err != nil {
+		log.Error("pinWorkerModelImage> %v", err)
+	}
+	return nil
+}
+
+// setWorkerModelsRegistryCredentials sets the credentials of the registry integrations on the docker worker models, for hatcheries
+func (api *API) setWorkerModelsRegistryCredentials(models []sdk.Model) []sdk.Model {
+	res := make([]sdk.Model, len(models))
+	for i := range models {
+		res[i] = models[i]
+		m := &res[i].ModelDocker
+		if res[i].Type != sdk.Docker || m.RegistryIntegration == "" {
+			continue
+		}
+		pi, err := loadRegistryIntegration(api.mustDB(), *m)
+		if err != nil {
+			log.Error("setWorkerModelsRegistryCredentials> unable to load registry of model %s: %v", res[i].Name, err)
+			continue
+		}
+		m.Registry = integration.RegistryURL(pi.Config, sdk.ParseDockerImage(m.Image))
+		m.Username = pi.Config["username"].Value
+		m.Password = pi.Config["password"].Value
+	}
+	return res
+}
+
+// checkWorkerModelsRegistryUpdates checks periodically if the pinned images of the worker models have been updated upstream,
+// a warning is added on the project of the registry integration
+func (api *API) checkWorkerModelsRegistryUpdates(ctx context.Context) {
+	tick := time.NewTicker(workerModelRegistryCheckInterval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error("checkWorkerModelsRegistryUpdates> exiting: %v", ctx.Err())
+			}
+			return
+		case <-tick.C:
+			models, err := worker.LoadWorkerModels(api.mustDB())
+			if err != nil {
+				log.Error("checkWorkerModelsRegistryUpdates> unable to load worker models: %v", err)
+				continue
+			}
+			for _, m := range models {
+				if m.Type != sdk.Docker || m.ModelDocker.RegistryIntegration == "" || m.ModelDocker.Digest == "" {
+					continue
+				}
+				if err := api.checkWorkerModelRegistryUpdate(ctx, m); err != nil {
+					log.Warning("checkWorkerModelsRegistryUpdates> unable to check image of worker model %s: %v", m.Name, err)
+				}
+			}
+		}
+	}
+}
+
+func (api *API) checkWorkerModelRegistryUpdate(ctx context.Context, m sdk.Model) error {
+	pi, err := loadRegistryIntegration(api.mustDB(), m.ModelDocker)
+	if err != nil {
+		return err
+	}
+	digest, err := integration.ResolveImageDigest(ctx, pi.Config, m.ModelDocker.Image)
+	if err != nil {
+		return err
+	}
+	if digest == m.ModelDocker.Digest {
+		return warning.RemoveWorkerModelImageUpdated(api.mustDB(), m.ModelDocker.RegistryProjectKey, m.Name)
+	}
+	return warning.AddWorkerModelImageUpdated(api.mustDB(), m.ModelDocker.RegistryProjectKey, m, digest)
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/engine/api/permission"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_pinWorkerModelImageForbidden(t *testing.T) {
+	api := &API{}
+
+	// a user with only read permission on the project of the registry must not use its credentials
+	u := &sdk.User{
+		Permissions: sdk.UserPermissions{
+			ProjectsPerm: map[string]int{"PROJ": permission.PermissionRead},
+		},
+	}
+	model := &sdk.Model{
+		Name: "mymodel",
+		Type: sdk.Docker,
+		ModelDocker: sdk.ModelDocker{
+			Image:               "myimage:latest",
+			RegistryProjectKey:  "PROJ",
+			RegistryIntegration: "myregistry",
+		},
+	}
+	err := api.pinWorkerModelImage(context.TODO(), u, model)
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrForbidden))
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/worker"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) getWorkerModelVersionsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		workerModelID, err := requestVarInt(r, "permModelID")
+		if err != nil {
+			return err
+		}
+
+		m, err := worker.LoadWorkerModelByID(api.mustDB(), workerModelID)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load worker model %d", workerModelID)
+		}
+
+		vs, err := worker.LoadWorkerModelVersions(api.mustDB(), m.ID)
+		if err != nil {
+			return err
+		}
+		for i := range vs {
+			vs[i].Current = vs[i].Version == m.Version
+			vs[i].Canary = m.CanaryPercent > 0 && vs[i].Version == m.CanaryVersion
+		}
+
+		return service.WriteJSON(w, vs, http.StatusOK)
+	}
+}
+
+func (api *API) getWorkerModelVersionHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		workerModelID, err := requestVarInt(r, "permModelID")
+		if err != nil {
+			return err
+		}
+		version, err := strconv.ParseInt(mux.Vars(r)["version"], 10, 64)
+		if err != nil {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid version %s", mux.Vars(r)["version"])
+		}
+
+		v, err := worker.LoadWorkerModelVersion(api.mustDB(), workerModelID, version)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, v, http.StatusOK)
+	}
+}
+
+func (api *API) postWorkerModelRollbackHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		workerModelID, err := requestVarInt(r, "permModelID")
+		if err != nil {
+			return err
+		}
+
+		var rollback sdk.ModelRollback
+		if err := service.UnmarshalBody(r, &rollback); err != nil {
+			return sdk.WrapError(err, "Cannot unmarshal body")
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WrapError(err, "unable to start transaction")
+		}
+		defer tx.Rollback() // nolint
+
+		m, err := worker.LoadWorkerModelByID(tx, workerModelID)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load worker model %d", workerModelID)
+		}
+
+		if err := worker.RollbackWorkerModel(tx, m, rollback.Version); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WrapError(err, "unable to commit transaction")
+		}
+
+		api.Cache.DeleteAll(cache.Key("api:workermodels:*"))
+
+		return service.WriteJSON(w, m, http.StatusOK)
+	}
+}
+
+func (api *API) putWorkerModelCanaryHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		workerModelID, err := requestVarInt(r, "permModelID")
+		if err != nil {
+			return err
+		}
+
+		var canary sdk.ModelCanary
+		if err := service.UnmarshalBody(r, &canary); err != nil {
+			return sdk.WrapError(err, "Cannot unmarshal body")
+		}
+
+		m, err := worker.LoadWorkerModelByID(api.mustDB(), workerModelID)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load worker model %d", workerModelID)
+		}
+
+		if err := worker.UpdateWorkerModelCanary(api.mustDB(), m, canary); err != nil {
+			return err
+		}
+
+		api.Cache.DeleteAll(cache.Key("api:workermodels:*"))
+
+		return service.WriteJSON(w, m, http.StatusOK)
+	}
+}
+
+func (api *API) deleteWorkerModelCanaryHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		workerModelID, err := requestVarInt(r, "permModelID")
+		if err != nil {
+			return err
+		}
+
+		m, err := worker.LoadWorkerModelByID(api.mustDB(), workerModelID)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load worker model %d", workerModelID)
+		}
+
+		if err := worker.DeleteWorkerModelCanary(api.mustDB(), m); err != nil {
+			return err
+		}
+
+		api.Cache.DeleteAll(cache.Key("api:workermodels:*"))
+
+		return service.WriteJSON(w, m, http.StatusOK)
+	}
+}
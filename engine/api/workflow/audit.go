@@ -25,6 +25,7 @@ var (
 		fmt.Sprintf("%T", sdk.EventWorkflowPermissionAdd{}):    addWorkflowPermissionAudit{},
 		fmt.Sprintf("%T", sdk.EventWorkflowPermissionUpdate{}): updateWorkflowPermissionAudit{},
 		fmt.Sprintf("%T", sdk.EventWorkflowPermissionDelete{}): deleteWorkflowPermissionAudit{},
+		fmt.Sprintf("%T", sdk.EventWorkflowNodeRunApproval{}):  workflowNodeRunApprovalAudit{},
 	}
 )
 
@@ -229,6 +230,32 @@ func (a deleteWorkflowPermissionAudit) Compute(db gorp.SqlExecutor, e sdk.Event)
 	})
 }
 
+type workflowNodeRunApprovalAudit struct{}
+
+func (a workflowNodeRunApprovalAudit) Compute(db gorp.SqlExecutor, e sdk.Event) error {
+	var wEvent sdk.EventWorkflowNodeRunApproval
+	if err := mapstructure.Decode(e.Payload, &wEvent); err != nil {
+		return sdk.WrapError(err, "Unable to decode payload")
+	}
+
+	b, err := json.MarshalIndent(wEvent, "", " ")
+	if err != nil {
+		return sdk.WrapError(err, "Unable to marshal approval")
+	}
+
+	return InsertAudit(db, &sdk.AuditWorkflow{
+		AuditCommon: sdk.AuditCommon{
+			EventType:   strings.Replace(e.EventType, "sdk.Event", "", -1),
+			Created:     e.Timestamp,
+			TriggeredBy: e.Username,
+		},
+		ProjectKey: e.ProjectKey,
+		WorkflowID: wEvent.WorkflowID,
+		DataType:   "json",
+		DataAfter:  string(b),
+	})
+}
+
 const keepAudits = 50
 
 func purgeAudits(db gorp.SqlExecutor) error {
@@ -142,10 +142,11 @@ func (w *Workflow) PostGet(db gorp.SqlExecutor) error {
 	var res = struct {
 		Metadata     sql.NullString `db:"metadata"`
 		PurgeTags    sql.NullString `db:"purge_tags"`
+		Concurrency  sql.NullString `db:"concurrency"`
 		WorkflowData sql.NullString `db:"workflow_data"`
 	}{}
 
-	if err := db.SelectOne(&res, "SELECT metadata, purge_tags, workflow_data FROM workflow WHERE id = $1", w.ID); err != nil {
+	if err := db.SelectOne(&res, "SELECT metadata, purge_tags, concurrency, workflow_data FROM workflow WHERE id = $1", w.ID); err != nil {
 		return sdk.WrapError(err, "PostGet> Unable to load marshalled workflow")
 	}
 
@@ -161,6 +162,16 @@ func (w *Workflow) PostGet(db gorp.SqlExecutor) error {
 	}
 	w.PurgeTags = purgeTags
 
+	if res.Concurrency.Valid {
+		concurrency := &sdk.WorkflowConcurrency{}
+		if err := gorpmapping.JSONNullString(res.Concurrency, concurrency); err != nil {
+			return err
+		}
+		if concurrency.Key != "" {
+			w.Concurrency = concurrency
+		}
+	}
+
 	data := &sdk.WorkflowData{}
 	if err := gorpmapping.JSONNullString(res.WorkflowData, data); err != nil {
 		return sdk.WrapError(err, "Unable to unmarshall workflow data")
@@ -206,11 +217,16 @@ func (w *Workflow) PostUpdate(db gorp.SqlExecutor) error {
 		return errPt
 	}
 
+	concurrency, errC := gorpmapping.JSONToNullString(w.Concurrency)
+	if errC != nil {
+		return sdk.WrapError(errC, "Workflow.PostUpdate> Unable to marshall workflow concurrency")
+	}
+
 	data, errD := gorpmapping.JSONToNullString(w.WorkflowData)
 	if errD != nil {
 		return sdk.WrapError(errD, "Workflow.PostUpdate> Unable to marshall workflow data")
 	}
-	if _, err := db.Exec("update workflow set purge_tags = $1, workflow_data = $3 where id = $2", pt, w.ID, data); err != nil {
+	if _, err := db.Exec("update workflow set purge_tags = $1, workflow_data = $3, concurrency = $4 where id = $2", pt, w.ID, data, concurrency); err != nil {
 		return err
 	}
 
@@ -1096,6 +1112,13 @@ func IsValid(ctx context.Context, store cache.Store, db gorp.SqlExecutor, w *sdk
 		return sdk.NewError(sdk.ErrWorkflowInvalid, fmt.Errorf("Invalid workflow name. It should match %s", sdk.NamePattern))
 	}
 
+	//Check concurrency group
+	if w.Concurrency != nil {
+		if err := w.Concurrency.IsValid(); err != nil {
+			return err
+		}
+	}
+
 	//Check duplicate refs
 	refs := w.References()
 	for i, ref1 := range refs {
@@ -1521,7 +1544,7 @@ func Push(ctx context.Context, db *gorp.DbMap, store cache.Store, proj *sdk.Proj
 		if opts != nil {
 			fromRepo = opts.FromRepository
 		}
-		pipDB, msgList, err := pipeline.ParseAndImport(tx, store, proj, &pip, u, pipeline.ImportOptions{Force: true, FromRepository: fromRepo})
+		pipDB, msgList, err := pipeline.ParseAndImport(ctx, tx, store, proj, &pip, u, pipeline.ImportOptions{Force: true, FromRepository: fromRepo})
 		if err != nil {
 			return nil, nil, sdk.ErrorWithFallback(err, sdk.ErrWrongRequest, "unable to import pipeline %s/%s", proj.Key, pip.Name)
 
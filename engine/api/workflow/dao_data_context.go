@@ -20,6 +20,8 @@ type sqlNodeContextData struct {
 	DefaultPipelineParameters sql.NullString `db:"default_pipeline_parameters"`
 	Conditions                sql.NullString `db:"conditions"`
 	Mutex                     bool           `db:"mutex"`
+	EphemeralEnvironment      sql.NullString `db:"ephemeral_environment"`
+	ApprovalGate              sql.NullString `db:"approval_gate"`
 }
 
 func insertNodeContextData(db gorp.SqlExecutor, w *sdk.Workflow, n *sdk.Node) error {
@@ -57,6 +59,18 @@ func insertNodeContextData(db gorp.SqlExecutor, w *sdk.Workflow, n *sdk.Node) er
 
 	tempContext.Mutex = n.Context.Mutex
 
+	var errEE error
+	tempContext.EphemeralEnvironment, errEE = gorpmapping.JSONToNullString(n.Context.EphemeralEnvironment)
+	if errEE != nil {
+		return sdk.WrapError(errEE, "insertNodeContextData> Cannot stringify ephemeral environment")
+	}
+
+	var errAG error
+	tempContext.ApprovalGate, errAG = gorpmapping.JSONToNullString(n.Context.ApprovalGate)
+	if errAG != nil {
+		return sdk.WrapError(errAG, "insertNodeContextData> Cannot stringify approval gate")
+	}
+
 	if n.Context.PipelineID != 0 {
 		//Checks pipeline parameters
 		if len(n.Context.DefaultPipelineParameters) > 0 {
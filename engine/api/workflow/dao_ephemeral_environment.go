@@ -0,0 +1,91 @@
+package workflow
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// InsertEphemeralEnvironment inserts an ephemeral environment
+func InsertEphemeralEnvironment(db gorp.SqlExecutor, env *sdk.EphemeralEnvironment) error {
+	env.Created = time.Now()
+	env.LastModified = env.Created
+	dbEnv := dbEphemeralEnvironment(*env)
+	if err := db.Insert(&dbEnv); err != nil {
+		return sdk.WrapError(err, "Unable to insert ephemeral environment %s", env.Name)
+	}
+	*env = sdk.EphemeralEnvironment(dbEnv)
+	return nil
+}
+
+// UpdateEphemeralEnvironment updates an ephemeral environment
+func UpdateEphemeralEnvironment(db gorp.SqlExecutor, env *sdk.EphemeralEnvironment) error {
+	env.LastModified = time.Now()
+	dbEnv := dbEphemeralEnvironment(*env)
+	if _, err := db.Update(&dbEnv); err != nil {
+		return sdk.WrapError(err, "Unable to update ephemeral environment %s", env.Name)
+	}
+	return nil
+}
+
+// LoadEphemeralEnvironmentByName loads an ephemeral environment created by a workflow node
+func LoadEphemeralEnvironmentByName(db gorp.SqlExecutor, workflowID, nodeID int64, name string) (*sdk.EphemeralEnvironment, error) {
+	query := `
+		SELECT * FROM workflow_ephemeral_environment
+		WHERE workflow_id = $1 AND workflow_node_id = $2 AND name = $3
+	`
+	var dbEnv dbEphemeralEnvironment
+	if err := db.SelectOne(&dbEnv, query, workflowID, nodeID, name); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sdk.WithStack(sdk.ErrNotFound)
+		}
+		return nil, sdk.WrapError(err, "Unable to load ephemeral environment %s", name)
+	}
+	env := sdk.EphemeralEnvironment(dbEnv)
+	return &env, nil
+}
+
+// LoadEphemeralEnvironmentByNodeRun loads the ephemeral environment deployed by a workflow node run
+func LoadEphemeralEnvironmentByNodeRun(db gorp.SqlExecutor, nodeRunID int64) (*sdk.EphemeralEnvironment, error) {
+	query := `SELECT * FROM workflow_ephemeral_environment WHERE workflow_node_run_id = $1`
+	var dbEnv dbEphemeralEnvironment
+	if err := db.SelectOne(&dbEnv, query, nodeRunID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sdk.WithStack(sdk.ErrNotFound)
+		}
+		return nil, sdk.WrapError(err, "Unable to load ephemeral environment for node run %d", nodeRunID)
+	}
+	env := sdk.EphemeralEnvironment(dbEnv)
+	return &env, nil
+}
+
+// LoadEphemeralEnvironmentsByWorkflow loads all the ephemeral environments of a workflow
+func LoadEphemeralEnvironmentsByWorkflow(db gorp.SqlExecutor, workflowID int64) ([]sdk.EphemeralEnvironment, error) {
+	query := `
+		SELECT * FROM workflow_ephemeral_environment
+		WHERE workflow_id = $1
+		ORDER BY created DESC
+	`
+	return loadEphemeralEnvironments(db, query, workflowID)
+}
+
+// LoadActiveEphemeralEnvironments loads all the ephemeral environments which are not tore down
+func LoadActiveEphemeralEnvironments(db gorp.SqlExecutor) ([]sdk.EphemeralEnvironment, error) {
+	query := `SELECT * FROM workflow_ephemeral_environment WHERE status = $1`
+	return loadEphemeralEnvironments(db, query, sdk.EphemeralEnvironmentStatusActive)
+}
+
+func loadEphemeralEnvironments(db gorp.SqlExecutor, query string, args ...interface{}) ([]sdk.EphemeralEnvironment, error) {
+	var dbEnvs []dbEphemeralEnvironment
+	if _, err := db.Select(&dbEnvs, query, args...); err != nil {
+		return nil, sdk.WrapError(err, "Unable to load ephemeral environments")
+	}
+	envs := make([]sdk.EphemeralEnvironment, len(dbEnvs))
+	for i := range dbEnvs {
+		envs[i] = sdk.EphemeralEnvironment(dbEnvs[i])
+	}
+	return envs, nil
+}
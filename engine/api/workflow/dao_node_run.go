@@ -20,6 +20,7 @@ import (
 
 const nodeRunFields string = `
 workflow_node_run.application_id,
+workflow_node_run.environment_id,
 workflow_node_run.workflow_id,
 workflow_node_run.workflow_run_id,
 workflow_node_run.id,
@@ -256,6 +257,9 @@ func fromDBNodeRun(rr NodeRun, opts LoadRunOptions) (*sdk.WorkflowNodeRun, error
 	} else {
 		r.ApplicationID = 0
 	}
+	if rr.EnvironmentID.Valid {
+		r.EnvironmentID = rr.EnvironmentID.Int64
+	}
 	r.WorkflowRunID = rr.WorkflowRunID
 	r.ID = rr.ID
 	r.WorkflowNodeID = rr.WorkflowNodeID
@@ -387,6 +391,8 @@ func makeDBNodeRun(n sdk.WorkflowNodeRun) (*NodeRun, error) {
 	nodeRunDB.WorkflowID.Int64 = n.WorkflowID
 	nodeRunDB.ApplicationID.Int64 = n.ApplicationID
 	nodeRunDB.ApplicationID.Valid = true
+	nodeRunDB.EnvironmentID.Int64 = n.EnvironmentID
+	nodeRunDB.EnvironmentID.Valid = n.EnvironmentID != 0
 	nodeRunDB.WorkflowRunID = n.WorkflowRunID
 	nodeRunDB.WorkflowNodeID = n.WorkflowNodeID
 	nodeRunDB.WorkflowNodeName = n.WorkflowNodeName
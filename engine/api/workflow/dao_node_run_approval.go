@@ -0,0 +1,80 @@
+package workflow
+
+import (
+	"database/sql"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// InsertNodeRunApproval inserts an approval request for a workflow node run
+func InsertNodeRunApproval(db gorp.SqlExecutor, a *sdk.WorkflowNodeRunApproval) error {
+	if a.Reviews == nil {
+		a.Reviews = sdk.WorkflowNodeRunApprovalReviews{}
+	}
+	dbA := dbNodeRunApproval(*a)
+	if err := db.Insert(&dbA); err != nil {
+		return sdk.WrapError(err, "Unable to insert approval for node run %d", a.WorkflowNodeRunID)
+	}
+	a.ID = dbA.ID
+	return nil
+}
+
+// UpdateNodeRunApproval updates an approval request
+func UpdateNodeRunApproval(db gorp.SqlExecutor, a *sdk.WorkflowNodeRunApproval) error {
+	dbA := dbNodeRunApproval(*a)
+	if _, err := db.Update(&dbA); err != nil {
+		return sdk.WrapError(err, "Unable to update approval %d", a.ID)
+	}
+	return nil
+}
+
+// LoadNodeRunApproval loads the approval request of a workflow node run
+func LoadNodeRunApproval(db gorp.SqlExecutor, nodeRunID int64) (*sdk.WorkflowNodeRunApproval, error) {
+	return loadNodeRunApproval(db, `SELECT * FROM workflow_node_run_approval WHERE workflow_node_run_id = $1`, nodeRunID)
+}
+
+// LoadNodeRunApprovalForUpdate loads an approval request and locks it until the end of the transaction
+func LoadNodeRunApprovalForUpdate(db gorp.SqlExecutor, id int64) (*sdk.WorkflowNodeRunApproval, error) {
+	return loadNodeRunApproval(db, `SELECT * FROM workflow_node_run_approval WHERE id = $1 FOR UPDATE`, id)
+}
+
+// LoadNodeRunApprovalByToken loads an approval request from its callback token
+func LoadNodeRunApprovalByToken(db gorp.SqlExecutor, token string) (*sdk.WorkflowNodeRunApproval, error) {
+	return loadNodeRunApproval(db, `SELECT * FROM workflow_node_run_approval WHERE token = $1`, token)
+}
+
+// LoadNodeRunApprovalsByRun loads all the approval requests of a workflow run
+func LoadNodeRunApprovalsByRun(db gorp.SqlExecutor, workflowRunID int64) ([]sdk.WorkflowNodeRunApproval, error) {
+	return loadNodeRunApprovals(db, `SELECT * FROM workflow_node_run_approval WHERE workflow_run_id = $1 ORDER BY id`, workflowRunID)
+}
+
+// LoadPendingNodeRunApprovals loads all the approval requests which have not been reviewed yet
+func LoadPendingNodeRunApprovals(db gorp.SqlExecutor) ([]sdk.WorkflowNodeRunApproval, error) {
+	return loadNodeRunApprovals(db, `SELECT * FROM workflow_node_run_approval WHERE status = $1`, sdk.ApprovalStatusPending)
+}
+
+func loadNodeRunApproval(db gorp.SqlExecutor, query string, args ...interface{}) (*sdk.WorkflowNodeRunApproval, error) {
+	var dbA dbNodeRunApproval
+	if err := db.SelectOne(&dbA, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sdk.WithStack(sdk.ErrNotFound)
+		}
+		return nil, sdk.WrapError(err, "Unable to load approval")
+	}
+	a := sdk.WorkflowNodeRunApproval(dbA)
+	return &a, nil
+}
+
+func loadNodeRunApprovals(db gorp.SqlExecutor, query string, args ...interface{}) ([]sdk.WorkflowNodeRunApproval, error) {
+	var dbAs []dbNodeRunApproval
+	if _, err := db.Select(&dbAs, query, args...); err != nil {
+		return nil, sdk.WrapError(err, "Unable to load approvals")
+	}
+	as := make([]sdk.WorkflowNodeRunApproval, len(dbAs))
+	for i := range dbAs {
+		as[i] = sdk.WorkflowNodeRunApproval(dbAs[i])
+	}
+	return as, nil
+}
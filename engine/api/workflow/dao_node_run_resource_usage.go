@@ -0,0 +1,99 @@
+package workflow
+
+import (
+	"encoding/json"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// ResourceUsageStatsRuns is the number of runs of each job used to compute resource usage stats
+const ResourceUsageStatsRuns = 100
+
+// InsertJobResourceUsage saves the resource usage sent by the worker of a job run, the usage of a job run
+// sent twice is replaced
+func InsertJobResourceUsage(db gorp.SqlExecutor, nr *sdk.WorkflowNodeRun, job *sdk.WorkflowNodeJobRun, u *sdk.JobResourceUsage) error {
+	u.WorkflowID = nr.WorkflowID
+	u.WorkflowRunID = nr.WorkflowRunID
+	u.WorkflowNodeRunID = nr.ID
+	u.WorkflowNodeJobRunID = job.ID
+	u.WorkflowNodeName = nr.WorkflowNodeName
+	u.JobName = job.Job.Action.Name
+	u.Num = nr.Number
+	u.RequestedMemory = sdk.RequestedMemory(job.Job.Action.Requirements)
+	u.Summarize()
+
+	steps, err := json.Marshal(u.Steps)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	total, err := json.Marshal(u.Total)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+
+	query := `INSERT INTO workflow_node_run_job_resource_usage (workflow_id, workflow_run_id, workflow_node_run_id, workflow_node_job_run_id,
+		workflow_node_name, job_name, num, requested_memory, steps, total)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	ON CONFLICT (workflow_node_job_run_id) DO UPDATE SET steps = $9, total = $10
+	RETURNING id, created`
+	if err := db.QueryRow(query, u.WorkflowID, u.WorkflowRunID, u.WorkflowNodeRunID, u.WorkflowNodeJobRunID,
+		u.WorkflowNodeName, u.JobName, u.Num, u.RequestedMemory, steps, total).Scan(&u.ID, &u.Created); err != nil {
+		return sdk.WrapError(err, "Unable to insert resource usage of job %d", job.ID)
+	}
+	return nil
+}
+
+// LoadRunJobResourceUsages loads the resource usage of the jobs of a workflow run
+func LoadRunJobResourceUsages(db gorp.SqlExecutor, workflowRunID int64) ([]sdk.JobResourceUsage, error) {
+	return loadJobResourceUsages(db, `SELECT `+jobResourceUsageFields+` FROM workflow_node_run_job_resource_usage
+	WHERE workflow_run_id = $1 ORDER BY id`, workflowRunID)
+}
+
+// LoadJobResourceUsages loads the resource usage of the last runs of each job of a workflow,
+// from the most recent to the oldest
+func LoadJobResourceUsages(db gorp.SqlExecutor, projectKey, workflowName string, runs int) ([]sdk.JobResourceUsage, error) {
+	return loadJobResourceUsages(db, `SELECT `+jobResourceUsageFields+` FROM (
+		SELECT workflow_node_run_job_resource_usage.*,
+			row_number() OVER (PARTITION BY workflow_node_name, job_name ORDER BY workflow_node_run_job_resource_usage.id DESC) AS rank
+		FROM workflow_node_run_job_resource_usage
+		JOIN workflow ON workflow.id = workflow_node_run_job_resource_usage.workflow_id
+		JOIN project ON project.id = workflow.project_id
+		WHERE project.projectkey = $1 AND workflow.name = $2
+	) AS usage
+	WHERE rank <= $3 ORDER BY id DESC`, projectKey, workflowName, runs)
+}
+
+const jobResourceUsageFields = `id, workflow_id, workflow_run_id, workflow_node_run_id, workflow_node_job_run_id,
+	workflow_node_name, job_name, num, requested_memory, created, steps, total`
+
+func loadJobResourceUsages(db gorp.SqlExecutor, query string, args ...interface{}) ([]sdk.JobResourceUsage, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, sdk.WrapError(err, "Unable to load resource usages")
+	}
+	defer rows.Close() // nolint
+
+	var us []sdk.JobResourceUsage
+	for rows.Next() {
+		var u sdk.JobResourceUsage
+		var steps, total []byte
+		if err := rows.Scan(&u.ID, &u.WorkflowID, &u.WorkflowRunID, &u.WorkflowNodeRunID, &u.WorkflowNodeJobRunID,
+			&u.WorkflowNodeName, &u.JobName, &u.Num, &u.RequestedMemory, &u.Created, &steps, &total); err != nil {
+			return nil, sdk.WrapError(err, "Unable to scan resource usage")
+		}
+		if len(steps) > 0 {
+			if err := json.Unmarshal(steps, &u.Steps); err != nil {
+				return nil, sdk.WrapError(err, "Unable to unmarshal resource usage steps")
+			}
+		}
+		if len(total) > 0 {
+			if err := json.Unmarshal(total, &u.Total); err != nil {
+				return nil, sdk.WrapError(err, "Unable to unmarshal resource usage total")
+			}
+		}
+		us = append(us, u)
+	}
+	return us, sdk.WithStack(rows.Err())
+}
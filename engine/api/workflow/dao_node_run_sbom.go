@@ -0,0 +1,169 @@
+package workflow
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/application"
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// HandleSBOMReport diffs the SBOM of a node run with the latest SBOM of the default branch and saves it,
+// on the default branch the components of the application are replaced
+func HandleSBOMReport(ctx context.Context, db gorp.SqlExecutor, cache cache.Store, proj *sdk.Project, nr *sdk.WorkflowNodeRun, workerReport sdk.SBOMWorkerReport) (*sdk.WorkflowNodeRunSBOM, error) {
+	defaultBranch, err := nodeRunDefaultBranch(ctx, db, cache, proj, nr)
+	if err != nil {
+		return nil, err
+	}
+
+	sbom := sdk.WorkflowNodeRunSBOM{
+		ApplicationID:     nr.ApplicationID,
+		WorkflowID:        nr.WorkflowID,
+		WorkflowRunID:     nr.WorkflowRunID,
+		WorkflowNodeRunID: nr.ID,
+		Num:               nr.Number,
+		Branch:            nr.VCSBranch,
+		Format:            workerReport.Format,
+		Components:        workerReport.Components,
+	}
+
+	branch := defaultBranch
+	if branch == "" {
+		branch = nr.VCSBranch
+	}
+	previous, err := loadLatestRunSBOM(db, nr, branch)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return nil, sdk.WrapError(err, "Unable to load previous SBOM")
+	}
+	var previousComponents []sdk.Component
+	if previous != nil {
+		previousComponents = previous.Components
+	}
+	sbom.Diff = sdk.DiffComponents(previousComponents, sbom.Components)
+
+	if err := insertNodeRunSBOM(db, &sbom); err != nil {
+		return nil, err
+	}
+
+	if defaultBranch != "" && defaultBranch == nr.VCSBranch {
+		if err := application.InsertComponents(db, sbom.Components, nr.ApplicationID); err != nil {
+			return nil, sdk.WrapError(err, "Unable to save application components")
+		}
+	}
+
+	return &sbom, nil
+}
+
+// CheckVulnerabilitiesPolicy returns the vulnerabilities of a node run violating the security policy,
+// vulnerabilities ignored on the application are skipped
+func CheckVulnerabilitiesPolicy(db gorp.SqlExecutor, policy sdk.ProjectSecurityPolicy, nr *sdk.WorkflowNodeRun, vs []sdk.Vulnerability) ([]string, error) {
+	if policy.MaxSeverity == "" {
+		return nil, nil
+	}
+	report := sdk.WorkflowNodeRunVulnerabilityReport{Report: sdk.WorkflowNodeRunVulnerability{Vulnerabilities: vs}}
+	synced, err := syncVunerabilitiesWithApplication(db, report, nr.ApplicationID)
+	if err != nil {
+		return nil, err
+	}
+	return policy.CheckVulnerabilities(synced), nil
+}
+
+// CommentSecurityPolicyViolations posts the security policy violations on the opened pull request of the node run branch
+func CommentSecurityPolicyViolations(ctx context.Context, db gorp.SqlExecutor, cache cache.Store, proj *sdk.Project, nr *sdk.WorkflowNodeRun, violations []string) error {
+	if nr.VCSServer == "" || nr.VCSRepository == "" || len(violations) == 0 {
+		return nil
+	}
+	projectVCSServer := repositoriesmanager.GetProjectVCSServer(proj, nr.VCSServer)
+	if projectVCSServer == nil {
+		return nil
+	}
+	client, err := repositoriesmanager.AuthorizedClient(ctx, db, cache, projectVCSServer)
+	if err != nil {
+		return sdk.WrapError(sdk.ErrNoReposManagerClientAuth, "Cannot get repo client %s : %v", nr.VCSServer, err)
+	}
+
+	prs, err := client.PullRequests(ctx, nr.VCSRepository)
+	if err != nil {
+		return sdk.WrapError(err, "Unable to get pull requests on repo %s", nr.VCSRepository)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Security policy of project %s is not respected by %s:\n", proj.Key, nr.WorkflowNodeName)
+	for _, v := range violations {
+		fmt.Fprintf(&b, "- %s\n", v)
+	}
+
+	for _, pr := range prs {
+		if pr.Head.Branch.DisplayID == nr.VCSBranch && !pr.Merged && !pr.Closed {
+			if err := client.PullRequestComment(ctx, nr.VCSRepository, pr.ID, b.String()); err != nil {
+				return sdk.WrapError(err, "Unable to send security policy violations on pull request %d", pr.ID)
+			}
+			log.Debug("CommentSecurityPolicyViolations> %d violations sent on pull request %d", len(violations), pr.ID)
+			break
+		}
+	}
+	return nil
+}
+
+// LoadNodeRunSBOM loads the SBOM sent by a node run
+func LoadNodeRunSBOM(db gorp.SqlExecutor, nodeRunID int64) (*sdk.WorkflowNodeRunSBOM, error) {
+	return loadNodeRunSBOM(db, `SELECT `+nodeRunSBOMFields+` FROM workflow_node_run_sbom
+	WHERE workflow_node_run_id = $1 ORDER BY id DESC LIMIT 1`, nodeRunID)
+}
+
+func loadLatestRunSBOM(db gorp.SqlExecutor, nr *sdk.WorkflowNodeRun, branch string) (*sdk.WorkflowNodeRunSBOM, error) {
+	return loadNodeRunSBOM(db, `SELECT `+nodeRunSBOMFields+` FROM workflow_node_run_sbom
+	WHERE application_id = $1 AND workflow_id = $2 AND branch = $3 AND workflow_node_run_id <> $4
+	ORDER BY workflow_number DESC, workflow_node_run_id DESC LIMIT 1`, nr.ApplicationID, nr.WorkflowID, branch, nr.ID)
+}
+
+const nodeRunSBOMFields = `id, application_id, workflow_id, workflow_run_id, workflow_node_run_id, workflow_number, branch, format, components, diff`
+
+func loadNodeRunSBOM(db gorp.SqlExecutor, query string, args ...interface{}) (*sdk.WorkflowNodeRunSBOM, error) {
+	var s sdk.WorkflowNodeRunSBOM
+	var components, diff []byte
+	if err := db.QueryRow(query, args...).Scan(&s.ID, &s.ApplicationID, &s.WorkflowID, &s.WorkflowRunID, &s.WorkflowNodeRunID,
+		&s.Num, &s.Branch, &s.Format, &components, &diff); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sdk.WithStack(sdk.ErrNotFound)
+		}
+		return nil, sdk.WrapError(err, "Unable to load SBOM")
+	}
+	if len(components) > 0 {
+		if err := json.Unmarshal(components, &s.Components); err != nil {
+			return nil, sdk.WrapError(err, "Unable to unmarshal SBOM components")
+		}
+	}
+	if len(diff) > 0 {
+		if err := json.Unmarshal(diff, &s.Diff); err != nil {
+			return nil, sdk.WrapError(err, "Unable to unmarshal SBOM diff")
+		}
+	}
+	return &s, nil
+}
+
+func insertNodeRunSBOM(db gorp.SqlExecutor, s *sdk.WorkflowNodeRunSBOM) error {
+	components, err := json.Marshal(s.Components)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	diff, err := json.Marshal(s.Diff)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	query := `INSERT INTO workflow_node_run_sbom (application_id, workflow_id, workflow_run_id, workflow_node_run_id, workflow_number, branch, format, components, diff)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
+	if err := db.QueryRow(query, s.ApplicationID, s.WorkflowID, s.WorkflowRunID, s.WorkflowNodeRunID, s.Num, s.Branch, s.Format,
+		components, diff).Scan(&s.ID); err != nil {
+		return sdk.WrapError(err, "Unable to insert SBOM")
+	}
+	return nil
+}
@@ -18,21 +18,9 @@ import (
 
 // HandleVulnerabilityReport calculate vulnerability trend and save report
 func HandleVulnerabilityReport(ctx context.Context, db gorp.SqlExecutor, cache cache.Store, proj *sdk.Project, nr *sdk.WorkflowNodeRun, workerReport sdk.VulnerabilityWorkerReport) error {
-	var defaultBranch string
-	// Get default branch
-	if nr.VCSServer != "" {
-		// Get vcs info to known if we are on the default branch or not
-		projectVCSServer := repositoriesmanager.GetProjectVCSServer(proj, nr.VCSServer)
-		client, erra := repositoriesmanager.AuthorizedClient(ctx, db, cache, projectVCSServer)
-		if erra != nil {
-			return sdk.WrapError(sdk.ErrNoReposManagerClientAuth, "HandleVulnerabilityReport> Cannot get repo client %s : %v", nr.VCSServer, erra)
-		}
-
-		b, errB := repositoriesmanager.DefaultBranch(ctx, client, nr.VCSRepository)
-		if errB != nil {
-			return sdk.WrapError(errB, "HandleVulnerabilityReport> Unable to get default branch")
-		}
-		defaultBranch = b.DisplayID
+	defaultBranch, err := nodeRunDefaultBranch(ctx, db, cache, proj, nr)
+	if err != nil {
+		return err
 	}
 
 	// Get report on the current node run if exist
@@ -87,6 +75,25 @@ func HandleVulnerabilityReport(ctx context.Context, db gorp.SqlExecutor, cache c
 	return nil
 }
 
+// nodeRunDefaultBranch returns the default branch of the repository of a node run, empty if the node run has no repository
+func nodeRunDefaultBranch(ctx context.Context, db gorp.SqlExecutor, cache cache.Store, proj *sdk.Project, nr *sdk.WorkflowNodeRun) (string, error) {
+	if nr.VCSServer == "" {
+		return "", nil
+	}
+	// Get vcs info to known if we are on the default branch or not
+	projectVCSServer := repositoriesmanager.GetProjectVCSServer(proj, nr.VCSServer)
+	client, erra := repositoriesmanager.AuthorizedClient(ctx, db, cache, projectVCSServer)
+	if erra != nil {
+		return "", sdk.WrapError(sdk.ErrNoReposManagerClientAuth, "Cannot get repo client %s : %v", nr.VCSServer, erra)
+	}
+
+	b, errB := repositoriesmanager.DefaultBranch(ctx, client, nr.VCSRepository)
+	if errB != nil {
+		return "", sdk.WrapError(errB, "Unable to get default branch")
+	}
+	return b.DisplayID, nil
+}
+
 func createNewVulnerabilityReport(db gorp.SqlExecutor, cache cache.Store, proj *sdk.Project, nr *sdk.WorkflowNodeRun, workerReport sdk.VulnerabilityWorkerReport, defaultBranch string) error {
 	// Build current report
 	nodeRunReport := sdk.WorkflowNodeRunVulnerabilityReport{
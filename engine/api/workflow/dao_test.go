@@ -2,6 +2,7 @@ package workflow_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"testing"
@@ -96,6 +97,66 @@ func TestInsertSimpleWorkflowAndExport(t *testing.T) {
 	fmt.Println(string(btes))
 }
 
+func TestInsertSimpleWorkflowWithApprovalGate(t *testing.T) {
+	db, cache, end := test.SetupPG(t)
+	defer end()
+	u, _ := assets.InsertAdminUser(db)
+
+	key := sdk.RandomString(10)
+	proj := assets.InsertTestProject(t, db, cache, key, key, u)
+
+	pip := sdk.Pipeline{
+		ProjectID:  proj.ID,
+		ProjectKey: proj.Key,
+		Name:       "pip1",
+	}
+
+	test.NoError(t, pipeline.InsertPipeline(db, cache, proj, &pip, u))
+
+	proj, _ = project.LoadByID(db, cache, proj.ID, u, project.LoadOptions.WithApplications, project.LoadOptions.WithPipelines, project.LoadOptions.WithEnvironments, project.LoadOptions.WithGroups)
+
+	gate := sdk.NodeApprovalGate{
+		Groups:   []string{"reviewers"},
+		Count:    2,
+		FourEyes: true,
+		Expiry:   60,
+	}
+	w := sdk.Workflow{
+		Name:       "test_1",
+		ProjectID:  proj.ID,
+		ProjectKey: proj.Key,
+		WorkflowData: &sdk.WorkflowData{
+			Node: sdk.Node{
+				Name: "node1",
+				Ref:  "node1",
+				Type: sdk.NodeTypePipeline,
+				Context: &sdk.NodeContext{
+					PipelineID:   pip.ID,
+					ApprovalGate: &gate,
+				},
+			},
+		},
+	}
+
+	(&w).RetroMigrate()
+
+	test.NoError(t, workflow.Insert(db, cache, &w, proj, u))
+
+	w1, err := workflow.Load(context.TODO(), db, cache, proj, "test_1", u, workflow.LoadOptions{})
+	test.NoError(t, err)
+
+	if assert.NotNil(t, w1.WorkflowData.Node.Context.ApprovalGate) {
+		assert.Equal(t, gate, *w1.WorkflowData.Node.Context.ApprovalGate)
+	}
+
+	// The gate is also stored with the other node context data
+	var dbGate sdk.NodeApprovalGate
+	s, err := db.SelectStr("SELECT approval_gate FROM w_node_context WHERE node_id = $1", w1.WorkflowData.Node.ID)
+	test.NoError(t, err)
+	test.NoError(t, json.Unmarshal([]byte(s), &dbGate))
+	assert.Equal(t, gate, dbGate)
+}
+
 func TestInsertSimpleWorkflowWithWrongName(t *testing.T) {
 	db, cache, end := test.SetupPG(t)
 	defer end()
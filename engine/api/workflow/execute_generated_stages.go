@@ -0,0 +1,103 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/sdk"
+)
+
+// AppendGeneratedStages appends the stages generated at run time by a job to its node run, they run after the
+// stages of the node run. A generated job can generate stages only up to sdk.MaxStageGeneration generations, and a
+// node run can't have more than sdk.MaxGeneratedJobs generated jobs. The steps of the generated jobs should be loaded.
+func AppendGeneratedStages(ctx context.Context, db gorp.SqlExecutor, nodeJobRun *sdk.WorkflowNodeJobRun, stages []sdk.Stage) ([]sdk.Stage, error) {
+	var end func()
+	ctx, end = observability.Span(ctx, "workflow.AppendGeneratedStages")
+	defer end()
+
+	if len(stages) == 0 {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "no stage generated")
+	}
+
+	nr, err := LoadAndLockNodeRunByID(ctx, db, nodeJobRun.WorkflowNodeRunID)
+	if err != nil {
+		return nil, err
+	}
+	if sdk.StatusIsTerminated(nr.Status) {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "node run %d is over", nr.ID)
+	}
+
+	if err := appendGeneratedStages(nr, nodeJobRun.ID, stages); err != nil {
+		return nil, err
+	}
+	if err := updateNodeRunStatusAndStage(db, nr); err != nil {
+		return nil, err
+	}
+
+	return nr.Stages[len(nr.Stages)-len(stages):], nil
+}
+
+// appendGeneratedStages checks the limits of generated stages then numbers the given stages and appends them to the
+// node run.
+func appendGeneratedStages(nr *sdk.WorkflowNodeRun, jobID int64, stages []sdk.Stage) error {
+	generation := -1
+	var generatedStages, generatedJobs int
+	for _, s := range nr.Stages {
+		for _, rj := range s.RunJobs {
+			if rj.ID == jobID {
+				generation = s.Generation + 1
+			}
+		}
+		if s.Generation > 0 {
+			generatedStages++
+			generatedJobs += len(s.Jobs)
+		}
+	}
+	if generation < 0 {
+		return sdk.NewErrorFrom(sdk.ErrNotFound, "job %d not found in node run %d", jobID, nr.ID)
+	}
+	if generation > sdk.MaxStageGeneration {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "a job can't generate stages beyond %d generations", sdk.MaxStageGeneration)
+	}
+	var newJobs int
+	for _, s := range stages {
+		newJobs += len(s.Jobs)
+	}
+	if generatedJobs+newJobs > sdk.MaxGeneratedJobs {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "a node run can't have more than %d generated jobs, %d already generated", sdk.MaxGeneratedJobs, generatedJobs)
+	}
+
+	// generated stages and jobs are not saved in the pipeline, they have negative ids to be unique in the node run
+	lastBuildOrder := 0
+	if len(nr.Stages) > 0 {
+		lastBuildOrder = nr.Stages[len(nr.Stages)-1].BuildOrder
+	}
+	for i := range stages {
+		s := &stages[i]
+		generatedStages++
+		s.ID = -int64(generatedStages)
+		s.BuildOrder += lastBuildOrder
+		s.Generation = generation
+		s.Status = ""
+		if s.Name == "" {
+			s.Name = fmt.Sprintf("Stage %d", len(nr.Stages)+i+1)
+		}
+		for j := range s.Jobs {
+			generatedJobs++
+			s.Jobs[j].PipelineActionID = -int64(generatedJobs)
+			s.Jobs[j].PipelineStageID = s.ID
+		}
+	}
+
+	// generated jobs can use the outputs of the jobs of previous stages
+	p := sdk.Pipeline{Stages: append(append([]sdk.Stage{}, nr.Stages...), stages...)}
+	if err := p.CheckJobOutputs(); err != nil {
+		return err
+	}
+
+	nr.Stages = p.Stages
+	return nil
+}
@@ -0,0 +1,65 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_appendGeneratedStages(t *testing.T) {
+	nr := &sdk.WorkflowNodeRun{
+		ID: 1,
+		Stages: []sdk.Stage{
+			{
+				ID:         10,
+				Name:       "build",
+				BuildOrder: 1,
+				Jobs:       []sdk.Job{{PipelineActionID: 100, Action: sdk.Action{Name: "generate"}}},
+				RunJobs:    []sdk.WorkflowNodeJobRun{{ID: 1000}},
+			},
+		},
+	}
+
+	stages := []sdk.Stage{
+		{BuildOrder: 1, Jobs: []sdk.Job{{Action: sdk.Action{Name: "a"}}, {Action: sdk.Action{Name: "b"}}}},
+		{Name: "deploy", BuildOrder: 2, Jobs: []sdk.Job{{Action: sdk.Action{Name: "c"}}}},
+	}
+	assert.NoError(t, appendGeneratedStages(nr, 1000, stages))
+	assert.Len(t, nr.Stages, 3)
+
+	assert.Equal(t, int64(-1), nr.Stages[1].ID)
+	assert.Equal(t, "Stage 2", nr.Stages[1].Name)
+	assert.Equal(t, 2, nr.Stages[1].BuildOrder)
+	assert.Equal(t, 1, nr.Stages[1].Generation)
+	assert.Equal(t, int64(-1), nr.Stages[1].Jobs[0].PipelineActionID)
+	assert.Equal(t, int64(-2), nr.Stages[1].Jobs[1].PipelineActionID)
+	assert.Equal(t, int64(-1), nr.Stages[1].Jobs[1].PipelineStageID)
+
+	assert.Equal(t, int64(-2), nr.Stages[2].ID)
+	assert.Equal(t, "deploy", nr.Stages[2].Name)
+	assert.Equal(t, 3, nr.Stages[2].BuildOrder)
+	assert.Equal(t, int64(-3), nr.Stages[2].Jobs[0].PipelineActionID)
+
+	// a generated job generates a new stage
+	nr.Stages[2].RunJobs = []sdk.WorkflowNodeJobRun{{ID: 1001}}
+	assert.NoError(t, appendGeneratedStages(nr, 1001, []sdk.Stage{{BuildOrder: 1, Jobs: []sdk.Job{{Action: sdk.Action{Name: "d"}}}}}))
+	assert.Len(t, nr.Stages, 4)
+	assert.Equal(t, int64(-3), nr.Stages[3].ID)
+	assert.Equal(t, 2, nr.Stages[3].Generation)
+	assert.Equal(t, int64(-4), nr.Stages[3].Jobs[0].PipelineActionID)
+
+	// unknown job
+	assert.Error(t, appendGeneratedStages(nr, 999, []sdk.Stage{{BuildOrder: 1, Jobs: []sdk.Job{{}}}}))
+
+	// too many generations
+	nr.Stages[3].Generation = sdk.MaxStageGeneration
+	nr.Stages[3].RunJobs = []sdk.WorkflowNodeJobRun{{ID: 1002}}
+	assert.Error(t, appendGeneratedStages(nr, 1002, []sdk.Stage{{BuildOrder: 1, Jobs: []sdk.Job{{}}}}))
+
+	// too many generated jobs
+	jobs := make([]sdk.Job, sdk.MaxGeneratedJobs)
+	assert.Error(t, appendGeneratedStages(nr, 1000, []sdk.Stage{{BuildOrder: 1, Jobs: jobs}}))
+	assert.Len(t, nr.Stages, 4)
+}
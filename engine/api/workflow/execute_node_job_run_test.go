@@ -0,0 +1,54 @@
+package workflow_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/engine/api/keys"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestLoadNodeJobRunKeysWithoutProvenanceKey(t *testing.T) {
+	db, cache, end := test.SetupPG(t)
+	defer end()
+	u, _ := assets.InsertAdminUser(db)
+	key := sdk.RandomString(10)
+	proj := assets.InsertTestProject(t, db, cache, key, key, u)
+
+	kp, err := keys.GenerateSSHKey("proj-mykey")
+	test.NoError(t, err)
+	test.NoError(t, project.InsertKey(db, &sdk.ProjectKey{Key: kp, ProjectID: proj.ID}))
+	_, err = project.LoadOrCreateProvenanceKey(db, proj.ID)
+	test.NoError(t, err)
+
+	proj, err = project.LoadByID(db, cache, proj.ID, u, project.LoadOptions.WithClearKeys)
+	test.NoError(t, err)
+
+	wr := &sdk.WorkflowRun{
+		Workflow: sdk.Workflow{
+			WorkflowData: &sdk.WorkflowData{
+				Node: sdk.Node{ID: 1, Name: "root", Context: &sdk.NodeContext{}},
+			},
+		},
+	}
+	params, secrets, err := workflow.LoadNodeJobRunKeys(proj, wr, &sdk.WorkflowNodeRun{WorkflowNodeID: 1})
+	test.NoError(t, err)
+
+	var names []string
+	for _, p := range params {
+		names = append(names, p.Name)
+	}
+	for _, s := range secrets {
+		names = append(names, s.Name)
+	}
+	assert.Contains(t, names, "cds.key.proj-mykey.priv")
+	for _, n := range names {
+		assert.False(t, strings.Contains(n, sdk.ProvenanceKeyName), "provenance key given to the job: %s", n)
+	}
+}
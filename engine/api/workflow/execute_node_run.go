@@ -254,6 +254,17 @@ func execute(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *
 			return nil, sdk.WrapError(err, "Unable to delete node %d job runs ", nr.ID)
 		}
 
+		//Do we release a concurrency group ? Reload the run to get the node runs triggered by the processing
+		processedWorkflowRun, err := LoadRunByID(db, nr.WorkflowRunID, LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return nil, sdk.WrapError(err, "Unable to reload workflow run id=%d", nr.WorkflowRunID)
+		}
+		r2, err := releaseConcurrencyGroup(ctx, db, store, proj, processedWorkflowRun)
+		if err != nil {
+			return nil, sdk.WrapError(err, "Unable to release concurrency group")
+		}
+		report, _ = report.Merge(r2, nil)
+
 		var hasMutex bool
 		var nodeName string
 
@@ -275,9 +286,19 @@ func execute(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *
 			where workflow.id = $1
 			and workflow_node_run.workflow_node_name = $2
 			and workflow_node_run.status = $3
+			and not exists (
+				select 1 from workflow_node_run_approval
+				where workflow_node_run_approval.workflow_node_run_id = workflow_node_run.id
+				and workflow_node_run_approval.status = $4
+			)
+			and not exists (
+				select 1 from workflow_run_tag
+				where workflow_run_tag.workflow_run_id = workflow_node_run.workflow_run_id
+				and workflow_run_tag.tag = $5
+			)
 			order by workflow_node_run.start asc
 			limit 1`
-			waitingRunID, errID := db.SelectInt(mutexQuery, updatedWorkflowRun.WorkflowID, nodeName, string(sdk.StatusWaiting))
+			waitingRunID, errID := db.SelectInt(mutexQuery, updatedWorkflowRun.WorkflowID, nodeName, string(sdk.StatusWaiting), sdk.ApprovalStatusPending, sdk.WorkflowRunTagConcurrencyQueued)
 			if errID != nil && errID != sql.ErrNoRows {
 				log.Error("workflow.execute> Unable to load mutex-locked workflow node run ID: %v", errID)
 				return report, nil
@@ -384,7 +405,7 @@ func addJobsToQueue(ctx context.Context, db gorp.SqlExecutor, stage *sdk.Stage,
 		}
 
 		// check that children actions used by job can be used by the project
-		if err := action.CheckChildrenForGroupIDsWithLoop(db, &job.Action, sdk.GroupsToIDs(groups)); err != nil {
+		if err := action.CheckChildrenForProjectWithLoop(db, &job.Action, wr.ProjectID, sdk.GroupsToIDs(groups)); err != nil {
 			spawnErrs.Append(err)
 		}
 
@@ -47,6 +47,7 @@ type NodeRun struct {
 	WorkflowID             sql.NullInt64  `db:"workflow_id"`
 	WorkflowRunID          int64          `db:"workflow_run_id"`
 	ApplicationID          sql.NullInt64  `db:"application_id"`
+	EnvironmentID          sql.NullInt64  `db:"environment_id"`
 	ID                     int64          `db:"id"`
 	WorkflowNodeID         int64          `db:"workflow_node_id"`
 	WorkflowNodeName       string         `db:"workflow_node_name"`
@@ -232,6 +233,12 @@ type dbNodeHookData sdk.NodeHook
 
 type dbAsCodeEvents sdk.AsCodeEvent
 
+// dbEphemeralEnvironment is a gorp wrapper around sdk.EphemeralEnvironment
+type dbEphemeralEnvironment sdk.EphemeralEnvironment
+
+// dbNodeRunApproval is a gorp wrapper around sdk.WorkflowNodeRunApproval
+type dbNodeRunApproval sdk.WorkflowNodeRunApproval
+
 func init() {
 	gorpmapping.Register(gorpmapping.New(Workflow{}, "workflow", true, "id"))
 	gorpmapping.Register(gorpmapping.New(Node{}, "workflow_node", true, "id"))
@@ -264,4 +271,6 @@ func init() {
 	gorpmapping.Register(gorpmapping.New(dbNodeOutGoingHookData{}, "w_node_outgoing_hook", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbNodeJoinData{}, "w_node_join", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbAsCodeEvents{}, "workflow_as_code_events", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbEphemeralEnvironment{}, "workflow_ephemeral_environment", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbNodeRunApproval{}, "workflow_node_run_approval", true, "id"))
 }
@@ -107,7 +107,7 @@ func computeRunStatus(status string, counter *statusCounter) {
 		counter.building++
 	case sdk.StatusFail.String():
 		counter.failed++
-	case sdk.StatusStopped.String():
+	case sdk.StatusStopped.String(), sdk.StatusBlockedByFreeze.String():
 		counter.stoppped++
 	case sdk.StatusSkipped.String():
 		counter.skipped++
@@ -21,6 +21,24 @@ type nodeRunContext struct {
 	NodeGroups         []sdk.GroupPermission
 }
 
+// newNodeRunContext builds the run context of a node from the workflow of the run
+func newNodeRunContext(wr *sdk.WorkflowRun, n *sdk.Node) nodeRunContext {
+	runContext := nodeRunContext{}
+	if n.Context.PipelineID != 0 {
+		runContext.Pipeline = wr.Workflow.Pipelines[n.Context.PipelineID]
+	}
+	if n.Context.ApplicationID != 0 {
+		runContext.Application = wr.Workflow.Applications[n.Context.ApplicationID]
+	}
+	if n.Context.EnvironmentID != 0 {
+		runContext.Environment = wr.Workflow.Environments[n.Context.EnvironmentID]
+	}
+	if n.Context.ProjectIntegrationID != 0 {
+		runContext.ProjectIntegration = wr.Workflow.ProjectIntegrations[n.Context.ProjectIntegrationID]
+	}
+	return runContext
+}
+
 func processWorkflowDataRun(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, wr *sdk.WorkflowRun, hookEvent *sdk.WorkflowNodeRunHookEvent, manual *sdk.WorkflowNodeRunManual, startingFromNode *int64) (*ProcessorReport, bool, error) {
 	//TRACEABILITY
 	var end func()
@@ -0,0 +1,124 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/interpolate"
+	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/slug"
+)
+
+const tagGitPullRequestID = "git.pr.id"
+
+// computeEphemeralEnvironmentParameters computes the name of the ephemeral environment of the node
+// and adds it in the build parameters of the node run. The name is a template interpolated with the
+// build parameters, git.pr.id is resolved from the pull requests of the repository.
+func computeEphemeralEnvironmentParameters(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, app sdk.Application, run *sdk.WorkflowNodeRun, n *sdk.Node, manual *sdk.WorkflowNodeRunManual) (string, bool, error) {
+	if n.Context.EphemeralEnvironment == nil {
+		return "", false, nil
+	}
+
+	if sdk.ParameterFind(&run.BuildParameters, tagGitPullRequestID) == nil && app.VCSServer != "" && run.VCSBranch != "" {
+		if prID, err := findPullRequestID(ctx, db, store, proj, app, run.VCSBranch); err != nil {
+			log.Warning("computeEphemeralEnvironmentParameters> unable to find pull request for branch %s: %v", run.VCSBranch, err)
+		} else if prID > 0 {
+			sdk.AddParameter(&run.BuildParameters, tagGitPullRequestID, sdk.StringParameter, strconv.Itoa(prID))
+		}
+	}
+
+	name, err := interpolate.Do(n.Context.EphemeralEnvironment.Name, sdk.ParametersToMap(run.BuildParameters))
+	if err != nil {
+		return "", false, sdk.WrapError(err, "unable to interpolate ephemeral environment name %s", n.Context.EphemeralEnvironment.Name)
+	}
+	name = slug.Convert(name)
+	if name == "" {
+		return "", false, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid empty ephemeral environment name for node %s", n.Name)
+	}
+
+	var teardown bool
+	if manual != nil {
+		if payload, ok := manual.Payload.(map[string]interface{}); ok {
+			teardown = fmt.Sprintf("%v", payload[sdk.EphemeralEnvironmentTeardownParam]) == "true"
+		}
+	}
+
+	sdk.ParameterAddOrSetValue(&run.BuildParameters, sdk.EphemeralEnvironmentNameParam, sdk.StringParameter, name)
+	sdk.ParameterAddOrSetValue(&run.BuildParameters, sdk.EphemeralEnvironmentTeardownParam, sdk.StringParameter, strconv.FormatBool(teardown))
+
+	return name, teardown, nil
+}
+
+func findPullRequestID(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, app sdk.Application, branch string) (int, error) {
+	vcsServer := repositoriesmanager.GetProjectVCSServer(proj, app.VCSServer)
+	if vcsServer == nil {
+		return 0, nil
+	}
+	client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, vcsServer)
+	if err != nil {
+		return 0, sdk.WrapError(err, "cannot get client")
+	}
+	prs, err := client.PullRequests(ctx, app.RepositoryFullname)
+	if err != nil {
+		return 0, sdk.WrapError(err, "unable to get pull requests on repo %s", app.RepositoryFullname)
+	}
+	for _, pr := range prs {
+		if pr.Head.Branch.DisplayID == branch && !pr.Merged && !pr.Closed {
+			return pr.ID, nil
+		}
+	}
+	return 0, nil
+}
+
+// upsertEphemeralEnvironment keeps track of the ephemeral environment deployed or tore down by the node run
+func upsertEphemeralEnvironment(db gorp.SqlExecutor, wr *sdk.WorkflowRun, n *sdk.Node, run *sdk.WorkflowNodeRun, name string, teardown bool) error {
+	env, err := LoadEphemeralEnvironmentByName(db, wr.WorkflowID, n.ID, name)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return err
+	}
+	if env == nil {
+		if teardown {
+			return nil
+		}
+		env = &sdk.EphemeralEnvironment{
+			ProjectID:      wr.ProjectID,
+			WorkflowID:     wr.WorkflowID,
+			WorkflowNodeID: n.ID,
+			Name:           name,
+		}
+	}
+
+	env.ApplicationID = n.Context.ApplicationID
+	env.ProjectIntegrationID = n.Context.ProjectIntegrationID
+	env.Branch = run.VCSBranch
+	if prID := sdk.ParameterFind(&run.BuildParameters, tagGitPullRequestID); prID != nil {
+		env.PullRequestID, _ = strconv.ParseInt(prID.Value, 10, 64)
+	}
+	env.WorkflowRunID = wr.ID
+	env.WorkflowNodeRunID = run.ID
+	if run.Manual != nil && !teardown {
+		env.TriggeredBy = run.Manual.User.Username
+	}
+
+	if teardown {
+		env.Status = sdk.EphemeralEnvironmentStatusTeardown
+	} else {
+		env.Status = sdk.EphemeralEnvironmentStatusActive
+		env.Expire = time.Time{}
+		if ttl := n.Context.EphemeralEnvironment.TTL; ttl > 0 {
+			env.Expire = time.Now().Add(time.Duration(ttl) * time.Minute)
+		}
+	}
+
+	if env.ID == 0 {
+		return InsertEphemeralEnvironment(db, env)
+	}
+	return UpdateEphemeralEnvironment(db, env)
+}
@@ -0,0 +1,50 @@
+package workflow
+
+import (
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/environment"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// checkFreezeWindows blocks the node run if a freeze window of its environment is open. Users allowed to
+// override the window can still run the node manually by asking for it.
+func checkFreezeWindows(db gorp.SqlExecutor, proj *sdk.Project, wr *sdk.WorkflowRun, n *sdk.Node, run *sdk.WorkflowNodeRun, manual *sdk.WorkflowNodeRunManual) error {
+	windows, err := environment.LoadFreezeWindowsByEnvironment(db, proj.ID, n.Context.EnvironmentID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, f := range windows {
+		active, err := f.IsActive(now)
+		if err != nil {
+			log.Warning("checkFreezeWindows> invalid freeze window %s on project %s: %v", f.Name, proj.Key, err)
+			continue
+		}
+		if !active {
+			continue
+		}
+
+		if manual != nil && manual.OverrideFreeze && f.CanOverride(&manual.User) {
+			AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+				ID:   sdk.MsgWorkflowNodeFreezeOverridden.ID,
+				Args: []interface{}{f.Name, manual.User.Username, n.Name},
+			})
+			continue
+		}
+
+		log.Debug("Noderun %s processed but not executed because of freeze window %s", n.Name, f.Name)
+		run.Status = sdk.StatusBlockedByFreeze.String()
+		run.Done = now
+		AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+			ID:   sdk.MsgWorkflowNodeBlockedByFreeze.ID,
+			Args: []interface{}{n.Name, f.Name, f.Reason},
+		})
+		return nil
+	}
+	return nil
+}
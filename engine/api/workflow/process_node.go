@@ -133,19 +133,7 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 
 	// BUILD RUN CONTEXT
 	// Process parameters for the jobs
-	runContext := nodeRunContext{}
-	if n.Context.PipelineID != 0 {
-		runContext.Pipeline = wr.Workflow.Pipelines[n.Context.PipelineID]
-	}
-	if n.Context.ApplicationID != 0 {
-		runContext.Application = wr.Workflow.Applications[n.Context.ApplicationID]
-	}
-	if n.Context.EnvironmentID != 0 {
-		runContext.Environment = wr.Workflow.Environments[n.Context.EnvironmentID]
-	}
-	if n.Context.ProjectIntegrationID != 0 {
-		runContext.ProjectIntegration = wr.Workflow.ProjectIntegrations[n.Context.ProjectIntegrationID]
-	}
+	runContext := newNodeRunContext(wr, n)
 
 	// NODE CONTEXT BUILD PARAMETER
 	computeNodeContextBuildParameters(ctx, proj, wr, run, n, runContext)
@@ -317,6 +305,15 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 		wr.Tag(tagEnvironment, wr.Workflow.Environments[n.Context.EnvironmentID].Name)
 	}
 
+	// Compute ephemeral environment
+	ephemeralName, ephemeralTeardown, errE := computeEphemeralEnvironmentParameters(ctx, db, store, proj, app, run, n, manual)
+	if errE != nil {
+		AddWorkflowRunInfo(wr, true, sdk.SpawnMsg{
+			ID:   sdk.MsgWorkflowError.ID,
+			Args: []interface{}{errE.Error()},
+		})
+	}
+
 	for _, info := range wr.Infos {
 		if info.IsError && info.SubNumber == wr.LastSubNumber {
 			run.Status = string(sdk.StatusFail)
@@ -325,9 +322,32 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 		}
 	}
 
+	// Check the freeze windows of the environment, the teardown of an ephemeral environment is never blocked
+	if run.Status == sdk.StatusWaiting.String() && n.Context.EnvironmentID != 0 && !ephemeralTeardown {
+		if err := checkFreezeWindows(db, proj, wr, n, run, manual); err != nil {
+			return nil, false, sdk.WrapError(err, "unable to check freeze windows")
+		}
+	}
+
+	// Apply the concurrency policy of the workflow when the run starts
+	var queued bool
+	if wr.Workflow.Concurrency != nil && run.Status == sdk.StatusWaiting.String() && subNumber == 0 && len(parents) == 0 && n.ID == wr.Workflow.WorkflowData.Node.ID {
+		var err error
+		queued, err = processStartConcurrency(ctx, db, wr, run)
+		if err != nil {
+			return nil, false, sdk.WrapError(err, "unable to process concurrency group")
+		}
+	}
+
 	if err := insertWorkflowNodeRun(db, run); err != nil {
 		return nil, false, sdk.WrapError(err, "unable to insert run (node id : %d, node name : %s, subnumber : %d)", run.WorkflowNodeID, run.WorkflowNodeName, run.SubNumber)
 	}
+
+	if ephemeralName != "" {
+		if err := upsertEphemeralEnvironment(db, wr, n, run, ephemeralName, ephemeralTeardown); err != nil {
+			return nil, false, sdk.WrapError(err, "unable to save ephemeral environment %s", ephemeralName)
+		}
+	}
 	wr.LastExecution = time.Now()
 
 	buildParameters := sdk.ParametersToMap(run.BuildParameters)
@@ -360,22 +380,31 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 		return nil, false, sdk.WrapError(err, "unable to update workflow run")
 	}
 
+	//The node run is blocked by a freeze window, it won't be executed
+	if run.Status == sdk.StatusBlockedByFreeze.String() {
+		return report, true, nil
+	}
+
+	//The run is queued in its concurrency group, the node run will be executed when the group is released
+	if queued {
+		return report, true, nil
+	}
+
+	//Check the approval gate, the node run waits until it is reviewed. The teardown of an ephemeral environment is never held.
+	if n.Context.ApprovalGate != nil && run.Status == sdk.StatusWaiting.String() && !ephemeralTeardown {
+		if err := requestNodeRunApproval(ctx, db, wr, n, run); err != nil {
+			return nil, false, sdk.WrapError(err, "unable to request approval")
+		}
+		return report, true, nil
+	}
+
 	//Check the context.mutex to know if we are allowed to run it
 	if n.Context.Mutex {
-		//Check if there are builing workflownoderun with the same workflow_node_name for the same workflow
-		mutexQuery := `select count(1)
-		from workflow_node_run
-		join workflow_run on workflow_run.id = workflow_node_run.workflow_run_id
-		join workflow on workflow.id = workflow_run.workflow_id
-		where workflow.id = $1
-		and workflow_node_run.id <> $2
-		and workflow_node_run.workflow_node_name = $3
-		and workflow_node_run.status = $4`
-		nbMutex, err := db.SelectInt(mutexQuery, n.WorkflowID, run.ID, n.Name, string(sdk.StatusBuilding))
+		locked, err := isNodeMutexLocked(db, n, run.ID)
 		if err != nil {
-			return nil, false, sdk.WrapError(err, "unable to check mutexes")
+			return nil, false, err
 		}
-		if nbMutex > 0 {
+		if locked {
 			log.Debug("Noderun %s processed but not executed because of mutex", n.Name)
 			AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
 				ID:   sdk.MsgWorkflowNodeMutex.ID,
@@ -401,6 +430,23 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 	return report, true, nil
 }
 
+// isNodeMutexLocked checks if there are building workflow node runs with the same workflow_node_name for the same workflow
+func isNodeMutexLocked(db gorp.SqlExecutor, n *sdk.Node, nodeRunID int64) (bool, error) {
+	mutexQuery := `select count(1)
+	from workflow_node_run
+	join workflow_run on workflow_run.id = workflow_node_run.workflow_run_id
+	join workflow on workflow.id = workflow_run.workflow_id
+	where workflow.id = $1
+	and workflow_node_run.id <> $2
+	and workflow_node_run.workflow_node_name = $3
+	and workflow_node_run.status = $4`
+	nbMutex, err := db.SelectInt(mutexQuery, n.WorkflowID, nodeRunID, n.Name, string(sdk.StatusBuilding))
+	if err != nil {
+		return false, sdk.WrapError(err, "unable to check mutexes")
+	}
+	return nbMutex > 0, nil
+}
+
 func getParentsStatus(wr *sdk.WorkflowRun, parents []*sdk.WorkflowNodeRun) string {
 	for _, p := range parents {
 		for _, v := range wr.WorkflowNodeRuns {
@@ -447,6 +493,9 @@ func createWorkflowNodeRun(wr *sdk.WorkflowRun, n *sdk.Node, parents []*sdk.Work
 	if n.Context.ApplicationID != 0 {
 		run.ApplicationID = n.Context.ApplicationID
 	}
+	if n.Context.EnvironmentID != 0 {
+		run.EnvironmentID = n.Context.EnvironmentID
+	}
 
 	parentsIDs := make([]int64, len(parents))
 	for i := range parents {
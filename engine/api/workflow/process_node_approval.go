@@ -0,0 +1,181 @@
+package workflow
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// requestNodeRunApproval creates the pending approval request of a node run which has an approval gate.
+// The node run stays at status Waiting until the request is approved, rejected or expired.
+func requestNodeRunApproval(ctx context.Context, db gorp.SqlExecutor, wr *sdk.WorkflowRun, n *sdk.Node, run *sdk.WorkflowNodeRun) error {
+	token, err := sdk.GenerateHash()
+	if err != nil {
+		return sdk.WrapError(err, "unable to generate approval token")
+	}
+
+	now := time.Now()
+	a := sdk.WorkflowNodeRunApproval{
+		WorkflowID:        wr.WorkflowID,
+		WorkflowRunID:     wr.ID,
+		WorkflowNodeRunID: run.ID,
+		WorkflowNodeName:  n.Name,
+		Status:            sdk.ApprovalStatusPending,
+		Token:             token,
+		Created:           now,
+		Gate:              *n.Context.ApprovalGate,
+	}
+	if p := sdk.ParameterFind(&run.BuildParameters, tagGitAuthor); p != nil {
+		a.Committer = p.Value
+	}
+	if a.Gate.Expiry > 0 {
+		a.Expire = now.Add(time.Duration(a.Gate.Expiry) * time.Minute)
+	}
+
+	if err := InsertNodeRunApproval(db, &a); err != nil {
+		return err
+	}
+
+	log.Debug("Noderun %s processed but not executed because it is waiting for approval", n.Name)
+	AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+		ID:   sdk.MsgWorkflowNodeApprovalWaiting.ID,
+		Args: []interface{}{n.Name, a.Gate.RequiredApprovals()},
+	})
+	return sdk.WrapError(UpdateWorkflowRun(ctx, db, wr), "unable to update workflow run")
+}
+
+// ReviewNodeRunApproval records the review of a user on the approval request of a node run.
+// Once enough approvals are collected the node run is executed, a rejection fails the node run.
+func ReviewNodeRunApproval(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, wr *sdk.WorkflowRun, nodeRun *sdk.WorkflowNodeRun, a *sdk.WorkflowNodeRunApproval, u *sdk.User, req sdk.WorkflowNodeRunApprovalRequest) (*ProcessorReport, error) {
+	var end func()
+	ctx, end = observability.Span(ctx, "workflow.ReviewNodeRunApproval",
+		observability.Tag(observability.TagWorkflowRun, wr.Number),
+		observability.Tag(observability.TagWorkflowNodeRun, nodeRun.ID),
+	)
+	defer end()
+
+	n := wr.Workflow.WorkflowData.NodeByID(nodeRun.WorkflowNodeID)
+	if n == nil {
+		return nil, sdk.WrapError(sdk.ErrWorkflowNodeNotFound, "unable to find node %d", nodeRun.WorkflowNodeID)
+	}
+	if n.Context != nil && n.Context.ApprovalGate != nil {
+		a.Gate = *n.Context.ApprovalGate
+	}
+
+	if err := a.AddReview(u, req.Approved, req.Comment, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := UpdateNodeRunApproval(db, a); err != nil {
+		return nil, err
+	}
+
+	report := new(ProcessorReport)
+	switch a.Status {
+	case sdk.ApprovalStatusApproved:
+		AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+			ID:   sdk.MsgWorkflowNodeApproved.ID,
+			Args: []interface{}{n.Name, u.Username},
+		})
+		if err := UpdateWorkflowRun(ctx, db, wr); err != nil {
+			return nil, sdk.WrapError(err, "unable to update workflow run")
+		}
+		return executeHeldNodeRun(ctx, db, store, proj, wr, n, nodeRun)
+	case sdk.ApprovalStatusRejected:
+		AddWorkflowRunInfo(wr, true, sdk.SpawnMsg{
+			ID:   sdk.MsgWorkflowNodeRejected.ID,
+			Args: []interface{}{n.Name, u.Username},
+		})
+		if err := UpdateWorkflowRun(ctx, db, wr); err != nil {
+			return nil, sdk.WrapError(err, "unable to update workflow run")
+		}
+		return failNodeRunApproval(ctx, db, store, proj, nodeRun)
+	}
+	return report, nil
+}
+
+// ExpireNodeRunApproval marks an approval request as expired and fails its node run
+func ExpireNodeRunApproval(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, a *sdk.WorkflowNodeRunApproval) (*ProcessorReport, error) {
+	a.Status = sdk.ApprovalStatusExpired
+	if err := UpdateNodeRunApproval(db, a); err != nil {
+		return nil, err
+	}
+
+	wr, err := LoadRunByID(db, a.WorkflowRunID, LoadRunOptions{})
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load workflow run %d", a.WorkflowRunID)
+	}
+	AddWorkflowRunInfo(wr, true, sdk.SpawnMsg{
+		ID:   sdk.MsgWorkflowNodeApprovalExpired.ID,
+		Args: []interface{}{a.WorkflowNodeName},
+	})
+	if err := UpdateWorkflowRun(ctx, db, wr); err != nil {
+		return nil, sdk.WrapError(err, "unable to update workflow run")
+	}
+
+	nodeRun, err := LoadNodeRunByID(db, a.WorkflowNodeRunID, LoadRunOptions{})
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load workflow node run %d", a.WorkflowNodeRunID)
+	}
+	return failNodeRunApproval(ctx, db, store, proj, nodeRun)
+}
+
+// executeHeldNodeRun executes a node run which was held at status Waiting, unless its mutex is locked.
+// In this case the node run will be executed when the mutex is released.
+func executeHeldNodeRun(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, wr *sdk.WorkflowRun, n *sdk.Node, nodeRun *sdk.WorkflowNodeRun) (*ProcessorReport, error) {
+	if nodeRun.Status != sdk.StatusWaiting.String() {
+		return new(ProcessorReport), nil
+	}
+
+	if n.Context.Mutex {
+		locked, err := isNodeMutexLocked(db, n, nodeRun.ID)
+		if err != nil {
+			return nil, err
+		}
+		if locked {
+			AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+				ID:   sdk.MsgWorkflowNodeMutex.ID,
+				Args: []interface{}{n.Name},
+			})
+			return new(ProcessorReport), sdk.WrapError(UpdateWorkflowRun(ctx, db, wr), "unable to update workflow run")
+		}
+	}
+
+	runContext := newNodeRunContext(wr, n)
+
+	report, err := execute(ctx, db, store, proj, nodeRun, runContext)
+	return report, sdk.WrapError(err, "unable to execute workflow node run %d", nodeRun.ID)
+}
+
+// failNodeRunApproval fails a node run which has not been approved, then reprocesses the workflow run
+func failNodeRunApproval(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, nodeRun *sdk.WorkflowNodeRun) (*ProcessorReport, error) {
+	report := new(ProcessorReport)
+	if sdk.StatusIsTerminated(nodeRun.Status) {
+		return report, nil
+	}
+
+	nodeRun.Status = sdk.StatusFail.String()
+	nodeRun.Done = time.Now()
+	for i := range nodeRun.Stages {
+		nodeRun.Stages[i].Status = sdk.StatusSkipped
+	}
+	if err := updateNodeRunStatusAndStage(db, nodeRun); err != nil {
+		return nil, sdk.WrapError(err, "unable to update node run %d at status %s", nodeRun.ID, nodeRun.Status)
+	}
+	report.Add(*nodeRun)
+
+	wr, err := LoadRunByID(db, nodeRun.WorkflowRunID, LoadRunOptions{})
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to reload workflow run %d", nodeRun.WorkflowRunID)
+	}
+	r1, _, err := processWorkflowDataRun(ctx, db, store, proj, wr, nil, nil, nil)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to reprocess workflow")
+	}
+	return report.Merge(r1, nil)
+}
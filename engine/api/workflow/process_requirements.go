@@ -51,7 +51,11 @@ func processNodeJobRunRequirements(db gorp.SqlExecutor, j sdk.Job, run *sdk.Work
 	var modelType string
 	if model != "" {
 		// Load the worker model
-		wm, err := worker.LoadWorkerModelByName(db, strings.Split(model, " ")[0])
+		modelName, modelVersion := sdk.ParseModelRequirement(model)
+		wm, err := worker.LoadWorkerModelByName(db, modelName)
+		if err == nil && modelVersion > 0 {
+			_, err = worker.LoadWorkerModelVersion(db, wm.ID, modelVersion)
+		}
 		if err != nil {
 			log.Error("getNodeJobRunRequirements> error while getting worker model %s: %v", model, err)
 			errm.Append(sdk.ErrNoWorkerModel)
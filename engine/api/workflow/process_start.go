@@ -3,11 +3,15 @@ package workflow
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-gorp/gorp"
 
 	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/interpolate"
 	"github.com/ovh/cds/sdk/log"
 )
 
@@ -150,3 +154,288 @@ func processAllJoins(ctx context.Context, db gorp.SqlExecutor, store cache.Store
 	}
 	return report, nil
 }
+
+// concurrencyGroupRun is a run of a concurrency group
+type concurrencyGroupRun struct {
+	ID     int64 `db:"id"`
+	Number int64 `db:"num"`
+}
+
+// loadConcurrencyGroupRuns loads the runs of a workflow at status Waiting or Building which have the given tag value.
+// If queued is true, only the runs queued in their concurrency group are loaded, otherwise they are excluded.
+func loadConcurrencyGroupRuns(db gorp.SqlExecutor, workflowID int64, tag, value string, queued bool) ([]concurrencyGroupRun, error) {
+	var exists = "not exists"
+	if queued {
+		exists = "exists"
+	}
+	query := fmt.Sprintf(`select workflow_run.id, workflow_run.num
+	from workflow_run
+	join workflow_run_tag on workflow_run_tag.workflow_run_id = workflow_run.id
+	where workflow_run.workflow_id = $1
+	and workflow_run_tag.tag = $2
+	and workflow_run_tag.value = $3
+	and workflow_run.status = ANY(string_to_array($4, ','))
+	and %s (
+		select 1 from workflow_run_tag queued_tag
+		where queued_tag.workflow_run_id = workflow_run.id
+		and queued_tag.tag = $5
+	)
+	order by workflow_run.num asc`, exists)
+
+	var runs []concurrencyGroupRun
+	statuses := strings.Join([]string{sdk.StatusWaiting.String(), sdk.StatusBuilding.String()}, ",")
+	if _, err := db.Select(&runs, query, workflowID, tag, value, statuses, sdk.WorkflowRunTagConcurrencyQueued); err != nil {
+		return nil, sdk.WrapError(err, "unable to load runs with tag %s=%s", tag, value)
+	}
+	return runs, nil
+}
+
+// concurrencyGroupKey returns the concurrency key computed when the run started
+func concurrencyGroupKey(wr *sdk.WorkflowRun) string {
+	for _, t := range wr.Tags {
+		if t.Tag == sdk.WorkflowRunTagConcurrency {
+			return t.Value
+		}
+	}
+	return ""
+}
+
+// lockConcurrencyGroups locks the workflow until the end of the transaction, so the runs of its concurrency
+// groups are checked and started or released one after the other
+func lockConcurrencyGroups(db gorp.SqlExecutor, workflowID int64) error {
+	if _, err := db.Exec("SELECT id FROM workflow WHERE id = $1 FOR UPDATE", workflowID); err != nil {
+		return sdk.WrapError(err, "unable to lock workflow %d", workflowID)
+	}
+	return nil
+}
+
+// processStartConcurrency applies the concurrency policy of the workflow when the root node of a run starts.
+// With the policy cancel-in-progress, the other runs of the concurrency group are marked as superseded, they are
+// stopped by StartWorkflowRun. With the other policies, it returns true if the run has to be queued.
+func processStartConcurrency(ctx context.Context, db gorp.SqlExecutor, wr *sdk.WorkflowRun, run *sdk.WorkflowNodeRun) (bool, error) {
+	c := wr.Workflow.Concurrency
+	key, err := interpolate.Do(c.Key, sdk.ParametersToMap(run.BuildParameters))
+	if err != nil {
+		return false, sdk.WrapError(err, "unable to interpolate concurrency key %s", c.Key)
+	}
+	if key == "" {
+		return false, nil
+	}
+	if err := lockConcurrencyGroups(db, wr.WorkflowID); err != nil {
+		return false, err
+	}
+	wr.Tag(sdk.WorkflowRunTagConcurrency, key)
+
+	runs, err := loadConcurrencyGroupRuns(db, wr.WorkflowID, sdk.WorkflowRunTagConcurrency, key, false)
+	if err != nil {
+		return false, err
+	}
+	others := make([]concurrencyGroupRun, 0, len(runs))
+	for _, r := range runs {
+		if r.ID != wr.ID {
+			others = append(others, r)
+		}
+	}
+	if len(others) == 0 {
+		return false, nil
+	}
+
+	if c.Policy != sdk.ConcurrencyPolicyCancelInProgress {
+		log.Debug("processStartConcurrency> workflow run %d queued in concurrency group %s", wr.Number, key)
+		wr.Tag(sdk.WorkflowRunTagConcurrencyQueued, "true")
+		AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+			ID:   sdk.MsgWorkflowRunQueued.ID,
+			Args: []interface{}{others[len(others)-1].Number, key},
+		})
+		return true, nil
+	}
+
+	for _, r := range others {
+		other, err := LoadRunByID(db, r.ID, LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return false, sdk.WrapError(err, "unable to load workflow run %d", r.ID)
+		}
+		other.Tag(sdk.WorkflowRunTagSupersededBy, strconv.FormatInt(wr.Number, 10))
+		AddWorkflowRunInfo(other, false, sdk.SpawnMsg{
+			ID:   sdk.MsgWorkflowRunSupersededBy.ID,
+			Args: []interface{}{wr.Number},
+		})
+		if err := UpdateWorkflowRun(ctx, db, other); err != nil {
+			return false, sdk.WrapError(err, "unable to update workflow run %d", other.Number)
+		}
+	}
+	return false, nil
+}
+
+// stopSupersededRuns stops the runs of the concurrency group which have been superseded by the given run
+func stopSupersededRuns(ctx context.Context, db *gorp.DbMap, store cache.Store, proj *sdk.Project, wr *sdk.WorkflowRun) (*ProcessorReport, error) {
+	report := new(ProcessorReport)
+	runs, err := loadConcurrencyGroupRuns(db, wr.WorkflowID, sdk.WorkflowRunTagSupersededBy, strconv.FormatInt(wr.Number, 10), false)
+	if err != nil {
+		return nil, err
+	}
+
+	spwnMsg := sdk.SpawnMsg{ID: sdk.MsgWorkflowRunSupersededBy.ID, Args: []interface{}{wr.Number}}
+	stopInfos := sdk.SpawnInfo{
+		APITime:    time.Now(),
+		RemoteTime: time.Now(),
+		Message:    spwnMsg,
+	}
+
+	for _, r := range runs {
+		other, err := LoadRunByID(db, r.ID, LoadRunOptions{})
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to load workflow run %d", r.ID)
+		}
+		for _, nodeRuns := range other.WorkflowNodeRuns {
+			for _, nodeRun := range nodeRuns {
+				if nodeRun.SubNumber != other.LastSubNumber || sdk.StatusIsTerminated(nodeRun.Status) {
+					continue
+				}
+				r1, err := StopWorkflowNodeRun(ctx, func() *gorp.DbMap { return db }, store, proj, nodeRun, stopInfos)
+				if err != nil {
+					return nil, sdk.WrapError(err, "unable to stop workflow node run %d", nodeRun.ID)
+				}
+				_, _ = report.Merge(r1, nil)
+			}
+		}
+
+		other, err = LoadRunByID(db, r.ID, LoadRunOptions{})
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to reload workflow run %d", r.ID)
+		}
+		if _, err := report.Merge(ResyncWorkflowRunStatus(db, other)); err != nil {
+			return nil, sdk.WrapError(err, "unable to resync workflow run %d", other.Number)
+		}
+	}
+	return report, nil
+}
+
+// releaseConcurrencyGroup starts the next queued run of the concurrency group once the given run is over.
+// With the policy skip-if-newer-pending, only the newest queued run is started, the older ones are skipped.
+func releaseConcurrencyGroup(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, wr *sdk.WorkflowRun) (*ProcessorReport, error) {
+	report := new(ProcessorReport)
+	c := wr.Workflow.Concurrency
+	if c == nil || c.Policy == sdk.ConcurrencyPolicyCancelInProgress || wr.TagExists(sdk.WorkflowRunTagConcurrencyQueued) {
+		return report, nil
+	}
+	key := concurrencyGroupKey(wr)
+	if key == "" {
+		return report, nil
+	}
+
+	for _, nodeRuns := range wr.WorkflowNodeRuns {
+		for _, nodeRun := range nodeRuns {
+			if !sdk.StatusIsTerminated(nodeRun.Status) {
+				return report, nil
+			}
+		}
+	}
+
+	if err := lockConcurrencyGroups(db, wr.WorkflowID); err != nil {
+		return nil, err
+	}
+
+	runs, err := loadConcurrencyGroupRuns(db, wr.WorkflowID, sdk.WorkflowRunTagConcurrency, key, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range runs {
+		if r.ID != wr.ID {
+			return report, nil
+		}
+	}
+
+	queued, err := loadConcurrencyGroupRuns(db, wr.WorkflowID, sdk.WorkflowRunTagConcurrency, key, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(queued) == 0 {
+		return report, nil
+	}
+
+	next := queued[0]
+	if c.Policy == sdk.ConcurrencyPolicySkipIfNewerPending {
+		next = queued[len(queued)-1]
+		for _, r := range queued[:len(queued)-1] {
+			r1, err := skipQueuedRun(ctx, db, r.ID, next.Number)
+			if err != nil {
+				return nil, err
+			}
+			_, _ = report.Merge(r1, nil)
+		}
+	}
+
+	r1, err := startQueuedRun(ctx, db, store, proj, next.ID, wr.Number, key)
+	if err != nil {
+		return nil, err
+	}
+	return report.Merge(r1, nil)
+}
+
+// skipQueuedRun skips the root node run of a queued run superseded by a newer queued run
+func skipQueuedRun(ctx context.Context, db gorp.SqlExecutor, id int64, supersededBy int64) (*ProcessorReport, error) {
+	report := new(ProcessorReport)
+	wr, err := LoadRunByID(db, id, LoadRunOptions{})
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load workflow run %d", id)
+	}
+
+	rootRuns := wr.WorkflowNodeRuns[wr.Workflow.WorkflowData.Node.ID]
+	if len(rootRuns) > 0 && rootRuns[0].Status == sdk.StatusWaiting.String() {
+		nodeRun := &rootRuns[0]
+		nodeRun.Status = sdk.StatusSkipped.String()
+		nodeRun.Done = time.Now()
+		for i := range nodeRun.Stages {
+			nodeRun.Stages[i].Status = sdk.StatusSkipped
+		}
+		if err := updateNodeRunStatusAndStage(db, nodeRun); err != nil {
+			return nil, sdk.WrapError(err, "unable to update node run %d at status %s", nodeRun.ID, nodeRun.Status)
+		}
+		report.Add(*nodeRun)
+	}
+
+	wr.RemoveTag(sdk.WorkflowRunTagConcurrencyQueued)
+	wr.Tag(sdk.WorkflowRunTagSupersededBy, strconv.FormatInt(supersededBy, 10))
+	AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+		ID:   sdk.MsgWorkflowRunSupersededBy.ID,
+		Args: []interface{}{supersededBy},
+	})
+	if err := UpdateWorkflowRun(ctx, db, wr); err != nil {
+		return nil, sdk.WrapError(err, "unable to update workflow run %d", wr.Number)
+	}
+	return report.Merge(ResyncWorkflowRunStatus(db, wr))
+}
+
+// startQueuedRun executes the root node run of a run which was queued in its concurrency group
+func startQueuedRun(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, id int64, releasedBy int64, key string) (*ProcessorReport, error) {
+	wr, err := LoadRunByID(db, id, LoadRunOptions{})
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load workflow run %d", id)
+	}
+
+	log.Debug("startQueuedRun> workflow run %d dequeued from concurrency group %s", wr.Number, key)
+	wr.RemoveTag(sdk.WorkflowRunTagConcurrencyQueued)
+	AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+		ID:   sdk.MsgWorkflowRunDequeued.ID,
+		Args: []interface{}{releasedBy, key},
+	})
+	if err := UpdateWorkflowRun(ctx, db, wr); err != nil {
+		return nil, sdk.WrapError(err, "unable to update workflow run %d", wr.Number)
+	}
+
+	rootRuns := wr.WorkflowNodeRuns[wr.Workflow.WorkflowData.Node.ID]
+	if len(rootRuns) == 0 || rootRuns[0].Status != sdk.StatusWaiting.String() {
+		return new(ProcessorReport), nil
+	}
+	nodeRun := &rootRuns[0]
+	n := wr.Workflow.WorkflowData.NodeByID(nodeRun.WorkflowNodeID)
+	if n == nil {
+		return nil, sdk.WrapError(sdk.ErrWorkflowNodeNotFound, "unable to find node %d", nodeRun.WorkflowNodeID)
+	}
+
+	if n.Context.ApprovalGate != nil {
+		return new(ProcessorReport), requestNodeRunApproval(ctx, db, wr, n, nodeRun)
+	}
+	return executeHeldNodeRun(ctx, db, store, proj, wr, n, nodeRun)
+}
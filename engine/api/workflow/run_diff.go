@@ -0,0 +1,41 @@
+package workflow
+
+import (
+	"context"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// DiffRuns compares two runs of a workflow, with the commits between the hashes of their root node runs
+func DiffRuns(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, from, to *sdk.WorkflowRun) sdk.WorkflowRunDiff {
+	diff := sdk.DiffWorkflowRuns(*from, *to)
+
+	fromRoot, toRoot := from.RootRun(), to.RootRun()
+	if fromRoot == nil || toRoot == nil || toRoot.VCSServer == "" || fromRoot.VCSRepository != toRoot.VCSRepository ||
+		diff.FromHash == "" || diff.ToHash == "" || diff.FromHash == diff.ToHash {
+		return diff
+	}
+
+	vcsServer := repositoriesmanager.GetProjectVCSServer(proj, toRoot.VCSServer)
+	if vcsServer == nil {
+		return diff
+	}
+	client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, vcsServer)
+	if err != nil {
+		log.Warning("DiffRuns> cannot get repo client %s: %v", toRoot.VCSServer, err)
+		return diff
+	}
+
+	commits, err := client.CommitsBetweenRefs(ctx, toRoot.VCSRepository, diff.FromHash, diff.ToHash)
+	if err != nil {
+		log.Warning("DiffRuns> unable to get commits between %s and %s on %s: %v", diff.FromHash, diff.ToHash, toRoot.VCSRepository, err)
+		return diff
+	}
+	diff.Commits = commits
+	return diff
+}
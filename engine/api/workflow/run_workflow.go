@@ -158,6 +158,15 @@ func StartWorkflowRun(ctx context.Context, db *gorp.DbMap, store cache.Store, p
 	if err := tx.Commit(); err != nil {
 		return nil, sdk.WrapError(err, "Unable to commit transaction")
 	}
+
+	//Stop the runs superseded in the concurrency group
+	if wr.Workflow.Concurrency != nil && wr.Workflow.Concurrency.Policy == sdk.ConcurrencyPolicyCancelInProgress {
+		r1, err := stopSupersededRuns(ctx, db, store, p, wr)
+		if err != nil {
+			return nil, sdk.WrapError(err, "Unable to stop superseded runs")
+		}
+		report.Merge(r1, nil) // nolint
+	}
 	return report, nil
 }
 
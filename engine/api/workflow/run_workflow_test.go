@@ -2,6 +2,7 @@ package workflow_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -494,7 +495,7 @@ func TestManualRun3(t *testing.T) {
 		}
 
 		//TakeNodeJobRun
-		j, _, _ = workflow.TakeNodeJobRun(context.TODO(), func() *gorp.DbMap { return db }, db, cache, proj, j.ID, "model", "worker", "1", []sdk.SpawnInfo{
+		j, _, _ = workflow.TakeNodeJobRun(context.TODO(), func() *gorp.DbMap { return db }, db, cache, proj, j.ID, "model", 0, "worker", "1", []sdk.SpawnInfo{
 			{
 				APITime:    time.Now(),
 				RemoteTime: time.Now(),
@@ -824,3 +825,129 @@ func TestNoJob(t *testing.T) {
 
 	assert.Equal(t, sdk.StatusSuccess.String(), nodeRun.Status)
 }
+
+func TestConcurrencyGroupReleasedAfterChildNode(t *testing.T) {
+	db, cache, end := test.SetupPG(t, bootstrap.InitiliazeDB)
+	defer end()
+	u, _ := assets.InsertAdminUser(db)
+	key := sdk.RandomString(10)
+	proj := assets.InsertTestProject(t, db, cache, key, key, u)
+	ctx := context.Background()
+
+	pips := make([]sdk.Pipeline, 2)
+	for i := range pips {
+		pips[i] = sdk.Pipeline{
+			ProjectID:  proj.ID,
+			ProjectKey: proj.Key,
+			Name:       fmt.Sprintf("pip%d", i+1),
+		}
+		test.NoError(t, pipeline.InsertPipeline(db, cache, proj, &pips[i], u))
+		s := sdk.NewStage("stage 1")
+		s.Enabled = true
+		s.PipelineID = pips[i].ID
+		pipeline.InsertStage(db, s)
+		j := &sdk.Job{
+			Enabled: true,
+			Action: sdk.Action{
+				Enabled: true,
+			},
+		}
+		pipeline.InsertJob(db, j, s.ID, &pips[i])
+	}
+
+	w := sdk.Workflow{
+		Name:       "test_concurrency",
+		ProjectID:  proj.ID,
+		ProjectKey: proj.Key,
+		Concurrency: &sdk.WorkflowConcurrency{
+			Key:    "group",
+			Policy: sdk.ConcurrencyPolicyQueue,
+		},
+		WorkflowData: &sdk.WorkflowData{
+			Node: sdk.Node{
+				Name: "node1",
+				Ref:  "node1",
+				Type: sdk.NodeTypePipeline,
+				Context: &sdk.NodeContext{
+					PipelineID: pips[0].ID,
+				},
+				Triggers: []sdk.NodeTrigger{
+					{
+						ChildNode: sdk.Node{
+							Name: "node2",
+							Ref:  "node2",
+							Type: sdk.NodeTypePipeline,
+							Context: &sdk.NodeContext{
+								PipelineID: pips[1].ID,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	(&w).RetroMigrate()
+	proj, _ = project.LoadByID(db, cache, proj.ID, u, project.LoadOptions.WithApplications, project.LoadOptions.WithPipelines, project.LoadOptions.WithEnvironments, project.LoadOptions.WithGroups, project.LoadOptions.WithVariablesWithClearPassword, project.LoadOptions.WithKeys)
+	test.NoError(t, workflow.Insert(db, cache, &w, proj, u))
+
+	w1, err := workflow.Load(ctx, db, cache, proj, "test_concurrency", u, workflow.LoadOptions{
+		DeepPipeline: true,
+	})
+	test.NoError(t, err)
+
+	runs := make([]*sdk.WorkflowRun, 2)
+	for i := range runs {
+		wr, err := workflow.CreateRun(db, w1, nil, u)
+		test.NoError(t, err)
+		wr.Workflow = *w1
+		_, err = workflow.StartWorkflowRun(ctx, db, cache, proj, wr, &sdk.WorkflowRunPostHandlerOption{
+			Manual: &sdk.WorkflowNodeRunManual{User: *u},
+		}, u, nil)
+		test.NoError(t, err)
+		runs[i] = wr
+	}
+
+	isQueued := func(id int64) bool {
+		wr, err := workflow.LoadRunByID(db, id, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		test.NoError(t, err)
+		return wr.TagExists(sdk.WorkflowRunTagConcurrencyQueued)
+	}
+	assert.True(t, isQueued(runs[1].ID))
+
+	// runJobs runs with success the jobs of the first run in the queue
+	runJobs := func() int {
+		jobs, err := workflow.LoadNodeJobRunQueue(ctx, db, cache, workflow.QueueFilter{
+			Rights:   permission.PermissionReadExecute,
+			GroupsID: []int64{proj.ProjectGroups[0].Group.ID},
+			User:     u,
+		})
+		test.NoError(t, err)
+
+		var count int
+		for i := range jobs {
+			j := &jobs[i]
+			nodeRun, err := workflow.LoadNodeRunByID(db, j.WorkflowNodeRunID, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+			test.NoError(t, err)
+			if nodeRun.WorkflowRunID != runs[0].ID {
+				continue
+			}
+			_, err = workflow.BookNodeJobRun(cache, j.ID, &sdk.Service{Name: "Hatchery", ID: 1})
+			test.NoError(t, err)
+			j, _, err = workflow.TakeNodeJobRun(ctx, func() *gorp.DbMap { return db }, db, cache, proj, j.ID, "model", 0, "worker", "1", nil)
+			test.NoError(t, err)
+			_, err = workflow.UpdateNodeJobRunStatus(ctx, func() *gorp.DbMap { return db }, db, cache, proj, j, sdk.StatusSuccess)
+			test.NoError(t, err)
+			count++
+		}
+		return count
+	}
+
+	// the root node is over but its child node has been triggered, the group is not released
+	assert.Equal(t, 1, runJobs())
+	assert.True(t, isQueued(runs[1].ID))
+
+	// the child node is over, the queued run is started
+	assert.Equal(t, 1, runJobs())
+	assert.False(t, isQueued(runs[1].ID))
+}
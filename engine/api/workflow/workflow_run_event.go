@@ -335,6 +335,24 @@ func sendVCSEventStatus(ctx context.Context, db gorp.SqlExecutor, store cache.St
 				}
 			}
 		}
+
+		//Send the URL of the ephemeral environment on pull request
+		urlParam := sdk.ParameterFind(&nodeRun.BuildParameters, sdk.EphemeralEnvironmentURLVariable)
+		if nodeRun.Status == sdk.StatusSuccess.String() && urlParam != nil && urlParam.Value != "" {
+			var envName string
+			if nameParam := sdk.ParameterFind(&nodeRun.BuildParameters, sdk.EphemeralEnvironmentNameParam); nameParam != nil {
+				envName = nameParam.Value
+			}
+			for _, pr := range prs {
+				if pr.Head.Branch.DisplayID == nodeRun.VCSBranch && !pr.Merged && !pr.Closed {
+					comment := fmt.Sprintf("Ephemeral environment %s deployed by %s is available at %s", envName, nodeRun.WorkflowNodeName, urlParam.Value)
+					if err := client.PullRequestComment(ctx, app.RepositoryFullname, pr.ID, comment); err != nil {
+						log.Error("sendVCSEventStatus> unable to send ephemeral environment URL on PR: %v", err)
+					}
+					break
+				}
+			}
+		}
 	}
 
 	return nil
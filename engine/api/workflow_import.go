@@ -295,7 +295,8 @@ func (api *API) postWorkflowPushHandler() service.Handler {
 			project.LoadOptions.WithEnvironments,
 			project.LoadOptions.WithPipelines,
 			project.LoadOptions.WithApplicationWithDeploymentStrategies,
-			project.LoadOptions.WithIntegrations)
+			project.LoadOptions.WithIntegrations,
+			project.LoadOptions.WithClearKeys)
 		if errp != nil {
 			return sdk.WrapError(errp, "postWorkflowPushHandler> Cannot load project %s", key)
 		}
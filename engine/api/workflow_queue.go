@@ -12,12 +12,14 @@ import (
 	"github.com/ovh/venom"
 	"github.com/sguiheux/go-coverage"
 
+	"github.com/ovh/cds/engine/api/action"
 	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/engine/api/event"
 	"github.com/ovh/cds/engine/api/group"
 	"github.com/ovh/cds/engine/api/metrics"
 	"github.com/ovh/cds/engine/api/observability"
 	"github.com/ovh/cds/engine/api/permission"
+	"github.com/ovh/cds/engine/api/pipeline"
 	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/repositoriesmanager"
 	"github.com/ovh/cds/engine/api/services"
@@ -26,6 +28,7 @@ import (
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/cdsclient"
+	"github.com/ovh/cds/sdk/exportentities"
 	"github.com/ovh/cds/sdk/log"
 )
 
@@ -53,12 +56,14 @@ func (api *API) postTakeWorkflowJobHandler() service.Handler {
 
 		//Load worker model
 		workerModel := getWorker(ctx).Name
+		var workerModelVersion int64
 		if getWorker(ctx).ModelID != 0 {
 			wm, errModel := worker.LoadWorkerModelByID(api.mustDB(), getWorker(ctx).ModelID)
 			if errModel != nil {
 				return sdk.ErrNoWorkerModel
 			}
 			workerModel = wm.Name
+			workerModelVersion = getWorker(ctx).ModelVersion
 		}
 
 		pbj, errl := workflow.LoadNodeJobRun(api.mustDB(), api.Cache, id)
@@ -94,7 +99,7 @@ func (api *API) postTakeWorkflowJobHandler() service.Handler {
 		}
 
 		pbji := &sdk.WorkflowNodeJobRunData{}
-		report, errT := takeJob(ctx, api.mustDB, api.Cache, p, id, takeForm, workerModel, pbji)
+		report, errT := takeJob(ctx, api.mustDB, api.Cache, p, id, takeForm, workerModel, workerModelVersion, pbji)
 		if errT != nil {
 			return sdk.WrapError(errT, "Cannot takeJob nodeJobRunID:%d", id)
 		}
@@ -106,7 +111,7 @@ func (api *API) postTakeWorkflowJobHandler() service.Handler {
 	}
 }
 
-func takeJob(ctx context.Context, dbFunc func() *gorp.DbMap, store cache.Store, p *sdk.Project, id int64, takeForm *sdk.WorkerTakeForm, workerModel string, wnjri *sdk.WorkflowNodeJobRunData) (*workflow.ProcessorReport, error) {
+func takeJob(ctx context.Context, dbFunc func() *gorp.DbMap, store cache.Store, p *sdk.Project, id int64, takeForm *sdk.WorkerTakeForm, workerModel string, workerModelVersion int64, wnjri *sdk.WorkflowNodeJobRunData) (*workflow.ProcessorReport, error) {
 	// Start a tx
 	tx, errBegin := dbFunc().Begin()
 	if errBegin != nil {
@@ -133,7 +138,7 @@ func takeJob(ctx context.Context, dbFunc func() *gorp.DbMap, store cache.Store,
 	}
 
 	//Take node job run
-	job, report, errTake := workflow.TakeNodeJobRun(ctx, dbFunc, tx, store, p, id, workerModel, getWorker(ctx).Name, getWorker(ctx).ID, infos)
+	job, report, errTake := workflow.TakeNodeJobRun(ctx, dbFunc, tx, store, p, id, workerModel, workerModelVersion, getWorker(ctx).Name, getWorker(ctx).ID, infos)
 	if errTake != nil {
 		return nil, sdk.WrapError(errTake, "Cannot take job %d", id)
 	}
@@ -339,10 +344,179 @@ func (api *API) postVulnerabilityReportHandler() service.Handler {
 		if err := workflow.HandleVulnerabilityReport(ctx, tx, api.Cache, p, nr, report); err != nil {
 			return sdk.WrapError(err, "Unable to handle report")
 		}
-		return tx.Commit()
+
+		policy, err := project.LoadSecurityPolicy(tx, p.ID)
+		if err != nil {
+			return err
+		}
+		violations, err := workflow.CheckVulnerabilitiesPolicy(tx, policy, nr, report.Vulnerabilities)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to check security policy")
+		}
+		policyReport, err := api.securityPolicyViolations(ctx, tx, p, nr, id, policy, violations)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+		return service.WriteJSON(w, policyReport, http.StatusOK)
+	}
+}
+
+func (api *API) postSBOMReportHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, err := requestVarInt(r, "permID")
+		if err != nil {
+			return sdk.WrapError(err, "Invalid id")
+		}
+		nr, err := workflow.LoadNodeRunByNodeJobID(api.mustDB(), id, workflow.LoadRunOptions{
+			DisableDetailledNodeRun: true,
+		})
+		if err != nil {
+			return sdk.WrapError(err, "Unable to save SBOM")
+		}
+		if nr.ApplicationID == 0 {
+			return sdk.WrapError(sdk.ErrApplicationNotFound, "There is no application linked")
+		}
+
+		var report sdk.SBOMWorkerReport
+		if err := service.UnmarshalBody(r, &report); err != nil {
+			return sdk.WrapError(err, "Unable to read body")
+		}
+
+		p, err := project.LoadProjectByNodeJobRunID(ctx, api.mustDB(), api.Cache, id, deprecatedGetUser(ctx))
+		if err != nil {
+			return sdk.WrapError(err, "Cannot load project by nodeJobRunID:%d", id)
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WrapError(err, "Unable to start transaction")
+		}
+		defer tx.Rollback() // nolint
+
+		sbom, err := workflow.HandleSBOMReport(ctx, tx, api.Cache, p, nr, report)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to handle SBOM")
+		}
+
+		policy, err := project.LoadSecurityPolicy(tx, p.ID)
+		if err != nil {
+			return err
+		}
+		policyReport, err := api.securityPolicyViolations(ctx, tx, p, nr, id, policy, policy.CheckComponents(sbom.Components))
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+		return service.WriteJSON(w, policyReport, http.StatusOK)
 	}
 }
 
+// postWorkflowJobStagesHandler appends the stages generated by a job to its node run
+func (api *API) postWorkflowJobStagesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, err := requestVarInt(r, "permID")
+		if err != nil {
+			return sdk.WrapError(err, "Invalid id")
+		}
+
+		var epip exportentities.PipelineV1
+		if err := service.UnmarshalBody(r, &epip); err != nil {
+			return sdk.WrapError(err, "Unable to read body")
+		}
+		pip, err := epip.Pipeline()
+		if err != nil {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid generated stages: %v", err)
+		}
+
+		p, err := project.LoadProjectByNodeJobRunID(ctx, api.mustDB(), api.Cache, id, deprecatedGetUser(ctx), project.LoadOptions.WithGroups, project.LoadOptions.WithClearKeys)
+		if err != nil {
+			return sdk.WrapError(err, "Cannot load project by nodeJobRunID:%d", id)
+		}
+		groupIDs := make([]int64, 0, len(p.ProjectGroups)+1)
+		groupIDs = append(groupIDs, group.SharedInfraGroup.ID)
+		for i := range p.ProjectGroups {
+			groupIDs = append(groupIDs, p.ProjectGroups[i].Group.ID)
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WrapError(err, "Unable to start transaction")
+		}
+		defer tx.Rollback() // nolint
+
+		job, err := workflow.LoadNodeJobRun(tx, api.Cache, id)
+		if err != nil {
+			return err
+		}
+		if job.Status != sdk.StatusBuilding.String() {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "job %d is not building", id)
+		}
+
+		// check and load the steps of the generated jobs as for a pipeline import
+		for i := range pip.Stages {
+			for j := range pip.Stages[i].Jobs {
+				genJob := &pip.Stages[i].Jobs[j]
+				if err := action.ResolveRepositoryChildren(ctx, tx, api.Cache, *p, &genJob.Action, groupIDs); err != nil {
+					return sdk.WrapError(err, "unable to resolve actions of job %s", genJob.Action.Name)
+				}
+				if err := pipeline.CheckJob(tx, genJob); err != nil {
+					return err
+				}
+				if err := genJob.Action.IsValid(); err != nil {
+					return err
+				}
+				if err := action.LoadStepsForProject(tx, &genJob.Action, p.ID, groupIDs); err != nil {
+					return err
+				}
+			}
+		}
+
+		stages, err := workflow.AppendGeneratedStages(ctx, tx, job, pip.Stages)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+		return service.WriteJSON(w, stages, http.StatusOK)
+	}
+}
+
+// securityPolicyViolations adds the violations in the spawn infos of the job and comments the pull request
+// of the node run if the policy asks for it
+func (api *API) securityPolicyViolations(ctx context.Context, db gorp.SqlExecutor, p *sdk.Project, nr *sdk.WorkflowNodeRun, jobID int64, policy sdk.ProjectSecurityPolicy, violations []string) (sdk.SecurityPolicyReport, error) {
+	report := sdk.SecurityPolicyReport{Violations: violations, FailNode: policy.FailNode && len(violations) > 0}
+	if len(violations) == 0 {
+		return report, nil
+	}
+
+	infos := make([]sdk.SpawnInfo, len(violations))
+	for i, v := range violations {
+		infos[i] = sdk.SpawnInfo{
+			RemoteTime: time.Now(),
+			Message:    sdk.SpawnMsg{ID: sdk.MsgSpawnInfoSecurityPolicyViolation.ID, Args: []interface{}{v}},
+		}
+	}
+	if err := workflow.AddSpawnInfosNodeJobRun(db, jobID, workflow.PrepareSpawnInfos(infos)); err != nil {
+		return report, sdk.WrapError(err, "Cannot save spawn info on node job run %d", jobID)
+	}
+
+	if policy.CommentPullRequest {
+		if err := workflow.CommentSecurityPolicyViolations(ctx, db, api.Cache, p, nr, violations); err != nil {
+			log.Error("securityPolicyViolations> unable to comment pull request: %v", err)
+		}
+	}
+	return report, nil
+}
+
 func (api *API) postSpawnInfosWorkflowJobHandler() service.AsynchronousHandler {
 	return func(ctx context.Context, r *http.Request) error {
 		id, errc := requestVarInt(r, "id")
@@ -1103,6 +1277,20 @@ func (api *API) postWorkflowJobVariableHandler() service.Handler {
 			return sdk.WrapError(err, "Unable to update node run %d", node.ID)
 		}
 
+		// Keep the URL of the ephemeral environment deployed by the node run
+		if v.Name == sdk.EphemeralEnvironmentURLVariable {
+			env, err := workflow.LoadEphemeralEnvironmentByNodeRun(tx, node.ID)
+			if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+				return err
+			}
+			if env != nil {
+				env.URL = v.Value
+				if err := workflow.UpdateEphemeralEnvironment(tx, env); err != nil {
+					return err
+				}
+			}
+		}
+
 		if err := tx.Commit(); err != nil {
 			return sdk.WrapError(err, "Unable to commit tx")
 		}
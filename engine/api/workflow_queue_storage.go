@@ -238,6 +238,10 @@ func (api *API) postWorkflowJobArtifactHandler() service.Handler {
 			_ = storageDriver.Delete(&art)
 			return sdk.WrapError(err, "Cannot update workflow node run")
 		}
+
+		if err := api.storeArtifactProvenance(storageDriver, &art, nodeRun, nodeJobRun); err != nil {
+			log.Error("postWorkflowJobArtifactHandler> unable to store provenance of artifact %s: %v", art.Name, err)
+		}
 		return nil
 	}
 }
@@ -286,6 +290,14 @@ func (api *API) postWorkflowJobArtifactWithTempURLCallbackHandler() service.Hand
 			return sdk.WrapError(err, "Cannot update workflow node run")
 		}
 
+		nodeJobRun, err := workflow.LoadNodeJobRun(api.mustDB(), api.Cache, art.WorkflowNodeJobRunID)
+		if err != nil {
+			log.Error("postWorkflowJobArtifactWithTempURLCallbackHandler> unable to load node job run %d: %v", art.WorkflowNodeJobRunID, err)
+			return nil
+		}
+		if err := api.storeArtifactProvenance(storageDriver, &art, nodeRun, nodeJobRun); err != nil {
+			log.Error("postWorkflowJobArtifactWithTempURLCallbackHandler> unable to store provenance of artifact %s: %v", art.Name, err)
+		}
 		return nil
 	}
 }
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) postWorkflowJobResourceUsageHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, err := requestVarInt(r, "permID")
+		if err != nil {
+			return sdk.WrapError(err, "Invalid id")
+		}
+
+		var usage sdk.JobResourceUsage
+		if err := service.UnmarshalBody(r, &usage); err != nil {
+			return sdk.WrapError(err, "Unable to read body")
+		}
+
+		job, err := workflow.LoadNodeJobRun(api.mustDB(), api.Cache, id)
+		if err != nil {
+			return sdk.WrapError(err, "Cannot get job run %d", id)
+		}
+		nr, err := workflow.LoadNodeRunByID(api.mustDB(), job.WorkflowNodeRunID, workflow.LoadRunOptions{
+			DisableDetailledNodeRun: true,
+		})
+		if err != nil {
+			return sdk.WrapError(err, "Cannot load node run %d", job.WorkflowNodeRunID)
+		}
+
+		if err := workflow.InsertJobResourceUsage(api.mustDB(), nr, job, &usage); err != nil {
+			return err
+		}
+		return service.WriteJSON(w, usage, http.StatusOK)
+	}
+}
+
+func (api *API) getWorkflowRunResourceUsageHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		run, err := workflow.LoadRun(api.mustDB(), key, name, number, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load workflow %s run number %d", name, number)
+		}
+
+		usages, err := workflow.LoadRunJobResourceUsages(api.mustDB(), run.ID)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, usages, http.StatusOK)
+	}
+}
+
+func (api *API) getWorkflowResourceUsageHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		runs := workflow.ResourceUsageStatsRuns
+		if r.FormValue("runs") != "" {
+			n, err := FormInt(r, "runs")
+			if err != nil {
+				return err
+			}
+			if n <= 0 || n > workflow.ResourceUsageStatsRuns {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "runs must be between 1 and %d", workflow.ResourceUsageStatsRuns)
+			}
+			runs = n
+		}
+
+		usages, err := workflow.LoadJobResourceUsages(api.mustDB(), key, name, runs)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, sdk.NewJobResourceUsageStats(usages), http.StatusOK)
+	}
+}
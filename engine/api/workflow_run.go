@@ -307,6 +307,40 @@ func (api *API) getWorkflowRunHandler() service.Handler {
 	}
 }
 
+// getWorkflowRunDiffHandler compares a workflow run with another one: build parameters, node statuses, tests and commits
+func (api *API) getWorkflowRunDiffHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+		otherNumber, err := requestVarInt(r, "otherNumber")
+		if err != nil {
+			return err
+		}
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key, deprecatedGetUser(ctx))
+		if err != nil {
+			return sdk.WrapError(err, "unable to load project %s", key)
+		}
+
+		opts := workflow.LoadRunOptions{WithTests: true}
+		from, err := workflow.LoadRun(api.mustDB(), key, name, number, opts)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load workflow %s run number %d", name, number)
+		}
+		to, err := workflow.LoadRun(api.mustDB(), key, name, otherNumber, opts)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load workflow %s run number %d", name, otherNumber)
+		}
+
+		return service.WriteJSON(w, workflow.DiffRuns(ctx, api.mustDB(), api.Cache, proj, from, to), http.StatusOK)
+	}
+}
+
 func (api *API) stopWorkflowRunHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		vars := mux.Vars(r)
@@ -926,6 +960,13 @@ func (api *API) initWorkflowRun(ctx context.Context, db *gorp.DbMap, cache cache
 		return
 	}
 
+	// Refresh the mirror of the repository on new commits, for the next clones
+	if opts.Hook != nil {
+		if err := api.refreshRepositoryMirror(ctx, db, *p, wf, wfRun); err != nil {
+			log.Warning("initWorkflowRun> unable to refresh repository mirror: %v", err)
+		}
+	}
+
 	workflow.ResyncNodeRunsWithCommits(db, cache, p, report)
 
 	// Purge workflow run
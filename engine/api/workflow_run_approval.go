@@ -0,0 +1,262 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/event"
+	"github.com/ovh/cds/engine/api/permission"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func (api *API) getWorkflowNodeRunApprovalHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+		id, err := requestVarInt(r, "nodeRunID")
+		if err != nil {
+			return err
+		}
+
+		nodeRun, err := workflow.LoadNodeRun(api.mustDB(), key, name, number, id, workflow.LoadRunOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load workflow node run")
+		}
+
+		a, err := workflow.LoadNodeRunApproval(api.mustDB(), nodeRun.ID)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load approval of node run %d", nodeRun.ID)
+		}
+
+		wr, err := workflow.LoadRunByID(api.mustDB(), nodeRun.WorkflowRunID, workflow.LoadRunOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load workflow run")
+		}
+		n := wr.Workflow.WorkflowData.NodeByID(nodeRun.WorkflowNodeID)
+		if n != nil && n.Context != nil && n.Context.ApprovalGate != nil {
+			a.Gate = *n.Context.ApprovalGate
+		}
+		// The callback URL is only given to the users who can review the node run
+		if a.Status == sdk.ApprovalStatusPending && n != nil && permission.AccessToWorkflowNode(&wr.Workflow, n, deprecatedGetUser(ctx), permission.PermissionReadExecute) {
+			a.CallbackURL = api.Config.URL.API + api.Router.GetRoute("POST", api.postWorkflowNodeRunApprovalCallbackHandler, map[string]string{"token": a.Token})
+		}
+
+		return service.WriteJSON(w, a, http.StatusOK)
+	}
+}
+
+func (api *API) postWorkflowNodeRunApprovalHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+		id, err := requestVarInt(r, "nodeRunID")
+		if err != nil {
+			return err
+		}
+
+		var req sdk.WorkflowNodeRunApprovalRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+
+		nodeRun, err := workflow.LoadNodeRun(api.mustDB(), key, name, number, id, workflow.LoadRunOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load workflow node run")
+		}
+
+		a, err := workflow.LoadNodeRunApproval(api.mustDB(), nodeRun.ID)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load approval of node run %d", nodeRun.ID)
+		}
+
+		if err := api.reviewWorkflowNodeRunApproval(ctx, a, deprecatedGetUser(ctx), req); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, a, http.StatusOK)
+	}
+}
+
+// postWorkflowNodeRunApprovalCallbackHandler is called by chat integrations on the callback URL of an approval.
+// The reviewer is the authenticated user, who must be allowed to execute the node.
+func (api *API) postWorkflowNodeRunApprovalCallbackHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		token := vars["token"]
+
+		var req sdk.WorkflowNodeRunApprovalRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+
+		a, err := workflow.LoadNodeRunApprovalByToken(api.mustDB(), token)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load approval")
+		}
+
+		if err := api.reviewWorkflowNodeRunApproval(ctx, a, deprecatedGetUser(ctx), req); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, a, http.StatusOK)
+	}
+}
+
+func (api *API) reviewWorkflowNodeRunApproval(ctx context.Context, a *sdk.WorkflowNodeRunApproval, u *sdk.User, req sdk.WorkflowNodeRunApprovalRequest) error {
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WrapError(err, "unable to start transaction")
+	}
+	defer tx.Rollback() // nolint
+
+	// Lock the approval request, concurrent reviews are counted one after the other
+	locked, err := workflow.LoadNodeRunApprovalForUpdate(tx, a.ID)
+	if err != nil {
+		return sdk.WrapError(err, "unable to lock approval %d", a.ID)
+	}
+	*a = *locked
+
+	wr, err := workflow.LoadRunByID(tx, a.WorkflowRunID, workflow.LoadRunOptions{})
+	if err != nil {
+		return sdk.WrapError(err, "unable to load workflow run %d", a.WorkflowRunID)
+	}
+
+	nodeRun, err := workflow.LoadNodeRunByID(tx, a.WorkflowNodeRunID, workflow.LoadRunOptions{})
+	if err != nil {
+		return sdk.WrapError(err, "unable to load workflow node run %d", a.WorkflowNodeRunID)
+	}
+
+	n := wr.Workflow.WorkflowData.NodeByID(nodeRun.WorkflowNodeID)
+	if n == nil {
+		return sdk.WrapError(sdk.ErrWorkflowNodeNotFound, "unable to find node %d", nodeRun.WorkflowNodeID)
+	}
+	if !permission.AccessToWorkflowNode(&wr.Workflow, n, u, permission.PermissionReadExecute) {
+		return sdk.WrapError(sdk.ErrNoPermExecution, "not enough right on node %s", n.Name)
+	}
+
+	proj, err := project.LoadByID(tx, api.Cache, wr.Workflow.ProjectID, u,
+		project.LoadOptions.WithVariables,
+		project.LoadOptions.WithFeatures,
+		project.LoadOptions.WithIntegrations,
+		project.LoadOptions.WithApplicationVariables,
+		project.LoadOptions.WithApplicationWithDeploymentStrategies,
+		project.LoadOptions.WithEnvironments,
+		project.LoadOptions.WithPipelines,
+	)
+	if err != nil {
+		return sdk.WrapError(err, "unable to load project %d", wr.Workflow.ProjectID)
+	}
+
+	report, err := workflow.ReviewNodeRunApproval(ctx, tx, api.Cache, proj, wr, nodeRun, a, u, req)
+	if err != nil {
+		return sdk.WrapError(err, "unable to review approval of node %s", n.Name)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return sdk.WrapError(err, "unable to commit transaction")
+	}
+
+	log.Info("reviewWorkflowNodeRunApproval> %s reviewed by %s on workflow %s/%s #%d", a, u.Username, proj.Key, wr.Workflow.Name, wr.Number)
+
+	event.PublishWorkflowNodeRunApproval(proj.Key, wr.Workflow.Name, wr.Number, *a, req.Approved, req.Comment, u)
+	go workflow.SendEvent(api.mustDB(), proj.Key, report)
+
+	return nil
+}
+
+// workflowNodeRunApprovalsRoutine fails the node runs whose approval request has expired
+func (api *API) workflowNodeRunApprovalsRoutine(ctx context.Context) {
+	tick := time.NewTicker(time.Minute).C
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error("Exiting workflowNodeRunApprovalsRoutine: %v", ctx.Err())
+			}
+			return
+		case <-tick:
+			approvals, err := workflow.LoadPendingNodeRunApprovals(api.mustDB())
+			if err != nil {
+				log.Warning("workflowNodeRunApprovalsRoutine> %v", err)
+				continue
+			}
+			now := time.Now()
+			for i := range approvals {
+				if !approvals[i].IsExpired(now) {
+					continue
+				}
+				if err := api.expireWorkflowNodeRunApproval(ctx, &approvals[i]); err != nil {
+					log.Warning("workflowNodeRunApprovalsRoutine> unable to expire approval %d: %v", approvals[i].ID, err)
+				}
+			}
+		}
+	}
+}
+
+func (api *API) expireWorkflowNodeRunApproval(ctx context.Context, a *sdk.WorkflowNodeRunApproval) error {
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WrapError(err, "unable to start transaction")
+	}
+	defer tx.Rollback() // nolint
+
+	locked, err := workflow.LoadNodeRunApprovalForUpdate(tx, a.ID)
+	if err != nil {
+		return sdk.WrapError(err, "unable to lock approval %d", a.ID)
+	}
+	if locked.Status != sdk.ApprovalStatusPending {
+		return nil
+	}
+	*a = *locked
+
+	wr, err := workflow.LoadRunByID(tx, a.WorkflowRunID, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+	if err != nil {
+		return sdk.WrapError(err, "unable to load workflow run %d", a.WorkflowRunID)
+	}
+
+	proj, err := project.LoadByID(tx, api.Cache, wr.Workflow.ProjectID, nil,
+		project.LoadOptions.WithVariables,
+		project.LoadOptions.WithFeatures,
+		project.LoadOptions.WithIntegrations,
+		project.LoadOptions.WithApplicationVariables,
+		project.LoadOptions.WithApplicationWithDeploymentStrategies,
+		project.LoadOptions.WithEnvironments,
+		project.LoadOptions.WithPipelines,
+	)
+	if err != nil {
+		return sdk.WrapError(err, "unable to load project %d", wr.Workflow.ProjectID)
+	}
+
+	report, err := workflow.ExpireNodeRunApproval(ctx, tx, api.Cache, proj, a)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return sdk.WrapError(err, "unable to commit transaction")
+	}
+
+	log.Info("expireWorkflowNodeRunApproval> approval of %s on workflow %s/%s #%d has expired", a.WorkflowNodeName, proj.Key, wr.Workflow.Name, wr.Number)
+
+	event.PublishWorkflowNodeRunApproval(proj.Key, wr.Workflow.Name, wr.Number, *a, false, "", nil)
+	go workflow.SendEvent(api.mustDB(), proj.Key, report)
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"golang.org/x/net/websocket"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/permission"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func debugSessionKey(jobID int64) string {
+	return cache.Key("workflow", "debug", strconv.FormatInt(jobID, 10))
+}
+
+// debugSessionChannel returns the channel where messages sent by the worker or by the user are published.
+func debugSessionChannel(jobID int64, from string) string {
+	return cache.Key("workflow", "debug", strconv.FormatInt(jobID, 10), from)
+}
+
+// getWorkflowJobDebugHandler is called by a worker kept alive after a job failure, it relays the
+// terminal of the worker to the users attached with getWorkflowNodeRunJobDebugHandler.
+func (api *API) getWorkflowJobDebugHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, err := requestVarInt(r, "permID")
+		if err != nil {
+			return sdk.WrapError(err, "invalid id")
+		}
+
+		if getWorker(ctx).ActionBuildID != id {
+			return sdk.WrapError(sdk.ErrForbidden, "job %d is not taken by worker %s", id, getWorker(ctx).Name)
+		}
+
+		job, err := workflow.LoadNodeJobRun(api.mustDB(), api.Cache, id)
+		if err != nil {
+			return err
+		}
+		if job.Status != sdk.StatusBuilding.String() {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "job %d is not building", id)
+		}
+		if job.Job.Action.KeepOnFailure <= 0 {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "debug session is not enabled on job %d", id)
+		}
+
+		timeout := time.Duration(job.Job.Action.KeepOnFailure) * time.Minute
+		key := debugSessionKey(id)
+		api.Cache.SetWithTTL(key, true, int(timeout.Seconds()))
+		defer api.Cache.Delete(key)
+
+		websocket.Server{Handler: func(ws *websocket.Conn) {
+			api.relayDebugSession(ctx, ws, debugSessionChannel(id, "user"), debugSessionChannel(id, "worker"), time.Now().Add(timeout), false)
+		}}.ServeHTTP(w, r)
+		return nil
+	}
+}
+
+// getWorkflowNodeRunJobDebugHandler attaches a user to the debug session opened by the worker of a failed job.
+func (api *API) getWorkflowNodeRunJobDebugHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		projectKey := vars["key"]
+		workflowName := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+		nodeRunID, err := requestVarInt(r, "nodeRunID")
+		if err != nil {
+			return err
+		}
+		runJobID, err := requestVarInt(r, "runJobId")
+		if err != nil {
+			return err
+		}
+
+		// a debug session gives a shell on the worker with the secrets of the job, it requires the write permission
+		// on the workflow or on its project
+		u := deprecatedGetUser(ctx)
+		if !u.Admin && !permission.AccessToProject(projectKey, u, permission.PermissionReadWriteExecute) {
+			if err := api.checkWorkflowPermissions(ctx, workflowName, permission.PermissionReadWriteExecute, vars); err != nil {
+				return sdk.NewErrorFrom(sdk.ErrForbidden, "write permission on workflow %s is required to attach to a debug session", workflowName)
+			}
+		}
+
+		nodeRun, err := workflow.LoadNodeRun(api.mustDB(), projectKey, workflowName, number, nodeRunID, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot find node run %d/%d for workflow %s in project %s", nodeRunID, number, workflowName, projectKey)
+		}
+
+		job, err := workflow.LoadNodeJobRun(api.mustDB(), api.Cache, runJobID)
+		if err != nil {
+			return err
+		}
+		if job.WorkflowNodeRunID != nodeRun.ID {
+			return sdk.WithStack(sdk.ErrWorkflowNodeRunJobNotFound)
+		}
+
+		var opened bool
+		if !api.Cache.Get(debugSessionKey(runJobID), &opened) || !opened {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "no debug session opened on job %d", runJobID)
+		}
+
+		log.Info("getWorkflowNodeRunJobDebugHandler> user %s attached to job %d", u.Username, runJobID)
+
+		websocket.Server{Handler: func(ws *websocket.Conn) {
+			api.relayDebugSession(ctx, ws, debugSessionChannel(runJobID, "worker"), debugSessionChannel(runJobID, "user"), time.Time{}, true)
+		}}.ServeHTTP(w, r)
+		return nil
+	}
+}
+
+// relayDebugSession sends to the websocket the messages published on the in channel and publishes on the
+// out channel the messages received from the websocket. A close message is published when the relay stops, if
+// stopOnClose is true the relay also stops when it receives a close message on the in channel.
+func (api *API) relayDebugSession(ctx context.Context, ws *websocket.Conn, in, out string, deadline time.Time, stopOnClose bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// the connection has been hijacked from the http server, replace its timeouts
+	if err := ws.SetDeadline(deadline); err != nil {
+		log.Warning("relayDebugSession> cannot set deadline: %v", err)
+		return
+	}
+
+	pubSub := api.Cache.Subscribe(in)
+	defer pubSub.Unsubscribe(in) // nolint
+
+	publish := func(msg sdk.DebugSessionMessage) {
+		btes, err := json.Marshal(msg)
+		if err != nil {
+			log.Warning("relayDebugSession> cannot marshal message: %v", err)
+			return
+		}
+		api.Cache.Publish(out, string(btes))
+	}
+	defer publish(sdk.DebugSessionMessage{Type: sdk.DebugSessionMessageClose})
+
+	go func() {
+		defer cancel()
+		for {
+			var msg sdk.DebugSessionMessage
+			if err := websocket.JSON.Receive(ws, &msg); err != nil {
+				if err != io.EOF {
+					log.Debug("relayDebugSession> cannot read message on %s: %v", out, err)
+				}
+				return
+			}
+			if msg.Type == sdk.DebugSessionMessageClose {
+				return
+			}
+			publish(msg)
+		}
+	}()
+
+	for ctx.Err() == nil {
+		s, err := api.Cache.GetMessageFromSubscription(ctx, pubSub)
+		if err != nil {
+			log.Warning("relayDebugSession> cannot get message from %s: %v", in, err)
+			return
+		}
+		if s == "" {
+			continue
+		}
+		var msg sdk.DebugSessionMessage
+		if err := json.Unmarshal([]byte(s), &msg); err != nil {
+			log.Warning("relayDebugSession> cannot unmarshal message from %s: %v", in, err)
+			continue
+		}
+		if err := websocket.JSON.Send(ws, msg); err != nil {
+			log.Debug("relayDebugSession> cannot send message from %s: %v", in, err)
+			return
+		}
+		if stopOnClose && msg.Type == sdk.DebugSessionMessageClose {
+			return
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/engine/api/bootstrap"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/permission"
+	"github.com/ovh/cds/engine/api/pipeline"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_getWorkflowNodeRunJobDebugHandler_Forbidden(t *testing.T) {
+	api, db, router, end := newTestAPI(t, bootstrap.InitiliazeDB)
+	defer end()
+
+	admin, _ := assets.InsertAdminUser(db)
+	key := sdk.RandomString(10)
+	proj := assets.InsertTestProject(t, db, api.Cache, key, key, admin)
+
+	// a user allowed to run the workflow but without write permission must not get a shell on the worker
+	g := &sdk.Group{Name: sdk.RandomString(10)}
+	u, pass := assets.InsertLambdaUser(db, g)
+	test.NoError(t, group.InsertGroupInProject(db, proj.ID, g.ID, permission.PermissionReadExecute))
+
+	pip := sdk.Pipeline{
+		ProjectID:  proj.ID,
+		ProjectKey: proj.Key,
+		Name:       "pip1",
+	}
+	test.NoError(t, pipeline.InsertPipeline(db, api.Cache, proj, &pip, admin))
+
+	proj, err := project.LoadByID(db, api.Cache, proj.ID, admin, project.LoadOptions.WithPipelines, project.LoadOptions.WithGroups)
+	test.NoError(t, err)
+
+	wf := sdk.Workflow{
+		Name:       "workflow1",
+		ProjectID:  proj.ID,
+		ProjectKey: proj.Key,
+		WorkflowData: &sdk.WorkflowData{
+			Node: sdk.Node{
+				Name: "root",
+				Context: &sdk.NodeContext{
+					PipelineID: pip.ID,
+				},
+			},
+		},
+	}
+	(&wf).RetroMigrate()
+	test.NoError(t, workflow.Insert(db, api.Cache, &wf, proj, admin))
+
+	vars := map[string]string{
+		"key":              proj.Key,
+		"permWorkflowName": wf.Name,
+		"number":           "1",
+		"nodeRunID":        "1",
+		"runJobId":         "1",
+	}
+	uri := router.GetRoute("GET", api.getWorkflowNodeRunJobDebugHandler, vars)
+	test.NotEmpty(t, uri)
+	req := assets.NewAuthentifiedRequest(t, u, pass, "GET", uri, nil)
+
+	w := httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -262,6 +262,7 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 		HTTPInsecure:      h.Config.API.HTTP.Insecure,
 		Name:              name,
 		Model:             spawnArgs.Model.ID,
+		ModelVersion:      spawnArgs.Model.Version,
 		HatcheryName:      h.Service().Name,
 		TTL:               h.Config.WorkerTTL,
 		GraylogHost:       h.Configuration().Provision.WorkerLogsOptions.Graylog.Host,
@@ -299,6 +300,7 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 	envsWm["CDS_TOKEN"] = udataParam.Token
 	envsWm["CDS_NAME"] = udataParam.Name
 	envsWm["CDS_MODEL"] = fmt.Sprintf("%d", udataParam.Model)
+	envsWm["CDS_MODEL_VERSION"] = fmt.Sprintf("%d", udataParam.ModelVersion)
 	envsWm["CDS_HATCHERY_NAME"] = udataParam.HatcheryName
 	envsWm["CDS_FROM_WORKER_IMAGE"] = fmt.Sprintf("%v", udataParam.FromWorkerImage)
 	envsWm["CDS_INSECURE"] = fmt.Sprintf("%v", udataParam.HTTPInsecure)
@@ -346,7 +348,7 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 			Containers: []apiv1.Container{
 				{
 					Name:    name,
-					Image:   spawnArgs.Model.ModelDocker.Image,
+					Image:   spawnArgs.Model.ModelDocker.ImageRef(),
 					Env:     envs,
 					Command: strings.Fields(spawnArgs.Model.ModelDocker.Shell),
 					Args:    []string{cmd},
@@ -360,6 +362,14 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 		},
 	}
 
+	if spawnArgs.Model.ModelDocker.Username != "" || spawnArgs.Model.ModelDocker.Password != "" {
+		secretName, err := h.createRegistrySecret(spawnArgs.Model)
+		if err != nil {
+			return "", err
+		}
+		podSchema.Spec.ImagePullSecrets = []apiv1.LocalObjectReference{{Name: secretName}}
+	}
+
 	var services []sdk.Requirement
 	for _, req := range spawnArgs.Requirements {
 		if req.Type == sdk.ServiceRequirement {
@@ -474,6 +484,7 @@ func (h *HatcheryKubernetes) routines(ctx context.Context) {
 
 			sdk.GoRoutine(ctx, "killAwolWorker", func(ctx context.Context) {
 				_ = h.killAwolWorkers()
+				_ = h.deleteUnusedRegistrySecrets()
 			})
 		case <-ctx.Done():
 			if ctx.Err() != nil {
@@ -0,0 +1,119 @@
+package kubernetes
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// LABEL_REGISTRY_SECRET is set on the image pull secrets created for the registry credentials of a worker model
+const LABEL_REGISTRY_SECRET = "CDS_REGISTRY_SECRET"
+
+// ANNOTATION_REGISTRY_SECRET_USED is the last time an image pull secret was used to spawn a worker
+const ANNOTATION_REGISTRY_SECRET_USED = "CDS_REGISTRY_SECRET_USED"
+
+// registrySecretGracePeriod is the delay during which a secret not used by a pod is kept, the pod
+// is created after the secret when a worker is spawned
+const registrySecretGracePeriod = 5 * time.Minute
+
+// registrySecretName returns the name of the image pull secret of a worker model
+func registrySecretName(m sdk.Model) string {
+	return fmt.Sprintf("cds-registry-%s", strings.ToLower(strings.Replace(m.Name, "_", "-", -1)))
+}
+
+// createRegistrySecret creates or updates the image pull secret with the registry credentials of a worker model,
+// it returns the name of the secret
+func (h *HatcheryKubernetes) createRegistrySecret(m sdk.Model) (string, error) {
+	name := registrySecretName(m)
+	auth := base64.StdEncoding.EncodeToString([]byte(m.ModelDocker.Username + ":" + m.ModelDocker.Password))
+	dockerConfig, err := json.Marshal(map[string]interface{}{
+		"auths": map[string]interface{}{
+			m.ModelDocker.Registry: map[string]string{
+				"username": m.ModelDocker.Username,
+				"password": m.ModelDocker.Password,
+				"auth":     auth,
+			},
+		},
+	})
+	if err != nil {
+		return "", sdk.WithStack(err)
+	}
+
+	secret := apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: h.Config.Namespace,
+			Labels: map[string]string{
+				LABEL_REGISTRY_SECRET: "true",
+				LABEL_WORKER_MODEL:    strings.ToLower(m.Name),
+				LABEL_HATCHERY_NAME:   h.Configuration().Name,
+			},
+			Annotations: map[string]string{
+				ANNOTATION_REGISTRY_SECRET_USED: time.Now().Format(time.RFC3339),
+			},
+		},
+		Type: apiv1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{apiv1.DockerConfigJsonKey: dockerConfig},
+	}
+
+	secrets := h.k8sClient.CoreV1().Secrets(h.Config.Namespace)
+	if _, err := secrets.Get(name, metav1.GetOptions{}); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return "", sdk.WrapError(err, "Cannot get secret %s", name)
+		}
+		if _, err := secrets.Create(&secret); err != nil {
+			return "", sdk.WrapError(err, "Cannot create secret %s", name)
+		}
+		return name, nil
+	}
+	if _, err := secrets.Update(&secret); err != nil {
+		return "", sdk.WrapError(err, "Cannot update secret %s", name)
+	}
+	return name, nil
+}
+
+// deleteUnusedRegistrySecrets deletes the image pull secrets of the hatchery which are not used by a worker pod anymore,
+// ie. when the pods of the worker model have been removed or when the worker model has been deleted
+func (h *HatcheryKubernetes) deleteUnusedRegistrySecrets() error {
+	pods, err := h.k8sClient.CoreV1().Pods(h.Config.Namespace).List(metav1.ListOptions{LabelSelector: LABEL_WORKER})
+	if err != nil {
+		return sdk.WrapError(err, "Cannot list pods")
+	}
+	used := make(map[string]struct{})
+	for _, pod := range pods.Items {
+		for _, s := range pod.Spec.ImagePullSecrets {
+			used[s.Name] = struct{}{}
+		}
+	}
+
+	secrets, err := h.k8sClient.CoreV1().Secrets(h.Config.Namespace).List(metav1.ListOptions{LabelSelector: LABEL_REGISTRY_SECRET})
+	if err != nil {
+		return sdk.WrapError(err, "Cannot list secrets")
+	}
+	var globalErr error
+	for _, secret := range secrets.Items {
+		if secret.Labels[LABEL_HATCHERY_NAME] != h.Configuration().Name {
+			continue
+		}
+		if _, ok := used[secret.Name]; ok {
+			continue
+		}
+		if lastUse, err := time.Parse(time.RFC3339, secret.Annotations[ANNOTATION_REGISTRY_SECRET_USED]); err == nil && time.Since(lastUse) < registrySecretGracePeriod {
+			continue
+		}
+		if err := h.k8sClient.CoreV1().Secrets(secret.Namespace).Delete(secret.Name, nil); err != nil && !k8serrors.IsNotFound(err) {
+			globalErr = err
+			log.Error("hatchery:kubernetes> deleteUnusedRegistrySecrets> Cannot delete secret %s (%s)", secret.Name, err)
+		}
+	}
+	return globalErr
+}
@@ -251,6 +251,7 @@ func (h *HatcheryLocal) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 		HTTPInsecure:      h.Config.API.HTTP.Insecure,
 		Name:              wName,
 		Model:             spawnArgs.Model.ID,
+		ModelVersion:      spawnArgs.Model.Version,
 		HatcheryName:      h.Service().Name,
 		GraylogHost:       h.Configuration().Provision.WorkerLogsOptions.Graylog.Host,
 		GraylogPort:       h.Configuration().Provision.WorkerLogsOptions.Graylog.Port,
@@ -89,6 +89,7 @@ func (h *HatcheryOpenstack) SpawnWorker(ctx context.Context, spawnArgs hatchery.
 		Name:              name,
 		Token:             h.Configuration().API.Token,
 		Model:             spawnArgs.Model.ID,
+		ModelVersion:      spawnArgs.Model.Version,
 		HatcheryName:      h.Service().Name,
 		TTL:               h.Config.WorkerTTL,
 		FromWorkerImage:   withExistingImage,
@@ -44,10 +44,20 @@ func (c *Common) initMetrics(hatcheryName string) error {
 	label = fmt.Sprintf("cds/%s/%s/disabled_workers", c.ServiceName(), hatcheryName)
 	c.metrics.DisabledWorkers = stats.Int64(label, "number of disabled workers", stats.UnitDimensionless)
 
+	label = fmt.Sprintf("cds/%s/%s/warm_pool_target", c.ServiceName(), hatcheryName)
+	c.metrics.WarmPoolTarget = stats.Int64(label, "target size of the warm pool", stats.UnitDimensionless)
+
+	label = fmt.Sprintf("cds/%s/%s/warm_pool_size", c.ServiceName(), hatcheryName)
+	c.metrics.WarmPoolSize = stats.Int64(label, "number of started workers in the warm pool", stats.UnitDimensionless)
+
+	label = fmt.Sprintf("cds/%s/%s/warm_pool_demand", c.ServiceName(), hatcheryName)
+	c.metrics.WarmPoolDemand = stats.Int64(label, "number of jobs in queue for the warm pool", stats.UnitDimensionless)
+
 	log.Info("hatchery> Stats initialized on %s", c.ServiceName())
 
 	tagCDSInstance, _ := tag.NewKey("cds")
 	tags := []tag.Key{tagCDSInstance, hatchery.TagHatchery, hatchery.TagHatcheryName}
+	poolTags := []tag.Key{tagCDSInstance, hatchery.TagHatchery, hatchery.TagHatcheryName, hatchery.TagWorkerModel}
 
 	return observability.RegisterView(
 		observability.NewViewCount("jobs_count", c.metrics.Jobs, tags),
@@ -59,5 +69,8 @@ func (c *Common) initMetrics(hatcheryName string) error {
 		observability.NewViewLast("checking_workers", c.metrics.CheckingWorkers, tags),
 		observability.NewViewLast("building_workers", c.metrics.BuildingWorkers, tags),
 		observability.NewViewLast("disabled_workers", c.metrics.DisabledWorkers, tags),
+		observability.NewViewLast("warm_pool_target", c.metrics.WarmPoolTarget, poolTags),
+		observability.NewViewLast("warm_pool_size", c.metrics.WarmPoolSize, poolTags),
+		observability.NewViewLast("warm_pool_demand", c.metrics.WarmPoolDemand, poolTags),
 	)
 }
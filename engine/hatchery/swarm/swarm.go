@@ -349,6 +349,7 @@ func (h *HatcherySwarm) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 		HTTPInsecure:      h.Config.API.HTTP.Insecure,
 		Name:              name,
 		Model:             spawnArgs.Model.ID,
+		ModelVersion:      spawnArgs.Model.Version,
 		TTL:               h.Config.WorkerTTL,
 		HatcheryName:      h.Service().Name,
 		GraylogHost:       h.Configuration().Provision.WorkerLogsOptions.Graylog.Host,
@@ -385,6 +386,7 @@ func (h *HatcherySwarm) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 	envsWm["CDS_TOKEN"] = udataParam.Token
 	envsWm["CDS_NAME"] = udataParam.Name
 	envsWm["CDS_MODEL"] = fmt.Sprintf("%d", udataParam.Model)
+	envsWm["CDS_MODEL_VERSION"] = fmt.Sprintf("%d", udataParam.ModelVersion)
 	envsWm["CDS_HATCHERY_NAME"] = udataParam.HatcheryName
 	envsWm["CDS_FROM_WORKER_IMAGE"] = fmt.Sprintf("%v", udataParam.FromWorkerImage)
 	envsWm["CDS_INSECURE"] = fmt.Sprintf("%v", udataParam.HTTPInsecure)
@@ -414,9 +416,15 @@ func (h *HatcherySwarm) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 		i++
 	}
 
+	auth, err := registryAuth(spawnArgs.Model.ModelDocker)
+	if err != nil {
+		return "", err
+	}
+
 	args := containerArgs{
 		name:         name,
-		image:        spawnArgs.Model.ModelDocker.Image,
+		image:        spawnArgs.Model.ModelDocker.ImageRef(),
+		registryAuth: auth,
 		network:      network,
 		networkAlias: networkAlias,
 		cmd:          cmds,
@@ -429,7 +437,7 @@ func (h *HatcherySwarm) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 
 	//start the worker
 	if err := h.createAndStartContainer(ctx, dockerClient, args, spawnArgs); err != nil {
-		log.Warning("hatchery> swarm> SpawnWorker> Unable to start container %s on %s with image %s err:%v", args.name, dockerClient.name, spawnArgs.Model.ModelDocker.Image, err)
+		log.Warning("hatchery> swarm> SpawnWorker> Unable to start container %s on %s with image %s err:%v", args.name, dockerClient.name, args.image, err)
 		return "", err
 	}
 
@@ -564,7 +572,7 @@ func (h *HatcherySwarm) WorkersStartedByModel(model *sdk.Model) int {
 
 		for _, c := range workers {
 			log.Debug("Container : %s %s [%s]", c.ID, c.Image, c.Status)
-			if c.Image == model.ModelDocker.Image {
+			if c.Image == model.ModelDocker.Image || c.Image == model.ModelDocker.ImageRef() {
 				list = append(list, c.ID)
 			}
 		}
@@ -41,6 +41,7 @@ func (h *HatcherySwarm) createNetwork(ctx context.Context, dockerClient *dockerC
 
 type containerArgs struct {
 	name, image, network, networkAlias string
+	registryAuth                       string
 	cmd, env                           []string
 	labels                             map[string]string
 	memory                             int64
@@ -112,6 +113,12 @@ checkImage:
 				break checkImage
 			}
 		}
+		for _, d := range img.RepoDigests {
+			if cArgs.image == d {
+				imageFound = true
+				break checkImage
+			}
+		}
 	}
 
 	if strings.HasSuffix(cArgs.image, ":latest") {
@@ -125,7 +132,7 @@ checkImage:
 		})
 
 		_, next := observability.Span(ctx, "swarm.dockerClient.pullImage", observability.Tag("image", cArgs.image))
-		if err := h.pullImage(dockerClient, cArgs.image, timeoutPullImage); err != nil {
+		if err := h.pullImage(dockerClient, cArgs.image, cArgs.registryAuth, timeoutPullImage); err != nil {
 			next()
 			hatchery.SendSpawnInfo(ctx, h, spawnArgs.JobID, sdk.SpawnMsg{
 				ID:   sdk.MsgSpawnInfoHatcheryEndDockerPullErr.ID,
@@ -2,6 +2,8 @@ package swarm
 
 import (
 	"bytes"
+	"encoding/base64"
+	"encoding/json"
 	"io"
 	"time"
 
@@ -12,7 +14,23 @@ import (
 	context "golang.org/x/net/context"
 )
 
-func (h *HatcherySwarm) pullImage(dockerClient *dockerClient, img string, timeout time.Duration) error {
+// registryAuth returns the encoded credentials of the registry of a worker model, empty if the model has no registry credentials
+func registryAuth(m sdk.ModelDocker) (string, error) {
+	if m.Username == "" && m.Password == "" {
+		return "", nil
+	}
+	btes, err := json.Marshal(types.AuthConfig{
+		Username:      m.Username,
+		Password:      m.Password,
+		ServerAddress: m.Registry,
+	})
+	if err != nil {
+		return "", sdk.WithStack(err)
+	}
+	return base64.URLEncoding.EncodeToString(btes), nil
+}
+
+func (h *HatcherySwarm) pullImage(dockerClient *dockerClient, img, auth string, timeout time.Duration) error {
 	t0 := time.Now()
 	log.Debug("hatchery> swarm> pullImage> pulling image %s on %s", img, dockerClient.name)
 
@@ -20,7 +38,7 @@ func (h *HatcherySwarm) pullImage(dockerClient *dockerClient, img string, timeou
 	defer cancel()
 
 	//Pull the worker image
-	opts := types.ImageCreateOptions{}
+	opts := types.ImageCreateOptions{RegistryAuth: auth}
 	res, err := dockerClient.ImageCreate(ctx, img, opts)
 	if err != nil {
 		log.Warning("hatchery> swarm> pullImage> Unable to pull image %s on %s: %s", img, dockerClient.name, err)
@@ -197,6 +197,7 @@ func (h *HatcheryVSphere) launchScriptWorker(name string, jobID int64, model sdk
 		Name:              name,
 		Token:             h.Configuration().API.Token,
 		Model:             model.ID,
+		ModelVersion:      model.Version,
 		HatcheryName:      h.Service().Name,
 		TTL:               h.Config.WorkerTTL,
 		FromWorkerImage:   true,
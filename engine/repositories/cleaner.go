@@ -68,15 +68,43 @@ func (s *Service) vacuumFilesystemCleanerRun() error {
 	sort.Strings(names)
 
 	for _, n := range names {
-		if err := s.vacuumFileSystemCleanerFunc(n); err != nil {
+		if n == mirrorsDirectory {
+			continue
+		}
+		if err := s.vacuumFileSystemCleanerFunc(s.Cfg.Basedir, n); err != nil {
 			log.Error("vacuumFilesystemCleanerRun> %v ", err)
 		}
 	}
 
+	return s.vacuumMirrorsCleanerRun()
+}
+
+// vacuumMirrorsCleanerRun removes the mirrors that have not been refreshed during the retention
+func (s *Service) vacuumMirrorsCleanerRun() error {
+	fi, err := os.Open(s.mirrorsDir())
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer fi.Close()
+
+	names, err := fi.Readdirnames(-1)
+	if err != nil {
+		return err
+	}
+
+	for _, n := range names {
+		if err := s.vacuumFileSystemCleanerFunc(s.mirrorsDir(), n); err != nil {
+			log.Error("vacuumMirrorsCleanerRun> %v ", err)
+		}
+	}
+
 	return nil
 }
 
-func (s *Service) vacuumFileSystemCleanerFunc(repoUUID string) error {
+func (s *Service) vacuumFileSystemCleanerFunc(dir, repoUUID string) error {
 	log.Debug("vacuumFileSystemCleanerFunc> Checking %s", repoUUID)
 
 	if err := s.dao.lock(repoUUID); err == errLockUnavailable {
@@ -92,7 +120,7 @@ func (s *Service) vacuumFileSystemCleanerFunc(repoUUID string) error {
 
 	log.Debug("vacuumFileSystemCleanerFunc> Removing %s", repoUUID)
 
-	path := filepath.Join(s.Cfg.Basedir, repoUUID)
+	path := filepath.Join(dir, repoUUID)
 	if err := os.RemoveAll(path); err != nil {
 		return err
 	}
@@ -19,6 +19,20 @@ func (s *Service) checkOrCreateRootFS() error {
 	return fmt.Errorf("bad configuration: %s is not a directory", s.Cfg.Basedir)
 }
 
+// mirrorsDirectory is the directory of the bare mirrors in the base directory
+const mirrorsDirectory = "mirrors"
+
+func (s *Service) mirrorsDir() string {
+	return filepath.Join(s.Cfg.Basedir, mirrorsDirectory)
+}
+
+func (s *Service) checkOrCreateMirrorsFS() error {
+	if err := s.checkOrCreateRootFS(); err != nil {
+		return sdk.WithStack(err)
+	}
+	return sdk.WrapError(os.MkdirAll(s.mirrorsDir(), os.FileMode(0700)), "unable to create directory %s", s.mirrorsDir())
+}
+
 func (s *Service) checkOrCreateFS(r *sdk.OperationRepo) error {
 	if err := s.checkOrCreateRootFS(); err != nil {
 		return sdk.WithStack(err)
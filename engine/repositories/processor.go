@@ -33,14 +33,28 @@ func (s *Service) do(op sdk.Operation) error {
 	log.Debug("repositories > processing > %v", op.UUID)
 
 	r := s.Repo(op)
-	if s.dao.lock(r.ID()) == errLockUnavailable {
+	// mirrors are not stored with the checkouts of the repository, they are locked separately
+	lockID := r.ID()
+	if op.Setup.Mirror.ID != "" {
+		lockID = op.Setup.Mirror.ID
+	}
+	if s.dao.lock(lockID) == errLockUnavailable {
 		return errLockUnavailable
 	}
-	defer s.dao.unlock(r.ID(), 24*time.Hour*time.Duration(s.Cfg.RepositoriesRentention))
+	defer s.dao.unlock(lockID, 24*time.Hour*time.Duration(s.Cfg.RepositoriesRentention))
 
 	switch {
+	// Create or refresh the mirror of the repository
+	case op.Setup.Mirror.ID != "":
+		if err := s.processMirror(&op); err != nil {
+			op.Error = err.Error()
+			op.Status = sdk.OperationStatusError
+		} else {
+			op.Error = ""
+			op.Status = sdk.OperationStatusDone
+		}
 	// Load workflow as code file
-	case op.Setup.Checkout.Branch != "":
+	case op.Setup.Checkout.Branch != "" || op.Setup.Checkout.Tag != "":
 		if err := s.processCheckout(&op); err != nil {
 			op.Error = err.Error()
 			op.Status = sdk.OperationStatusError
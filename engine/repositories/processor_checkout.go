@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	repo "github.com/fsamin/go-repo"
+
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 )
@@ -25,6 +27,10 @@ func (s *Service) processCheckout(op *sdk.Operation) error {
 		}
 	}
 
+	if op.Setup.Checkout.Tag != "" {
+		return s.processCheckoutTag(op, gitRepo)
+	}
+
 	if op.Setup.Checkout.Branch == "" {
 		op.Setup.Checkout.Branch = op.RepositoryInfo.DefaultBranch
 	}
@@ -68,3 +74,27 @@ func (s *Service) processCheckout(op *sdk.Operation) error {
 	log.Info("Repositories> processCheckout> repository %s ready", op.URL)
 	return nil
 }
+
+func (s *Service) processCheckoutTag(op *sdk.Operation, gitRepo repo.Repo) error {
+	// fetching the default branch also fetches the tags of its history
+	log.Debug("Repositories> processCheckoutTag> fetching tags from %s", op.URL)
+	if err := gitRepo.FetchRemoteBranch("origin", op.RepositoryInfo.DefaultBranch); err != nil {
+		log.Error("Repositories> processCheckoutTag> FetchRemoteBranch> [%s] error %v", op.UUID, err)
+		return err
+	}
+
+	if err := gitRepo.Checkout("tags/" + op.Setup.Checkout.Tag); err != nil {
+		log.Error("Repositories> processCheckoutTag> Checkout> [%s] error %v", op.UUID, err)
+		return err
+	}
+
+	currentCommit, err := gitRepo.LatestCommit()
+	if err != nil {
+		log.Error("Repositories> processCheckoutTag> LatestCommit> [%s] error %v", op.UUID, err)
+		return err
+	}
+	op.Setup.Checkout.Commit = currentCommit.LongHash
+
+	log.Info("Repositories> processCheckoutTag> repository %s ready on tag %s", op.URL, op.Setup.Checkout.Tag)
+	return nil
+}
@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/vcs"
+	"github.com/ovh/cds/sdk/vcs/git"
+)
+
+func (s *Service) processMirror(op *sdk.Operation) error {
+	r := s.Repo(*op)
+	if !mirrorIDRegexp.MatchString(op.Setup.Mirror.ID) {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid mirror id %s", op.Setup.Mirror.ID)
+	}
+	if err := s.checkOrCreateMirrorsFS(); err != nil {
+		log.Error("Repositories> processMirror> checkOrCreateMirrorsFS> [%s] Error %v", op.UUID, err)
+		return err
+	}
+	path := filepath.Join(s.mirrorsDir(), op.Setup.Mirror.ID)
+
+	var auth *git.AuthOpts
+	if op.RepositoryStrategy.ConnectionType == "ssh" {
+		keyDir, err := ioutil.TempDir("", "cds-repositories-key")
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer os.RemoveAll(keyDir) // nolint
+
+		key := vcs.SSHKey{
+			Filename: filepath.Join(keyDir, "id_rsa"),
+			Content:  []byte(op.RepositoryStrategy.SSHKeyContent),
+		}
+		if err := ioutil.WriteFile(key.Filename, key.Content, os.FileMode(0600)); err != nil {
+			return sdk.WithStack(err)
+		}
+		auth = &git.AuthOpts{PrivateKey: key}
+	} else if op.RepositoryStrategy.User != "" && op.RepositoryStrategy.Password != "" {
+		auth = &git.AuthOpts{
+			Username: op.RepositoryStrategy.User,
+			Password: op.RepositoryStrategy.Password,
+		}
+	}
+
+	log.Info("Repositories> processMirror> [%s] mirroring %s into %s", op.UUID, op.RepoFullName, path)
+	stderr := new(bytes.Buffer)
+	if err := git.Mirror(r.URL, path, auth, &git.OutputOpts{Stdout: ioutil.Discard, Stderr: stderr}); err != nil {
+		// git outputs can contain the credentials of the repository, keep them in debug logs only
+		log.Debug("Repositories> processMirror> [%s] git output: %s", op.UUID, stderr.String())
+		return sdk.WrapError(err, "unable to mirror repository %s", op.RepoFullName)
+	}
+	return nil
+}
@@ -7,6 +7,11 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"net/http/cgi"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
@@ -105,6 +110,44 @@ func readOperationMultipart(r *http.Request) (*sdk.Operation, map[string][]byte,
 	return op, files, nil
 }
 
+var mirrorIDRegexp = regexp.MustCompile("^[0-9a-f]{40}$")
+
+// mirrorHandler serves the mirrors with the git smart HTTP protocol, only fetches are allowed
+func (s *Service) mirrorHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id := muxVar(r, "id")
+		path := muxVar(r, "path")
+		if !mirrorIDRegexp.MatchString(id) {
+			return sdk.WithStack(sdk.ErrNotFound)
+		}
+
+		switch {
+		case r.Method == http.MethodGet && path == "info/refs" && r.URL.Query().Get("service") == "git-upload-pack":
+		case r.Method == http.MethodPost && path == "git-upload-pack":
+		default:
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		if _, err := os.Stat(filepath.Join(s.mirrorsDir(), id, "HEAD")); err != nil {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "mirror %s not found", id)
+		}
+
+		gitPath, err := exec.LookPath("git")
+		if err != nil {
+			return sdk.WrapError(err, "git not found")
+		}
+
+		h := &cgi.Handler{
+			Path: gitPath,
+			Args: []string{"http-backend"},
+			Root: strings.TrimSuffix(r.URL.Path, "/"+id+"/"+path),
+			Env:  []string{"GIT_PROJECT_ROOT=" + s.mirrorsDir(), "GIT_HTTP_EXPORT_ALL=1"},
+		}
+		h.ServeHTTP(w, r)
+		return nil
+	}
+}
+
 func (s *Service) getOperationsHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		uuid := muxVar(r, "uuid")
@@ -3,12 +3,21 @@ package repositories
 import (
 	"archive/tar"
 	"bytes"
+	"context"
 	"encoding/json"
+	"io/ioutil"
+	"net/http"
 	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
 
+	"github.com/gorilla/mux"
+
 	"github.com/ovh/cds/sdk"
 
+	"github.com/ovh/cds/engine/api"
 	"github.com/ovh/cds/engine/api/test"
 	"github.com/stretchr/testify/assert"
 )
@@ -140,3 +149,52 @@ func getWorkflowTarFile(t *testing.T, buf *bytes.Buffer) {
 		t.Fail()
 	}
 }
+
+func Test_mirrorHandler(t *testing.T) {
+	basedir, err := ioutil.TempDir("", "Test_mirrorHandler")
+	test.NoError(t, err)
+	defer os.RemoveAll(basedir)
+
+	// Prepare a repository with a commit and its mirror
+	src := filepath.Join(basedir, "src")
+	runGit := func(dir string, args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=cds", "GIT_AUTHOR_EMAIL=cds@localhost",
+			"GIT_COMMITTER_NAME=cds", "GIT_COMMITTER_EMAIL=cds@localhost")
+		out, err := cmd.CombinedOutput()
+		test.NoError(t, err, string(out))
+	}
+	runGit(basedir, "init", "-q", src)
+	test.NoError(t, ioutil.WriteFile(filepath.Join(src, "README.md"), []byte("mirrored"), 0600))
+	runGit(src, "add", "README.md")
+	runGit(src, "commit", "-q", "-m", "initial commit")
+
+	s := &Service{
+		Router: &api.Router{Mux: mux.NewRouter(), Background: context.Background()},
+	}
+	s.Cfg.Basedir = basedir
+	s.initRouter(context.Background())
+	id := sdk.RepositoryMirrorID("MYPROJ", "github", "ovh/cds")
+	runGit(basedir, "clone", "-q", "--mirror", src, filepath.Join(s.mirrorsDir(), id))
+
+	srv := httptest.NewServer(s.Router.Mux)
+	defer srv.Close()
+
+	// Clone from the mirror
+	runGit(basedir, "clone", "-q", srv.URL+"/mirrors/"+id, filepath.Join(basedir, "clone"))
+	btes, err := ioutil.ReadFile(filepath.Join(basedir, "clone", "README.md"))
+	test.NoError(t, err)
+	assert.Equal(t, "mirrored", string(btes))
+
+	// Pushes and unknown mirrors are refused
+	resp, err := http.Get(srv.URL + "/mirrors/" + id + "/info/refs?service=git-receive-pack")
+	test.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp, err = http.Get(srv.URL + "/mirrors/" + sdk.RepositoryMirrorID("MYPROJ", "github", "ovh/unknown") + "/info/refs?service=git-upload-pack")
+	test.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -19,4 +19,5 @@ func (s *Service) initRouter(ctx context.Context) {
 	r.Handle("/mon/status", r.GET(s.getStatusHandler))
 	r.Handle("/operations", r.POST(s.postOperationHandler))
 	r.Handle("/operations/{uuid}", r.GET(s.getOperationsHandler))
+	r.Handle("/mirrors/{id}/{path:.*}", r.GET(s.mirrorHandler), r.POST(s.mirrorHandler))
 }
@@ -22,7 +22,7 @@ type Service struct {
 // Configuration is the vcs configuration structure
 type Configuration struct {
 	Name                   string `toml:"name" comment:"Name of this CDS Repositories Service\n Enter a name to enable this service" json:"name"`
-	Basedir                string `toml:"basedir" comment:"Root directory where the service will store all checked-out repositories" json:"basedir"`
+	Basedir                string `toml:"basedir" comment:"Root directory where the service will store all checked-out repositories and their mirrors" json:"basedir"`
 	OperationRetention     int    `toml:"operation_retention" comment:"Operation retention in redis store (in days)" default:"5" json:"operation_retention"`
 	RepositoriesRentention int    `toml:"repositories_retention" comment:"Re retention on the filesystem (in days)" default:"10" json:"repositories_retention"`
 	HTTP                   struct {
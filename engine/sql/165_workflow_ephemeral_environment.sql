@@ -0,0 +1,30 @@
+-- +migrate Up
+CREATE TABLE workflow_ephemeral_environment
+(
+    id BIGSERIAL PRIMARY KEY,
+    project_id BIGINT NOT NULL,
+    workflow_id BIGINT NOT NULL,
+    workflow_node_id BIGINT NOT NULL,
+    application_id BIGINT DEFAULT 0,
+    project_integration_id BIGINT DEFAULT 0,
+    name VARCHAR(256) NOT NULL,
+    url TEXT DEFAULT '',
+    status VARCHAR(25) NOT NULL,
+    branch VARCHAR(256) DEFAULT '',
+    pull_request_id BIGINT DEFAULT 0,
+    workflow_run_id BIGINT DEFAULT 0,
+    workflow_node_run_id BIGINT DEFAULT 0,
+    triggered_by VARCHAR(256) DEFAULT '',
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    last_modified TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    expire TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_EPHEMERAL_ENVIRONMENT_PROJECT', 'workflow_ephemeral_environment', 'project', 'project_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_EPHEMERAL_ENVIRONMENT_WORKFLOW', 'workflow_ephemeral_environment', 'workflow', 'workflow_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_EPHEMERAL_ENVIRONMENT_W_NODE', 'workflow_ephemeral_environment', 'w_node', 'workflow_node_id', 'id');
+SELECT create_unique_index('workflow_ephemeral_environment', 'IDX_WORKFLOW_EPHEMERAL_ENVIRONMENT_NAME', 'workflow_id,workflow_node_id,name');
+SELECT create_index('workflow_ephemeral_environment', 'IDX_WORKFLOW_EPHEMERAL_ENVIRONMENT_STATUS', 'status');
+
+-- +migrate Down
+DROP TABLE workflow_ephemeral_environment;
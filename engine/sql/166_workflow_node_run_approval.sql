@@ -0,0 +1,24 @@
+-- +migrate Up
+CREATE TABLE workflow_node_run_approval
+(
+    id BIGSERIAL PRIMARY KEY,
+    workflow_id BIGINT NOT NULL,
+    workflow_run_id BIGINT NOT NULL,
+    workflow_node_run_id BIGINT NOT NULL,
+    workflow_node_name VARCHAR(256) NOT NULL,
+    status VARCHAR(25) NOT NULL,
+    committer VARCHAR(256) DEFAULT '',
+    token VARCHAR(256) NOT NULL,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    expire TIMESTAMP WITH TIME ZONE NOT NULL,
+    reviews JSONB
+);
+
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_NODE_RUN_APPROVAL_WORKFLOW', 'workflow_node_run_approval', 'workflow', 'workflow_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_NODE_RUN_APPROVAL_WORKFLOW_RUN', 'workflow_node_run_approval', 'workflow_run', 'workflow_run_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_NODE_RUN_APPROVAL_WORKFLOW_NODE_RUN', 'workflow_node_run_approval', 'workflow_node_run', 'workflow_node_run_id', 'id');
+SELECT create_unique_index('workflow_node_run_approval', 'IDX_WORKFLOW_NODE_RUN_APPROVAL_TOKEN', 'token');
+SELECT create_index('workflow_node_run_approval', 'IDX_WORKFLOW_NODE_RUN_APPROVAL_STATUS', 'status');
+
+-- +migrate Down
+DROP TABLE workflow_node_run_approval;
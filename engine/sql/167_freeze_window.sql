@@ -0,0 +1,23 @@
+-- +migrate Up
+CREATE TABLE freeze_window
+(
+    id BIGSERIAL PRIMARY KEY,
+    project_id BIGINT NOT NULL,
+    environment_id BIGINT,
+    name VARCHAR(256) NOT NULL,
+    reason TEXT DEFAULT '',
+    cron VARCHAR(256) DEFAULT '',
+    duration BIGINT DEFAULT 0,
+    timezone VARCHAR(256) DEFAULT '',
+    start_date TIMESTAMP WITH TIME ZONE,
+    end_date TIMESTAMP WITH TIME ZONE,
+    override_groups JSONB,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+
+SELECT create_foreign_key_idx_cascade('FK_FREEZE_WINDOW_PROJECT', 'freeze_window', 'project', 'project_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_FREEZE_WINDOW_ENVIRONMENT', 'freeze_window', 'environment', 'environment_id', 'id');
+SELECT create_unique_index('freeze_window', 'IDX_FREEZE_WINDOW_PROJECT_NAME', 'project_id,name');
+
+-- +migrate Down
+DROP TABLE freeze_window;
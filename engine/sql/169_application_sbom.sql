@@ -0,0 +1,49 @@
+-- +migrate Up
+ALTER TABLE application_vulnerability ALTER COLUMN title TYPE TEXT;
+ALTER TABLE application_vulnerability ALTER COLUMN component TYPE TEXT;
+
+CREATE TABLE application_component
+(
+    id BIGSERIAL PRIMARY KEY,
+    application_id BIGINT NOT NULL,
+    name TEXT NOT NULL,
+    version VARCHAR(256) DEFAULT '',
+    type VARCHAR(256) DEFAULT '',
+    purl TEXT DEFAULT '',
+    licenses JSONB
+);
+SELECT create_foreign_key_idx_cascade('FK_APPLICATION_COMPONENT_APPLICATION', 'application_component', 'application', 'application_id', 'id');
+
+CREATE TABLE workflow_node_run_sbom
+(
+    id BIGSERIAL PRIMARY KEY,
+    application_id BIGINT NOT NULL,
+    workflow_id BIGINT NOT NULL,
+    workflow_run_id BIGINT NOT NULL,
+    workflow_node_run_id BIGINT NOT NULL,
+    workflow_number BIGINT NOT NULL,
+    branch VARCHAR(256) DEFAULT '',
+    format VARCHAR(50) DEFAULT '',
+    components JSONB,
+    diff JSONB
+);
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_NODE_RUN_SBOM_APPLICATION', 'workflow_node_run_sbom', 'application', 'application_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_NODE_RUN_SBOM_WORKFLOW_RUN', 'workflow_node_run_sbom', 'workflow_run', 'workflow_run_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_NODE_RUN_SBOM_WORKFLOW_NODE_RUN', 'workflow_node_run_sbom', 'workflow_node_run', 'workflow_node_run_id', 'id');
+
+CREATE TABLE project_security_policy
+(
+    project_id BIGINT PRIMARY KEY,
+    max_severity VARCHAR(50) DEFAULT '',
+    forbidden_licenses JSONB,
+    fail_node BOOLEAN DEFAULT false,
+    comment_pull_request BOOLEAN DEFAULT false
+);
+SELECT create_foreign_key_idx_cascade('FK_PROJECT_SECURITY_POLICY_PROJECT', 'project_security_policy', 'project', 'project_id', 'id');
+
+-- +migrate Down
+DROP TABLE project_security_policy;
+DROP TABLE workflow_node_run_sbom;
+DROP TABLE application_component;
+ALTER TABLE application_vulnerability ALTER COLUMN title TYPE VARCHAR(100);
+ALTER TABLE application_vulnerability ALTER COLUMN component TYPE VARCHAR(200);
@@ -0,0 +1,40 @@
+-- +migrate Up
+CREATE TABLE application_release
+(
+    id BIGSERIAL PRIMARY KEY,
+    application_id BIGINT NOT NULL,
+    version VARCHAR(256) NOT NULL,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    created_by VARCHAR(256) DEFAULT '',
+    workflow_name VARCHAR(256) DEFAULT '',
+    workflow_run_id BIGINT NOT NULL,
+    num BIGINT NOT NULL,
+    workflow_node_run_id BIGINT NOT NULL,
+    node_name VARCHAR(256) DEFAULT '',
+    pipeline_name VARCHAR(256) DEFAULT '',
+    repository VARCHAR(256) DEFAULT '',
+    branch VARCHAR(256) DEFAULT '',
+    vcs_hash VARCHAR(256) DEFAULT ''
+);
+SELECT create_foreign_key_idx_cascade('FK_APPLICATION_RELEASE_APPLICATION', 'application_release', 'application', 'application_id', 'id');
+SELECT create_unique_index('application_release', 'IDX_APPLICATION_RELEASE_VERSION', 'application_id,version');
+
+CREATE TABLE application_release_artifact
+(
+    id BIGSERIAL PRIMARY KEY,
+    application_release_id BIGINT NOT NULL,
+    name VARCHAR(256) NOT NULL,
+    size BIGINT DEFAULT 0,
+    perm INT DEFAULT 0,
+    md5sum VARCHAR(50) DEFAULT '',
+    sha512sum VARCHAR(256) DEFAULT '',
+    object_path TEXT DEFAULT '',
+    project_integration_id BIGINT,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_APPLICATION_RELEASE_ARTIFACT_RELEASE', 'application_release_artifact', 'application_release', 'application_release_id', 'id');
+SELECT create_unique_index('application_release_artifact', 'IDX_APPLICATION_RELEASE_ARTIFACT_NAME', 'application_release_id,name');
+
+-- +migrate Down
+DROP TABLE application_release_artifact;
+DROP TABLE application_release;
@@ -0,0 +1,29 @@
+-- +migrate Up
+CREATE TABLE worker_model_version
+(
+    id BIGSERIAL PRIMARY KEY,
+    worker_model_id BIGINT NOT NULL,
+    version BIGINT NOT NULL,
+    type VARCHAR(256) NOT NULL,
+    model JSONB,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    created_by VARCHAR(256) DEFAULT ''
+);
+SELECT create_foreign_key_idx_cascade('FK_WORKER_MODEL_VERSION_WORKER_MODEL', 'worker_model_version', 'worker_model', 'worker_model_id', 'id');
+SELECT create_unique_index('worker_model_version', 'IDX_WORKER_MODEL_VERSION_VERSION', 'worker_model_id,version');
+
+ALTER TABLE worker_model ADD COLUMN version BIGINT DEFAULT 0;
+ALTER TABLE worker_model ADD COLUMN canary_version BIGINT DEFAULT 0;
+ALTER TABLE worker_model ADD COLUMN canary_percent BIGINT DEFAULT 0;
+ALTER TABLE worker ADD COLUMN model_version BIGINT DEFAULT 0;
+
+INSERT INTO worker_model_version (worker_model_id, version, type, model, created)
+SELECT id, 1, type, model, user_last_modified FROM worker_model;
+UPDATE worker_model SET version = 1;
+
+-- +migrate Down
+ALTER TABLE worker DROP COLUMN model_version;
+ALTER TABLE worker_model DROP COLUMN canary_percent;
+ALTER TABLE worker_model DROP COLUMN canary_version;
+ALTER TABLE worker_model DROP COLUMN version;
+DROP TABLE worker_model_version;
@@ -0,0 +1,23 @@
+-- +migrate Up
+CREATE TABLE workflow_node_run_job_resource_usage
+(
+    id BIGSERIAL PRIMARY KEY,
+    workflow_id BIGINT NOT NULL,
+    workflow_run_id BIGINT NOT NULL,
+    workflow_node_run_id BIGINT NOT NULL,
+    workflow_node_job_run_id BIGINT NOT NULL,
+    workflow_node_name VARCHAR(256) NOT NULL,
+    job_name VARCHAR(256) NOT NULL,
+    num BIGINT NOT NULL,
+    requested_memory BIGINT DEFAULT 0,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    steps JSONB,
+    total JSONB
+);
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_NODE_RUN_JOB_RESOURCE_USAGE_WORKFLOW', 'workflow_node_run_job_resource_usage', 'workflow', 'workflow_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_NODE_RUN_JOB_RESOURCE_USAGE_WORKFLOW_RUN', 'workflow_node_run_job_resource_usage', 'workflow_run', 'workflow_run_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_NODE_RUN_JOB_RESOURCE_USAGE_WORKFLOW_NODE_RUN', 'workflow_node_run_job_resource_usage', 'workflow_node_run', 'workflow_node_run_id', 'id');
+SELECT create_unique_index('workflow_node_run_job_resource_usage', 'IDX_WORKFLOW_NODE_RUN_JOB_RESOURCE_USAGE_JOB', 'workflow_node_job_run_id');
+
+-- +migrate Down
+DROP TABLE workflow_node_run_job_resource_usage;
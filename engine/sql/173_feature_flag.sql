@@ -0,0 +1,15 @@
+-- +migrate Up
+CREATE TABLE feature_flag
+(
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(256) NOT NULL,
+    enabled BOOLEAN DEFAULT false,
+    project_keys JSONB,
+    groups JSONB,
+    percentage INT DEFAULT 0,
+    last_modified TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_unique_index('feature_flag', 'IDX_FEATURE_FLAG_NAME', 'name');
+
+-- +migrate Down
+DROP TABLE feature_flag;
@@ -0,0 +1,21 @@
+-- +migrate Up
+CREATE TABLE action_repository
+(
+    id BIGSERIAL PRIMARY KEY,
+    action_id BIGINT NOT NULL,
+    project_id BIGINT NOT NULL,
+    vcs_server VARCHAR(256) NOT NULL,
+    repository VARCHAR(256) NOT NULL,
+    ref VARCHAR(256) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    commit VARCHAR(256) NOT NULL DEFAULT '',
+    outputs JSONB,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    last_modified TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_ACTION_REPOSITORY_ACTION', 'action_repository', 'action', 'action_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_ACTION_REPOSITORY_PROJECT', 'action_repository', 'project', 'project_id', 'id');
+SELECT create_unique_index('action_repository', 'IDX_ACTION_REPOSITORY_PROJECT_REF', 'project_id,repository,ref,name');
+
+-- +migrate Down
+DROP TABLE action_repository;
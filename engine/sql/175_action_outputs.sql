@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE action ADD COLUMN outputs JSONB DEFAULT '[]';
+UPDATE action SET outputs = action_repository.outputs FROM action_repository WHERE action_repository.action_id = action.id AND action_repository.outputs IS NOT NULL;
+ALTER TABLE action_repository DROP COLUMN outputs;
+
+-- +migrate Down
+ALTER TABLE action_repository ADD COLUMN outputs JSONB;
+UPDATE action_repository SET outputs = action.outputs FROM action WHERE action_repository.action_id = action.id;
+ALTER TABLE action DROP COLUMN outputs;
@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE action ADD COLUMN condition TEXT NOT NULL DEFAULT '';
+ALTER TABLE action_edge ADD COLUMN condition TEXT NOT NULL DEFAULT '';
+ALTER TABLE action_edge ADD COLUMN continue_on_error BOOLEAN NOT NULL DEFAULT false;
+
+-- +migrate Down
+ALTER TABLE action DROP COLUMN condition;
+ALTER TABLE action_edge DROP COLUMN condition;
+ALTER TABLE action_edge DROP COLUMN continue_on_error;
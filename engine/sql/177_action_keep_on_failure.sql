@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE action ADD COLUMN keep_on_failure INT NOT NULL DEFAULT 0;
+
+-- +migrate Down
+ALTER TABLE action DROP COLUMN keep_on_failure;
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE w_node_context ADD COLUMN ephemeral_environment JSONB;
+
+-- +migrate Down
+ALTER TABLE w_node_context DROP COLUMN ephemeral_environment;
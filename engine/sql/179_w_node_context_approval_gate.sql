@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE w_node_context ADD COLUMN approval_gate JSONB;
+
+-- +migrate Down
+ALTER TABLE w_node_context DROP COLUMN approval_gate;
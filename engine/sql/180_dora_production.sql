@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE environment ADD COLUMN production BOOLEAN DEFAULT false;
+ALTER TABLE workflow_node_run ADD COLUMN environment_id BIGINT;
+
+-- +migrate Down
+ALTER TABLE environment DROP COLUMN production;
+ALTER TABLE workflow_node_run DROP COLUMN environment_id;
@@ -50,11 +50,15 @@ func runCheckoutApplication(w *currentWorker) BuiltInAction {
 			sendLog(fmt.Sprintf("branch is empty, using the default branch %s", defaultBranch))
 		}
 
+		setCloneOptsFromParameters(opts, a.Parameters)
+
 		r := regexp.MustCompile("{{.*}}")
 		if commit != nil && commit.Value != "" && !r.MatchString(commit.Value) {
 			opts.CheckoutCommit = commit.Value
 		}
 
+		w.setCloneMirror(opts, a.Parameters, *params, gitURL)
+
 		var dir string
 		if directory != nil {
 			dir = directory.Value
@@ -113,11 +113,14 @@ func runDeployApplication(w *currentWorker) BuiltInAction {
 
 		sendLog(fmt.Sprintf("# Plugin %s v%s is ready", manifest.Name, manifest.Version))
 
-		query := integrationplugin.DeployQuery{
-			Options: sdk.ParametersToMap(*params),
+		var res *integrationplugin.DeployResult
+		options := sdk.ParametersToMap(*params)
+		if options[sdk.EphemeralEnvironmentTeardownParam] == "true" {
+			sendLog(fmt.Sprintf("# Tearing down ephemeral environment %s", options[sdk.EphemeralEnvironmentNameParam]))
+			res, err = integrationPluginClient.Teardown(ctx, &integrationplugin.TeardownQuery{Options: options})
+		} else {
+			res, err = integrationPluginClient.Deploy(ctx, &integrationplugin.DeployQuery{Options: options})
 		}
-
-		res, err := integrationPluginClient.Deploy(ctx, &query)
 		if err != nil {
 			res := sdk.Result{
 				Reason: fmt.Sprintf("Error deploying application: %v", err),
@@ -132,6 +135,17 @@ func runDeployApplication(w *currentWorker) BuiltInAction {
 
 		if strings.ToUpper(res.Status) == strings.ToUpper(sdk.StatusSuccess.String()) {
 			integrationPluginClientStop(ctx, integrationPluginClient, done, stopLogs)
+			if res.Url != "" {
+				sendLog(fmt.Sprintf("# URL: %s", res.Url))
+				v := sdk.Variable{
+					Name:  sdk.EphemeralEnvironmentURLVariable,
+					Type:  sdk.StringVariable,
+					Value: res.Url,
+				}
+				if _, err := w.addVariableInPipelineBuild(v, params); err != nil {
+					sendLog(fmt.Sprintf("Unable to export variable %s: %v", v.Name, err))
+				}
+			}
 			return sdk.Result{
 				Status: sdk.StatusSuccess.String(),
 			}
@@ -3,6 +3,7 @@ package main
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -11,6 +12,7 @@ import (
 	"github.com/blang/semver"
 
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
 	"github.com/ovh/cds/sdk/log"
 	"github.com/ovh/cds/sdk/vcs"
 	"github.com/ovh/cds/sdk/vcs/git"
@@ -183,6 +185,7 @@ func runGitClone(w *currentWorker) BuiltInAction {
 		if submodules != nil && submodules.Value == "false" {
 			opts.Recursive = false
 		}
+		setCloneOptsFromParameters(opts, a.Parameters)
 
 		// if there is no branch, check if there a defaultBranch
 		if (opts.Branch == "" || opts.Branch == "{{.git.branch}}") && defaultBranch != "" && tag == "" {
@@ -196,6 +199,8 @@ func runGitClone(w *currentWorker) BuiltInAction {
 			opts.CheckoutCommit = commit.Value
 		}
 
+		w.setCloneMirror(opts, a.Parameters, *params, gitURL)
+
 		var dir string
 		if directory != nil {
 			dir = directory.Value
@@ -204,6 +209,45 @@ func runGitClone(w *currentWorker) BuiltInAction {
 	}
 }
 
+// setCloneMirror clones the repository of the application from its mirror served by the API, the repository
+// is cloned from its URL if the mirror is unavailable
+func (w *currentWorker) setCloneMirror(opts *git.CloneOpts, actionParams []sdk.Parameter, params []sdk.Parameter, gitURL string) {
+	if sdk.ParameterValue(actionParams, "mirror") == "false" || w.currentJob.wJob == nil || w.id == "" || gitURL == "" {
+		return
+	}
+	if gitURL != sdk.ParameterValue(params, "git.url") && gitURL != sdk.ParameterValue(params, "git.http_url") {
+		return
+	}
+	opts.MirrorURL = fmt.Sprintf("%s/queue/workflows/%d/mirror", strings.TrimSuffix(w.apiEndpoint, "/"), w.currentJob.wJob.ID)
+	opts.MirrorUserAgent = sdk.WorkerAgent
+	opts.MirrorHeaders = map[string]string{
+		sdk.AuthHeader:                base64.StdEncoding.EncodeToString([]byte(w.id)),
+		cdsclient.RequestedNameHeader: w.status.Name,
+	}
+}
+
+// setCloneOptsFromParameters sets the LFS, sparse checkout and partial clone options from the action parameters
+func setCloneOptsFromParameters(opts *git.CloneOpts, params []sdk.Parameter) {
+	opts.Filter = strings.TrimSpace(sdk.ParameterValue(params, "filter"))
+	opts.SparseCheckout = splitParameterList(sdk.ParameterValue(params, "sparseCheckout"))
+	opts.LFS = sdk.ParameterValue(params, "lfs") == "true"
+	if opts.LFS {
+		opts.LFSInclude = splitParameterList(sdk.ParameterValue(params, "lfsInclude"))
+		opts.LFSExclude = splitParameterList(sdk.ParameterValue(params, "lfsExclude"))
+	}
+}
+
+// splitParameterList returns the values of a parameter separated by commas or new lines
+func splitParameterList(v string) []string {
+	var res []string
+	for _, s := range strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == '\n' }) {
+		if s = strings.TrimSpace(s); s != "" {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
 func gitClone(w *currentWorker, params *[]sdk.Parameter, url string, dir string, auth *git.AuthOpts, clone *git.CloneOpts, sendLog LoggerFunc) sdk.Result {
 	//Prepare all options - logs
 	stdErr := new(bytes.Buffer)
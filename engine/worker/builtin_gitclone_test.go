@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/vcs/git"
+)
+
+func Test_setCloneMirror(t *testing.T) {
+	w := &currentWorker{apiEndpoint: "http://localhost:8081/", id: "123456"}
+	w.status.Name = "worker1"
+	w.currentJob.wJob = &sdk.WorkflowNodeJobRun{ID: 42}
+	params := []sdk.Parameter{
+		{Name: "git.url", Value: "ssh://git@github.com/ovh/cds.git"},
+		{Name: "git.http_url", Value: "https://github.com/ovh/cds.git"},
+	}
+
+	opts := &git.CloneOpts{}
+	w.setCloneMirror(opts, nil, params, "https://github.com/ovh/cds.git")
+	assert.Equal(t, "http://localhost:8081/queue/workflows/42/mirror", opts.MirrorURL)
+	assert.Equal(t, sdk.WorkerAgent, opts.MirrorUserAgent)
+	assert.Equal(t, "MTIzNDU2", opts.MirrorHeaders[sdk.AuthHeader])
+
+	// Another repository is not mirrored
+	opts = &git.CloneOpts{}
+	w.setCloneMirror(opts, nil, params, "https://github.com/ovh/venom.git")
+	assert.Empty(t, opts.MirrorURL)
+
+	// The mirror can be disabled on the action
+	opts = &git.CloneOpts{}
+	w.setCloneMirror(opts, []sdk.Parameter{{Name: "mirror", Value: "false"}}, params, "https://github.com/ovh/cds.git")
+	assert.Empty(t, opts.MirrorURL)
+}
@@ -8,7 +8,6 @@ import (
 	"io/ioutil"
 	"net/http"
 	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/ovh/cds/sdk"
@@ -74,12 +73,7 @@ func runParseJunitTestResultAction(w *currentWorker) BuiltInAction {
 		}
 
 		// replace secrets in the content of the xml files analyzed
-		dataS := string(data)
-		for i := range logsecrets {
-			if len(logsecrets[i].Value) >= sdk.SecretMinLength {
-				dataS = strings.Replace(dataS, logsecrets[i].Value, "**"+logsecrets[i].Name+"**", -1)
-			}
-		}
+		dataS := logsecrets.mask(string(data))
 
 		uri := fmt.Sprintf("/queue/workflows/%d/test", w.currentJob.wJob.ID)
 
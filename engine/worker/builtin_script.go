@@ -142,56 +142,7 @@ func runScriptAction(w *currentWorker) BuiltInAction {
 			cmd := exec.CommandContext(ctx, script.shell, script.opts...)
 			res.Status = sdk.StatusUnknown.String()
 
-			env := os.Environ()
-			cmd.Env = []string{"CI=1"}
-			// filter technical env variables
-			for _, e := range env {
-				if strings.HasPrefix(e, "CDS_") {
-					continue
-				}
-				cmd.Env = append(cmd.Env, e)
-			}
-
-			//We have to let it here for some legacy reason
-			cmd.Env = append(cmd.Env, "CDS_KEY=********")
-
-			// worker export http port
-			cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%d", WorkerServerPort, w.exportPort))
-
-			//DEPRECATED - BEGIN
-			// manage keys
-			if w.currentJob.pkey != "" && w.currentJob.gitsshPath != "" {
-				cmd.Env = append(cmd.Env, fmt.Sprintf("PKEY=%s", w.currentJob.pkey))
-				cmd.Env = append(cmd.Env, fmt.Sprintf("GIT_SSH=%s", w.currentJob.gitsshPath))
-			}
-			//DEPRECATED - END
-
-			//set up environment variables from pipeline build job parameters
-			for _, p := range *params {
-				// avoid put private key in environment var as it's a binary value
-				if strings.HasPrefix(p.Name, "cds.key.") && strings.HasSuffix(p.Name, ".priv") {
-					continue
-				}
-				if p.Type == sdk.KeyParameter && !strings.HasSuffix(p.Name, ".pub") {
-					continue
-				}
-
-				cmd.Env = append(cmd.Env, cdsEnvVartoENV(p)...)
-
-				envName := strings.Replace(p.Name, ".", "_", -1)
-				envName = strings.Replace(envName, "-", "_", -1)
-				envName = strings.ToUpper(envName)
-				cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", envName, p.Value))
-			}
-
-			for _, p := range w.currentJob.buildVariables {
-				envName := strings.Replace(p.Name, ".", "_", -1)
-				envName = strings.Replace(envName, "-", "_", -1)
-				envName = strings.ToUpper(envName)
-				cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", envName, p.Value))
-			}
-
-			workerpath, err := osext.Executable()
+			cmd.Env, err = w.environ(*params)
 			if err != nil {
 				log.Warning("runScriptAction: Cannot get worker path: %s", err)
 				res.Reason = "Failure due to internal error (Worker Path)"
@@ -200,14 +151,6 @@ func runScriptAction(w *currentWorker) BuiltInAction {
 				chanRes <- res
 			}
 
-			log.Info("Worker binary path: %s", path.Dir(workerpath))
-			for i := range cmd.Env {
-				if strings.HasPrefix(cmd.Env[i], "PATH") {
-					cmd.Env[i] = fmt.Sprintf("%s:%s", cmd.Env[i], path.Dir(workerpath))
-					break
-				}
-			}
-
 			stdout, err := cmd.StdoutPipe()
 			if err != nil {
 				log.Warning("runScriptAction: Cannot get stdout pipe: %s", err)
@@ -306,3 +249,68 @@ func isShell(in string) bool {
 	}
 	return false
 }
+
+// environ returns the environment variables of a process run by a step, built from the job parameters
+func (w *currentWorker) environ(params []sdk.Parameter) ([]string, error) {
+	env := []string{"CI=1"}
+	// filter technical env variables
+	for _, e := range os.Environ() {
+		if strings.HasPrefix(e, "CDS_") {
+			continue
+		}
+		env = append(env, e)
+	}
+
+	//We have to let it here for some legacy reason
+	env = append(env, "CDS_KEY=********")
+
+	// worker export http port
+	env = append(env, fmt.Sprintf("%s=%d", WorkerServerPort, w.exportPort))
+
+	//DEPRECATED - BEGIN
+	// manage keys
+	if w.currentJob.pkey != "" && w.currentJob.gitsshPath != "" {
+		env = append(env, fmt.Sprintf("PKEY=%s", w.currentJob.pkey))
+		env = append(env, fmt.Sprintf("GIT_SSH=%s", w.currentJob.gitsshPath))
+	}
+	//DEPRECATED - END
+
+	//set up environment variables from pipeline build job parameters
+	for _, p := range params {
+		// avoid put private key in environment var as it's a binary value
+		if strings.HasPrefix(p.Name, "cds.key.") && strings.HasSuffix(p.Name, ".priv") {
+			continue
+		}
+		if p.Type == sdk.KeyParameter && !strings.HasSuffix(p.Name, ".pub") {
+			continue
+		}
+
+		env = append(env, cdsEnvVartoENV(p)...)
+
+		envName := strings.Replace(p.Name, ".", "_", -1)
+		envName = strings.Replace(envName, "-", "_", -1)
+		envName = strings.ToUpper(envName)
+		env = append(env, fmt.Sprintf("%s=%s", envName, p.Value))
+	}
+
+	for _, p := range w.currentJob.buildVariables {
+		envName := strings.Replace(p.Name, ".", "_", -1)
+		envName = strings.Replace(envName, "-", "_", -1)
+		envName = strings.ToUpper(envName)
+		env = append(env, fmt.Sprintf("%s=%s", envName, p.Value))
+	}
+
+	workerpath, err := osext.Executable()
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Worker binary path: %s", path.Dir(workerpath))
+	for i := range env {
+		if strings.HasPrefix(env[i], "PATH") {
+			env[i] = fmt.Sprintf("%s:%s", env[i], path.Dir(workerpath))
+			break
+		}
+	}
+	return env, nil
+}
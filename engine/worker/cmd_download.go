@@ -24,12 +24,14 @@ var (
 	cmdDownloadNumber       string
 	cmdDownloadArtefactName string
 	cmdDownloadTag          string
+	cmdDownloadRelease      string
+	cmdDownloadApplication  string
 )
 
 func cmdDownload(w *currentWorker) *cobra.Command {
 	c := &cobra.Command{
 		Use:   "download",
-		Short: "worker download [--workflow=<workflow-name>] [--number=<run-number>] [--tag=<tag>] [--pattern=<pattern>]",
+		Short: "worker download [--workflow=<workflow-name>] [--number=<run-number>] [--tag=<tag>] [--pattern=<pattern>] [--release=<version>] [--application=<application-name>]",
 		Long: `
 Inside a job, there are two ways to download an artifact:
 
@@ -49,6 +51,11 @@ Theses two commands have the same result:
 	worker download
 	worker download --workflow={{.cds.workflow}} --number={{.cds.run.number}}
 
+The artifacts of a release of an application can be downloaded by version:
+
+	worker download --release=1.2.0
+	worker download --release=1.2.0 --application=myapp --pattern="*.tar.gz"
+
 		`,
 		Run: downloadCmd(w),
 	}
@@ -56,6 +63,8 @@ Theses two commands have the same result:
 	c.Flags().StringVar(&cmdDownloadNumber, "number", "", "Workflow Number to download from. Optional, default: current workflow run")
 	c.Flags().StringVar(&cmdDownloadArtefactName, "pattern", "", "Pattern matching files to download. Optional, default: *")
 	c.Flags().StringVar(&cmdDownloadTag, "tag", "", "Tag matching files to download. Optional")
+	c.Flags().StringVar(&cmdDownloadRelease, "release", "", "Version of the application release to download from. Optional")
+	c.Flags().StringVar(&cmdDownloadApplication, "application", "", "Application of the release to download from. Optional, default: current application")
 	return c
 }
 
@@ -64,6 +73,8 @@ type workerDownloadArtifact struct {
 	Number      int64  `json:"number"`
 	Pattern     string `json:"pattern" cli:"pattern"`
 	Tag         string `json:"tag" cli:"tag"`
+	Release     string `json:"release"`
+	Application string `json:"application"`
 	Destination string `json:"destination"`
 }
 
@@ -94,6 +105,8 @@ func downloadCmd(w *currentWorker) func(cmd *cobra.Command, args []string) {
 			Number:      number,
 			Pattern:     cmdDownloadArtefactName,
 			Tag:         cmdDownloadTag,
+			Release:     cmdDownloadRelease,
+			Application: cmdDownloadApplication,
 			Destination: wd,
 		}
 
@@ -142,6 +155,11 @@ func (wk *currentWorker) downloadHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if reqArgs.Release != "" {
+		wk.downloadReleaseHandler(w, r, reqArgs)
+		return
+	}
+
 	sendLog := getLogger(wk, wk.currentJob.wJob.ID, wk.currentJob.currentStep)
 
 	currentProject := sdk.ParameterValue(wk.currentJob.params, "cds.project")
@@ -255,3 +273,58 @@ func (wk *currentWorker) downloadHandler(w http.ResponseWriter, r *http.Request)
 	}
 
 }
+
+// downloadReleaseHandler downloads the artifacts of a release of an application, the current application by default
+func (wk *currentWorker) downloadReleaseHandler(w http.ResponseWriter, r *http.Request, reqArgs workerDownloadArtifact) {
+	sendLog := getLogger(wk, wk.currentJob.wJob.ID, wk.currentJob.currentStep)
+	projectKey := sdk.ParameterValue(wk.currentJob.params, "cds.project")
+	if reqArgs.Application == "" {
+		reqArgs.Application = sdk.ParameterValue(wk.currentJob.params, "cds.application")
+	}
+	if reqArgs.Application == "" {
+		writeError(w, r, sdk.NewErrorFrom(sdk.ErrWrongRequest, "application is mandatory to download a release"))
+		return
+	}
+
+	regexp, errp := regexp.Compile(reqArgs.Pattern)
+	if errp != nil {
+		writeError(w, r, sdk.NewError(sdk.ErrWrongRequest, fmt.Errorf("Invalid pattern %s : %s", reqArgs.Pattern, errp)))
+		return
+	}
+
+	rel, err := wk.client.ApplicationReleaseGet(projectKey, reqArgs.Application, reqArgs.Release)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	for _, a := range rel.Artifacts {
+		if reqArgs.Pattern != "" && !regexp.MatchString(a.Name) {
+			sendLog(fmt.Sprintf("%s does not match pattern %s - skipped", a.Name, reqArgs.Pattern))
+			continue
+		}
+
+		path := path.Join(reqArgs.Destination, a.Name)
+		f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(a.Perm))
+		if err != nil {
+			writeError(w, r, sdk.NewError(sdk.ErrUnknownError, fmt.Errorf("Cannot download artifact (OpenFile) %s: %s", a.Name, err)))
+			return
+		}
+		sendLog(fmt.Sprintf("downloading artifact %s from release %s of application %s/%s (%s)...", a.Name, rel.Version, projectKey, reqArgs.Application, path))
+		if err := wk.client.ApplicationReleaseArtifactDownload(projectKey, reqArgs.Application, rel.Version, a.Name, f); err != nil {
+			_ = f.Close()
+			writeError(w, r, sdk.NewError(sdk.ErrUnknownError, fmt.Errorf("Cannot download artifact %s: %s", a.Name, err)))
+			return
+		}
+		if err := f.Close(); err != nil {
+			writeError(w, r, sdk.NewError(sdk.ErrUnknownError, fmt.Errorf("Cannot download artifact %s: %s", a.Name, err)))
+			return
+		}
+
+		sha512sum, err := sdk.FileSHA512sum(path)
+		if err != nil || sha512sum != a.SHA512sum {
+			writeError(w, r, sdk.NewError(sdk.ErrUnknownError, fmt.Errorf("Invalid sha512sum of artifact %s", a.Name)))
+			return
+		}
+	}
+}
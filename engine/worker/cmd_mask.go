@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/sdk"
+)
+
+var cmdMaskName string
+
+func cmdMask(w *currentWorker) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "mask",
+		Short: "worker mask [--name NAME] [value]",
+		Long: `
+Inside a job, mask a secret created during the job (a token retrieved from a vault, a generated password...) in the logs of the next steps:
+
+	# the value is read from stdin when it is not given as argument, which avoids to leak it in the process list
+	vault read -field=token secret/deploy | worker mask --name deploy.token
+
+The secret is displayed as **deploy.token** in the logs, as the password and key variables of the job. Its base64,
+url and json encoded forms are masked too.
+		`,
+		Run: maskCmd(w),
+	}
+	c.Flags().StringVar(&cmdMaskName, "name", "masked", "Name of the secret displayed instead of its value")
+	return c
+}
+
+type maskedValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func maskCmd(w *currentWorker) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		portS := os.Getenv(WorkerServerPort)
+		if portS == "" {
+			sdk.Exit("%s not found, are you running inside a CDS worker job?\n", WorkerServerPort)
+		}
+
+		port, errPort := strconv.Atoi(portS)
+		if errPort != nil {
+			sdk.Exit("cannot parse '%s' as a port number", portS)
+		}
+
+		var value string
+		switch len(args) {
+		case 0:
+			btes, err := ioutil.ReadAll(os.Stdin)
+			if err != nil {
+				sdk.Exit("cannot read value from stdin: %v\n", err)
+			}
+			value = strings.TrimSuffix(string(btes), "\n")
+		case 1:
+			value = args[0]
+		default:
+			sdk.Exit("Wrong usage: Example : echo $VALUE | worker mask --name my.secret")
+		}
+		if len(value) < sdk.SecretMinLength {
+			sdk.Exit("a secret must contain at least %d characters\n", sdk.SecretMinLength)
+		}
+
+		data, errMarshal := json.Marshal(maskedValue{Name: cmdMaskName, Value: value})
+		if errMarshal != nil {
+			sdk.Exit("internal error (%s)\n", errMarshal)
+		}
+
+		req, errRequest := http.NewRequest("POST", fmt.Sprintf("http://127.0.0.1:%d/mask", port), bytes.NewReader(data))
+		if errRequest != nil {
+			sdk.Exit("cannot post worker mask (Request): %s\n", errRequest)
+		}
+
+		client := http.DefaultClient
+		client.Timeout = 5 * time.Second
+
+		resp, errDo := client.Do(req)
+		if errDo != nil {
+			sdk.Exit("command failed: %v\n", errDo)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= 300 {
+			body, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				sdk.Exit("mask failed: unable to read body %v\n", err)
+			}
+			cdsError := sdk.DecodeError(body)
+			sdk.Exit("mask failed: %v\n", cdsError)
+		}
+	}
+}
+
+func (wk *currentWorker) maskHandler(w http.ResponseWriter, r *http.Request) {
+	data, errRead := ioutil.ReadAll(r.Body)
+	if errRead != nil {
+		writeError(w, r, sdk.NewError(sdk.ErrWrongRequest, errRead))
+		return
+	}
+	defer r.Body.Close() // nolint
+
+	var v maskedValue
+	if err := json.Unmarshal(data, &v); err != nil {
+		writeError(w, r, sdk.NewError(sdk.ErrWrongRequest, err))
+		return
+	}
+	if v.Name == "" || len(v.Value) < sdk.SecretMinLength {
+		writeError(w, r, sdk.ErrWrongRequest)
+		return
+	}
+
+	logsecrets.add(v.Name, v.Value)
+	w.WriteHeader(http.StatusOK)
+}
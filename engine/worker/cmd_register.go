@@ -29,6 +29,7 @@ func cmdRegisterRun(w *currentWorker) func(cmd *cobra.Command, args []string) {
 			Token:            w.token,
 			HatcheryName:     w.hatchery.name,
 			ModelID:          w.model.ID,
+			ModelVersion:     w.model.Version,
 		}
 
 		if err := w.register(form); err != nil {
@@ -365,6 +365,7 @@ func (w *currentWorker) doRegister() error {
 			Token:        w.token,
 			HatcheryName: w.hatchery.name,
 			ModelID:      w.model.ID,
+			ModelVersion: w.model.Version,
 		}
 		if err := w.register(form); err != nil {
 			log.Error("Cannot register: %s", err)
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func cmdSARIF(w *currentWorker) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "sarif",
+		Short: "worker sarif report.sarif",
+		Long: `
+Inside a job, send the findings of a static analysis tool in SARIF format. They are added to the vulnerabilities of the application:
+
+	# worker sarif <file>
+	worker sarif gosec.sarif
+
+The findings are checked against the security policy of the project. If a finding is above the max severity of the policy,
+the command exits 1 when the policy is configured to fail the node.
+		`,
+		Run: securityReportCmd("sarif"),
+	}
+	return c
+}
+
+func cmdSBOM(w *currentWorker) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "sbom",
+		Short: "worker sbom bom.json",
+		Long: `
+Inside a job, send a SBOM in CycloneDX or SPDX json format. On the default branch, the components and their licenses are saved on the application.
+The components are compared with the latest SBOM of the default branch:
+
+	# worker sbom <file>
+	worker sbom bom.json
+
+The licenses are checked against the security policy of the project. If a component uses a forbidden license,
+the command exits 1 when the policy is configured to fail the node.
+		`,
+		Run: securityReportCmd("sbom"),
+	}
+	return c
+}
+
+func securityReportCmd(kind string) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		portS := os.Getenv(WorkerServerPort)
+		if portS == "" {
+			sdk.Exit("%s not found, are you running inside a CDS worker job?\n", WorkerServerPort)
+		}
+
+		port, errPort := strconv.Atoi(portS)
+		if errPort != nil {
+			sdk.Exit("cannot parse '%s' as a port number", portS)
+		}
+
+		if len(args) != 1 {
+			sdk.Exit("Wrong usage: Example : worker %s <file>", kind)
+		}
+
+		data, errMarshal := json.Marshal(filePath{Path: args[0]})
+		if errMarshal != nil {
+			sdk.Exit("internal error (%s)\n", errMarshal)
+		}
+
+		req, errRequest := http.NewRequest("POST", fmt.Sprintf("http://127.0.0.1:%d/%s", port, kind), bytes.NewReader(data))
+		if errRequest != nil {
+			sdk.Exit("cannot post worker %s (Request): %s\n", kind, errRequest)
+		}
+
+		client := http.DefaultClient
+		client.Timeout = 5 * time.Minute
+
+		resp, errDo := client.Do(req)
+		if errDo != nil {
+			sdk.Exit("command failed: %v\n", errDo)
+		}
+		defer resp.Body.Close()
+
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			sdk.Exit("%s failed: unable to read body %v\n", kind, err)
+		}
+		if resp.StatusCode >= 300 {
+			sdk.Exit("%s failed: %v\n", kind, sdk.DecodeError(body))
+		}
+
+		var report sdk.SecurityPolicyReport
+		if err := json.Unmarshal(body, &report); err != nil {
+			sdk.Exit("%s failed: unable to read security policy report %v\n", kind, err)
+		}
+		for _, v := range report.Violations {
+			fmt.Println(v)
+		}
+		if report.FailNode {
+			sdk.Exit("security policy of the project is not respected\n")
+		}
+	}
+}
+
+func (wk *currentWorker) sarifHandler(w http.ResponseWriter, r *http.Request) {
+	btes, err := wk.readSecurityReportFile(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	report, err := sdk.ParseSARIF(btes)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	res, err := wk.client.QueueJobSendVulnerabilityReport(ctx, wk.currentJob.wJob.ID, report)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, res, http.StatusOK)
+}
+
+func (wk *currentWorker) sbomHandler(w http.ResponseWriter, r *http.Request) {
+	btes, err := wk.readSecurityReportFile(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	report, err := sdk.ParseSBOM(btes)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	res, err := wk.client.QueueJobSendSBOM(ctx, wk.currentJob.wJob.ID, report)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	sendLog := getLogger(wk, wk.currentJob.wJob.ID, wk.currentJob.currentStep)
+	sendLog(fmt.Sprintf("%d components sent", len(report.Components)))
+	writeJSON(w, res, http.StatusOK)
+}
+
+func (wk *currentWorker) readSecurityReportFile(r *http.Request) ([]byte, error) {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, sdk.NewError(sdk.ErrWrongRequest, err)
+	}
+
+	var a filePath
+	if err := json.Unmarshal(data, &a); err != nil {
+		return nil, sdk.NewError(sdk.ErrWrongRequest, err)
+	}
+
+	btes, err := ioutil.ReadFile(a.Path)
+	if err != nil {
+		return nil, sdk.NewError(sdk.ErrWrongRequest, err)
+	}
+	return btes, nil
+}
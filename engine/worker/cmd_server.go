@@ -41,12 +41,16 @@ func (w *currentWorker) serve(c context.Context) (int, error) {
 	r.HandleFunc("/exit", w.exitHandler)
 	r.HandleFunc("/key/{key}/install", w.keyInstallHandler)
 	r.HandleFunc("/services/{type}", w.serviceHandler)
+	r.HandleFunc("/stages", w.stagesHandler)
 	r.HandleFunc("/tag", w.tagHandler)
 	r.HandleFunc("/tmpl", w.tmplHandler)
 	r.HandleFunc("/upload", w.uploadHandler)
 	r.HandleFunc("/checksecret", w.checkSecretHandler)
+	r.HandleFunc("/mask", w.maskHandler)
 	r.HandleFunc("/var", w.addBuildVarHandler)
 	r.HandleFunc("/vulnerability", w.vulnerabilityHandler)
+	r.HandleFunc("/sarif", w.sarifHandler)
+	r.HandleFunc("/sbom", w.sbomHandler)
 
 	srv := &http.Server{
 		Handler:      r,
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// max size of a file of generated stages
+const maxGeneratedStagesFileSize = 1 << 20
+
+func cmdAppendStages(w *currentWorker) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "append-stages",
+		Short: "worker append-stages stages.yml",
+		Long: fmt.Sprintf(`
+Inside a job, generate stages and jobs that are appended to the current pipeline run. The file uses the syntax of the stages and jobs of a pipeline:
+
+	# worker append-stages <file>
+	worker append-stages stages.yml
+
+	# stages.yml
+	stages:
+	- build
+	jobs:
+	- job: build-api
+	  stage: build
+	  steps:
+	  - script: make -C engine/api build
+
+The generated stages run after the stages of the pipeline. A generated job can also generate stages, up to %d generations,
+and a pipeline run can't have more than %d generated jobs.
+		`, sdk.MaxStageGeneration, sdk.MaxGeneratedJobs),
+		Run: appendStagesCmd(w),
+	}
+	return c
+}
+
+func appendStagesCmd(w *currentWorker) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		portS := os.Getenv(WorkerServerPort)
+		if portS == "" {
+			sdk.Exit("%s not found, are you running inside a CDS worker job?\n", WorkerServerPort)
+		}
+
+		port, errPort := strconv.Atoi(portS)
+		if errPort != nil {
+			sdk.Exit("cannot parse '%s' as a port number", portS)
+		}
+
+		if len(args) != 1 {
+			sdk.Exit("Wrong usage: Example : worker append-stages <file>")
+		}
+
+		data, errMarshal := json.Marshal(filePath{Path: args[0]})
+		if errMarshal != nil {
+			sdk.Exit("internal error (%s)\n", errMarshal)
+		}
+
+		req, errRequest := http.NewRequest("POST", fmt.Sprintf("http://127.0.0.1:%d/stages", port), bytes.NewReader(data))
+		if errRequest != nil {
+			sdk.Exit("cannot post worker append-stages (Request): %s\n", errRequest)
+		}
+
+		client := http.DefaultClient
+		client.Timeout = 5 * time.Minute
+
+		resp, errDo := client.Do(req)
+		if errDo != nil {
+			sdk.Exit("command failed: %v\n", errDo)
+		}
+		defer resp.Body.Close()
+
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			sdk.Exit("append-stages failed: unable to read body %v\n", err)
+		}
+		if resp.StatusCode >= 300 {
+			sdk.Exit("append-stages failed: %v\n", sdk.DecodeError(body))
+		}
+
+		var stages []sdk.Stage
+		if err := json.Unmarshal(body, &stages); err != nil {
+			sdk.Exit("append-stages failed: unable to read stages %v\n", err)
+		}
+		for _, s := range stages {
+			for _, j := range s.Jobs {
+				fmt.Printf("%s: %s\n", s.Name, j.Action.Name)
+			}
+		}
+	}
+}
+
+func (wk *currentWorker) stagesHandler(w http.ResponseWriter, r *http.Request) {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, r, sdk.NewError(sdk.ErrWrongRequest, err))
+		return
+	}
+	defer r.Body.Close() // nolint
+
+	var a filePath
+	if err := json.Unmarshal(data, &a); err != nil {
+		writeError(w, r, sdk.NewError(sdk.ErrWrongRequest, err))
+		return
+	}
+	if !path.IsAbs(a.Path) {
+		a.Path = path.Join(wk.currentJob.workingDirectory, a.Path)
+	}
+
+	fi, err := os.Stat(a.Path)
+	if err != nil {
+		writeError(w, r, sdk.NewError(sdk.ErrWrongRequest, err))
+		return
+	}
+	if fi.Size() > maxGeneratedStagesFileSize {
+		writeError(w, r, sdk.NewErrorFrom(sdk.ErrWrongRequest, "file %s is bigger than %d bytes", a.Path, maxGeneratedStagesFileSize))
+		return
+	}
+	btes, err := ioutil.ReadFile(a.Path)
+	if err != nil {
+		writeError(w, r, sdk.NewError(sdk.ErrWrongRequest, err))
+		return
+	}
+
+	var p exportentities.PipelineV1
+	if err := exportentities.Unmarshal(btes, exportentities.FormatYAML, &p); err != nil {
+		writeError(w, r, sdk.NewErrorFrom(sdk.ErrWrongRequest, "cannot parse file %s: %v", a.Path, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	stages, err := wk.client.QueueJobAppendStages(ctx, wk.currentJob.wJob.ID, p)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	var nbJobs int
+	for _, s := range stages {
+		nbJobs += len(s.Jobs)
+	}
+	sendLog := getLogger(wk, wk.currentJob.wJob.ID, wk.currentJob.currentStep)
+	sendLog(fmt.Sprintf("%d stages with %d jobs appended to the pipeline", len(stages), nbJobs))
+	writeJSON(w, stages, http.StatusOK)
+}
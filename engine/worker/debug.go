@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// debugShell is a shell started in the workspace of a failed job for a debug session
+type debugShell struct {
+	cmd  *exec.Cmd
+	ptm  *os.File
+	done chan struct{}
+}
+
+func startDebugShell(ws *websocket.Conn, wd string, env []string) (*debugShell, error) {
+	shell := "sh"
+	if _, err := exec.LookPath("bash"); err == nil {
+		shell = "bash"
+	}
+
+	cmd := exec.Command(shell)
+	cmd.Dir = wd
+	cmd.Env = append(env, "TERM=xterm")
+	ptm, err := startPty(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &debugShell{cmd: cmd, ptm: ptm, done: make(chan struct{})}
+	go func() {
+		defer close(s.done)
+		buf := make([]byte, 4096)
+		for {
+			n, err := ptm.Read(buf)
+			if n > 0 {
+				if err := websocket.JSON.Send(ws, sdk.DebugSessionMessage{Type: sdk.DebugSessionMessageData, Data: buf[:n]}); err != nil {
+					log.Debug("debugSession> cannot send output: %v", err)
+				}
+			}
+			if err != nil {
+				// reading the master side fails when the shell exits
+				_ = cmd.Wait()
+				return
+			}
+		}
+	}()
+	return s, nil
+}
+
+func (s *debugShell) stop() {
+	if s.cmd.Process != nil {
+		_ = s.cmd.Process.Kill()
+	}
+	// closing the master side unblocks the output reader if a process started by the shell keeps the terminal open
+	s.ptm.Close()
+	<-s.done
+}
+
+// debugSession keeps the worker alive after a job failure and gives a shell in the workspace of the job
+// to the users attached with cdsctl, until the shell exits or the keep on failure delay expires.
+func (w *currentWorker) debugSession(ctx context.Context, jobInfo *sdk.WorkflowNodeJobRunData, wd string) {
+	jobID := jobInfo.NodeJobRun.ID
+	timeout := time.Duration(jobInfo.NodeJobRun.Job.Action.KeepOnFailure) * time.Minute
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	env, err := w.environ(jobInfo.NodeJobRun.Parameters)
+	if err != nil {
+		log.Error("debugSession> cannot compute environment: %v", err)
+		return
+	}
+
+	ws, err := w.client.QueueJobDebugSession(jobID)
+	if err != nil {
+		log.Error("debugSession> cannot open debug session for job %d: %v", jobID, err)
+		return
+	}
+	defer ws.Close()
+
+	params := sdk.ParametersToMap(jobInfo.NodeJobRun.Parameters)
+	_ = w.sendLog(jobID, fmt.Sprintf("Worker kept alive %s for debugging, attach with: cdsctl workflow debug %s %s %s %s\n",
+		timeout, params["cds.project"], params["cds.workflow"], params["cds.run.number"], params["cds.job"]), w.currentJob.currentStep, false)
+
+	msgs := make(chan sdk.DebugSessionMessage)
+	go func() {
+		defer close(msgs)
+		for {
+			var msg sdk.DebugSessionMessage
+			if err := websocket.JSON.Receive(ws, &msg); err != nil {
+				if err != io.EOF && ctx.Err() == nil {
+					log.Warning("debugSession> cannot read message: %v", err)
+				}
+				return
+			}
+			select {
+			case msgs <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var shell *debugShell
+	defer func() {
+		if shell != nil {
+			shell.stop()
+		}
+		_ = websocket.JSON.Send(ws, sdk.DebugSessionMessage{Type: sdk.DebugSessionMessageClose})
+		_ = w.sendLog(jobID, "Debug session ended\n", w.currentJob.currentStep, true)
+		log.Info("debugSession> debug session ended for job %d", jobID)
+	}()
+
+	for {
+		// the done channel is nil while no shell is started
+		var shellDone chan struct{}
+		if shell != nil {
+			shellDone = shell.done
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-shellDone:
+			// the user exited the shell
+			shell.ptm.Close()
+			shell = nil
+			return
+		case msg, ok := <-msgs:
+			if !ok {
+				return
+			}
+			switch msg.Type {
+			case sdk.DebugSessionMessageClose:
+				// the user detached, a new shell is started on the next attach
+				if shell != nil {
+					shell.stop()
+					shell = nil
+				}
+				continue
+			case sdk.DebugSessionMessageData, sdk.DebugSessionMessageResize:
+			default:
+				continue
+			}
+
+			if shell == nil {
+				log.Info("debugSession> starting debug shell for job %d", jobID)
+				shell, err = startDebugShell(ws, wd, env)
+				if err != nil {
+					log.Error("debugSession> cannot start shell: %v", err)
+					_ = websocket.JSON.Send(ws, sdk.DebugSessionMessage{Type: sdk.DebugSessionMessageData, Data: []byte(err.Error() + "\r\n")})
+					return
+				}
+			}
+
+			if msg.Type == sdk.DebugSessionMessageResize {
+				if err := resizePty(shell.ptm, msg.Cols, msg.Rows); err != nil {
+					log.Warning("debugSession> cannot resize terminal: %v", err)
+				}
+				continue
+			}
+			if _, err := shell.ptm.Write(msg.Data); err != nil {
+				log.Warning("debugSession> cannot write to shell: %v", err)
+			}
+		}
+	}
+}
@@ -40,6 +40,7 @@ const (
 	flagToken               = "token"
 	flagName                = "name"
 	flagModel               = "model"
+	flagModelVersion        = "model-version"
 	flagHatcheryName        = "hatchery-name"
 )
 
@@ -66,6 +67,7 @@ func initFlagsRun(cmd *cobra.Command) {
 	flags.String(flagToken, "", "CDS Token")
 	flags.String(flagName, "", "Name of worker")
 	flags.Int(flagModel, 0, "Model of worker")
+	flags.Int64(flagModelVersion, 0, "Version of the model of worker")
 	flags.String(flagHatcheryName, "", "Hatchery Name spawing worker")
 }
 
@@ -180,7 +182,7 @@ func initFlags(cmd *cobra.Command, w *currentWorker) {
 		os.Exit(4)
 	}
 
-	w.model = sdk.Model{ID: int64(FlagInt(cmd, flagModel))}
+	w.model = sdk.Model{ID: int64(FlagInt(cmd, flagModel)), Version: FlagInt64(cmd, flagModelVersion)}
 
 	w.basedir = FlagString(cmd, flagBaseDir)
 	if w.basedir == "" {
@@ -5,7 +5,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
@@ -15,14 +14,8 @@ import (
 	"github.com/ovh/cds/sdk/log"
 )
 
-var logsecrets []sdk.Variable
-
 func (wk *currentWorker) sendLog(buildID int64, value string, stepOrder int, final bool) error {
-	for i := range logsecrets {
-		if len(logsecrets[i].Value) >= sdk.SecretMinLength {
-			value = strings.Replace(value, logsecrets[i].Value, "**"+logsecrets[i].Name+"**", -1)
-		}
-	}
+	value = logsecrets.mask(value)
 
 	l := sdk.NewLog(buildID, value, wk.currentJob.wJob.WorkflowNodeRunID, stepOrder)
 	if final {
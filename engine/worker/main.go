@@ -43,6 +43,7 @@ type currentWorker struct {
 		params           []sdk.Parameter
 		secrets          []sdk.Variable
 		workingDirectory string
+		resourceUsage    []sdk.StepResourceUsage
 	}
 	status struct {
 		Name   string `json:"name"`
@@ -62,7 +63,11 @@ func main() {
 	cmd.AddCommand(cmdDownload(w))
 	cmd.AddCommand(cmdTmpl(w))
 	cmd.AddCommand(cmdCheckSecret(w))
+	cmd.AddCommand(cmdMask(w))
 	cmd.AddCommand(cmdTag(w))
+	cmd.AddCommand(cmdSARIF(w))
+	cmd.AddCommand(cmdSBOM(w))
+	cmd.AddCommand(cmdAppendStages(w))
 	cmd.AddCommand(cmdRun(w))
 	cmd.AddCommand(cmdUpdate(w))
 	cmd.AddCommand(cmdExit(w))
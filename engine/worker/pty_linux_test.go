@@ -0,0 +1,24 @@
+package main
+
+import (
+	"io/ioutil"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartPty(t *testing.T) {
+	cmd := exec.Command("sh", "-c", "tty && echo $FOO")
+	cmd.Env = []string{"FOO=bar"}
+	ptm, err := startPty(cmd)
+	assert.NoError(t, err)
+	defer ptm.Close()
+	assert.NoError(t, resizePty(ptm, 80, 24))
+
+	// reading the master side fails once the command exits
+	out, _ := ioutil.ReadAll(ptm)
+	assert.NoError(t, cmd.Wait())
+	assert.Contains(t, string(out), "/dev/pts/")
+	assert.Contains(t, string(out), "bar")
+}
@@ -0,0 +1,20 @@
+// +build !linux
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// startPty is only implemented on linux
+func startPty(cmd *exec.Cmd) (*os.File, error) {
+	return nil, fmt.Errorf("debug session is not supported on %s", runtime.GOOS)
+}
+
+// resizePty is only implemented on linux
+func resizePty(ptm *os.File, cols, rows uint16) error {
+	return nil
+}
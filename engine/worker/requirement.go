@@ -115,11 +115,11 @@ func checkModelRequirement(w *currentWorker, r sdk.Requirement) (bool, error) {
 	if w.model.ID == 0 {
 		return false, nil
 	}
-	t := strings.Split(r.Value, " ")
-	if len(t) > 0 {
-		return t[0] == w.model.Name, nil
+	name, version := sdk.ParseModelRequirement(r.Value)
+	if version > 0 && version != w.model.Version {
+		return false, nil
 	}
-	return false, nil
+	return name == w.model.Name, nil
 }
 
 func checkNetworkAccessRequirement(w *currentWorker, r sdk.Requirement) (bool, error) {
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const resourceSamplingInterval = 2 * time.Second
+
+// resourceCounters are the cumulative counters of the processes started by the worker
+type resourceCounters struct {
+	cpuSeconds float64
+	maxMemory  int64
+	diskRead   int64
+	diskWrite  int64
+	networkRx  int64
+	networkTx  int64
+}
+
+// resourceSampler samples the memory of the process tree of the worker while a step is running
+type resourceSampler struct {
+	stepOrder int
+	start     resourceCounters
+	maxMemory int64
+	done      chan struct{}
+	stopped   chan struct{}
+}
+
+func startResourceSampler(stepOrder int) *resourceSampler {
+	s := &resourceSampler{
+		stepOrder: stepOrder,
+		start:     readResourceCounters(),
+		done:      make(chan struct{}),
+		stopped:   make(chan struct{}),
+	}
+	go s.sample()
+	return s
+}
+
+func (s *resourceSampler) sample() {
+	defer close(s.stopped)
+	tick := time.NewTicker(resourceSamplingInterval)
+	defer tick.Stop()
+	for {
+		if m := processTreeMemory(); m > s.maxMemory {
+			s.maxMemory = m
+		}
+		select {
+		case <-s.done:
+			return
+		case <-tick.C:
+		}
+	}
+}
+
+// stop stops the sampling and returns the usage of the step
+func (s *resourceSampler) stop() sdk.StepResourceUsage {
+	close(s.done)
+	<-s.stopped
+
+	end := readResourceCounters()
+	// The peak memory of the children is only known once they are waited for, it is useful for steps
+	// shorter than the sampling interval
+	if end.maxMemory > s.start.maxMemory && end.maxMemory > s.maxMemory {
+		s.maxMemory = end.maxMemory
+	}
+	return sdk.StepResourceUsage{
+		StepOrder:  s.stepOrder,
+		CPUSeconds: end.cpuSeconds - s.start.cpuSeconds,
+		MaxMemory:  s.maxMemory,
+		DiskRead:   end.diskRead - s.start.diskRead,
+		DiskWrite:  end.diskWrite - s.start.diskWrite,
+		NetworkRx:  end.networkRx - s.start.networkRx,
+		NetworkTx:  end.networkTx - s.start.networkTx,
+	}
+}
+
+// sendResourceUsage sends the resource usage of the steps of the job, it must be sent before the job result
+func (w *currentWorker) sendResourceUsage(ctx context.Context, jobID int64) {
+	if len(w.currentJob.resourceUsage) == 0 {
+		return
+	}
+	usage := sdk.JobResourceUsage{Steps: w.currentJob.resourceUsage}
+	usage.Summarize()
+	w.currentJob.resourceUsage = nil
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := w.client.QueueSendResourceUsage(ctx, jobID, usage); err != nil {
+		log.Warning("sendResourceUsage> Unable to send resource usage of job %d: %v", jobID, err)
+	}
+}
+
+// parseProcStat parses the content of /proc/[pid]/stat and returns the pid, the parent pid and the resident
+// set size in pages
+func parseProcStat(content string) (pid, ppid, rss int64, err error) {
+	// The command name is between parenthesis and may contain spaces
+	i := strings.LastIndex(content, ")")
+	if i < 0 {
+		return 0, 0, 0, fmt.Errorf("invalid stat %q", content)
+	}
+	pid, err = strconv.ParseInt(strings.TrimSpace(content[:strings.Index(content, "(")]), 10, 64)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	// Fields after the command name start at the state (3rd field)
+	fields := strings.Fields(content[i+1:])
+	if len(fields) < 22 {
+		return 0, 0, 0, fmt.Errorf("invalid stat %q", content)
+	}
+	if ppid, err = strconv.ParseInt(fields[1], 10, 64); err != nil {
+		return 0, 0, 0, err
+	}
+	if rss, err = strconv.ParseInt(fields[21], 10, 64); err != nil {
+		return 0, 0, 0, err
+	}
+	return pid, ppid, rss, nil
+}
+
+// parseNetDev parses the content of /proc/net/dev and returns the bytes received and transmitted
+// on all interfaces but the loopback
+func parseNetDev(r io.Reader) (rx, tx int64, err error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		i := strings.Index(line, ":")
+		if i < 0 {
+			continue
+		}
+		if strings.TrimSpace(line[:i]) == "lo" {
+			continue
+		}
+		fields := strings.Fields(line[i+1:])
+		if len(fields) < 9 {
+			continue
+		}
+		r, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+		t, err := strconv.ParseInt(fields[8], 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+		rx += r
+		tx += t
+	}
+	return rx, tx, scanner.Err()
+}
+
+// descendantsMemory returns the sum of the memory of the descendants of root
+func descendantsMemory(root int64, parents map[int64]int64, memory map[int64]int64) int64 {
+	children := map[int64][]int64{}
+	for pid, ppid := range parents {
+		children[ppid] = append(children[ppid], pid)
+	}
+	var total int64
+	queue := children[root]
+	for len(queue) > 0 {
+		pid := queue[0]
+		queue = queue[1:]
+		total += memory[pid]
+		queue = append(queue, children[pid]...)
+	}
+	return total
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/ovh/cds/sdk/log"
+)
+
+// readResourceCounters reads the usage of the children waited by the worker and the network counters
+// of the host (or the container)
+func readResourceCounters() resourceCounters {
+	var c resourceCounters
+	var ru syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_CHILDREN, &ru); err != nil {
+		log.Debug("readResourceCounters> unable to get rusage: %v", err)
+	} else {
+		c.cpuSeconds = float64(ru.Utime.Sec+ru.Stime.Sec) + float64(ru.Utime.Usec+ru.Stime.Usec)/1e6
+		// maxrss is in kilobytes, inblock and oublock are 512 bytes blocks
+		c.maxMemory = int64(ru.Maxrss) * 1024
+		c.diskRead = int64(ru.Inblock) * 512
+		c.diskWrite = int64(ru.Oublock) * 512
+	}
+
+	f, err := os.Open("/proc/net/dev")
+	if err != nil {
+		log.Debug("readResourceCounters> unable to read network counters: %v", err)
+		return c
+	}
+	defer f.Close() // nolint
+	if c.networkRx, c.networkTx, err = parseNetDev(f); err != nil {
+		log.Debug("readResourceCounters> unable to parse network counters: %v", err)
+	}
+	return c
+}
+
+// processTreeMemory returns the resident memory in bytes of the running descendants of the worker
+func processTreeMemory() int64 {
+	stats, err := filepath.Glob("/proc/[0-9]*/stat")
+	if err != nil {
+		return 0
+	}
+	parents := make(map[int64]int64, len(stats))
+	memory := make(map[int64]int64, len(stats))
+	for _, s := range stats {
+		btes, err := ioutil.ReadFile(s)
+		if err != nil {
+			// The process may have ended
+			continue
+		}
+		pid, ppid, rss, err := parseProcStat(string(btes))
+		if err != nil {
+			continue
+		}
+		parents[pid] = ppid
+		memory[pid] = rss * int64(os.Getpagesize())
+	}
+	return descendantsMemory(int64(os.Getpid()), parents, memory)
+}
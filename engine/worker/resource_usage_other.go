@@ -0,0 +1,13 @@
+// +build !linux
+
+package main
+
+// readResourceCounters is only implemented on linux
+func readResourceCounters() resourceCounters {
+	return resourceCounters{}
+}
+
+// processTreeMemory is only implemented on linux
+func processTreeMemory() int64 {
+	return 0
+}
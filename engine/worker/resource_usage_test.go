@@ -0,0 +1,39 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProcStat(t *testing.T) {
+	pid, ppid, rss, err := parseProcStat("4242 (go build (1)) S 4241 4242 4200 0 -1 4194560 1200 0 0 0 12 3 0 0 20 0 9 0 1234 987654 2048 18446744073709551615 1 1 0 0 0 0 0 0 0 0 0 0 17 2 0 0 0 0 0")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4242), pid)
+	assert.Equal(t, int64(4241), ppid)
+	assert.Equal(t, int64(2048), rss)
+
+	_, _, _, err = parseProcStat("4242 (bash) S")
+	assert.Error(t, err)
+}
+
+func TestParseNetDev(t *testing.T) {
+	netdev := `Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+    lo: 1000      10    0    0    0     0          0         0     1000      10    0    0    0     0       0          0
+  eth0: 2000      20    0    0    0     0          0         0      300       3    0    0    0     0       0          0
+  eth1:  500       5    0    0    0     0          0         0       50       1    0    0    0     0       0          0
+`
+	rx, tx, err := parseNetDev(strings.NewReader(netdev))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2500), rx)
+	assert.Equal(t, int64(350), tx)
+}
+
+func TestDescendantsMemory(t *testing.T) {
+	parents := map[int64]int64{1: 0, 10: 1, 11: 10, 12: 11, 13: 10, 20: 1}
+	memory := map[int64]int64{1: 1000, 10: 100, 11: 10, 12: 1, 13: 5, 20: 2000}
+	assert.Equal(t, int64(16), descendantsMemory(10, parents, memory))
+	assert.Equal(t, int64(0), descendantsMemory(12, parents, memory))
+}
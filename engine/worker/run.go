@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/user"
 	"path"
+	"regexp"
 	"strings"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/ovh/cds/sdk/cdsclient"
 	"github.com/ovh/cds/sdk/interpolate"
 	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/luascript"
 	"github.com/ovh/cds/sdk/vcs"
 )
 
@@ -199,16 +201,111 @@ func (w *currentWorker) runJob(ctx context.Context, a *sdk.Action, buildID int64
 		r.Status = sdk.StatusDisabled.String()
 	}
 
+	if r.Status == sdk.StatusSuccess.String() {
+		var err error
+		switch a.Type {
+		case sdk.RepositoryAction:
+			err = w.exportActionOutputs(ctx, a, buildID, params)
+		case sdk.JoinedAction:
+			err = w.exportOutputs(ctx, a.Outputs, sdk.JobOutputVariablePrefix+a.Name+".", a.Parameters, buildID, params)
+		}
+		if err != nil {
+			r.Status = sdk.StatusFail.String()
+			r.Reason = err.Error()
+		}
+	}
+
 	return r
 }
 
+// exportActionOutputs exports the outputs of an action defined in a repository as build variables named
+// cds.build.<step name or action name>.<output name>
+func (w *currentWorker) exportActionOutputs(ctx context.Context, a *sdk.Action, buildID int64, params *[]sdk.Parameter) error {
+	if len(a.Outputs) == 0 {
+		return nil
+	}
+	prefix := a.StepName
+	if prefix == "" {
+		ref, err := sdk.ParseActionRepositoryRef(a.Name)
+		if err != nil {
+			return err
+		}
+		prefix = ref.Name
+	}
+	return w.exportOutputs(ctx, a.Outputs, "cds.build."+prefix+".", a.Parameters, buildID, params)
+}
+
+// exportOutputs interpolates the outputs with the variables of the job, checks their type then exports them as build
+// variables named <prefix><output name>. The file of a file output is uploaded as an artifact, and the name of the
+// artifact is exported.
+func (w *currentWorker) exportOutputs(ctx context.Context, outputs sdk.ActionOutputs, prefix string, actionParams []sdk.Parameter, buildID int64, params *[]sdk.Parameter) error {
+	if len(outputs) == 0 {
+		return nil
+	}
+
+	tmp := map[string]string{}
+	for _, v := range w.currentJob.buildVariables {
+		tmp[v.Name] = v.Value
+	}
+	for _, v := range *params {
+		tmp[v.Name] = v.Value
+	}
+	for _, v := range actionParams {
+		tmp[v.Name] = v.Value
+	}
+
+	for _, o := range outputs {
+		value, err := interpolate.Do(o.Value, tmp)
+		if err != nil {
+			return sdk.WrapError(err, "unable to interpolate output %s", o.Name)
+		}
+		if err := o.CheckValue(value); err != nil {
+			return err
+		}
+		if o.Type == sdk.FileOutput {
+			value, err = w.uploadOutputFile(ctx, o, value, buildID, *params)
+			if err != nil {
+				return err
+			}
+		}
+		v := sdk.Variable{
+			Name:  prefix + o.Name,
+			Type:  sdk.StringVariable,
+			Value: value,
+		}
+		if _, err := w.addVariableInPipelineBuild(v, params); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// uploadOutputFile uploads the file of a file output as an artifact then returns the name of the artifact.
+func (w *currentWorker) uploadOutputFile(ctx context.Context, o sdk.ActionOutput, filePath string, buildID int64, params []sdk.Parameter) (string, error) {
+	if !path.IsAbs(filePath) {
+		filePath = path.Join(w.currentJob.workingDirectory, filePath)
+	}
+	if fi, err := os.Stat(filePath); err != nil || fi.IsDir() {
+		return "", fmt.Errorf("file %s of output %s not found", filePath, o.Name)
+	}
+
+	projectKey := sdk.ParameterValue(params, "cds.project")
+	tag := sdk.ParameterValue(params, "cds.version")
+	if _, _, err := w.client.QueueArtifactUpload(ctx, projectKey, sdk.DefaultIfEmptyStorage(""), buildID, tag, filePath); err != nil {
+		return "", sdk.WrapError(err, "unable to upload file %s of output %s", filePath, o.Name)
+	}
+	return path.Base(filePath), nil
+}
+
 func (w *currentWorker) runSteps(ctx context.Context, steps []sdk.Action, a *sdk.Action, buildID int64, params *[]sdk.Parameter, secrets []sdk.Variable, stepOrder int, stepName string, stepBaseCount int) (sdk.Result, int) {
 	log.Info("runSteps> start run %d stepOrder:%d len(steps):%d context=%p", buildID, stepOrder, len(steps), ctx)
 	defer func() {
 		log.Info("runSteps> end run %d stepOrder:%d len(steps):%d context=%p (%s)", buildID, stepOrder, len(steps), ctx, ctx.Err())
 	}()
-	var criticalStepFailed bool
+	var criticalStepFailed, stepFailed bool
 	var nbDisabledChildren int
+	// statuses of the previous named steps, available in step conditions
+	stepStatuses := map[string]string{}
 
 	// Nothing to do, success !
 	if len(steps) == 0 {
@@ -248,16 +345,49 @@ func (w *currentWorker) runSteps(ctx context.Context, steps []sdk.Action, a *sdk
 			continue
 		}
 
-		if !criticalStepFailed || child.AlwaysExecuted {
-			// Update step status
-			if err := w.updateStepStatus(ctx, buildID, w.currentJob.currentStep, sdk.StatusBuilding.String()); err != nil {
-				log.Warning("Cannot update step (%d) status (%s) for build %d: %s\n", w.currentJob.currentStep, sdk.StatusDisabled.String(), buildID, err)
+		// a step with a condition on the status of the previous steps can run after a failure, ie. with cds_status == "Fail"
+		if !criticalStepFailed || child.AlwaysExecuted || stepConditionChecksStatus(child.Condition) {
+			conditionOK, errCondition := checkStepCondition(child.Condition, *params, stepFailed, stepStatuses)
+			if errCondition == nil && !conditionOK {
+				if err := w.updateStepStatus(ctx, buildID, w.currentJob.currentStep, sdk.StatusSkipped.String()); err != nil {
+					log.Warning("Cannot update step (%d) status (%s) for build %d: %s", w.currentJob.currentStep, sdk.StatusSkipped.String(), buildID, err)
+				}
+				_ = w.sendLog(buildID, fmt.Sprintf("End of Step \"%s\" [Skipped - condition %s not met]\n", childName, child.Condition), w.currentJob.currentStep, true)
+				continue
 			}
-			_ = w.sendLog(buildID, fmt.Sprintf("Starting step \"%s\"\n", childName), w.currentJob.currentStep, false)
 
-			r = w.startAction(ctx, &child, buildID, params, secrets, w.currentJob.currentStep, childName)
+			if errCondition != nil {
+				r = sdk.Result{
+					Status:  sdk.StatusFail.String(),
+					BuildID: buildID,
+					Reason:  fmt.Sprintf("cannot evaluate condition %s: %v", child.Condition, errCondition),
+				}
+			} else {
+				// Update step status
+				if err := w.updateStepStatus(ctx, buildID, w.currentJob.currentStep, sdk.StatusBuilding.String()); err != nil {
+					log.Warning("Cannot update step (%d) status (%s) for build %d: %s\n", w.currentJob.currentStep, sdk.StatusDisabled.String(), buildID, err)
+				}
+				_ = w.sendLog(buildID, fmt.Sprintf("Starting step \"%s\"\n", childName), w.currentJob.currentStep, false)
+
+				// Only the steps of the job are sampled, the usage of nested actions is part of their step
+				var sampler *resourceSampler
+				if stepOrder == -1 {
+					sampler = startResourceSampler(w.currentJob.currentStep)
+				}
+				r = w.startAction(ctx, &child, buildID, params, secrets, w.currentJob.currentStep, childName)
+				if sampler != nil {
+					w.currentJob.resourceUsage = append(w.currentJob.resourceUsage, sampler.stop())
+				}
+			}
 			if r.Status != sdk.StatusSuccess.String() && !child.Optional {
-				criticalStepFailed = true
+				stepFailed = true
+				// the next steps run if the step can continue on error, but the job fails
+				if !child.ContinueOnError {
+					criticalStepFailed = true
+				}
+			}
+			if child.StepName != "" {
+				stepStatuses[child.StepName] = r.Status
 			}
 
 			if r.Reason != "" {
@@ -270,7 +400,7 @@ func (w *currentWorker) runSteps(ctx context.Context, steps []sdk.Action, a *sdk
 			if err := w.updateStepStatus(ctx, buildID, w.currentJob.currentStep, r.Status); err != nil {
 				log.Warning("Cannot update step (%d) status (%s) for build %d: %s", w.currentJob.currentStep, sdk.StatusDisabled.String(), buildID, err)
 			}
-		} else if criticalStepFailed && !child.AlwaysExecuted { // Update status of steps which are never built
+		} else { // Update status of steps which are never built
 			// Update step status
 			if err := w.updateStepStatus(ctx, buildID, w.currentJob.currentStep, sdk.StatusNeverBuilt.String()); err != nil {
 				log.Warning("Cannot update step (%d) status (%s) for build %d: %s", w.currentJob.currentStep, sdk.StatusNeverBuilt.String(), buildID, err)
@@ -278,7 +408,7 @@ func (w *currentWorker) runSteps(ctx context.Context, steps []sdk.Action, a *sdk
 		}
 	}
 
-	if criticalStepFailed {
+	if stepFailed {
 		r.Status = sdk.StatusFail.String()
 	} else {
 		r.Status = sdk.StatusSuccess.String()
@@ -287,6 +417,31 @@ func (w *currentWorker) runSteps(ctx context.Context, steps []sdk.Action, a *sdk
 	return r, nbDisabledChildren
 }
 
+// checkStepCondition evaluates the condition of a step with the variables of the job. The status of the previous steps
+// is available as cds.status, and the status of a previous named step as cds.step.<step name>.status.
+func checkStepCondition(condition string, params []sdk.Parameter, stepFailed bool, stepStatuses map[string]string) (bool, error) {
+	if condition == "" {
+		return true, nil
+	}
+	vars := sdk.ParametersToMap(params)
+	vars["cds.status"] = sdk.StatusSuccess.String()
+	if stepFailed {
+		vars["cds.status"] = sdk.StatusFail.String()
+	}
+	for name, status := range stepStatuses {
+		vars["cds.step."+name+".status"] = status
+	}
+	return luascript.CheckExpression(condition, vars)
+}
+
+var stepConditionStatusRegexp = regexp.MustCompile(`\bcds_(status|step_\w+_status)\b`)
+
+// stepConditionChecksStatus returns true if the condition of a step uses the status of the previous steps,
+// other conditions are not evaluated after a failure as if they were combined with cds_status == "Success"
+func stepConditionChecksStatus(condition string) bool {
+	return stepConditionStatusRegexp.MatchString(condition)
+}
+
 func (w *currentWorker) updateStepStatus(ctx context.Context, buildID int64, stepOrder int, status string) error {
 	step := sdk.StepStatus{
 		StepOrder: stepOrder,
@@ -352,6 +507,31 @@ func workingDirectory(basedir string, jobInfo *sdk.WorkflowNodeJobRunData, suffi
 	return dir
 }
 
+// checkJobCondition evaluates the condition of the job with its variables, the job is skipped if it's false.
+func (w *currentWorker) checkJobCondition(jobInfo *sdk.WorkflowNodeJobRunData) (sdk.Result, bool) {
+	condition := jobInfo.NodeJobRun.Job.Action.Condition
+	if condition == "" {
+		return sdk.Result{}, true
+	}
+	ok, err := luascript.CheckExpression(condition, sdk.ParametersToMap(jobInfo.NodeJobRun.Parameters))
+	if err != nil {
+		reason := fmt.Sprintf("Error: cannot evaluate job condition %s: %v", condition, err)
+		_ = w.sendLog(jobInfo.NodeJobRun.ID, reason+"\n", 0, true)
+		return sdk.Result{
+			Status: sdk.StatusFail.String(),
+			Reason: reason,
+		}, false
+	}
+	if !ok {
+		_ = w.sendLog(jobInfo.NodeJobRun.ID, fmt.Sprintf("Job skipped, condition %s not met\n", condition), 0, true)
+		return sdk.Result{
+			Status: sdk.StatusSkipped.String(),
+			Reason: fmt.Sprintf("condition %s not met", condition),
+		}, false
+	}
+	return sdk.Result{}, true
+}
+
 func (w *currentWorker) processJob(ctx context.Context, jobInfo *sdk.WorkflowNodeJobRunData) sdk.Result {
 	t0 := time.Now()
 	ctx, cancel := context.WithTimeout(ctx, 6*time.Hour)
@@ -437,9 +617,16 @@ func (w *currentWorker) processJob(ctx context.Context, jobInfo *sdk.WorkflowNod
 		}
 	}
 
-	logsecrets = jobInfo.Secrets
-	res := w.startAction(ctx, &jobInfo.NodeJobRun.Job.Action, jobInfo.NodeJobRun.ID, &jobInfo.NodeJobRun.Parameters, logsecrets, -1, "")
-	logsecrets = nil
+	logsecrets.reset(jobInfo.Secrets)
+	res, run := w.checkJobCondition(jobInfo)
+	if run {
+		res = w.startAction(ctx, &jobInfo.NodeJobRun.Job.Action, jobInfo.NodeJobRun.ID, &jobInfo.NodeJobRun.Parameters, jobInfo.Secrets, -1, "")
+	}
+	// the job stays building and its workspace is kept during the debug session
+	if res.Status == sdk.StatusFail.String() && jobInfo.NodeJobRun.Job.Action.KeepOnFailure > 0 && ctx.Err() == nil {
+		w.debugSession(ctx, jobInfo, wd)
+	}
+	logsecrets.reset(nil)
 
 	if err := teardownBuildDirectory(wd); err != nil {
 		log.Error("Cannot remove build directory: %s", err)
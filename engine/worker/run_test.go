@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestCheckStepCondition(t *testing.T) {
+	params := []sdk.Parameter{{Name: "git.branch", Type: sdk.StringParameter, Value: "master"}}
+
+	ok, err := checkStepCondition("", params, true, nil)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = checkStepCondition(`git_branch == "master" and cds_status == "Success"`, params, false, nil)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = checkStepCondition(`cds_status == "Success"`, params, true, nil)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = checkStepCondition(`cds_step_test_status == "Fail"`, params, true, map[string]string{"test": sdk.StatusFail.String()})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = checkStepCondition(`git_branch ==`, params, false, nil)
+	assert.Error(t, err)
+}
+
+func TestStepConditionChecksStatus(t *testing.T) {
+	assert.False(t, stepConditionChecksStatus(""))
+	assert.False(t, stepConditionChecksStatus(`git_branch == "master"`))
+	assert.True(t, stepConditionChecksStatus(`cds_status == "Fail"`))
+	assert.True(t, stepConditionChecksStatus(`git_branch == "master" and cds_step_test_status == "Fail"`))
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/base64"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// logsecrets masks the secrets of the current job in its logs
+var logsecrets = &secretMasker{}
+
+// secretMasker replaces the known secret values, and their encoded forms, in logs
+type secretMasker struct {
+	mutex   sync.RWMutex
+	secrets []maskedSecret
+}
+
+type maskedSecret struct {
+	value       string
+	replacement string
+}
+
+// reset replaces the known secrets by the given variables
+func (m *secretMasker) reset(vars []sdk.Variable) {
+	m.mutex.Lock()
+	m.secrets = nil
+	m.mutex.Unlock()
+	for _, v := range vars {
+		m.add(v.Name, v.Value)
+	}
+}
+
+// add adds a secret value with its base64, url and json encoded forms. As logs are sent line by line,
+// each line of a multi-line secret (like a private key) is also masked
+func (m *secretMasker) add(name, value string) {
+	replacement := "**" + name + "**"
+
+	values := secretForms(value)
+	if strings.Contains(value, "\n") {
+		for _, line := range strings.Split(value, "\n") {
+			line = strings.TrimSpace(line)
+			// Keep the armor of the keys readable
+			if strings.HasPrefix(line, "-----") {
+				continue
+			}
+			values = append(values, secretForms(line)...)
+		}
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for _, v := range values {
+		if len(v) < sdk.SecretMinLength || m.has(v) {
+			continue
+		}
+		m.secrets = append(m.secrets, maskedSecret{value: v, replacement: replacement})
+	}
+	// Longest values first to never leave a part of a secret containing another one
+	sort.SliceStable(m.secrets, func(i, j int) bool {
+		return len(m.secrets[i].value) > len(m.secrets[j].value)
+	})
+}
+
+func (m *secretMasker) has(value string) bool {
+	for _, s := range m.secrets {
+		if s.value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// mask replaces all the known secrets in s
+func (m *secretMasker) mask(s string) string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	for _, secret := range m.secrets {
+		if strings.Contains(s, secret.value) {
+			s = strings.Replace(s, secret.value, secret.replacement, -1)
+		}
+	}
+	return s
+}
+
+func secretForms(value string) []string {
+	if len(value) < sdk.SecretMinLength {
+		return nil
+	}
+	quoted := strconv.Quote(value)
+	return []string{
+		value,
+		base64.StdEncoding.EncodeToString([]byte(value)),
+		base64.RawStdEncoding.EncodeToString([]byte(value)),
+		base64.URLEncoding.EncodeToString([]byte(value)),
+		base64.RawURLEncoding.EncodeToString([]byte(value)),
+		url.QueryEscape(value),
+		url.PathEscape(value),
+		quoted[1 : len(quoted)-1],
+	}
+}
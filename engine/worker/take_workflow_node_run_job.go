@@ -42,6 +42,7 @@ func (w *currentWorker) takeWorkflowJob(ctx context.Context, job sdk.WorkflowNod
 	w.currentJob.gitsshPath = ""
 	w.currentJob.pkey = ""
 	w.currentJob.buildVariables = nil
+	w.currentJob.resourceUsage = nil
 
 	start := time.Now()
 
@@ -104,6 +105,7 @@ func (w *currentWorker) takeWorkflowJob(ctx context.Context, job sdk.WorkflowNod
 
 	//Wait until the logchannel is empty
 	w.drainLogsAndCloseLogger(ctx)
+	w.sendResourceUsage(ctx, job.ID)
 	res.BuildID = job.ID
 	// Try to send result through grpc
 	if w.grpc.conn != nil {
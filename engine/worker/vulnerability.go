@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -23,11 +24,23 @@ func (wk *currentWorker) vulnerabilityHandler(w http.ResponseWriter, r *http.Req
 
 	var lasterr error
 	var code int
+	var body []byte
 	for try := 1; try <= 10; try++ {
 		log.Info("vulnerabilityHandler> Sending vulnerability report...")
-		_, code, lasterr = sdk.Request("POST", uri, data)
+		body, code, lasterr = sdk.Request("POST", uri, data)
 		if lasterr == nil && code < 300 {
 			log.Info("vulnerabilityHandler> Send vulnerability report OK")
+			var report sdk.SecurityPolicyReport
+			if err := json.Unmarshal(body, &report); err != nil {
+				log.Error("vulnerabilityHandler> Cannot read security policy report: %v", err)
+				writeError(w, r, sdk.WithStack(err))
+				return
+			}
+			sendLog := getLogger(wk, wk.currentJob.wJob.ID, wk.currentJob.currentStep)
+			for _, v := range report.Violations {
+				sendLog(v)
+			}
+			writeJSON(w, report, http.StatusOK)
 			return
 		}
 		log.Warning("vulnerabilityHandler> Cannot send vulnerability report: HTTP %d err: %s - try: %d - new try in 5s", code, lasterr, try)
@@ -5,6 +5,8 @@ import (
 	json "encoding/json"
 
 	"github.com/pkg/errors"
+
+	"github.com/ovh/cds/sdk/luascript"
 )
 
 // Action type
@@ -13,6 +15,8 @@ const (
 	BuiltinAction = "Builtin"
 	PluginAction  = "Plugin"
 	JoinedAction  = "Joined"
+	// RepositoryAction is an action defined as code in a repository, see ActionRepositoryRef
+	RepositoryAction = "Repository"
 )
 
 // Builtin Action
@@ -29,6 +33,9 @@ const (
 	DefaultGitCloneParameterTagValue = "{{.git.tag}}"
 )
 
+// MaxKeepOnFailure is the maximum number of minutes a worker can be kept alive after a job failure.
+const MaxKeepOnFailure = 60
+
 // NewAction instanciate a new Action
 func NewAction(name string) *Action {
 	return &Action{
@@ -39,17 +46,26 @@ func NewAction(name string) *Action {
 
 // Action is the base element of CDS pipeline
 type Action struct {
-	ID          int64  `json:"id" yaml:"-" db:"id"`
-	GroupID     *int64 `json:"group_id,omitempty" yaml:"-" db:"group_id"`
-	Name        string `json:"name" db:"name"`
-	Type        string `json:"type" yaml:"-" db:"type"`
-	Description string `json:"description" yaml:"desc,omitempty" db:"description"`
-	Enabled     bool   `json:"enabled" yaml:"-" db:"enabled"`
-	Deprecated  bool   `json:"deprecated" yaml:"-" db:"deprecated"`
+	ID          int64         `json:"id" yaml:"-" db:"id"`
+	GroupID     *int64        `json:"group_id,omitempty" yaml:"-" db:"group_id"`
+	Name        string        `json:"name" db:"name"`
+	Type        string        `json:"type" yaml:"-" db:"type"`
+	Description string        `json:"description" yaml:"desc,omitempty" db:"description"`
+	Enabled     bool          `json:"enabled" yaml:"-" db:"enabled"`
+	Deprecated  bool          `json:"deprecated" yaml:"-" db:"deprecated"`
+	Outputs     ActionOutputs `json:"outputs,omitempty" yaml:"-" db:"outputs"`
+	// Condition is a lua expression evaluated by the worker, the job or the step runs only if it's true. For a step
+	// it's an aggregate from action_edge, it is evaluated after a failure of a previous step only if it uses cds_status
+	// or the status of a previous step.
+	Condition string `json:"condition,omitempty" yaml:"-" db:"condition"`
+	// KeepOnFailure is the number of minutes a worker stays alive after a job failure to allow a debug session.
+	KeepOnFailure int `json:"keep_on_failure,omitempty" yaml:"-" db:"keep_on_failure"`
 	// aggregates from action_edge
 	StepName       string `json:"step_name,omitempty" yaml:"step_name,omitempty" db:"-"`
 	Optional       bool   `json:"optional" yaml:"-" db:"-"`
 	AlwaysExecuted bool   `json:"always_executed" yaml:"-" db:"-"`
+	// ContinueOnError runs the next steps when the step fails, unlike Optional the job still fails.
+	ContinueOnError bool `json:"continue_on_error,omitempty" yaml:"-" db:"-"`
 	// aggregates
 	Requirements RequirementList `json:"requirements" db:"-"`
 	Parameters   []Parameter     `json:"parameters" db:"-"`
@@ -100,6 +116,25 @@ func (a Action) IsValid() error {
 		return err
 	}
 
+	outputs := make(map[string]struct{}, len(a.Outputs))
+	for i := range a.Outputs {
+		if err := a.Outputs[i].IsValid(); err != nil {
+			return err
+		}
+		if _, ok := outputs[a.Outputs[i].Name]; ok {
+			return NewErrorFrom(ErrWrongRequest, "output %s is declared twice", a.Outputs[i].Name)
+		}
+		outputs[a.Outputs[i].Name] = struct{}{}
+	}
+
+	if err := a.CheckConditions(); err != nil {
+		return err
+	}
+
+	if a.KeepOnFailure < 0 || a.KeepOnFailure > MaxKeepOnFailure {
+		return NewErrorFrom(ErrWrongRequest, "keep on failure must be between 0 and %d minutes", MaxKeepOnFailure)
+	}
+
 	for i := range a.Actions {
 		if a.Actions[i].ID == 0 {
 			return NewErrorFrom(ErrWrongRequest, "invalid action id for child")
@@ -114,6 +149,29 @@ func (a Action) IsValid() error {
 	return nil
 }
 
+// CheckConditions returns an error if the condition of the action or of one of its steps is not a valid lua expression.
+func (a Action) CheckConditions() error {
+	if err := checkCondition(a.Condition); err != nil {
+		return err
+	}
+	for i := range a.Actions {
+		if err := checkCondition(a.Actions[i].Condition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkCondition(condition string) error {
+	if condition == "" {
+		return nil
+	}
+	if err := luascript.CheckExpressionSyntax(condition); err != nil {
+		return NewErrorFrom(ErrWrongRequest, "invalid condition %q: %v", condition, err)
+	}
+	return nil
+}
+
 // FlattenRequirements returns all requirements for an action and its children.
 func (a *Action) FlattenRequirements() RequirementList {
 	if !a.Enabled {
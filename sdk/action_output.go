@@ -0,0 +1,106 @@
+package sdk
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Output types that are not parameter types
+const (
+	JSONOutput = "json"
+	FileOutput = "file"
+)
+
+// ActionOutputTypes are the types allowed for an action or a job output
+var ActionOutputTypes = []string{StringParameter, NumberParameter, BooleanParameter, JSONOutput, FileOutput}
+
+// JobOutputVariablePrefix prefixes the build variables exported for job outputs, a job output is used in next
+// stages with {{.cds.output.<job name>.<output name>}} and in next workflow nodes with
+// {{.workflow.<node name>.output.<job name>.<output name>}}.
+const JobOutputVariablePrefix = "cds.output."
+
+// ActionOutput is a typed value returned by an action defined in a repository or by a job. Its value is
+// interpolated with the variables of the job when the action succeeded, then exported as a build variable.
+// The value of a file output is the path of a file in the workspace, the file is uploaded as an artifact and the
+// name of the artifact is exported.
+type ActionOutput struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Value       string `json:"value"`
+}
+
+// IsValid returns an error if the output name or type is invalid.
+func (o ActionOutput) IsValid() error {
+	if !NamePatternRegex.MatchString(o.Name) {
+		return NewErrorFrom(ErrWrongRequest, "invalid output name %s, it should match %s", o.Name, NamePattern)
+	}
+	if !IsInArray(o.Type, ActionOutputTypes) {
+		return NewErrorFrom(ErrWrongRequest, "invalid type %s for output %s, it should be one of %s", o.Type, o.Name, strings.Join(ActionOutputTypes, ", "))
+	}
+	return nil
+}
+
+// CheckValue returns an error if the given value does not match the output type.
+func (o ActionOutput) CheckValue(v string) error {
+	switch o.Type {
+	case NumberParameter:
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return NewErrorFrom(ErrWrongRequest, "output %s should be a number, got %q", o.Name, v)
+		}
+	case BooleanParameter:
+		if _, err := strconv.ParseBool(v); err != nil {
+			return NewErrorFrom(ErrWrongRequest, "output %s should be a boolean, got %q", o.Name, v)
+		}
+	case JSONOutput:
+		if !json.Valid([]byte(v)) {
+			return NewErrorFrom(ErrWrongRequest, "output %s should be a valid json, got %q", o.Name, v)
+		}
+	case FileOutput:
+		if strings.TrimSpace(v) == "" {
+			return NewErrorFrom(ErrWrongRequest, "output %s should be a file path", o.Name)
+		}
+	}
+	return nil
+}
+
+// ActionOutputs is a list of outputs stored as JSON
+type ActionOutputs []ActionOutput
+
+// Find returns the output with given name.
+func (o ActionOutputs) Find(name string) *ActionOutput {
+	for i := range o {
+		if o[i].Name == name {
+			return &o[i]
+		}
+	}
+	return nil
+}
+
+// Value returns driver.Value from action outputs.
+func (o ActionOutputs) Value() (driver.Value, error) {
+	j, err := json.Marshal(o)
+	return j, WrapError(err, "cannot marshal ActionOutputs")
+}
+
+// Scan action outputs.
+func (o *ActionOutputs) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(errors.New("type assertion .([]byte) failed"))
+	}
+	return WrapError(json.Unmarshal(source, o), "cannot unmarshal ActionOutputs")
+}
+
+// JobOutputVariableName returns the name of the build variable exported for given job output.
+func JobOutputVariableName(jobName, outputName string) string {
+	return fmt.Sprintf("%s%s.%s", JobOutputVariablePrefix, jobName, outputName)
+}
@@ -0,0 +1,35 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActionOutput(t *testing.T) {
+	assert.NoError(t, ActionOutput{Name: "version", Type: StringParameter}.IsValid())
+	assert.NoError(t, ActionOutput{Name: "binary", Type: FileOutput}.IsValid())
+	assert.Error(t, ActionOutput{Name: "bad name", Type: StringParameter}.IsValid())
+	assert.Error(t, ActionOutput{Name: "version", Type: ListParameter}.IsValid())
+
+	assert.NoError(t, ActionOutput{Name: "count", Type: NumberParameter}.CheckValue("1.5"))
+	assert.Error(t, ActionOutput{Name: "count", Type: NumberParameter}.CheckValue("one"))
+	assert.NoError(t, ActionOutput{Name: "ok", Type: BooleanParameter}.CheckValue("true"))
+	assert.Error(t, ActionOutput{Name: "ok", Type: BooleanParameter}.CheckValue("yes"))
+	assert.NoError(t, ActionOutput{Name: "report", Type: JSONOutput}.CheckValue(`{"coverage": 80}`))
+	assert.Error(t, ActionOutput{Name: "report", Type: JSONOutput}.CheckValue(`{"coverage": `))
+	assert.NoError(t, ActionOutput{Name: "binary", Type: FileOutput}.CheckValue("dist/cds"))
+	assert.Error(t, ActionOutput{Name: "binary", Type: FileOutput}.CheckValue(" "))
+	assert.NoError(t, ActionOutput{Name: "version", Type: StringParameter}.CheckValue("anything"))
+}
+
+func TestActionIsValidWithDuplicatedOutputs(t *testing.T) {
+	a := Action{
+		Name: "build",
+		Outputs: ActionOutputs{
+			{Name: "version", Type: StringParameter},
+			{Name: "version", Type: NumberParameter},
+		},
+	}
+	assert.Error(t, a.IsValid())
+}
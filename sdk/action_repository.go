@@ -0,0 +1,90 @@
+package sdk
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ActionRepositoryDirectory is the directory of a repository where actions are defined as code, one file per action
+const ActionRepositoryDirectory = ".cds/actions"
+
+// A ref that looks like a version is a tag, other refs are branches
+var actionRepositoryTagRegexp = regexp.MustCompile(`^v?[0-9]+(\.[0-9]+)*([-+][0-9A-Za-z.-]+)?$`)
+
+// ActionRepositoryRef references an action defined as code in a repository, written repo@ref/action-name
+// (ie. ovh/cds@v1.2.0/build-go).
+type ActionRepositoryRef struct {
+	Repository string `json:"repository"`
+	Ref        string `json:"ref"`
+	Name       string `json:"name"`
+}
+
+// IsActionRepositoryRef returns true if the given step action name references an action defined in a repository.
+func IsActionRepositoryRef(s string) bool {
+	return strings.Contains(s, "@")
+}
+
+// ParseActionRepositoryRef parses a repo@ref/action-name reference, the ref can contain slashes.
+func ParseActionRepositoryRef(s string) (ActionRepositoryRef, error) {
+	var r ActionRepositoryRef
+	i := strings.Index(s, "@")
+	j := strings.LastIndex(s, "/")
+	if i <= 0 || j <= i+1 || j == len(s)-1 {
+		return r, NewErrorFrom(ErrWrongRequest, "invalid repository action %s, it should be repo@ref/action-name", s)
+	}
+	r.Repository, r.Ref, r.Name = s[:i], s[i+1:j], s[j+1:]
+	if !NamePatternRegex.MatchString(r.Name) {
+		return r, NewErrorFrom(ErrWrongRequest, "invalid repository action name %s, it should match %s", r.Name, NamePattern)
+	}
+	if strings.Contains(r.Ref, "..") || strings.ContainsAny(r.Ref, " ~^:?*[\\") {
+		return r, NewErrorFrom(ErrWrongRequest, "invalid repository action ref %s", r.Ref)
+	}
+	return r, nil
+}
+
+// String returns the repo@ref/action-name reference.
+func (r ActionRepositoryRef) String() string {
+	return fmt.Sprintf("%s@%s/%s", r.Repository, r.Ref, r.Name)
+}
+
+// IsTag returns true if the ref is a version tag. Tags are immutable so an action resolved for a tag is never
+// refreshed, whereas an action resolved for a branch is refreshed each time a pipeline using it is imported.
+func (r ActionRepositoryRef) IsTag() bool {
+	return actionRepositoryTagRegexp.MatchString(r.Ref)
+}
+
+// FilePath returns the path of the file defining the action in the repository.
+func (r ActionRepositoryRef) FilePath() string {
+	return ActionRepositoryDirectory + "/" + r.Name + ".yml"
+}
+
+// ActionRepository is an action defined as code in a repository and resolved for a project. The action itself is
+// stored as an action of type Repository named with its reference.
+type ActionRepository struct {
+	ID           int64     `json:"id" db:"id"`
+	ActionID     int64     `json:"action_id" db:"action_id"`
+	ProjectID    int64     `json:"project_id" db:"project_id"`
+	VCSServer    string    `json:"vcs_server" db:"vcs_server"`
+	Repository   string    `json:"repository" db:"repository"`
+	Ref          string    `json:"ref" db:"ref"`
+	Name         string    `json:"name" db:"name"`
+	Commit       string    `json:"commit" db:"commit"`
+	Created      time.Time `json:"created" db:"created"`
+	LastModified time.Time `json:"last_modified" db:"last_modified"`
+	// aggregates
+	Pipelines []ActionRepositoryUsage `json:"pipelines,omitempty" db:"-"`
+}
+
+// ActionRepositoryRef returns the reference of the action.
+func (a ActionRepository) ActionRepositoryRef() ActionRepositoryRef {
+	return ActionRepositoryRef{Repository: a.Repository, Ref: a.Ref, Name: a.Name}
+}
+
+// ActionRepositoryUsage is a job of a pipeline that uses a version of an action defined in a repository.
+type ActionRepositoryUsage struct {
+	PipelineName string `json:"pipeline_name"`
+	StageName    string `json:"stage_name"`
+	JobName      string `json:"job_name"`
+}
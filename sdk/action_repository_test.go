@@ -0,0 +1,26 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseActionRepositoryRef(t *testing.T) {
+	r, err := ParseActionRepositoryRef("ovh/cds@v1.2.0/build-go")
+	assert.NoError(t, err)
+	assert.Equal(t, ActionRepositoryRef{Repository: "ovh/cds", Ref: "v1.2.0", Name: "build-go"}, r)
+	assert.True(t, r.IsTag())
+	assert.Equal(t, "ovh/cds@v1.2.0/build-go", r.String())
+	assert.Equal(t, ".cds/actions/build-go.yml", r.FilePath())
+
+	r, err = ParseActionRepositoryRef("ovh/cds@feature/x/build")
+	assert.NoError(t, err)
+	assert.Equal(t, ActionRepositoryRef{Repository: "ovh/cds", Ref: "feature/x", Name: "build"}, r)
+	assert.False(t, r.IsTag())
+
+	for _, s := range []string{"build-go", "@v1/build", "ovh/cds@/build", "ovh/cds@v1/", "ovh/cds@v1/bad name", "ovh/cds@a..b/build", "ovh/cds@a:b/build"} {
+		_, err := ParseActionRepositoryRef(s)
+		assert.Error(t, err, s)
+	}
+}
@@ -156,6 +156,17 @@ func NewCheckoutApplication(s string) Action {
 	return newAction
 }
 
+// NewStepCheckoutApplication returns an action (basically used as a step of a job) of checkout application type
+// with clone options
+func NewStepCheckoutApplication(v map[string]string) Action {
+	newAction := Action{
+		Name:       CheckoutApplicationAction,
+		Type:       BuiltinAction,
+		Parameters: ParametersFromMap(v),
+	}
+	return newAction
+}
+
 // NewDeployApplication returns an action (basically used as a step of a job) of deploy application type
 func NewDeployApplication(s string) Action {
 	newAction := Action{
@@ -194,8 +205,11 @@ func NewStepDefault(n string, args map[string]string) *Action {
 		Parameters: []Parameter{},
 	}
 
+	// an action defined in a repository is named with its repo@ref/action-name reference
 	splitted := strings.Split(n, "/")
-	if len(splitted) == 2 {
+	if IsActionRepositoryRef(n) {
+		newAction.Type = RepositoryAction
+	} else if len(splitted) == 2 {
 		newAction.Name = splitted[1]
 		newAction.Group = &Group{Name: splitted[0]}
 	}
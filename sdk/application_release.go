@@ -0,0 +1,128 @@
+package sdk
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/blang/semver"
+)
+
+// ApplicationRelease is an immutable version of an application, its artifacts are promoted from a workflow run
+type ApplicationRelease struct {
+	ID                int64                        `json:"id" db:"id"`
+	ApplicationID     int64                        `json:"application_id" db:"application_id"`
+	Version           string                       `json:"version" db:"version" cli:"version,key"`
+	Created           time.Time                    `json:"created" db:"created" cli:"created"`
+	CreatedBy         string                       `json:"created_by" db:"created_by" cli:"created_by"`
+	WorkflowName      string                       `json:"workflow_name" db:"workflow_name" cli:"workflow"`
+	WorkflowRunID     int64                        `json:"workflow_run_id" db:"workflow_run_id"`
+	Number            int64                        `json:"num" db:"num" cli:"num"`
+	WorkflowNodeRunID int64                        `json:"workflow_node_run_id" db:"workflow_node_run_id"`
+	NodeName          string                       `json:"node_name" db:"node_name"`
+	PipelineName      string                       `json:"pipeline_name" db:"pipeline_name" cli:"pipeline"`
+	Repository        string                       `json:"repository" db:"repository"`
+	Branch            string                       `json:"branch" db:"branch" cli:"branch"`
+	Commit            string                       `json:"commit" db:"vcs_hash" cli:"commit"`
+	Artifacts         []ApplicationReleaseArtifact `json:"artifacts" db:"-"`
+}
+
+// ApplicationReleaseArtifact is a copy of a workflow run artifact stored in the release repository of an application
+type ApplicationReleaseArtifact struct {
+	ID                   int64     `json:"id" db:"id"`
+	ReleaseID            int64     `json:"release_id" db:"application_release_id"`
+	ApplicationID        int64     `json:"application_id" db:"-"`
+	Version              string    `json:"version" db:"-"`
+	Name                 string    `json:"name" db:"name"`
+	Size                 int64     `json:"size" db:"size"`
+	Perm                 uint32    `json:"perm" db:"perm"`
+	MD5sum               string    `json:"md5sum" db:"md5sum"`
+	SHA512sum            string    `json:"sha512sum" db:"sha512sum"`
+	ObjectPath           string    `json:"object_path" db:"object_path"`
+	ProjectIntegrationID *int64    `json:"project_integration_id,omitempty" db:"project_integration_id"`
+	Created              time.Time `json:"created" db:"created"`
+}
+
+// GetName returns the name of the artifact
+func (a *ApplicationReleaseArtifact) GetName() string {
+	return a.Name
+}
+
+// GetPath returns the path of the artifact, all the artifacts of a release are stored in the same container,
+// outside of the containers of the workflow runs
+func (a *ApplicationReleaseArtifact) GetPath() string {
+	return url.QueryEscape(fmt.Sprintf("release-%d-%s", a.ApplicationID, a.Version))
+}
+
+// ApplicationReleasePromotion is the request to promote the artifacts of a workflow run to a release
+type ApplicationReleasePromotion struct {
+	Version      string   `json:"version"`
+	WorkflowName string   `json:"workflow_name"`
+	Number       int64    `json:"num"`
+	NodeName     string   `json:"node_name,omitempty"`
+	Artifacts    []string `json:"artifacts,omitempty"`
+}
+
+// IsValid checks the promotion request
+func (p ApplicationReleasePromotion) IsValid() error {
+	if err := IsValidReleaseVersion(p.Version); err != nil {
+		return err
+	}
+	if p.WorkflowName == "" || p.Number <= 0 {
+		return NewErrorFrom(ErrWrongRequest, "workflow name and run number are mandatory")
+	}
+	return nil
+}
+
+// IsValidReleaseVersion checks that a release version is a semantic version
+func IsValidReleaseVersion(v string) error {
+	if _, err := semver.Parse(v); err != nil {
+		return NewErrorFrom(ErrWrongRequest, "invalid release version %s: %v", v, err)
+	}
+	return nil
+}
+
+// SortApplicationReleases sorts releases by semantic version, the latest first
+func SortApplicationReleases(rs []ApplicationRelease) {
+	sort.SliceStable(rs, func(i, j int) bool {
+		vi, erri := semver.Parse(rs[i].Version)
+		vj, errj := semver.Parse(rs[j].Version)
+		if erri != nil || errj != nil {
+			return rs[i].Version > rs[j].Version
+		}
+		return vi.GT(vj)
+	})
+}
+
+// ArtifactByName returns the artifact of the release with the given name
+func (r ApplicationRelease) ArtifactByName(name string) *ApplicationReleaseArtifact {
+	for i := range r.Artifacts {
+		if r.Artifacts[i].Name == name {
+			return &r.Artifacts[i]
+		}
+	}
+	return nil
+}
+
+// FilterApplicationReleases returns the releases matching a semantic version range (ie. ">=1.2.0 <2.0.0")
+func FilterApplicationReleases(rs []ApplicationRelease, versionRange string) ([]ApplicationRelease, error) {
+	if versionRange == "" {
+		return rs, nil
+	}
+	match, err := semver.ParseRange(versionRange)
+	if err != nil {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid version range %s: %v", versionRange, err)
+	}
+	res := []ApplicationRelease{}
+	for _, r := range rs {
+		v, err := semver.Parse(r.Version)
+		if err != nil {
+			continue
+		}
+		if match(v) {
+			res = append(res, r)
+		}
+	}
+	return res, nil
+}
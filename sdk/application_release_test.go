@@ -0,0 +1,41 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortApplicationReleases(t *testing.T) {
+	rs := []ApplicationRelease{{Version: "1.2.0"}, {Version: "1.10.0"}, {Version: "1.2.0-rc.1"}, {Version: "0.9.1"}}
+	SortApplicationReleases(rs)
+
+	var versions []string
+	for _, r := range rs {
+		versions = append(versions, r.Version)
+	}
+	assert.Equal(t, []string{"1.10.0", "1.2.0", "1.2.0-rc.1", "0.9.1"}, versions)
+}
+
+func TestFilterApplicationReleases(t *testing.T) {
+	rs := []ApplicationRelease{{Version: "2.0.0"}, {Version: "1.10.0"}, {Version: "1.2.0"}, {Version: "0.9.1"}}
+
+	res, err := FilterApplicationReleases(rs, ">=1.2.0 <2.0.0")
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, "1.10.0", res[0].Version)
+	assert.Equal(t, "1.2.0", res[1].Version)
+
+	res, err = FilterApplicationReleases(rs, "")
+	assert.NoError(t, err)
+	assert.Len(t, res, 4)
+
+	_, err = FilterApplicationReleases(rs, "not a range")
+	assert.Error(t, err)
+}
+
+func TestApplicationReleasePromotionIsValid(t *testing.T) {
+	assert.NoError(t, ApplicationReleasePromotion{Version: "1.0.0", WorkflowName: "w", Number: 1}.IsValid())
+	assert.Error(t, ApplicationReleasePromotion{Version: "v1", WorkflowName: "w", Number: 1}.IsValid())
+	assert.Error(t, ApplicationReleasePromotion{Version: "1.0.0", Number: 1}.IsValid())
+}
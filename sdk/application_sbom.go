@@ -0,0 +1,232 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SBOM formats supported by the worker
+const (
+	SBOMFormatCycloneDX = "cyclonedx"
+	SBOMFormatSPDX      = "spdx"
+)
+
+// Component is a software component of an application, found in a SBOM
+type Component struct {
+	ID            int64    `json:"id" db:"id"`
+	ApplicationID int64    `json:"application_id" db:"application_id"`
+	Name          string   `json:"name" db:"name"`
+	Version       string   `json:"version" db:"version"`
+	Type          string   `json:"type" db:"type"`
+	PURL          string   `json:"purl,omitempty" db:"purl"`
+	Licenses      []string `json:"licenses" db:"-"`
+}
+
+func (c Component) key() string {
+	if c.Type == "" {
+		return c.Name
+	}
+	return c.Type + "/" + c.Name
+}
+
+// SBOMWorkerReport is a SBOM sent by a worker, converted to a list of components
+type SBOMWorkerReport struct {
+	Format     string      `json:"format"`
+	Components []Component `json:"components"`
+}
+
+// ComponentUpdate is a component of which the version changed between two SBOMs
+type ComponentUpdate struct {
+	Name            string `json:"name"`
+	Type            string `json:"type"`
+	PreviousVersion string `json:"previous_version"`
+	Version         string `json:"version"`
+}
+
+// ComponentsDiff is the difference between the components of two SBOMs
+type ComponentsDiff struct {
+	Added   []Component       `json:"added"`
+	Removed []Component       `json:"removed"`
+	Updated []ComponentUpdate `json:"updated"`
+}
+
+// WorkflowNodeRunSBOM is the SBOM of a node run, diffed with the components of the application
+// on the default branch
+type WorkflowNodeRunSBOM struct {
+	ID                int64          `json:"id" db:"id"`
+	ApplicationID     int64          `json:"application_id" db:"application_id"`
+	WorkflowID        int64          `json:"workflow_id" db:"workflow_id"`
+	WorkflowRunID     int64          `json:"workflow_run_id" db:"workflow_run_id"`
+	WorkflowNodeRunID int64          `json:"workflow_node_run_id" db:"workflow_node_run_id"`
+	Num               int64          `json:"num" db:"workflow_number"`
+	Branch            string         `json:"branch" db:"branch"`
+	Format            string         `json:"format" db:"format"`
+	Components        []Component    `json:"components" db:"-"`
+	Diff              ComponentsDiff `json:"diff" db:"-"`
+}
+
+// ParseSBOM reads a CycloneDX or SPDX json SBOM
+func ParseSBOM(btes []byte) (SBOMWorkerReport, error) {
+	var doc struct {
+		BOMFormat   string `json:"bomFormat"`
+		SPDXVersion string `json:"spdxVersion"`
+	}
+	if err := json.Unmarshal(btes, &doc); err != nil {
+		return SBOMWorkerReport{}, NewErrorFrom(ErrWrongRequest, "invalid SBOM: %v", err)
+	}
+
+	switch {
+	case strings.EqualFold(doc.BOMFormat, "CycloneDX"):
+		return parseCycloneDX(btes)
+	case doc.SPDXVersion != "":
+		return parseSPDX(btes)
+	}
+	return SBOMWorkerReport{}, NewErrorFrom(ErrWrongRequest, "unsupported SBOM format, only CycloneDX and SPDX json documents are supported")
+}
+
+type cycloneDXComponent struct {
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Group    string `json:"group"`
+	Version  string `json:"version"`
+	PURL     string `json:"purl"`
+	Licenses []struct {
+		License struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"license"`
+		Expression string `json:"expression"`
+	} `json:"licenses"`
+	Components []cycloneDXComponent `json:"components"`
+}
+
+func parseCycloneDX(btes []byte) (SBOMWorkerReport, error) {
+	var bom struct {
+		Components []cycloneDXComponent `json:"components"`
+	}
+	if err := json.Unmarshal(btes, &bom); err != nil {
+		return SBOMWorkerReport{}, NewErrorFrom(ErrWrongRequest, "invalid CycloneDX SBOM: %v", err)
+	}
+
+	report := SBOMWorkerReport{Format: SBOMFormatCycloneDX, Components: []Component{}}
+	var walk func(cs []cycloneDXComponent)
+	walk = func(cs []cycloneDXComponent) {
+		for _, c := range cs {
+			name := c.Name
+			if c.Group != "" {
+				name = c.Group + "/" + c.Name
+			}
+			comp := Component{Name: name, Version: c.Version, Type: c.Type, PURL: c.PURL, Licenses: []string{}}
+			for _, l := range c.Licenses {
+				switch {
+				case l.License.ID != "":
+					comp.Licenses = append(comp.Licenses, l.License.ID)
+				case l.License.Name != "":
+					comp.Licenses = append(comp.Licenses, l.License.Name)
+				case l.Expression != "":
+					comp.Licenses = append(comp.Licenses, licensesFromExpression(l.Expression)...)
+				}
+			}
+			report.Components = append(report.Components, comp)
+			walk(c.Components)
+		}
+	}
+	walk(bom.Components)
+	return report, nil
+}
+
+func parseSPDX(btes []byte) (SBOMWorkerReport, error) {
+	var doc struct {
+		Packages []struct {
+			SPDXID           string `json:"SPDXID"`
+			Name             string `json:"name"`
+			VersionInfo      string `json:"versionInfo"`
+			LicenseConcluded string `json:"licenseConcluded"`
+			LicenseDeclared  string `json:"licenseDeclared"`
+			ExternalRefs     []struct {
+				ReferenceType    string `json:"referenceType"`
+				ReferenceLocator string `json:"referenceLocator"`
+			} `json:"externalRefs"`
+		} `json:"packages"`
+	}
+	if err := json.Unmarshal(btes, &doc); err != nil {
+		return SBOMWorkerReport{}, NewErrorFrom(ErrWrongRequest, "invalid SPDX SBOM: %v", err)
+	}
+
+	report := SBOMWorkerReport{Format: SBOMFormatSPDX, Components: make([]Component, 0, len(doc.Packages))}
+	for _, p := range doc.Packages {
+		comp := Component{Name: p.Name, Version: p.VersionInfo, Type: "library", Licenses: []string{}}
+		for _, ref := range p.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				comp.PURL = ref.ReferenceLocator
+			}
+		}
+		license := p.LicenseConcluded
+		if spdxNoLicense(license) {
+			license = p.LicenseDeclared
+		}
+		if !spdxNoLicense(license) {
+			comp.Licenses = licensesFromExpression(license)
+		}
+		report.Components = append(report.Components, comp)
+	}
+	return report, nil
+}
+
+func spdxNoLicense(l string) bool {
+	return l == "" || l == "NOASSERTION" || l == "NONE"
+}
+
+// licensesFromExpression returns the licenses of a SPDX license expression like "(MIT OR Apache-2.0)"
+func licensesFromExpression(expr string) []string {
+	res := []string{}
+	for _, s := range strings.Fields(strings.NewReplacer("(", " ", ")", " ").Replace(expr)) {
+		switch strings.ToUpper(s) {
+		case "AND", "OR", "WITH":
+			continue
+		}
+		res = append(res, s)
+	}
+	return res
+}
+
+// DiffComponents returns the components added, removed and updated between two SBOMs
+func DiffComponents(previous, current []Component) ComponentsDiff {
+	diff := ComponentsDiff{Added: []Component{}, Removed: []Component{}, Updated: []ComponentUpdate{}}
+
+	previousByKey := make(map[string]Component, len(previous))
+	for _, c := range previous {
+		previousByKey[c.key()] = c
+	}
+	currentByKey := make(map[string]Component, len(current))
+	for _, c := range current {
+		currentByKey[c.key()] = c
+	}
+
+	for k, c := range currentByKey {
+		p, ok := previousByKey[k]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, c)
+		case p.Version != c.Version:
+			diff.Updated = append(diff.Updated, ComponentUpdate{Name: c.Name, Type: c.Type, PreviousVersion: p.Version, Version: c.Version})
+		}
+	}
+	for k, p := range previousByKey {
+		if _, ok := currentByKey[k]; !ok {
+			diff.Removed = append(diff.Removed, p)
+		}
+	}
+
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].key() < diff.Added[j].key() })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].key() < diff.Removed[j].key() })
+	sort.Slice(diff.Updated, func(i, j int) bool { return diff.Updated[i].Name < diff.Updated[j].Name })
+	return diff
+}
+
+// String returns a summary of the diff
+func (d ComponentsDiff) String() string {
+	return fmt.Sprintf("%d added, %d removed, %d updated", len(d.Added), len(d.Removed), len(d.Updated))
+}
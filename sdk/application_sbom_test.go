@@ -0,0 +1,126 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSBOMCycloneDX(t *testing.T) {
+	report, err := ParseSBOM([]byte(`{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.4",
+  "components": [
+    {
+      "type": "library",
+      "group": "github.com/ovh",
+      "name": "cds",
+      "version": "0.38.0",
+      "purl": "pkg:golang/github.com/ovh/cds@0.38.0",
+      "licenses": [{"license": {"id": "BSD-3-Clause"}}],
+      "components": [
+        {"type": "library", "name": "yaml", "version": "2.2.1", "licenses": [{"expression": "(MIT OR Apache-2.0)"}]}
+      ]
+    }
+  ]
+}`))
+	assert.NoError(t, err)
+	assert.Equal(t, SBOMFormatCycloneDX, report.Format)
+	assert.Len(t, report.Components, 2)
+	assert.Equal(t, "github.com/ovh/cds", report.Components[0].Name)
+	assert.Equal(t, []string{"BSD-3-Clause"}, report.Components[0].Licenses)
+	assert.Equal(t, []string{"MIT", "Apache-2.0"}, report.Components[1].Licenses)
+}
+
+func TestParseSBOMSPDX(t *testing.T) {
+	report, err := ParseSBOM([]byte(`{
+  "spdxVersion": "SPDX-2.2",
+  "packages": [
+    {
+      "name": "lodash",
+      "versionInfo": "4.17.20",
+      "licenseConcluded": "NOASSERTION",
+      "licenseDeclared": "MIT",
+      "externalRefs": [{"referenceType": "purl", "referenceLocator": "pkg:npm/lodash@4.17.20"}]
+    }
+  ]
+}`))
+	assert.NoError(t, err)
+	assert.Equal(t, SBOMFormatSPDX, report.Format)
+	assert.Len(t, report.Components, 1)
+	assert.Equal(t, "pkg:npm/lodash@4.17.20", report.Components[0].PURL)
+	assert.Equal(t, []string{"MIT"}, report.Components[0].Licenses)
+
+	_, err = ParseSBOM([]byte(`{"foo": "bar"}`))
+	assert.Error(t, err)
+}
+
+func TestDiffComponents(t *testing.T) {
+	previous := []Component{
+		{Name: "a", Version: "1.0", Type: "library"},
+		{Name: "b", Version: "1.0", Type: "library"},
+		{Name: "c", Version: "1.0", Type: "library"},
+	}
+	current := []Component{
+		{Name: "a", Version: "1.0", Type: "library"},
+		{Name: "b", Version: "2.0", Type: "library"},
+		{Name: "d", Version: "1.0", Type: "library"},
+	}
+	diff := DiffComponents(previous, current)
+	assert.Len(t, diff.Added, 1)
+	assert.Equal(t, "d", diff.Added[0].Name)
+	assert.Len(t, diff.Removed, 1)
+	assert.Equal(t, "c", diff.Removed[0].Name)
+	assert.Equal(t, []ComponentUpdate{{Name: "b", Type: "library", PreviousVersion: "1.0", Version: "2.0"}}, diff.Updated)
+}
+
+func TestParseSARIF(t *testing.T) {
+	report, err := ParseSARIF([]byte(`{
+  "version": "2.1.0",
+  "runs": [
+    {
+      "tool": {"driver": {"name": "gosec", "rules": [
+        {"id": "G101", "shortDescription": {"text": "Hardcoded credentials"}, "helpUri": "https://example.com/G101", "properties": {"security-severity": "9.1"}},
+        {"id": "G104", "shortDescription": {"text": "Errors unhandled"}}
+      ]}},
+      "results": [
+        {"ruleId": "G101", "message": {"text": "Potential hardcoded credentials"}, "locations": [{"physicalLocation": {"artifactLocation": {"uri": "main.go"}, "region": {"startLine": 12}}}]},
+        {"ruleId": "G104", "level": "note", "message": {"text": "Errors unhandled"}}
+      ]
+    }
+  ]
+}`))
+	assert.NoError(t, err)
+	assert.Equal(t, VulnerabilityTypeSARIF, report.Type)
+	assert.Len(t, report.Vulnerabilities, 2)
+	assert.Equal(t, SeverityCritical, report.Vulnerabilities[0].Severity)
+	assert.Equal(t, "main.go", report.Vulnerabilities[0].Component)
+	assert.Equal(t, "Hardcoded credentials", report.Vulnerabilities[0].Title)
+	assert.Equal(t, "gosec", report.Vulnerabilities[0].Origin)
+	assert.Equal(t, SeverityLow, report.Vulnerabilities[1].Severity)
+	assert.Equal(t, int64(1), report.Summary[SeverityCritical])
+}
+
+func TestProjectSecurityPolicy(t *testing.T) {
+	p := ProjectSecurityPolicy{MaxSeverity: "foo"}
+	assert.Error(t, p.IsValid())
+
+	p = ProjectSecurityPolicy{ForbiddenLicenses: []string{" "}}
+	assert.Error(t, p.IsValid())
+
+	p = ProjectSecurityPolicy{MaxSeverity: SeverityMedium, ForbiddenLicenses: []string{"GPL-3.0"}}
+	assert.NoError(t, p.IsValid())
+
+	violations := p.CheckVulnerabilities([]Vulnerability{
+		{CVE: "CVE-1", Severity: SeverityHigh, Component: "foo"},
+		{CVE: "CVE-2", Severity: SeverityCritical, Component: "foo", Ignored: true},
+		{CVE: "CVE-3", Severity: SeverityMedium, Component: "foo"},
+	})
+	assert.Len(t, violations, 1)
+
+	violations = p.CheckComponents([]Component{
+		{Name: "a", Licenses: []string{"MIT"}},
+		{Name: "b", Licenses: []string{"gpl-3.0"}},
+	})
+	assert.Len(t, violations, 1)
+}
@@ -0,0 +1,231 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/openpgp"
+)
+
+// Provenance statement types, see https://in-toto.io and https://slsa.dev
+const (
+	InTotoStatementType       = "https://in-toto.io/Statement/v0.1"
+	SLSAProvenancePredicate   = "https://slsa.dev/provenance/v0.2"
+	InTotoPayloadType         = "application/vnd.in-toto+json"
+	ProvenanceBuildType       = "https://github.com/ovh/cds/workflow@v1"
+	ProvenanceKeyName         = "proj-cds-provenance"
+	ArtifactProvenanceSuffix  = ".provenance.json"
+	provenanceEnvelopeVersion = "DSSEv1"
+)
+
+// ProvenanceStatement is an in-toto statement with a SLSA provenance predicate describing how an artifact was built
+type ProvenanceStatement struct {
+	Type          string              `json:"_type"`
+	PredicateType string              `json:"predicateType"`
+	Subject       []ProvenanceSubject `json:"subject"`
+	Predicate     ProvenancePredicate `json:"predicate"`
+}
+
+// ProvenanceSubject is an artifact described by a provenance statement
+type ProvenanceSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// ProvenancePredicate is the SLSA provenance of an artifact
+type ProvenancePredicate struct {
+	Builder    ProvenanceBuilder    `json:"builder"`
+	BuildType  string               `json:"buildType"`
+	Invocation ProvenanceInvocation `json:"invocation"`
+	Metadata   ProvenanceMetadata   `json:"metadata"`
+	Materials  []ProvenanceMaterial `json:"materials,omitempty"`
+}
+
+// ProvenanceBuilder identifies the CDS instance and the worker which built the artifact
+type ProvenanceBuilder struct {
+	ID          string `json:"id"`
+	WorkerName  string `json:"worker_name,omitempty"`
+	WorkerModel string `json:"worker_model,omitempty"`
+	WorkerImage string `json:"worker_image,omitempty"`
+}
+
+// ProvenanceInvocation describes the workflow run which built the artifact
+type ProvenanceInvocation struct {
+	ConfigSource ProvenanceConfigSource `json:"configSource"`
+	Parameters   map[string]string      `json:"parameters,omitempty"`
+}
+
+// ProvenanceConfigSource is the project, workflow and pipeline which built the artifact
+type ProvenanceConfigSource struct {
+	Project       string `json:"project"`
+	Workflow      string `json:"workflow"`
+	Pipeline      string `json:"pipeline"`
+	Application   string `json:"application,omitempty"`
+	WorkflowRunID int64  `json:"workflow_run_id"`
+	Number        int64  `json:"num"`
+	SubNumber     int64  `json:"sub_num"`
+	NodeRunID     int64  `json:"node_run_id"`
+	JobRunID      int64  `json:"job_run_id"`
+}
+
+// ProvenanceMetadata contains the build dates
+type ProvenanceMetadata struct {
+	BuildStartedOn  time.Time `json:"buildStartedOn"`
+	BuildFinishedOn time.Time `json:"buildFinishedOn"`
+}
+
+// ProvenanceMaterial is a source used to build the artifact
+type ProvenanceMaterial struct {
+	URI    string            `json:"uri"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+// ProvenanceEnvelope is a signed provenance statement, following the DSSE envelope format.
+// Signatures are armored PGP detached signatures of the pre-authentication encoding of the payload
+type ProvenanceEnvelope struct {
+	PayloadType string                `json:"payloadType"`
+	Payload     string                `json:"payload"`
+	Signatures  []ProvenanceSignature `json:"signatures"`
+}
+
+// ProvenanceSignature is the signature of a provenance envelope
+type ProvenanceSignature struct {
+	KeyID   string `json:"keyid"`
+	KeyName string `json:"keyname,omitempty"`
+	Sig     string `json:"sig"`
+}
+
+// provenanceParameters are the build parameters kept in provenance statements, secrets are never included
+var provenanceParameters = []string{"cds.project", "cds.workflow", "cds.pipeline", "cds.application", "cds.environment",
+	"cds.version", "cds.run", "cds.triggered_by.username", "git.repository", "git.url", "git.http_url", "git.branch", "git.tag", "git.hash"}
+
+// NewProvenanceStatement returns the provenance statement of an artifact uploaded by a job
+func NewProvenanceStatement(builderID string, art WorkflowNodeRunArtifact, nr WorkflowNodeRun, job WorkflowNodeJobRun, workerImage string) ProvenanceStatement {
+	digest := map[string]string{}
+	if art.SHA512sum != "" {
+		digest["sha512"] = art.SHA512sum
+	}
+	if art.MD5sum != "" {
+		digest["md5"] = art.MD5sum
+	}
+
+	params := make(map[string]string)
+	for _, name := range provenanceParameters {
+		if v := ParameterValue(nr.BuildParameters, name); v != "" {
+			params[name] = v
+		}
+	}
+
+	s := ProvenanceStatement{
+		Type:          InTotoStatementType,
+		PredicateType: SLSAProvenancePredicate,
+		Subject:       []ProvenanceSubject{{Name: art.Name, Digest: digest}},
+		Predicate: ProvenancePredicate{
+			Builder: ProvenanceBuilder{
+				ID:          builderID,
+				WorkerName:  job.Job.WorkerName,
+				WorkerModel: job.Model,
+				WorkerImage: workerImage,
+			},
+			BuildType: ProvenanceBuildType,
+			Invocation: ProvenanceInvocation{
+				ConfigSource: ProvenanceConfigSource{
+					Project:       params["cds.project"],
+					Workflow:      params["cds.workflow"],
+					Pipeline:      params["cds.pipeline"],
+					Application:   params["cds.application"],
+					WorkflowRunID: nr.WorkflowRunID,
+					Number:        nr.Number,
+					SubNumber:     nr.SubNumber,
+					NodeRunID:     nr.ID,
+					JobRunID:      job.ID,
+				},
+				Parameters: params,
+			},
+			Metadata: ProvenanceMetadata{
+				BuildStartedOn:  job.Start,
+				BuildFinishedOn: art.Created,
+			},
+		},
+	}
+
+	if nr.VCSHash != "" {
+		uri := nr.VCSRepository
+		if u := params["git.http_url"]; u != "" {
+			uri = u
+		}
+		s.Predicate.Materials = []ProvenanceMaterial{{URI: uri, Digest: map[string]string{"sha1": nr.VCSHash}}}
+	}
+	return s
+}
+
+// ProvenancePAE returns the pre-authentication encoding of a payload, this is the signed content of a provenance envelope
+func ProvenancePAE(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("%s %d %s %d %s", provenanceEnvelopeVersion, len(payloadType), payloadType, len(payload), payload))
+}
+
+// Statement decodes the provenance statement of the envelope, the signature is not checked
+func (e ProvenanceEnvelope) Statement() (*ProvenanceStatement, error) {
+	payload, err := base64.StdEncoding.DecodeString(e.Payload)
+	if err != nil {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid provenance payload: %v", err)
+	}
+	var s ProvenanceStatement
+	if err := json.Unmarshal(payload, &s); err != nil {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid provenance statement: %v", err)
+	}
+	return &s, nil
+}
+
+// VerifyProvenance checks the signature of a provenance envelope with an armored PGP public key,
+// and checks that the statement describes an artifact with the given sha512sum
+func VerifyProvenance(e ProvenanceEnvelope, publicKey string, sha512sum string) (*ProvenanceStatement, error) {
+	if e.PayloadType != InTotoPayloadType {
+		return nil, fmt.Errorf("unsupported payload type %s", e.PayloadType)
+	}
+	keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(publicKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %v", err)
+	}
+	payload, err := base64.StdEncoding.DecodeString(e.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid provenance payload: %v", err)
+	}
+
+	var verified bool
+	for _, sig := range e.Signatures {
+		if _, err := openpgp.CheckArmoredDetachedSignature(keyring, bytes.NewReader(ProvenancePAE(e.PayloadType, payload)), strings.NewReader(sig.Sig)); err == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, fmt.Errorf("provenance signature is not valid")
+	}
+
+	s, err := e.Statement()
+	if err != nil {
+		return nil, err
+	}
+	for _, subject := range s.Subject {
+		if subject.Digest["sha512"] != "" && subject.Digest["sha512"] == sha512sum {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("artifact does not match the provenance subjects")
+}
+
+// ProvenanceParametersNames returns the sorted names of the parameters of a provenance statement
+func (s ProvenanceStatement) ProvenanceParametersNames() []string {
+	names := make([]string, 0, len(s.Predicate.Invocation.Parameters))
+	for n := range s.Predicate.Invocation.Parameters {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -26,6 +26,8 @@ type ExecutedJob struct {
 	Reason     string       `json:"reason" db:"-"`
 	WorkerName string       `json:"worker_name" db:"-"`
 	WorkerID   string       `json:"worker_id" db:"-"`
+	// WorkerModelVersion is the version of the worker model which ran the job
+	WorkerModelVersion int64 `json:"worker_model_version,omitempty" db:"-"`
 }
 
 // ExecutedJobSummary is a light representation of ExecutedJob for CDS event
@@ -122,6 +124,8 @@ func StatusFromString(in string) Status {
 		return StatusSkipped
 	case StatusStopped.String():
 		return StatusStopped
+	case StatusBlockedByFreeze.String():
+		return StatusBlockedByFreeze
 	case StatusWorkerPending.String():
 		return StatusWorkerPending
 	case StatusWorkerRegistering.String():
@@ -155,6 +159,7 @@ const (
 	StatusUnknown           Status = "Unknown"
 	StatusSkipped           Status = "Skipped"
 	StatusStopped           Status = "Stopped"
+	StatusBlockedByFreeze   Status = "BlockedByFreeze"
 	StatusWorkerPending     Status = "Pending"
 	StatusWorkerRegistering Status = "Registering"
 )
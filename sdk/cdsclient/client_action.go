@@ -102,3 +102,11 @@ func (c *client) ActionBuiltinGet(name string, mods ...RequestModifier) (*sdk.Ac
 
 	return &a, nil
 }
+
+func (c *client) ActionRepositoryList(projectKey string) ([]sdk.ActionRepository, error) {
+	ars := []sdk.ActionRepository{}
+	if _, err := c.GetJSON(context.Background(), fmt.Sprintf("/project/%s/action/repository", projectKey), &ars); err != nil {
+		return nil, err
+	}
+	return ars, nil
+}
@@ -45,6 +45,32 @@ func (c *client) AdminCDSMigrationList() ([]sdk.Migration, error) {
 	return migrations, nil
 }
 
+func (c *client) AdminFeatureList() ([]sdk.Feature, error) {
+	var fs []sdk.Feature
+	if _, err := c.GetJSON(context.Background(), "/admin/feature", &fs); err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+func (c *client) AdminFeatureGet(name string) (*sdk.Feature, error) {
+	var f sdk.Feature
+	if _, err := c.GetJSON(context.Background(), "/admin/feature/"+url.QueryEscape(name), &f); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (c *client) AdminFeatureSet(f sdk.Feature) error {
+	_, err := c.PutJSON(context.Background(), "/admin/feature/"+url.QueryEscape(f.Name), f, nil)
+	return err
+}
+
+func (c *client) AdminFeatureDelete(name string) error {
+	_, err := c.DeleteJSON(context.Background(), "/admin/feature/"+url.QueryEscape(name), nil)
+	return err
+}
+
 func (c *client) Services() ([]sdk.Service, error) {
 	srvs := []sdk.Service{}
 	if _, err := c.GetJSON(context.Background(), "/admin/services", &srvs); err != nil {
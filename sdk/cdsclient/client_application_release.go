@@ -0,0 +1,54 @@
+package cdsclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *client) ApplicationReleaseList(projectKey string, appName string, versionRange string) ([]sdk.ApplicationRelease, error) {
+	path := fmt.Sprintf("/project/%s/application/%s/release", projectKey, appName)
+	if versionRange != "" {
+		path += "?range=" + url.QueryEscape(versionRange)
+	}
+	rs := []sdk.ApplicationRelease{}
+	if _, err := c.GetJSON(context.Background(), path, &rs); err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+func (c *client) ApplicationReleaseGet(projectKey string, appName string, version string) (*sdk.ApplicationRelease, error) {
+	var rel sdk.ApplicationRelease
+	path := fmt.Sprintf("/project/%s/application/%s/release/%s", projectKey, appName, url.PathEscape(version))
+	if _, err := c.GetJSON(context.Background(), path, &rel); err != nil {
+		return nil, err
+	}
+	return &rel, nil
+}
+
+func (c *client) ApplicationReleasePromote(projectKey string, appName string, promotion sdk.ApplicationReleasePromotion) (*sdk.ApplicationRelease, error) {
+	var rel sdk.ApplicationRelease
+	path := fmt.Sprintf("/project/%s/application/%s/release", projectKey, appName)
+	if _, err := c.PostJSON(context.Background(), path, promotion, &rel); err != nil {
+		return nil, err
+	}
+	return &rel, nil
+}
+
+func (c *client) ApplicationReleaseArtifactDownload(projectKey string, appName string, version string, artifactName string, w io.Writer) error {
+	path := fmt.Sprintf("/project/%s/application/%s/release/%s/artifact/%s/download", projectKey, appName, url.PathEscape(version), url.PathEscape(artifactName))
+	reader, _, code, err := c.Stream(context.Background(), "GET", path, nil, true)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	if code >= 300 {
+		return fmt.Errorf("Cannot download artifact %s of release %s. HTTP code error : %d", artifactName, version, code)
+	}
+	_, err = io.Copy(w, reader)
+	return err
+}
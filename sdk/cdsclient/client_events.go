@@ -3,12 +3,58 @@ package cdsclient
 import (
 	"context"
 	"log"
+	"net/http"
+	"sync"
 	"time"
+
+	"github.com/ovh/cds/sdk"
 )
 
 func (c *client) EventsListen(ctx context.Context, chanSSEvt chan<- SSEvent) {
+	c.EventsListenWithOptions(ctx, chanSSEvt, EventsListenOptions{})
+}
+
+func (c *client) EventsListenWithOptions(ctx context.Context, chanSSEvt chan<- SSEvent, opts EventsListenOptions) {
+	path := "/events"
+	if q := opts.Filter.Values().Encode(); q != "" {
+		path += "?" + q
+	}
+
+	var mutex sync.Mutex
+	lastEventID := opts.LastEventID
+
+	// Keep the id of the last received event to replay the missed events on reconnection
+	received := make(chan SSEvent)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case evt := <-received:
+				if evt.ID != "" {
+					mutex.Lock()
+					lastEventID = evt.ID
+					mutex.Unlock()
+				}
+				select {
+				case chanSSEvt <- evt:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
 	for ctx.Err() == nil {
-		if err := c.RequestSSEGet(ctx, "/events", chanSSEvt); err != nil {
+		mutex.Lock()
+		id := lastEventID
+		mutex.Unlock()
+
+		if err := c.RequestSSEGet(ctx, path, received, func(req *http.Request) {
+			if id != "" {
+				req.Header.Set(sdk.EventsLastEventIDHeader, id)
+			}
+		}); err != nil {
 			log.Println("EventsListen", err)
 		}
 		time.Sleep(1 * time.Second)
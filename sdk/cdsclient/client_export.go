@@ -48,9 +48,9 @@ func (c *client) EnvironmentExport(projectKey, name string, exportFormat string)
 	return body, nil
 }
 
-func (c *client) WorkerModelExport(id int64, format string) ([]byte, error) {
+func (c *client) WorkerModelExport(id int64, format string, mods ...RequestModifier) ([]byte, error) {
 	path := fmt.Sprintf("/worker/model/%d/export?format=%s", id, url.QueryEscape(format))
-	bodyReader, _, _, err := c.Stream(context.Background(), "GET", path, nil, true)
+	bodyReader, _, _, err := c.Stream(context.Background(), "GET", path, nil, true, mods...)
 	if err != nil {
 		return nil, err
 	}
@@ -130,7 +130,7 @@ func (c *client) EnvironmentImport(projectKey string, content io.Reader, format
 }
 
 // WorkerModelImport import a worker model via as code
-func (c *client) WorkerModelImport(content io.Reader, format string, force bool) (*sdk.Model, error) {
+func (c *client) WorkerModelImport(content io.Reader, format string, force bool, opts ...RequestModifier) (*sdk.Model, error) {
 	url := "/worker/model/import"
 	if force {
 		url += "?force=true"
@@ -154,6 +154,7 @@ func (c *client) WorkerModelImport(content io.Reader, format string, force bool)
 		return nil, exportentities.ErrUnsupportedFormat
 	}
 
+	mods = append(mods, opts...)
 	btes, _, code, err := c.Request(context.Background(), "POST", url, content, mods...)
 	if err != nil {
 		return nil, err
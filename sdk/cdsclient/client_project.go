@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/ovh/cds/engine/api/permission"
 	"github.com/ovh/cds/sdk"
@@ -88,3 +90,61 @@ func (c *client) ProjectGroupsImport(projectKey string, content io.Reader, forma
 
 	return proj, nil
 }
+
+func (c *client) ProjectBundleExport(projectKey, passphrase string) ([]byte, error) {
+	path := fmt.Sprintf("/project/%s/bundle", projectKey)
+	btes, _, _, err := c.Request(context.Background(), "GET", path, nil, func(r *http.Request) {
+		r.Header.Set(sdk.ProjectBundlePassphraseHeader, passphrase)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return btes, nil
+}
+
+func (c *client) ProjectBundleImport(projectKey, passphrase string, content io.Reader, dryRun, force bool, groupMapping []string) (*sdk.ProjectBundleImportReport, error) {
+	params := url.Values{}
+	params.Set("dryRun", fmt.Sprintf("%t", dryRun))
+	params.Set("force", fmt.Sprintf("%t", force))
+	for _, g := range groupMapping {
+		params.Add("group", g)
+	}
+	path := fmt.Sprintf("/project/%s/bundle?%s", projectKey, params.Encode())
+
+	btes, _, _, err := c.Request(context.Background(), "POST", path, content, func(r *http.Request) {
+		r.Header.Set("Content-Type", "application/tar")
+		r.Header.Set(sdk.ProjectBundlePassphraseHeader, passphrase)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var report sdk.ProjectBundleImportReport
+	if err := json.Unmarshal(btes, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (c *client) ProjectDORAMetrics(projectKey string, days int64, application, environment string) ([]sdk.DORAMetrics, error) {
+	q := url.Values{}
+	if days > 0 {
+		q.Set("days", strconv.FormatInt(days, 10))
+	}
+	if application != "" {
+		q.Set("application", application)
+	}
+	if environment != "" {
+		q.Set("environment", environment)
+	}
+	path := fmt.Sprintf("/project/%s/metrics/dora", projectKey)
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	ms := []sdk.DORAMetrics{}
+	if _, err := c.GetJSON(context.Background(), path, &ms); err != nil {
+		return nil, err
+	}
+	return ms, nil
+}
@@ -24,3 +24,11 @@ func (c *client) ProjectKeysDelete(projectKey string, keyName string) error {
 	_, _, _, err := c.Request(context.Background(), "DELETE", "/project/"+projectKey+"/keys/"+url.QueryEscape(keyName), nil)
 	return err
 }
+
+func (c *client) ProjectProvenanceKeyGet(projectKey string) (*sdk.ProjectKey, error) {
+	var k sdk.ProjectKey
+	if _, err := c.GetJSON(context.Background(), "/project/"+projectKey+"/provenance/key", &k); err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
@@ -18,7 +18,10 @@ import (
 	"strings"
 	"time"
 
+	"golang.org/x/net/websocket"
+
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
 )
 
 // shrinkQueue is used to shrink the polled queue 200% of the channel capacity (l)
@@ -263,6 +266,12 @@ func (c *client) QueueSendResult(ctx context.Context, id int64, res sdk.Result)
 	return err
 }
 
+func (c *client) QueueSendResourceUsage(ctx context.Context, id int64, usage sdk.JobResourceUsage) error {
+	path := fmt.Sprintf("/queue/workflows/%d/usage", id)
+	_, err := c.PostJSON(ctx, path, usage, nil)
+	return err
+}
+
 func (c *client) QueueArtifactUpload(ctx context.Context, projectKey, integrationName string, nodeJobRunID int64, tag, filePath string) (bool, time.Duration, error) {
 	t0 := time.Now()
 	store := new(sdk.ArtifactsStore)
@@ -486,6 +495,39 @@ func (c *client) QueueJobTag(ctx context.Context, jobID int64, tags []sdk.Workfl
 	return err
 }
 
+// QueueJobSendVulnerabilityReport sends a vulnerability report and returns the violations of the project security policy
+func (c *client) QueueJobSendVulnerabilityReport(ctx context.Context, jobID int64, report sdk.VulnerabilityWorkerReport) (*sdk.SecurityPolicyReport, error) {
+	path := fmt.Sprintf("/queue/workflows/%d/vulnerability", jobID)
+	var res sdk.SecurityPolicyReport
+	if _, err := c.PostJSON(ctx, path, report, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// QueueJobSendSBOM sends the components of a SBOM and returns the violations of the project security policy
+func (c *client) QueueJobSendSBOM(ctx context.Context, jobID int64, report sdk.SBOMWorkerReport) (*sdk.SecurityPolicyReport, error) {
+	path := fmt.Sprintf("/queue/workflows/%d/sbom", jobID)
+	var res sdk.SecurityPolicyReport
+	if _, err := c.PostJSON(ctx, path, report, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (c *client) QueueJobAppendStages(ctx context.Context, jobID int64, p exportentities.PipelineV1) ([]sdk.Stage, error) {
+	path := fmt.Sprintf("/queue/workflows/%d/stages", jobID)
+	var stages []sdk.Stage
+	if _, err := c.PostJSON(ctx, path, p, &stages); err != nil {
+		return nil, err
+	}
+	return stages, nil
+}
+
+func (c *client) QueueJobDebugSession(jobID int64) (*websocket.Conn, error) {
+	return c.Websocket(fmt.Sprintf("/queue/workflows/%d/debug", jobID))
+}
+
 func (c *client) QueueServiceLogs(ctx context.Context, logs []sdk.ServiceLog) error {
 	status, err := c.PostJSON(ctx, "/queue/workflows/log/service", logs, nil)
 	if status >= 400 {
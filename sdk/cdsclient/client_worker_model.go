@@ -165,3 +165,48 @@ func (c *client) WorkerModelDelete(name string) error {
 	_, errDelete := c.DeleteJSON(context.Background(), uri, nil)
 	return errDelete
 }
+
+// WorkerModelVersions returns all the versions of a worker model, last version first
+func (c *client) WorkerModelVersions(id int64) ([]sdk.ModelVersion, error) {
+	var vs []sdk.ModelVersion
+	if _, err := c.GetJSON(context.Background(), fmt.Sprintf("/worker/model/%d/version", id), &vs); err != nil {
+		return nil, err
+	}
+	return vs, nil
+}
+
+// WorkerModelVersion returns a version of a worker model, used by hatcheries for jobs pinned to a version
+func (c *client) WorkerModelVersion(id, version int64) (*sdk.ModelVersion, error) {
+	var v sdk.ModelVersion
+	if _, err := c.GetJSON(context.Background(), fmt.Sprintf("/worker/model/%d/version/%d", id, version), &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// WorkerModelRollback restores a version as the current version of a worker model
+func (c *client) WorkerModelRollback(id, version int64) (*sdk.Model, error) {
+	var m sdk.Model
+	if _, err := c.PostJSON(context.Background(), fmt.Sprintf("/worker/model/%d/rollback", id), sdk.ModelRollback{Version: version}, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// WorkerModelCanary starts or updates the canary rollout of a version of a worker model
+func (c *client) WorkerModelCanary(id int64, canary sdk.ModelCanary) (*sdk.Model, error) {
+	var m sdk.Model
+	if _, err := c.PutJSON(context.Background(), fmt.Sprintf("/worker/model/%d/canary", id), canary, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// WorkerModelCanaryDelete stops the canary rollout of a worker model
+func (c *client) WorkerModelCanaryDelete(id int64) (*sdk.Model, error) {
+	var m sdk.Model
+	if _, err := c.DeleteJSON(context.Background(), fmt.Sprintf("/worker/model/%d/canary", id), &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
@@ -12,6 +12,8 @@ import (
 	"net/url"
 	"time"
 
+	"golang.org/x/net/websocket"
+
 	"github.com/ovh/cds/sdk"
 )
 
@@ -70,6 +72,36 @@ func (c *client) WorkflowRunGet(projectKey string, workflowName string, number i
 	return &run, nil
 }
 
+func (c *client) WorkflowRunDiff(projectKey string, workflowName string, number, otherNumber int64) (*sdk.WorkflowRunDiff, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/diff/%d", projectKey, workflowName, number, otherNumber)
+	var diff sdk.WorkflowRunDiff
+	if _, err := c.GetJSON(context.Background(), url, &diff); err != nil {
+		return nil, err
+	}
+	return &diff, nil
+}
+
+func (c *client) WorkflowRunResourceUsage(projectKey string, workflowName string, number int64) ([]sdk.JobResourceUsage, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/usage", projectKey, workflowName, number)
+	var usages []sdk.JobResourceUsage
+	if _, err := c.GetJSON(context.Background(), url, &usages); err != nil {
+		return nil, err
+	}
+	return usages, nil
+}
+
+func (c *client) WorkflowResourceUsage(projectKey string, workflowName string, runs int64) ([]sdk.JobResourceUsageStats, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/usage", projectKey, workflowName)
+	if runs > 0 {
+		url = fmt.Sprintf("%s?runs=%d", url, runs)
+	}
+	var stats []sdk.JobResourceUsageStats
+	if _, err := c.GetJSON(context.Background(), url, &stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
 func (c *client) WorkflowRunResync(projectKey string, workflowName string, number int64) (*sdk.WorkflowRun, error) {
 	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/resync", projectKey, workflowName, number)
 	var run sdk.WorkflowRun
@@ -168,6 +200,10 @@ func (c *client) WorkflowNodeRunJobStep(projectKey string, workflowName string,
 	return &buildState, nil
 }
 
+func (c *client) WorkflowNodeRunJobDebug(projectKey string, workflowName string, number int64, nodeRunID, job int64) (*websocket.Conn, error) {
+	return c.Websocket(fmt.Sprintf("/project/%s/workflows/%s/runs/%d/nodes/%d/job/%d/debug", projectKey, workflowName, number, nodeRunID, job))
+}
+
 func (c *client) WorkflowNodeRunArtifactDownload(projectKey string, workflowName string, a sdk.WorkflowNodeRunArtifact, w io.Writer) error {
 	var url = fmt.Sprintf("/project/%s/workflows/%s/artifact/%d", projectKey, workflowName, a.ID)
 	var reader io.ReadCloser
@@ -187,6 +223,15 @@ func (c *client) WorkflowNodeRunArtifactDownload(projectKey string, workflowName
 	return err
 }
 
+func (c *client) WorkflowNodeRunArtifactProvenance(projectKey string, workflowName string, artifactID int64) (*sdk.ProvenanceEnvelope, error) {
+	var envelope sdk.ProvenanceEnvelope
+	url := fmt.Sprintf("/project/%s/workflows/%s/artifact/%d/provenance", projectKey, workflowName, artifactID)
+	if _, err := c.GetJSON(context.Background(), url, &envelope); err != nil {
+		return nil, err
+	}
+	return &envelope, nil
+}
+
 func (c *client) WorkflowNodeRunRelease(projectKey string, workflowName string, runNumber int64, nodeRunID int64, release sdk.WorkflowNodeRunRelease) error {
 	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/nodes/%d/release", projectKey, workflowName, runNumber, nodeRunID)
 	btes, _ := json.Marshal(release)
@@ -274,6 +319,21 @@ func (c *client) WorkflowNodeStop(projectKey string, workflowName string, number
 	return nodeRun, nil
 }
 
+func (c *client) WorkflowNodeRunApprove(projectKey string, workflowName string, number, nodeRunID int64, req sdk.WorkflowNodeRunApprovalRequest) (*sdk.WorkflowNodeRunApproval, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/nodes/%d/approval", projectKey, workflowName, number, nodeRunID)
+
+	approval := &sdk.WorkflowNodeRunApproval{}
+	code, err := c.PostJSON(context.Background(), url, req, approval)
+	if err != nil {
+		return nil, err
+	}
+	if code >= 300 {
+		return nil, fmt.Errorf("Cannot review approval of workflow node %d. HTTP code error: %d", nodeRunID, code)
+	}
+
+	return approval, nil
+}
+
 func (c *client) WorkflowCachePush(projectKey, integrationName, ref string, tarContent io.Reader, size int) error {
 	store := new(sdk.ArtifactsStore)
 	uri := fmt.Sprintf("/project/%s/storage/%s", projectKey, integrationName)
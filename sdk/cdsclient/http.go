@@ -3,6 +3,7 @@ package cdsclient
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -15,6 +16,7 @@ import (
 	"strings"
 
 	"github.com/dgrijalva/jwt-go"
+	"golang.org/x/net/websocket"
 
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/tracingutils"
@@ -225,25 +227,7 @@ func (c *client) Stream(ctx context.Context, method string, path string, body io
 
 		//No auth on /login route
 		if !strings.HasPrefix(path, "/login") {
-			if c.config.Hash != "" {
-				basedHash := base64.StdEncoding.EncodeToString([]byte(c.config.Hash))
-				req.Header.Set(AuthHeader, basedHash)
-			}
-
-			if _, _, err := new(jwt.Parser).ParseUnverified(c.config.AccessToken, &sdk.AccessTokenJWTClaims{}); err == nil {
-				if c.config.Verbose {
-					fmt.Println("JWT recognized")
-				}
-				auth := "Bearer " + c.config.AccessToken
-				req.Header.Add("Authorization", auth)
-			} else {
-				// TEMPORARY CODE TO HANDLE OLD TOKEN
-				if c.config.User != "" && c.config.Token != "" {
-					req.Header.Add(SessionTokenHeader, c.config.Token)
-					req.SetBasicAuth(c.config.User, c.config.Token)
-				}
-				// TEMPORARY CODE - END
-			}
+			c.setAuthHeaders(req)
 		}
 
 		if c.config.Verbose {
@@ -315,6 +299,64 @@ func (c *client) Stream(ctx context.Context, method string, path string, body io
 	return nil, nil, 0, fmt.Errorf("x%d: %s", c.config.Retry, savederror)
 }
 
+// setAuthHeaders sets the authentication headers of the client on given request
+func (c *client) setAuthHeaders(req *http.Request) {
+	if c.config.Hash != "" {
+		basedHash := base64.StdEncoding.EncodeToString([]byte(c.config.Hash))
+		req.Header.Set(AuthHeader, basedHash)
+	}
+
+	if _, _, err := new(jwt.Parser).ParseUnverified(c.config.AccessToken, &sdk.AccessTokenJWTClaims{}); err == nil {
+		if c.config.Verbose {
+			fmt.Println("JWT recognized")
+		}
+		auth := "Bearer " + c.config.AccessToken
+		req.Header.Add("Authorization", auth)
+	} else {
+		// TEMPORARY CODE TO HANDLE OLD TOKEN
+		if c.config.User != "" && c.config.Token != "" {
+			req.Header.Add(SessionTokenHeader, c.config.Token)
+			req.SetBasicAuth(c.config.User, c.config.Token)
+		}
+		// TEMPORARY CODE - END
+	}
+}
+
+// Websocket opens an authenticated websocket connection on given path
+func (c *client) Websocket(path string) (*websocket.Conn, error) {
+	req, err := http.NewRequest(http.MethodGet, c.config.Host+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.config.userAgent)
+	req.Header.Add(RequestedWithHeader, RequestedWithValue)
+	if c.name != "" {
+		req.Header.Add(RequestedNameHeader, c.name)
+	}
+	c.setAuthHeaders(req)
+
+	origin := *req.URL
+	location := *req.URL
+	if location.Scheme == "https" {
+		location.Scheme = "wss"
+	} else {
+		location.Scheme = "ws"
+	}
+
+	config, err := websocket.NewConfig(location.String(), origin.String())
+	if err != nil {
+		return nil, err
+	}
+	config.Header = req.Header
+	config.TlsConfig = &tls.Config{InsecureSkipVerify: c.config.InsecureSkipVerifyTLS}
+
+	if c.config.Verbose {
+		log.Printf("Websocket > %s\n", location.String())
+	}
+
+	return websocket.DialConfig(config)
+}
+
 // UploadMultiPart upload multipart
 func (c *client) UploadMultiPart(method string, path string, body *bytes.Buffer, mods ...RequestModifier) ([]byte, int, error) {
 	var req *http.Request
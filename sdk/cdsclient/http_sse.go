@@ -14,11 +14,13 @@ import (
 const (
 	sseEvent = "event"
 	sseData  = "data"
+	sseID    = "id"
 )
 
 //SSEvent is a go representation of an http server-sent event
 type SSEvent struct {
 	URI  string
+	ID   string
 	Type string
 	Data io.Reader
 }
@@ -79,6 +81,7 @@ func (c *client) RequestSSEGet(ctx context.Context, path string, evCh chan<- SSE
 	delim := []byte{':', ' '}
 
 	var currEvent *SSEvent
+	var currID string
 	var EOF bool
 
 	for !EOF {
@@ -109,9 +112,13 @@ func (c *client) RequestSSEGet(ctx context.Context, path string, evCh chan<- SSE
 
 		currEvent = &SSEvent{URI: uri}
 		switch string(spl[0]) {
+		case sseID:
+			currID = string(bytes.TrimSpace(spl[1]))
 		case sseEvent:
 			currEvent.Type = string(bytes.TrimSpace(spl[1]))
 		case sseData:
+			currEvent.ID = currID
+			currID = ""
 			currEvent.Data = bytes.NewBuffer(bytes.TrimSpace(spl[1]))
 			evCh <- *currEvent
 		}
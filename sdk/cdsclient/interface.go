@@ -5,9 +5,13 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
+	"golang.org/x/net/websocket"
+
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
 )
 
 type Filter struct {
@@ -37,6 +41,10 @@ type AdminService interface {
 	AdminCDSMigrationList() ([]sdk.Migration, error)
 	AdminCDSMigrationCancel(id int64) error
 	AdminCDSMigrationReset(id int64) error
+	AdminFeatureList() ([]sdk.Feature, error)
+	AdminFeatureGet(name string) (*sdk.Feature, error)
+	AdminFeatureSet(f sdk.Feature) error
+	AdminFeatureDelete(name string) error
 	Services() ([]sdk.Service, error)
 	ServicesByName(name string) (*sdk.Service, error)
 	ServiceDelete(name string) error
@@ -57,8 +65,8 @@ type ExportImportInterface interface {
 	WorkflowExport(projectKey, name string, mods ...RequestModifier) ([]byte, error)
 	WorkflowPull(projectKey, name string, mods ...RequestModifier) (*tar.Reader, error)
 	WorkflowImport(projectKey string, content io.Reader, format string, force bool) ([]string, error)
-	WorkerModelExport(id int64, format string) ([]byte, error)
-	WorkerModelImport(content io.Reader, format string, force bool) (*sdk.Model, error)
+	WorkerModelExport(id int64, format string, mods ...RequestModifier) ([]byte, error)
+	WorkerModelImport(content io.Reader, format string, force bool, mods ...RequestModifier) (*sdk.Model, error)
 	WorkflowPush(projectKey string, tarContent io.Reader, mods ...RequestModifier) ([]string, *tar.Reader, error)
 	WorkflowAsCodeInterface
 }
@@ -84,6 +92,15 @@ type ApplicationClient interface {
 	ApplicationList(projectKey string) ([]sdk.Application, error)
 	ApplicationVariableClient
 	ApplicationKeysClient
+	ApplicationReleaseClient
+}
+
+// ApplicationReleaseClient exposes application releases related functions
+type ApplicationReleaseClient interface {
+	ApplicationReleaseList(projectKey string, appName string, versionRange string) ([]sdk.ApplicationRelease, error)
+	ApplicationReleaseGet(projectKey string, appName string, version string) (*sdk.ApplicationRelease, error)
+	ApplicationReleasePromote(projectKey string, appName string, promotion sdk.ApplicationReleasePromotion) (*sdk.ApplicationRelease, error)
+	ApplicationReleaseArtifactDownload(projectKey string, appName string, version string, artifactName string, w io.Writer) error
 }
 
 // ApplicationKeysClient exposes application keys related functions
@@ -134,6 +151,15 @@ type EnvironmentVariableClient interface {
 type EventsClient interface {
 	// Must be  run in a go routine
 	EventsListen(ctx context.Context, chanSSEvt chan<- SSEvent)
+	// Must be  run in a go routine, missed events are replayed on reconnection
+	EventsListenWithOptions(ctx context.Context, chanSSEvt chan<- SSEvent, opts EventsListenOptions)
+}
+
+// EventsListenOptions are the filters and the replay position of the events stream
+type EventsListenOptions struct {
+	Filter sdk.EventsStreamFilter
+	// LastEventID replays the buffered events published after this event
+	LastEventID string
 }
 
 // DownloadClient exposes download related functions
@@ -152,6 +178,7 @@ type ActionClient interface {
 	ActionExport(groupName, name string, format string) ([]byte, error)
 	ActionBuiltinList() ([]sdk.Action, error)
 	ActionBuiltinGet(name string, mods ...RequestModifier) (*sdk.Action, error)
+	ActionRepositoryList(projectKey string) ([]sdk.ActionRepository, error)
 }
 
 // GroupClient exposes groups related functions
@@ -210,6 +237,9 @@ type ProjectClient interface {
 	ProjectIntegrationGet(projectKey string, integrationName string, clearPassword bool) (sdk.ProjectIntegration, error)
 	ProjectIntegrationList(projectKey string) ([]sdk.ProjectIntegration, error)
 	ProjectIntegrationDelete(projectKey string, integrationName string) error
+	ProjectBundleExport(projectKey, passphrase string) ([]byte, error)
+	ProjectBundleImport(projectKey, passphrase string, content io.Reader, dryRun, force bool, groupMapping []string) (*sdk.ProjectBundleImportReport, error)
+	ProjectDORAMetrics(projectKey string, days int64, application, environment string) ([]sdk.DORAMetrics, error)
 }
 
 // ProjectKeysClient exposes project keys related functions
@@ -217,6 +247,7 @@ type ProjectKeysClient interface {
 	ProjectKeysList(projectKey string) ([]sdk.ProjectKey, error)
 	ProjectKeyCreate(projectKey string, key *sdk.ProjectKey) error
 	ProjectKeysDelete(projectKey string, keyProjectName string) error
+	ProjectProvenanceKeyGet(projectKey string) (*sdk.ProjectKey, error)
 }
 
 // ProjectVariablesClient exposes project variables related functions
@@ -240,10 +271,15 @@ type QueueClient interface {
 	QueueJobInfo(id int64) (*sdk.WorkflowNodeJobRun, error)
 	QueueJobSendSpawnInfo(ctx context.Context, id int64, in []sdk.SpawnInfo) error
 	QueueSendResult(ctx context.Context, id int64, res sdk.Result) error
+	QueueSendResourceUsage(ctx context.Context, id int64, usage sdk.JobResourceUsage) error
 	QueueArtifactUpload(ctx context.Context, projectKey, integrationName string, nodeJobRunID int64, tag, filePath string) (bool, time.Duration, error)
 	QueueStaticFilesUpload(ctx context.Context, projectKey, integrationName string, nodeJobRunID int64, name, entrypoint, staticKey string, tarContent io.Reader) (string, bool, time.Duration, error)
 	QueueJobTag(ctx context.Context, jobID int64, tags []sdk.WorkflowRunTag) error
+	QueueJobSendVulnerabilityReport(ctx context.Context, jobID int64, report sdk.VulnerabilityWorkerReport) (*sdk.SecurityPolicyReport, error)
+	QueueJobSendSBOM(ctx context.Context, jobID int64, report sdk.SBOMWorkerReport) (*sdk.SecurityPolicyReport, error)
+	QueueJobAppendStages(ctx context.Context, jobID int64, p exportentities.PipelineV1) ([]sdk.Stage, error)
 	QueueJobIncAttempts(ctx context.Context, jobID int64) ([]int64, error)
+	QueueJobDebugSession(jobID int64) (*websocket.Conn, error)
 	QueueServiceLogs(ctx context.Context, logs []sdk.ServiceLog) error
 }
 
@@ -277,6 +313,11 @@ type WorkerClient interface {
 	WorkerModels() ([]sdk.Model, error)
 	WorkerModelsByBinary(binary string) ([]sdk.Model, error)
 	WorkerModelsByState(state string) ([]sdk.Model, error)
+	WorkerModelVersions(id int64) ([]sdk.ModelVersion, error)
+	WorkerModelVersion(id, version int64) (*sdk.ModelVersion, error)
+	WorkerModelRollback(id, version int64) (*sdk.Model, error)
+	WorkerModelCanary(id int64, canary sdk.ModelCanary) (*sdk.Model, error)
+	WorkerModelCanaryDelete(id int64) (*sdk.Model, error)
 	WorkerRegister(ctx context.Context, form sdk.WorkerRegistrationForm) (*sdk.Worker, bool, error)
 	WorkerSetStatus(ctx context.Context, status sdk.Status) error
 }
@@ -297,6 +338,9 @@ type WorkflowClient interface {
 	WorkflowGroupDelete(projectKey, name, groupName string) error
 	WorkflowRunGet(projectKey string, workflowName string, number int64) (*sdk.WorkflowRun, error)
 	WorkflowRunResync(projectKey string, workflowName string, number int64) (*sdk.WorkflowRun, error)
+	WorkflowRunDiff(projectKey string, workflowName string, number, otherNumber int64) (*sdk.WorkflowRunDiff, error)
+	WorkflowRunResourceUsage(projectKey string, workflowName string, number int64) ([]sdk.JobResourceUsage, error)
+	WorkflowResourceUsage(projectKey string, workflowName string, runs int64) ([]sdk.JobResourceUsageStats, error)
 	WorkflowRunSearch(projectKey string, offset, limit int64, filter ...Filter) ([]sdk.WorkflowRun, error)
 	WorkflowRunList(projectKey string, workflowName string, offset, limit int64) ([]sdk.WorkflowRun, error)
 	WorkflowRunArtifacts(projectKey string, name string, number int64) ([]sdk.WorkflowNodeRunArtifact, error)
@@ -306,9 +350,12 @@ type WorkflowClient interface {
 	WorkflowRunNumberSet(projectKey string, workflowName string, number int64) error
 	WorkflowStop(projectKey string, workflowName string, number int64) (*sdk.WorkflowRun, error)
 	WorkflowNodeStop(projectKey string, workflowName string, number, fromNodeID int64) (*sdk.WorkflowNodeRun, error)
+	WorkflowNodeRunApprove(projectKey string, workflowName string, number, nodeRunID int64, req sdk.WorkflowNodeRunApprovalRequest) (*sdk.WorkflowNodeRunApproval, error)
 	WorkflowNodeRun(projectKey string, name string, number int64, nodeRunID int64) (*sdk.WorkflowNodeRun, error)
 	WorkflowNodeRunArtifactDownload(projectKey string, name string, a sdk.WorkflowNodeRunArtifact, w io.Writer) error
+	WorkflowNodeRunArtifactProvenance(projectKey string, name string, artifactID int64) (*sdk.ProvenanceEnvelope, error)
 	WorkflowNodeRunJobStep(projectKey string, workflowName string, number int64, nodeRunID, job int64, step int) (*sdk.BuildState, error)
+	WorkflowNodeRunJobDebug(projectKey string, workflowName string, number int64, nodeRunID, job int64) (*websocket.Conn, error)
 	WorkflowNodeRunRelease(projectKey string, workflowName string, runNumber int64, nodeRunID int64, release sdk.WorkflowNodeRunRelease) error
 	WorkflowAllHooksList() ([]sdk.WorkflowNodeHook, error)
 	WorkflowCachePush(projectKey, integrationName, ref string, tarContent io.Reader, size int) error
@@ -439,6 +486,24 @@ func WithUsage() RequestModifier {
 	}
 }
 
+// WithWorkerModelVersion allow a provider to export a version of a worker model
+func WithWorkerModelVersion(version int64) RequestModifier {
+	return func(r *http.Request) {
+		q := r.URL.Query()
+		q.Set("version", strconv.FormatInt(version, 10))
+		r.URL.RawQuery = q.Encode()
+	}
+}
+
+// WithWorkerModelCanary allow a provider to import a worker model as a canary version sent to the given percentage of the jobs
+func WithWorkerModelCanary(percent int64) RequestModifier {
+	return func(r *http.Request) {
+		q := r.URL.Query()
+		q.Set("canary", strconv.FormatInt(percent, 10))
+		r.URL.RawQuery = q.Encode()
+	}
+}
+
 // AccessTokenClient is the interface for access token management
 type AccessTokenClient interface {
 	AccessTokenListByUser(username string) ([]sdk.AccessToken, error)
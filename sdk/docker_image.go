@@ -0,0 +1,69 @@
+package sdk
+
+import "strings"
+
+// DockerHubRegistry is the registry of the images without registry host
+const DockerHubRegistry = "docker.io"
+
+// DockerImage is a parsed docker image reference
+type DockerImage struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// ParseDockerImage parses an image reference like registry.local:5000/foo/bar:tag or golang@sha256:...
+func ParseDockerImage(image string) DockerImage {
+	var img DockerImage
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		img.Digest = name[i+1:]
+		name = name[:i]
+	}
+	if i := strings.LastIndex(name, ":"); i >= 0 && !strings.Contains(name[i+1:], "/") {
+		img.Tag = name[i+1:]
+		name = name[:i]
+	}
+
+	img.Registry = DockerHubRegistry
+	if i := strings.Index(name, "/"); i >= 0 {
+		host := name[:i]
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			img.Registry = host
+			name = name[i+1:]
+		}
+	}
+	if img.Registry == DockerHubRegistry && !strings.Contains(name, "/") {
+		name = "library/" + name
+	}
+	img.Repository = name
+
+	if img.Tag == "" && img.Digest == "" {
+		img.Tag = "latest"
+	}
+	return img
+}
+
+// Reference returns the tag or the digest of the image
+func (i DockerImage) Reference() string {
+	if i.Digest != "" {
+		return i.Digest
+	}
+	return i.Tag
+}
+
+// ImageRef returns the image to pull, the image is pinned to its digest when it is resolved from a registry integration
+func (m ModelDocker) ImageRef() string {
+	if m.Digest == "" {
+		return m.Image
+	}
+	name := m.Image
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.LastIndex(name, ":"); i >= 0 && !strings.Contains(name[i+1:], "/") {
+		name = name[:i]
+	}
+	return name + "@" + m.Digest
+}
@@ -0,0 +1,20 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDockerImage(t *testing.T) {
+	assert.Equal(t, DockerImage{Registry: "docker.io", Repository: "library/golang", Tag: "1.11"}, ParseDockerImage("golang:1.11"))
+	assert.Equal(t, DockerImage{Registry: "docker.io", Repository: "ovhcom/cds-api", Tag: "latest"}, ParseDockerImage("ovhcom/cds-api"))
+	assert.Equal(t, DockerImage{Registry: "registry.local:5000", Repository: "foo/bar", Tag: "v1"}, ParseDockerImage("registry.local:5000/foo/bar:v1"))
+	assert.Equal(t, DockerImage{Registry: "localhost", Repository: "foo", Digest: "sha256:abcd"}, ParseDockerImage("localhost/foo@sha256:abcd"))
+}
+
+func TestModelDockerImageRef(t *testing.T) {
+	assert.Equal(t, "golang:1.11", ModelDocker{Image: "golang:1.11"}.ImageRef())
+	assert.Equal(t, "golang@sha256:abcd", ModelDocker{Image: "golang:1.11", Digest: "sha256:abcd"}.ImageRef())
+	assert.Equal(t, "registry.local:5000/foo@sha256:abcd", ModelDocker{Image: "registry.local:5000/foo", Digest: "sha256:abcd"}.ImageRef())
+}
@@ -0,0 +1,113 @@
+package sdk
+
+import (
+	"sort"
+	"time"
+)
+
+// DORAMetricsDefaultDays is the default period of the DORA metrics
+const DORAMetricsDefaultDays = 30
+
+// Deployment is a finished run of a workflow node bound to an application and a production environment
+type Deployment struct {
+	ProjectKey      string      `json:"project_key"`
+	ApplicationName string      `json:"application_name"`
+	EnvironmentName string      `json:"environment_name"`
+	WorkflowName    string      `json:"workflow_name"`
+	Number          int64       `json:"num"`
+	Status          string      `json:"status"`
+	Done            time.Time   `json:"done"`
+	Commits         []VCSCommit `json:"commits,omitempty"`
+}
+
+// DORAMetrics are the delivery performance metrics of an application on an environment
+type DORAMetrics struct {
+	ProjectKey      string    `json:"project_key" cli:"project_key"`
+	ApplicationName string    `json:"application_name" cli:"application,key"`
+	EnvironmentName string    `json:"environment_name" cli:"environment"`
+	From            time.Time `json:"from" cli:"-"`
+	To              time.Time `json:"to" cli:"-"`
+	Deployments     int       `json:"deployments" cli:"deployments"`
+	Failures        int       `json:"failures" cli:"failures"`
+	// DeploymentFrequency is the number of successful deployments per day
+	DeploymentFrequency float64 `json:"deployment_frequency" cli:"deployment_frequency"`
+	// LeadTimeForChanges is the median duration in seconds between a commit and its successful deployment
+	LeadTimeForChanges float64 `json:"lead_time_for_changes" cli:"lead_time_for_changes"`
+	// ChangeFailureRate is the ratio of failed deployments
+	ChangeFailureRate float64 `json:"change_failure_rate" cli:"change_failure_rate"`
+	// TimeToRestore is the median duration in seconds between a failed deployment and the next successful one
+	TimeToRestore float64 `json:"time_to_restore" cli:"time_to_restore"`
+}
+
+// ComputeDORAMetrics computes the DORA metrics of each application and environment from the deployments
+// done between from and to, deployments must be sorted by done date
+func ComputeDORAMetrics(deployments []Deployment, from, to time.Time) []DORAMetrics {
+	type key struct{ project, app, env string }
+	var keys []key
+	byKey := map[key][]Deployment{}
+	for _, d := range deployments {
+		k := key{d.ProjectKey, d.ApplicationName, d.EnvironmentName}
+		if _, has := byKey[k]; !has {
+			keys = append(keys, k)
+		}
+		byKey[k] = append(byKey[k], d)
+	}
+
+	days := to.Sub(from).Hours() / 24
+	ms := make([]DORAMetrics, 0, len(keys))
+	for _, k := range keys {
+		m := DORAMetrics{
+			ProjectKey:      k.project,
+			ApplicationName: k.app,
+			EnvironmentName: k.env,
+			From:            from,
+			To:              to,
+		}
+
+		var leadTimes, restoreTimes []float64
+		// Date of the first failure since the last successful deployment
+		var failedSince *time.Time
+		for _, d := range byKey[k] {
+			switch d.Status {
+			case StatusSuccess.String():
+				m.Deployments++
+				for _, c := range d.Commits {
+					if c.Timestamp == 0 {
+						continue
+					}
+					commitDate := time.Unix(c.Timestamp/1000, 0)
+					if lt := d.Done.Sub(commitDate); lt >= 0 {
+						leadTimes = append(leadTimes, lt.Seconds())
+					}
+				}
+				if failedSince != nil {
+					restoreTimes = append(restoreTimes, d.Done.Sub(*failedSince).Seconds())
+					failedSince = nil
+				}
+			case StatusFail.String():
+				m.Deployments++
+				m.Failures++
+				if failedSince == nil {
+					done := d.Done
+					failedSince = &done
+				}
+			}
+		}
+
+		if days > 0 {
+			m.DeploymentFrequency = float64(m.Deployments-m.Failures) / days
+		}
+		if m.Deployments > 0 {
+			m.ChangeFailureRate = float64(m.Failures) / float64(m.Deployments)
+		}
+		m.LeadTimeForChanges = median(leadTimes)
+		m.TimeToRestore = median(restoreTimes)
+		ms = append(ms, m)
+	}
+	return ms
+}
+
+func median(values []float64) float64 {
+	sort.Float64s(values)
+	return Percentile(values, 50)
+}
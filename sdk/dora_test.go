@@ -0,0 +1,45 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeDORAMetrics(t *testing.T) {
+	to := time.Date(2019, 4, 30, 0, 0, 0, 0, time.UTC)
+	from := to.Add(-10 * 24 * time.Hour)
+	at := func(day, hour int) time.Time { return from.Add(time.Duration(day*24+hour) * time.Hour) }
+	commit := func(d time.Time) VCSCommit { return VCSCommit{Timestamp: d.Unix() * 1000} }
+
+	deployments := []Deployment{
+		{ApplicationName: "api", EnvironmentName: "prod", Status: StatusSuccess.String(), Done: at(1, 0),
+			Commits: []VCSCommit{commit(at(0, 22)), commit(at(0, 23))}},
+		{ApplicationName: "api", EnvironmentName: "preprod", Status: StatusSuccess.String(), Done: at(1, 0)},
+		{ApplicationName: "api", EnvironmentName: "prod", Status: StatusFail.String(), Done: at(2, 0),
+			Commits: []VCSCommit{commit(at(1, 20))}},
+		{ApplicationName: "api", EnvironmentName: "prod", Status: StatusFail.String(), Done: at(2, 1)},
+		{ApplicationName: "api", EnvironmentName: "prod", Status: StatusSuccess.String(), Done: at(2, 3),
+			Commits: []VCSCommit{commit(at(2, 2))}},
+	}
+
+	ms := ComputeDORAMetrics(deployments, from, to)
+	assert.Len(t, ms, 2)
+
+	prod := ms[0]
+	assert.Equal(t, "prod", prod.EnvironmentName)
+	assert.Equal(t, 4, prod.Deployments)
+	assert.Equal(t, 2, prod.Failures)
+	assert.Equal(t, 0.2, prod.DeploymentFrequency)
+	assert.Equal(t, 0.5, prod.ChangeFailureRate)
+	// Lead times are 2h, 1h and 1h
+	assert.Equal(t, time.Hour.Seconds(), prod.LeadTimeForChanges)
+	// Restored 3 hours after the first failure
+	assert.Equal(t, (3 * time.Hour).Seconds(), prod.TimeToRestore)
+
+	preprod := ms[1]
+	assert.Equal(t, 1, preprod.Deployments)
+	assert.Equal(t, float64(0), preprod.ChangeFailureRate)
+	assert.Equal(t, float64(0), preprod.LeadTimeForChanges)
+}
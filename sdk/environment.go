@@ -16,6 +16,7 @@ type Environment struct {
 	Keys           []EnvironmentKey `json:"keys"`
 	Usage          *Usage           `json:"usage,omitempty"`
 	FromRepository string           `json:"from_repository,omitempty"`
+	Production     bool             `json:"production"`
 }
 
 // EnvironmentVariableAudit represents an audit on an environment variable
@@ -0,0 +1,54 @@
+package sdk
+
+import (
+	"time"
+)
+
+// Ephemeral environment statuses
+const (
+	EphemeralEnvironmentStatusActive   = "Active"
+	EphemeralEnvironmentStatusTeardown = "Teardown"
+)
+
+// Ephemeral environment build parameters
+const (
+	EphemeralEnvironmentNameParam     = "cds.ephemeral.name"
+	EphemeralEnvironmentTeardownParam = "cds.ephemeral.teardown"
+	EphemeralEnvironmentURLVariable   = "cds.build.ephemeral.url"
+)
+
+// NodeEphemeralEnvironment is the configuration of a workflow node which deploys
+// on a new environment for each branch, tore down when the pull request is closed
+// or after a TTL.
+type NodeEphemeralEnvironment struct {
+	// Name is a template interpolated with the run parameters, ie. preview-{{.git.branch}}
+	Name string `json:"name"`
+	// TTL of the environment in minutes, 0 means no expiry
+	TTL int64 `json:"ttl,omitempty"`
+}
+
+// EphemeralEnvironment is an environment created by a workflow node run
+type EphemeralEnvironment struct {
+	ID                   int64     `json:"id" db:"id"`
+	ProjectID            int64     `json:"project_id" db:"project_id"`
+	WorkflowID           int64     `json:"workflow_id" db:"workflow_id"`
+	WorkflowNodeID       int64     `json:"workflow_node_id" db:"workflow_node_id"`
+	ApplicationID        int64     `json:"application_id" db:"application_id"`
+	ProjectIntegrationID int64     `json:"project_integration_id" db:"project_integration_id"`
+	Name                 string    `json:"name" db:"name"`
+	URL                  string    `json:"url" db:"url"`
+	Status               string    `json:"status" db:"status"`
+	Branch               string    `json:"branch" db:"branch"`
+	PullRequestID        int64     `json:"pull_request_id,omitempty" db:"pull_request_id"`
+	WorkflowRunID        int64     `json:"workflow_run_id" db:"workflow_run_id"`
+	WorkflowNodeRunID    int64     `json:"workflow_node_run_id" db:"workflow_node_run_id"`
+	TriggeredBy          string    `json:"triggered_by" db:"triggered_by"`
+	Created              time.Time `json:"created" db:"created"`
+	LastModified         time.Time `json:"last_modified" db:"last_modified"`
+	Expire               time.Time `json:"expire,omitempty" db:"expire"`
+}
+
+// IsExpired returns true if the environment has a TTL and has expired
+func (e EphemeralEnvironment) IsExpired(t time.Time) bool {
+	return !e.Expire.IsZero() && e.Expire.Before(t)
+}
@@ -189,6 +189,10 @@ var (
 	ErrWorkflowAsCodeOverride                        = Error{ID: 172, Status: http.StatusForbidden}
 	ErrProjectSecretDataUnknown                      = Error{ID: 173, Status: http.StatusBadRequest}
 	ErrApplicationMandatoryOnWorkflowAsCode          = Error{ID: 174, Status: http.StatusBadRequest}
+	ErrWorkflowNodeRunApprovalNotPending             = Error{ID: 175, Status: http.StatusBadRequest}
+	ErrWorkflowNodeRunApprovalForbidden              = Error{ID: 176, Status: http.StatusForbidden}
+	ErrInvalidFreezeWindow                           = Error{ID: 177, Status: http.StatusBadRequest}
+	ErrInvalidBundlePassphrase                       = Error{ID: 178, Status: http.StatusBadRequest}
 )
 
 var errorsAmericanEnglish = map[int]string{
@@ -360,6 +364,10 @@ var errorsAmericanEnglish = map[int]string{
 	ErrWorkflowAsCodeOverride.ID:                        "You cannot override workflow from this repository",
 	ErrProjectSecretDataUnknown.ID:                      "Invalid encrypted data",
 	ErrApplicationMandatoryOnWorkflowAsCode.ID:          "An application linked to a git repository is mandatory on the workflow root",
+	ErrWorkflowNodeRunApprovalNotPending.ID:             "The workflow node run is not waiting for an approval",
+	ErrWorkflowNodeRunApprovalForbidden.ID:              "You are not allowed to review this workflow node run",
+	ErrInvalidFreezeWindow.ID:                           "Invalid freeze window",
+	ErrInvalidBundlePassphrase.ID:                       "Invalid bundle passphrase",
 }
 
 var errorsFrench = map[int]string{
@@ -531,6 +539,10 @@ var errorsFrench = map[int]string{
 	ErrWorkflowAsCodeOverride.ID:                        "Vous ne pouvez pas importer le workflow depuis ce dépôt",
 	ErrProjectSecretDataUnknown.ID:                      "Donnée chiffrée non valide",
 	ErrApplicationMandatoryOnWorkflowAsCode.ID:          "Une application liée à un dépôt git est obligatoire à la racine du workflow",
+	ErrWorkflowNodeRunApprovalNotPending.ID:             "Le pipeline n'est pas en attente d'approbation",
+	ErrWorkflowNodeRunApprovalForbidden.ID:              "Vous n'êtes pas autorisé à approuver ce pipeline",
+	ErrInvalidFreezeWindow.ID:                           "Période de gel invalide",
+	ErrInvalidBundlePassphrase.ID:                       "Phrase secrète du bundle invalide",
 }
 
 var errorsLanguages = []map[int]string{
@@ -1,6 +1,8 @@
 package sdk
 
 import (
+	"net/url"
+	"strings"
 	"time"
 )
 
@@ -15,6 +17,7 @@ const (
 // Status is  "Waiting" "Building" "Success" "Fail" "Unknown", optional
 // DateEvent is a date (timestamp format)
 type Event struct {
+	ID                int64                  `json:"id,omitempty"`
 	Timestamp         time.Time              `json:"timestamp"`
 	Hostname          string                 `json:"hostname"`
 	CDSName           string                 `json:"cdsname"`
@@ -40,6 +43,81 @@ type EventFilter struct {
 	Filter      TimelineFilter `json:"filter"`
 }
 
+// EventsLastEventIDHeader is the SSE header sent by a client reconnecting to the events stream
+const EventsLastEventIDHeader = "Last-Event-ID"
+
+// EventsStreamFilter filters the events sent on the SSE stream, an empty field matches all events
+// Event types can be given with or without the "sdk." prefix
+type EventsStreamFilter struct {
+	ProjectKeys   []string `json:"project_keys,omitempty"`
+	WorkflowNames []string `json:"workflow_names,omitempty"`
+	EventTypes    []string `json:"event_types,omitempty"`
+	Status        []string `json:"status,omitempty"`
+}
+
+// NewEventsStreamFilter reads a filter from the query parameters of the events stream
+func NewEventsStreamFilter(v url.Values) EventsStreamFilter {
+	return EventsStreamFilter{
+		ProjectKeys:   v["project"],
+		WorkflowNames: v["workflow"],
+		EventTypes:    v["type"],
+		Status:        v["status"],
+	}
+}
+
+// Values returns the filter as query parameters of the events stream
+func (f EventsStreamFilter) Values() url.Values {
+	v := url.Values{}
+	for _, p := range f.ProjectKeys {
+		v.Add("project", p)
+	}
+	for _, w := range f.WorkflowNames {
+		v.Add("workflow", w)
+	}
+	for _, t := range f.EventTypes {
+		v.Add("type", t)
+	}
+	for _, s := range f.Status {
+		v.Add("status", s)
+	}
+	return v
+}
+
+// Match returns true if the event is selected by the filter
+func (f EventsStreamFilter) Match(e Event) bool {
+	if len(f.ProjectKeys) > 0 && !IsInArray(e.ProjectKey, f.ProjectKeys) {
+		return false
+	}
+	if len(f.WorkflowNames) > 0 && !IsInArray(e.WorkflowName, f.WorkflowNames) {
+		return false
+	}
+	if len(f.EventTypes) > 0 {
+		var found bool
+		for _, t := range f.EventTypes {
+			if e.EventType == t || e.EventType == "sdk."+strings.TrimPrefix(t, "sdk.") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(f.Status) > 0 {
+		var found bool
+		for _, s := range f.Status {
+			if strings.EqualFold(e.Status, s) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
 // EventSubscription data send to api to subscribe to an event
 type EventSubscription struct {
 	UUID         string `json:"uuid"`
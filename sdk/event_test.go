@@ -0,0 +1,22 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventsStreamFilter(t *testing.T) {
+	f := NewEventsStreamFilter(EventsStreamFilter{
+		ProjectKeys: []string{"KEY"},
+		EventTypes:  []string{"EventRunWorkflow", "sdk.EventRunWorkflowNode"},
+		Status:      []string{StatusFail.String()},
+	}.Values())
+
+	assert.True(t, EventsStreamFilter{}.Match(Event{EventType: "sdk.EventProjectAdd"}))
+	assert.True(t, f.Match(Event{ProjectKey: "KEY", EventType: "sdk.EventRunWorkflow", Status: StatusFail.String()}))
+	assert.True(t, f.Match(Event{ProjectKey: "KEY", EventType: "sdk.EventRunWorkflowNode", Status: StatusFail.String()}))
+	assert.False(t, f.Match(Event{ProjectKey: "OTHER", EventType: "sdk.EventRunWorkflow", Status: StatusFail.String()}))
+	assert.False(t, f.Match(Event{ProjectKey: "KEY", EventType: "sdk.EventRunWorkflowJob", Status: StatusFail.String()}))
+	assert.False(t, f.Match(Event{ProjectKey: "KEY", EventType: "sdk.EventRunWorkflow", Status: StatusSuccess.String()}))
+}
@@ -18,6 +18,17 @@ type EventWorkflowDelete struct {
 	Workflow Workflow `json:"workflow"`
 }
 
+// EventWorkflowNodeRunApproval represents the event when reviewing the approval request of a workflow node run
+type EventWorkflowNodeRunApproval struct {
+	WorkflowID        int64  `json:"workflow_id"`
+	WorkflowRunNumber int64  `json:"workflow_run_number"`
+	WorkflowNodeRunID int64  `json:"workflow_node_run_id"`
+	WorkflowNodeName  string `json:"workflow_node_name"`
+	Status            string `json:"status"`
+	Approved          bool   `json:"approved"`
+	Comment           string `json:"comment"`
+}
+
 // EventWorkflowPermissionAdd represents the event when adding a workflow permission
 type EventWorkflowPermissionAdd struct {
 	WorkflowID int64           `json:"workflow_id"`
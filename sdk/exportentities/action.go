@@ -3,6 +3,7 @@ package exportentities
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/mitchellh/mapstructure"
@@ -20,6 +21,7 @@ type Action struct {
 	Parameters   map[string]ParameterValue `json:"parameters,omitempty" yaml:"parameters,omitempty"`
 	Requirements []Requirement             `json:"requirements,omitempty" yaml:"requirements,omitempty"`
 	Steps        []Step                    `json:"steps,omitempty" yaml:"steps,omitempty"`
+	Outputs      map[string]OutputValue    `json:"outputs,omitempty" yaml:"outputs,omitempty"`
 }
 
 // ActionVersion is a version
@@ -57,6 +59,7 @@ func NewAction(a sdk.Action) Action {
 	}
 	ea.Steps = newSteps(a)
 	ea.Requirements = newRequirements(a.Requirements)
+	ea.Outputs = newOutputs(a.Outputs)
 	// enabled is the default value
 	// set enable attribute only if it's disabled
 	// no need to export it if action is enabled
@@ -84,6 +87,12 @@ func newSteps(a sdk.Action) []Step {
 		if act.AlwaysExecuted {
 			s["always_executed"] = act.AlwaysExecuted
 		}
+		if act.ContinueOnError {
+			s["continue_on_error"] = act.ContinueOnError
+		}
+		if act.Condition != "" {
+			s["if"] = act.Condition
+		}
 
 		switch act.Type {
 		case sdk.BuiltinAction:
@@ -207,6 +216,9 @@ func newSteps(a sdk.Action) []Step {
 				if tag != nil && tag.Value != "" && tag.Value != sdk.DefaultGitCloneParameterTagValue {
 					gitCloneArgs["tag"] = tag.Value
 				}
+				for k, v := range cloneOptionsArgs(act.Parameters) {
+					gitCloneArgs[k] = v
+				}
 				s["gitClone"] = gitCloneArgs
 			case sdk.GitTagAction:
 				gitTagArgs := map[string]string{}
@@ -261,7 +273,12 @@ func newSteps(a sdk.Action) []Step {
 				}
 			case sdk.CheckoutApplicationAction:
 				directory := sdk.ParameterFind(&act.Parameters, "directory")
-				if directory != nil {
+				if opts := cloneOptionsArgs(act.Parameters); len(opts) > 0 {
+					if directory != nil {
+						opts["directory"] = directory.Value
+					}
+					s["checkout"] = opts
+				} else if directory != nil {
 					s["checkout"] = directory.Value
 				}
 			case sdk.DeployApplicationAction:
@@ -424,6 +441,24 @@ func (s Step) AsJUnitReport() (*sdk.Action, bool, error) {
 	return &a, true, nil
 }
 
+// cloneOptionsArgs returns the LFS, sparse checkout, partial clone and mirror parameters of a GitClone or
+// CheckoutApplication action that are not set to their default value
+func cloneOptionsArgs(params []sdk.Parameter) map[string]string {
+	args := map[string]string{}
+	for _, name := range []string{"filter", "sparseCheckout", "lfsInclude", "lfsExclude"} {
+		if v := sdk.ParameterValue(params, name); v != "" {
+			args[name] = v
+		}
+	}
+	if sdk.ParameterValue(params, "lfs") == "true" {
+		args["lfs"] = "true"
+	}
+	if sdk.ParameterValue(params, "mirror") == "false" {
+		args["mirror"] = "false"
+	}
+	return args
+}
+
 //AsGitClone returns the step a sdk.Action
 func (s Step) AsGitClone() (*sdk.Action, bool, error) {
 	if !s.IsValid() {
@@ -609,11 +644,19 @@ func (s Step) AsCheckoutApplication() (*sdk.Action, bool, error) {
 		return nil, false, nil
 	}
 
-	bS, ok := bI.(string)
-	if !ok {
-		return nil, true, sdk.NewErrorFrom(sdk.ErrMalformattedStep, "checkout must be a string")
+	var a sdk.Action
+	switch reflect.ValueOf(bI).Kind() {
+	case reflect.String:
+		a = sdk.NewCheckoutApplication(bI.(string))
+	case reflect.Map:
+		argss := map[string]string{}
+		if err := mapstructure.Decode(bI, &argss); err != nil {
+			return nil, true, sdk.NewErrorWithStack(err, sdk.ErrMalformattedStep)
+		}
+		a = sdk.NewStepCheckoutApplication(argss)
+	default:
+		return nil, true, sdk.NewErrorFrom(sdk.ErrMalformattedStep, "checkout must be a string or a map")
 	}
-	a := sdk.NewCheckoutApplication(bS)
 
 	var err error
 	a.StepName, err = s.Name()
@@ -735,6 +778,17 @@ func (s Step) Name() (string, error) {
 	return "", nil
 }
 
+// Condition returns the condition of the step if exist
+func (s Step) Condition() (string, error) {
+	if stepAttr, ok := s["if"]; ok {
+		if condition, okCondition := stepAttr.(string); okCondition {
+			return condition, nil
+		}
+		return "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "if must be a string")
+	}
+	return "", nil
+}
+
 // Action returns an sdk.Action
 func (ea *Action) Action() (sdk.Action, error) {
 	a := sdk.Action{
@@ -766,11 +820,49 @@ func (ea *Action) Action() (sdk.Action, error) {
 
 	a.Requirements = computeJobRequirements(ea.Requirements)
 
+	a.Outputs = computeOutputs(ea.Outputs)
+
 	children, err := computeSteps(ea.Steps)
 	if err != nil {
 		return a, err
 	}
 	a.Actions = children
+	if err := a.CheckConditions(); err != nil {
+		return a, err
+	}
 
 	return a, nil
 }
+
+func newOutputs(os sdk.ActionOutputs) map[string]OutputValue {
+	if len(os) == 0 {
+		return nil
+	}
+	res := make(map[string]OutputValue, len(os))
+	for _, o := range os {
+		res[o.Name] = OutputValue{
+			Type:        o.Type,
+			Description: o.Description,
+			Value:       o.Value,
+		}
+	}
+	return res
+}
+
+func computeOutputs(os map[string]OutputValue) sdk.ActionOutputs {
+	var res sdk.ActionOutputs
+	for name, v := range os {
+		o := sdk.ActionOutput{
+			Name:        name,
+			Type:        v.Type,
+			Description: v.Description,
+			Value:       v.Value,
+		}
+		if o.Type == "" {
+			o.Type = sdk.StringParameter
+		}
+		res = append(res, o)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
@@ -6,15 +6,17 @@ import (
 
 // Environment is a struct to export sdk.Environment
 type Environment struct {
-	Name   string                   `json:"name" yaml:"name"`
-	Values map[string]VariableValue `json:"values,omitempty" yaml:"values,omitempty"`
-	Keys   map[string]KeyValue      `json:"keys,omitempty" yaml:"keys,omitempty"`
+	Name       string                   `json:"name" yaml:"name"`
+	Production bool                     `json:"production,omitempty" yaml:"production,omitempty"`
+	Values     map[string]VariableValue `json:"values,omitempty" yaml:"values,omitempty"`
+	Keys       map[string]KeyValue      `json:"keys,omitempty" yaml:"keys,omitempty"`
 }
 
 //NewEnvironment returns an Environment from an sdk.Environment pointer
 func NewEnvironment(e sdk.Environment, keys []EncryptedKey) (env *Environment) {
 	env = new(Environment)
 	env.Name = e.Name
+	env.Production = e.Production
 	env.Values = make(map[string]VariableValue, len(e.Variable))
 	for _, v := range e.Variable {
 		env.Values[v.Name] = VariableValue{
@@ -36,6 +38,7 @@ func NewEnvironment(e sdk.Environment, keys []EncryptedKey) (env *Environment) {
 func (e *Environment) Environment() (env *sdk.Environment) {
 	env = new(sdk.Environment)
 	env.Name = e.Name
+	env.Production = e.Production
 	env.Variable = make([]sdk.Variable, len(e.Values))
 	var i int
 	for k, v := range e.Values {
@@ -0,0 +1,241 @@
+package exportentities
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// LintMessage is a problem found by the linter in a CDS file
+type LintMessage struct {
+	File    string `json:"file" cli:"file"`
+	Message string `json:"message" cli:"message"`
+}
+
+func (m LintMessage) String() string {
+	return fmt.Sprintf("%s: %s", m.File, m.Message)
+}
+
+// Builtin variables, the variables starting with one of the builtin prefixes are defined
+// by the project, application, environment, pipeline or a previous step.
+var (
+	lintBuiltinVariables = []string{
+		"cds.application", "cds.environment", "cds.integration", "cds.job", "cds.manual", "cds.node", "cds.node.id",
+		"cds.pipeline", "cds.project", "cds.run", "cds.run.number", "cds.run.subnumber", "cds.stage", "cds.status",
+		"cds.triggered_by.email", "cds.triggered_by.fullname", "cds.triggered_by.username", "cds.ui.pipeline.run",
+		"cds.version", "cds.worker", "cds.workflow", "cds.workspace", "cds.hatchery.name", "cds.release.version",
+		"cds.ephemeral.name", "cds.ephemeral.teardown", "cds.dest.application", "cds.dest.environment", "cds.dest.pipeline",
+		"git.author", "git.author.email", "git.branch", "git.connection.type", "git.default_branch", "git.describe",
+		"git.hash", "git.hash.before", "git.hash.short", "git.http_url", "git.message", "git.pr.id", "git.repository",
+		"git.server", "git.tag", "git.url", "git.http.user", "git.http.password", "git.ssh.key", "git.pgp.key",
+	}
+	lintBuiltinPrefixes = []string{
+		"cds.proj.", "cds.app.", "cds.env.", "cds.pip.", "cds.key.", "cds.integration.", "cds.build.", "cds.parent.", "git.semver.",
+	}
+	lintVariableRegexp = regexp.MustCompile(`{{\s*\.((?:cds|git)\.[a-zA-Z0-9_.\-]+)`)
+)
+
+// LintDirectory checks the CDS files (*.yml) of a directory, see Lint
+func LintDirectory(dir string) ([]LintMessage, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.yml"))
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	files := make(map[string][]byte, len(paths))
+	for _, p := range paths {
+		btes, err := ioutil.ReadFile(p)
+		if err != nil {
+			return nil, sdk.WithStack(err)
+		}
+		files[filepath.Base(p)] = btes
+	}
+	return Lint(files)
+}
+
+// Lint checks CDS yaml files offline. The kind of each file is given by its name (.pip.yml, .app.yml,
+// .env.yml, .model.yml for worker models, .yml for workflows). It checks the files against their JSON Schema, the pipelines used by
+// the workflows, the dependency cycles, the hook models and the cds and git variables.
+func Lint(files map[string][]byte) ([]LintMessage, error) {
+	msgs := []LintMessage{}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pipelines := map[string]bool{}
+	workflows := map[string]Workflow{}
+	for _, name := range names {
+		kind := lintFileKind(name)
+		violations, err := ValidateSchema(kind, files[name], FormatYAML)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range violations {
+			msgs = append(msgs, LintMessage{File: name, Message: v})
+		}
+		if len(violations) > 0 {
+			continue
+		}
+
+		for _, v := range lintVariableRegexp.FindAllStringSubmatch(string(files[name]), -1) {
+			if !lintIsBuiltinVariable(v[1]) {
+				msgs = append(msgs, LintMessage{File: name, Message: fmt.Sprintf("undefined variable %s", v[1])})
+			}
+		}
+
+		switch kind {
+		case SchemaKindPipeline:
+			var p PipelineV1
+			if err := Unmarshal(files[name], FormatYAML, &p); err != nil {
+				return nil, err
+			}
+			pipelines[p.Name] = true
+		case SchemaKindWorkflow:
+			var w Workflow
+			if err := Unmarshal(files[name], FormatYAML, &w); err != nil {
+				return nil, err
+			}
+			workflows[name] = w
+		}
+	}
+
+	for _, name := range names {
+		w, ok := workflows[name]
+		if !ok {
+			continue
+		}
+		for _, m := range lintWorkflow(w, pipelines) {
+			msgs = append(msgs, LintMessage{File: name, Message: m})
+		}
+	}
+	return msgs, nil
+}
+
+func lintFileKind(name string) string {
+	switch {
+	case strings.HasSuffix(name, ".pip.yml"):
+		return SchemaKindPipeline
+	case strings.HasSuffix(name, ".app.yml"):
+		return SchemaKindApplication
+	case strings.HasSuffix(name, ".env.yml"):
+		return SchemaKindEnvironment
+	case strings.HasSuffix(name, ".model.yml"):
+		return SchemaKindWorkerModel
+	}
+	return SchemaKindWorkflow
+}
+
+func lintIsBuiltinVariable(v string) bool {
+	if sdk.IsInArray(v, lintBuiltinVariables) {
+		return true
+	}
+	for _, p := range lintBuiltinPrefixes {
+		if strings.HasPrefix(v, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func lintWorkflow(w Workflow, pipelines map[string]bool) []string {
+	msgs := []string{}
+	entries := w.Entries()
+	nodes := make([]string, 0, len(entries))
+	for name := range entries {
+		nodes = append(nodes, name)
+	}
+	sort.Strings(nodes)
+
+	hookModels := map[string]bool{}
+	for _, m := range sdk.BuiltinHookModels {
+		hookModels[m.Name] = true
+	}
+	outgoingHookModels := map[string]bool{}
+	for _, m := range sdk.BuiltinOutgoingHookModels {
+		outgoingHookModels[m.Name] = true
+	}
+
+	for _, name := range nodes {
+		e := entries[name]
+		if e.PipelineName != "" && !pipelines[e.PipelineName] {
+			msgs = append(msgs, fmt.Sprintf("node %s uses an unknown pipeline %s", name, e.PipelineName))
+		}
+		for _, d := range e.DependsOn {
+			if _, ok := entries[d]; !ok {
+				msgs = append(msgs, fmt.Sprintf("node %s depends on an unknown node %s", name, d))
+			}
+		}
+		if e.OutgoingHookModelName != "" && !outgoingHookModels[e.OutgoingHookModelName] {
+			msgs = append(msgs, fmt.Sprintf("node %s uses an unknown outgoing hook model %s", name, e.OutgoingHookModelName))
+		}
+	}
+
+	hooks := w.Hooks
+	if len(w.PipelineHooks) > 0 {
+		hooks = map[string][]HookEntry{w.PipelineName: w.PipelineHooks}
+	}
+	for _, name := range nodes {
+		for _, h := range hooks[name] {
+			if !hookModels[h.Model] {
+				msgs = append(msgs, fmt.Sprintf("node %s uses an unknown hook model %s", name, h.Model))
+			}
+		}
+	}
+
+	if cycle := lintDependencyCycle(entries, nodes); len(cycle) > 0 {
+		msgs = append(msgs, fmt.Sprintf("dependency cycle %s", strings.Join(cycle, " -> ")))
+	}
+	return msgs
+}
+
+// lintDependencyCycle returns the first cycle found in the depends_on of the nodes
+func lintDependencyCycle(entries map[string]NodeEntry, nodes []string) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(entries))
+	var path []string
+
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		state[name] = visiting
+		path = append(path, name)
+		for _, d := range entries[name].DependsOn {
+			if _, ok := entries[d]; !ok {
+				continue
+			}
+			switch state[d] {
+			case visiting:
+				for i := range path {
+					if path[i] == d {
+						return append(append([]string{}, path[i:]...), d)
+					}
+				}
+			case unvisited:
+				if cycle := visit(d); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+
+	for _, name := range nodes {
+		if state[name] == unvisited {
+			if cycle := visit(name); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
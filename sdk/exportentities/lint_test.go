@@ -0,0 +1,110 @@
+package exportentities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSchema(t *testing.T) {
+	for _, k := range SchemaKinds() {
+		s, err := GetSchema(k)
+		assert.NoError(t, err)
+		assert.Equal(t, "object", s.Type)
+		assert.Contains(t, s.Required, "name")
+	}
+
+	s, err := GetSchema(SchemaKindWorkflow)
+	assert.NoError(t, err)
+	assert.Equal(t, "#/definitions/NodeEntry", s.Properties["workflow"].AdditionalProperties.(*Schema).Ref)
+	assert.NotNil(t, s.Definitions["NodeEntry"].Properties["approval_gate"])
+
+	_, err = GetSchema("unknown")
+	assert.Error(t, err)
+}
+
+func TestValidateSchema(t *testing.T) {
+	violations, err := ValidateSchema(SchemaKindWorkflow, []byte(`name: my-workflow
+workflow:
+  root:
+    pipeline: build
+    conditions:
+      check:
+      - variable: git.branch
+        operator: eq
+        value: master
+`), FormatYAML)
+	assert.NoError(t, err)
+	assert.Empty(t, violations)
+
+	violations, err = ValidateSchema(SchemaKindWorkflow, []byte(`name: my-workflow
+workflow:
+  root:
+    pipline: build
+`), FormatYAML)
+	assert.NoError(t, err)
+	assert.Len(t, violations, 1)
+
+	violations, err = ValidateSchema(SchemaKindPipeline, []byte(`version: v1.0
+jobs:
+- job: build
+  steps:
+  - script: make
+`), FormatYAML)
+	assert.NoError(t, err)
+	assert.Len(t, violations, 1)
+}
+
+func TestLint(t *testing.T) {
+	msgs, err := Lint(map[string][]byte{
+		"build.pip.yml": []byte(`version: v1.0
+name: build
+jobs:
+- job: build
+  steps:
+  - script: echo {{.cds.version}} {{.git.branch}} {{.cds.app.foo}}
+`),
+		"my-workflow.yml": []byte(`name: my-workflow
+version: v1.0
+workflow:
+  build:
+    pipeline: build
+  deploy:
+    pipeline: deploy
+    depends_on:
+    - build
+    - check
+  check:
+    pipeline: build
+    depends_on:
+    - deploy
+hooks:
+  build:
+  - type: Unknown
+`),
+		"bad.pip.yml": []byte(`version: v1.0
+name: bad
+jobs:
+- job: build
+  steps:
+  - script: echo {{.cds.unknown}}
+`),
+		"go.model.yml": []byte(`name: go
+group: shared.infra
+type: docker
+image: golang:1.11
+`),
+		"bad.model.yml": []byte(`name: bad
+type: docker
+image: golang:1.11
+`),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []LintMessage{
+		{File: "bad.model.yml", Message: "group: group is required"},
+		{File: "bad.pip.yml", Message: "undefined variable cds.unknown"},
+		{File: "my-workflow.yml", Message: "node deploy uses an unknown pipeline deploy"},
+		{File: "my-workflow.yml", Message: "node build uses an unknown hook model Unknown"},
+		{File: "my-workflow.yml", Message: "dependency cycle check -> deploy -> check"},
+	}, msgs)
+}
@@ -43,38 +43,49 @@ type Stage struct {
 
 // Job represents exported sdk.Job
 type Job struct {
-	Name           string        `json:"job,omitempty" yaml:"job,omitempty"`     //This will ONLY be set with Pipelinev1
-	Stage          string        `json:"stage,omitempty" yaml:"stage,omitempty"` //This will ONLY be set with Pipelinev1
-	Description    string        `json:"description,omitempty" yaml:"description,omitempty"`
-	Enabled        *bool         `json:"enabled,omitempty" yaml:"enabled,omitempty"`
-	Steps          []Step        `json:"steps,omitempty" yaml:"steps,omitempty"`
-	Requirements   []Requirement `json:"requirements,omitempty" yaml:"requirements,omitempty"`
-	Optional       *bool         `json:"optional,omitempty" yaml:"optional,omitempty"`
-	AlwaysExecuted *bool         `json:"always_executed,omitempty" yaml:"always_executed,omitempty"`
+	Name           string                 `json:"job,omitempty" yaml:"job,omitempty"`     //This will ONLY be set with Pipelinev1
+	Stage          string                 `json:"stage,omitempty" yaml:"stage,omitempty"` //This will ONLY be set with Pipelinev1
+	Description    string                 `json:"description,omitempty" yaml:"description,omitempty"`
+	Enabled        *bool                  `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Steps          []Step                 `json:"steps,omitempty" yaml:"steps,omitempty"`
+	Requirements   []Requirement          `json:"requirements,omitempty" yaml:"requirements,omitempty"`
+	Optional       *bool                  `json:"optional,omitempty" yaml:"optional,omitempty"`
+	AlwaysExecuted *bool                  `json:"always_executed,omitempty" yaml:"always_executed,omitempty"`
+	Outputs        map[string]OutputValue `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+	If             string                 `json:"if,omitempty" yaml:"if,omitempty"`
+	KeepOnFailure  int                    `json:"keep_on_failure,omitempty" yaml:"keep_on_failure,omitempty"`
 }
 
 // Step represents exported step used in a job
 type Step map[string]interface{}
 
+// stepAttributes are the keys of a step that are not its action
+var stepAttributes = map[string]struct{}{
+	"enabled":           {},
+	"optional":          {},
+	"always_executed":   {},
+	"continue_on_error": {},
+	"if":                {},
+	"name":              {},
+}
+
 // IsValid returns true is the step is valid
 func (s Step) IsValid() bool {
-	keys := []string{}
-	for k := range s {
-		if k != "enabled" && k != "optional" && k != "always_executed" && k != "name" {
-			keys = append(keys, k)
-		}
-	}
-	return len(keys) == 1
+	return len(s.actionKeys()) == 1
 }
 
 func (s Step) key() string {
+	return s.actionKeys()[0]
+}
+
+func (s Step) actionKeys() []string {
 	keys := []string{}
 	for k := range s {
-		if k != "enabled" && k != "optional" && k != "always_executed" && k != "name" {
+		if _, ok := stepAttributes[k]; !ok {
 			keys = append(keys, k)
 		}
 	}
-	return keys[0]
+	return keys
 }
 
 // Requirement represents an exported sdk.Requirement
@@ -192,6 +203,9 @@ func newJob(j sdk.Job) Job {
 	jo.Steps = newSteps(j.Action)
 	jo.Description = j.Action.Description
 	jo.Requirements = newRequirements(j.Action.Requirements)
+	jo.Outputs = newOutputs(j.Action.Outputs)
+	jo.If = j.Action.Condition
+	jo.KeepOnFailure = j.Action.KeepOnFailure
 	return jo
 }
 
@@ -215,6 +229,14 @@ func computeSteps(steps []Step) ([]sdk.Action, error) {
 		if err != nil {
 			return nil, err
 		}
+		a.Condition, err = s.Condition()
+		if err != nil {
+			return nil, err
+		}
+		a.ContinueOnError, err = s.IsFlagged("continue_on_error")
+		if err != nil {
+			return nil, err
+		}
 		res[i] = *a
 	}
 	return res, nil
@@ -325,6 +347,12 @@ func computeJob(name string, j Job) (*sdk.Job, error) {
 	}
 	job.Action.Enabled = job.Enabled
 	job.Action.Requirements = computeJobRequirements(j.Requirements)
+	job.Action.Outputs = computeOutputs(j.Outputs)
+	job.Action.Condition = j.If
+	if j.KeepOnFailure < 0 || j.KeepOnFailure > sdk.MaxKeepOnFailure {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "keep_on_failure of job %s must be between 0 and %d minutes", name, sdk.MaxKeepOnFailure)
+	}
+	job.Action.KeepOnFailure = j.KeepOnFailure
 
 	//Compute steps for the jobs
 	children, err := computeSteps(j.Steps)
@@ -332,6 +360,9 @@ func computeJob(name string, j Job) (*sdk.Job, error) {
 		return nil, err
 	}
 	job.Action.Actions = children
+	if err := job.Action.CheckConditions(); err != nil {
+		return nil, err
+	}
 
 	return &job, nil
 }
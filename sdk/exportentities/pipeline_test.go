@@ -598,6 +598,42 @@ jobs:
 	assert.Len(t, p.Stages[0].Jobs[0].Action.Actions[0].Parameters, 1)
 }
 
+func Test_ImportPipelineWithCheckoutOptions(t *testing.T) {
+	in := `name: build-all-images
+jobs:
+- job: build
+  steps:
+  - checkout:
+      directory: '.'
+      filter: blob:none
+      sparseCheckout: sdk,engine
+      lfs: "true"
+      lfsInclude: '*.bin'
+`
+
+	payload := &PipelineV1{}
+	test.NoError(t, yaml.Unmarshal([]byte(in), payload))
+
+	p, err := payload.Pipeline()
+	test.NoError(t, err)
+
+	assert.Len(t, p.Stages[0].Jobs[0].Action.Actions, 1)
+	a := p.Stages[0].Jobs[0].Action.Actions[0]
+	assert.Equal(t, sdk.CheckoutApplicationAction, a.Name)
+	assert.Len(t, a.Parameters, 5)
+	assert.Equal(t, "sdk,engine", sdk.ParameterValue(a.Parameters, "sparseCheckout"))
+
+	steps := newSteps(p.Stages[0].Jobs[0].Action)
+	assert.Len(t, steps, 1)
+	assert.Equal(t, map[string]string{
+		"directory":      ".",
+		"filter":         "blob:none",
+		"sparseCheckout": "sdk,engine",
+		"lfs":            "true",
+		"lfsInclude":     "*.bin",
+	}, steps[0]["checkout"])
+}
+
 func Test_IsFlagged(t *testing.T) {
 	testc := []struct {
 		flag     string
@@ -744,3 +780,138 @@ func TestExportAndImportPipelineV1_YAML(t *testing.T) {
 		}
 	}
 }
+
+func Test_ImportPipelineWithRepositoryAction(t *testing.T) {
+	in := `name: build
+jobs:
+- job: build
+  steps:
+  - ovh/cds@v1.2.0/build-go:
+      package: ./engine/...
+`
+
+	payload := &PipelineV1{}
+	test.NoError(t, yaml.Unmarshal([]byte(in), payload))
+
+	p, err := payload.Pipeline()
+	test.NoError(t, err)
+
+	assert.Len(t, p.Stages[0].Jobs[0].Action.Actions, 1)
+	a := p.Stages[0].Jobs[0].Action.Actions[0]
+	assert.Equal(t, "ovh/cds@v1.2.0/build-go", a.Name)
+	assert.Equal(t, sdk.RepositoryAction, a.Type)
+	assert.Nil(t, a.Group)
+	assert.Equal(t, "./engine/...", sdk.ParameterValue(a.Parameters, "package"))
+
+	steps := newSteps(p.Stages[0].Jobs[0].Action)
+	assert.Len(t, steps, 1)
+	assert.Equal(t, map[string]string{"package": "./engine/..."}, steps[0]["ovh/cds@v1.2.0/build-go"])
+}
+
+func Test_ImportPipelineWithJobOutputs(t *testing.T) {
+	in := `name: build
+stages:
+- build
+- deploy
+jobs:
+- job: compile
+  stage: build
+  steps:
+  - script: worker export version 1.0.0
+  outputs:
+    version:
+      value: '{{.cds.build.version}}'
+    binary:
+      type: file
+      value: dist/cds
+- job: deploy
+  stage: deploy
+  steps:
+  - script: echo {{.cds.output.compile.version}}
+`
+
+	payload := &PipelineV1{}
+	test.NoError(t, yaml.Unmarshal([]byte(in), payload))
+
+	p, err := payload.Pipeline()
+	test.NoError(t, err)
+	assert.NoError(t, p.CheckJobOutputs())
+
+	outputs := p.Stages[0].Jobs[0].Action.Outputs
+	assert.Equal(t, sdk.ActionOutputs{
+		{Name: "binary", Type: sdk.FileOutput, Value: "dist/cds"},
+		{Name: "version", Type: sdk.StringParameter, Value: "{{.cds.build.version}}"},
+	}, outputs)
+
+	exported := NewPipelineV1(*p)
+	assert.Equal(t, map[string]OutputValue{
+		"binary":  {Type: sdk.FileOutput, Value: "dist/cds"},
+		"version": {Type: sdk.StringParameter, Value: "{{.cds.build.version}}"},
+	}, exported.Jobs[0].Outputs)
+}
+
+func Test_ImportPipelineWithConditions(t *testing.T) {
+	in := `name: build
+stages:
+- build
+jobs:
+- job: compile
+  stage: build
+  if: git_branch == "master"
+  steps:
+  - script: make test
+    name: test
+    continue_on_error: true
+  - script: make report
+    always_executed: true
+    if: cds_step_test_status == "Fail"
+`
+
+	payload := &PipelineV1{}
+	test.NoError(t, yaml.Unmarshal([]byte(in), payload))
+
+	p, err := payload.Pipeline()
+	test.NoError(t, err)
+
+	job := p.Stages[0].Jobs[0].Action
+	assert.Equal(t, `git_branch == "master"`, job.Condition)
+	assert.True(t, job.Actions[0].ContinueOnError)
+	assert.Equal(t, "", job.Actions[0].Condition)
+	assert.False(t, job.Actions[1].ContinueOnError)
+	assert.True(t, job.Actions[1].AlwaysExecuted)
+	assert.Equal(t, `cds_step_test_status == "Fail"`, job.Actions[1].Condition)
+
+	exported := NewPipelineV1(*p)
+	assert.Equal(t, `git_branch == "master"`, exported.Jobs[0].If)
+	assert.Equal(t, true, exported.Jobs[0].Steps[0]["continue_on_error"])
+	assert.Nil(t, exported.Jobs[0].Steps[0]["if"])
+	assert.Equal(t, `cds_step_test_status == "Fail"`, exported.Jobs[0].Steps[1]["if"])
+
+	payload.Jobs[0].If = `git_branch = "master"`
+	_, err = payload.Pipeline()
+	assert.Error(t, err)
+}
+
+func Test_ImportPipelineWithKeepOnFailure(t *testing.T) {
+	in := `name: build
+jobs:
+- job: compile
+  keep_on_failure: 15
+  steps:
+  - script: make
+`
+
+	payload := &PipelineV1{}
+	test.NoError(t, yaml.Unmarshal([]byte(in), payload))
+
+	p, err := payload.Pipeline()
+	test.NoError(t, err)
+	assert.Equal(t, 15, p.Stages[0].Jobs[0].Action.KeepOnFailure)
+
+	exported := NewPipelineV1(*p)
+	assert.Equal(t, 15, exported.Jobs[0].KeepOnFailure)
+
+	payload.Jobs[0].KeepOnFailure = sdk.MaxKeepOnFailure + 1
+	_, err = payload.Pipeline()
+	assert.Error(t, err)
+}
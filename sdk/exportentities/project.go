@@ -0,0 +1,81 @@
+package exportentities
+
+import (
+	"github.com/ovh/cds/sdk"
+)
+
+// ProjectVersion1 is the version of the project manifest
+const ProjectVersion1 = "v1.0"
+
+// Project is the manifest of a project bundle, it contains the project entities which are not
+// exported in their own file. Secrets are encrypted with the bundle passphrase.
+type Project struct {
+	Version      string                        `json:"version,omitempty" yaml:"version,omitempty"`
+	Key          string                        `json:"key" yaml:"key"`
+	Name         string                        `json:"name" yaml:"name"`
+	Description  string                        `json:"description,omitempty" yaml:"description,omitempty"`
+	Variables    map[string]VariableValue      `json:"variables,omitempty" yaml:"variables,omitempty"`
+	Keys         map[string]KeyValue           `json:"keys,omitempty" yaml:"keys,omitempty"`
+	Permissions  map[string]int                `json:"permissions,omitempty" yaml:"permissions,omitempty"`
+	Integrations map[string]ProjectIntegration `json:"integrations,omitempty" yaml:"integrations,omitempty"`
+	Encryption   ProjectEncryption             `json:"encryption" yaml:"encryption"`
+}
+
+// ProjectIntegration is an exported sdk.ProjectIntegration, the integration model is given by its name
+type ProjectIntegration struct {
+	Model  string                `json:"model" yaml:"model"`
+	Config sdk.IntegrationConfig `json:"config,omitempty" yaml:"config,omitempty"`
+}
+
+// ProjectEncryption contains the salt used to derive the encryption key from the bundle passphrase
+// and a known value encrypted with this key, used to check the passphrase before importing the bundle.
+type ProjectEncryption struct {
+	Salt  string `json:"salt" yaml:"salt"`
+	Check string `json:"check" yaml:"check"`
+}
+
+// NewProject returns the manifest of a project, secret variables, keys and integrations
+// secrets have to be encrypted by the caller.
+func NewProject(proj sdk.Project, keys []EncryptedKey) Project {
+	p := Project{
+		Version:     ProjectVersion1,
+		Key:         proj.Key,
+		Name:        proj.Name,
+		Description: proj.Description,
+	}
+
+	p.Variables = make(map[string]VariableValue, len(proj.Variable))
+	for _, v := range proj.Variable {
+		at := v.Type
+		if at == sdk.StringVariable {
+			at = ""
+		}
+		p.Variables[v.Name] = VariableValue{
+			Type:  at,
+			Value: v.Value,
+		}
+	}
+
+	p.Keys = make(map[string]KeyValue, len(keys))
+	for _, k := range keys {
+		p.Keys[k.Name] = KeyValue{
+			Type:  k.Type,
+			Value: k.Content,
+		}
+	}
+
+	p.Permissions = make(map[string]int, len(proj.ProjectGroups))
+	for _, gp := range proj.ProjectGroups {
+		p.Permissions[gp.Group.Name] = gp.Permission
+	}
+
+	p.Integrations = make(map[string]ProjectIntegration, len(proj.Integrations))
+	for _, i := range proj.Integrations {
+		p.Integrations[i.Name] = ProjectIntegration{
+			Model:  i.Model.Name,
+			Config: i.Config,
+		}
+	}
+
+	return p
+}
@@ -0,0 +1,133 @@
+package exportentities
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// Name pattern for project bundle files.
+const (
+	BundleProjectName     = "project.yml"
+	BundleWorkflowName    = "workflows/" + PullWorkflowName
+	BundlePipelineName    = "pipelines/" + PullPipelineName
+	BundleApplicationName = "applications/" + PullApplicationName
+	BundleEnvironmentName = "environments/" + PullEnvironmentName
+)
+
+// ProjectBundle contains the yaml files of all the entities of a project, indexed by entity name.
+type ProjectBundle struct {
+	Project      []byte
+	Workflows    map[string][]byte
+	Pipelines    map[string][]byte
+	Applications map[string][]byte
+	Environments map[string][]byte
+}
+
+// NewProjectBundle returns an empty project bundle
+func NewProjectBundle() *ProjectBundle {
+	return &ProjectBundle{
+		Workflows:    map[string][]byte{},
+		Pipelines:    map[string][]byte{},
+		Applications: map[string][]byte{},
+		Environments: map[string][]byte{},
+	}
+}
+
+// Tar writes the project bundle as a tar in the writer
+func (b ProjectBundle) Tar(writer io.Writer) error {
+	tw := tar.NewWriter(writer)
+	defer func() {
+		if err := tw.Close(); err != nil {
+			log.Error("%v", sdk.WrapError(err, "unable to close tar writer"))
+		}
+	}()
+
+	if err := writeBundleFile(tw, BundleProjectName, b.Project); err != nil {
+		return err
+	}
+	for _, files := range []struct {
+		pattern string
+		values  map[string][]byte
+	}{
+		{BundleApplicationName, b.Applications},
+		{BundleEnvironmentName, b.Environments},
+		{BundlePipelineName, b.Pipelines},
+		{BundleWorkflowName, b.Workflows},
+	} {
+		names := make([]string, 0, len(files.values))
+		for name := range files.values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if err := writeBundleFile(tw, fmt.Sprintf(files.pattern, name), files.values[name]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func writeBundleFile(tw *tar.Writer, name string, btes []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name: name,
+		Mode: 0644,
+		Size: int64(len(btes)),
+	}); err != nil {
+		return sdk.WrapError(err, "unable to write header for %s", name)
+	}
+	if _, err := tw.Write(btes); err != nil {
+		return sdk.WrapError(err, "unable to write %s", name)
+	}
+	return nil
+}
+
+// ReadProjectBundle reads a project bundle from a tar
+func ReadProjectBundle(r io.Reader) (*ProjectBundle, error) {
+	b := NewProjectBundle()
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to read bundle: %v", err)
+		}
+
+		buff := new(bytes.Buffer)
+		if _, err := io.Copy(buff, tr); err != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to read bundle file %s: %v", hdr.Name, err)
+		}
+
+		dir, file := path.Split(hdr.Name)
+		switch {
+		case hdr.Name == BundleProjectName:
+			b.Project = buff.Bytes()
+		case dir == "applications/" && strings.HasSuffix(file, ".app.yml"):
+			b.Applications[strings.TrimSuffix(file, ".app.yml")] = buff.Bytes()
+		case dir == "environments/" && strings.HasSuffix(file, ".env.yml"):
+			b.Environments[strings.TrimSuffix(file, ".env.yml")] = buff.Bytes()
+		case dir == "pipelines/" && strings.HasSuffix(file, ".pip.yml"):
+			b.Pipelines[strings.TrimSuffix(file, ".pip.yml")] = buff.Bytes()
+		case dir == "workflows/" && strings.HasSuffix(file, ".yml"):
+			b.Workflows[strings.TrimSuffix(file, ".yml")] = buff.Bytes()
+		default:
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unexpected file %s in bundle", hdr.Name)
+		}
+	}
+
+	if b.Project == nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing %s in bundle", BundleProjectName)
+	}
+	return b, nil
+}
@@ -0,0 +1,209 @@
+package exportentities
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Kinds of CDS files which have a JSON Schema
+const (
+	SchemaKindWorkflow    = "workflow"
+	SchemaKindPipeline    = "pipeline"
+	SchemaKindApplication = "application"
+	SchemaKindEnvironment = "environment"
+	SchemaKindWorkerModel = "worker-model"
+)
+
+var schemaEntities = map[string]struct {
+	entity   interface{}
+	required []string
+}{
+	SchemaKindWorkflow:    {Workflow{}, []string{"name"}},
+	SchemaKindPipeline:    {PipelineV1{}, []string{"name"}},
+	SchemaKindApplication: {Application{}, []string{"name"}},
+	SchemaKindEnvironment: {Environment{}, []string{"name"}},
+	SchemaKindWorkerModel: {WorkerModel{}, []string{"name", "group", "type"}},
+}
+
+// Schema is a JSON Schema (draft-04) describing a CDS file
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	ID                   string             `json:"id,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 interface{}        `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	MinProperties        int                `json:"minProperties,omitempty"`
+	Definitions          map[string]*Schema `json:"definitions,omitempty"`
+}
+
+// SchemaKinds returns the kinds of CDS files which have a JSON Schema
+func SchemaKinds() []string {
+	kinds := make([]string, 0, len(schemaEntities))
+	for k := range schemaEntities {
+		kinds = append(kinds, k)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// GetSchema returns the JSON Schema of a kind of CDS file, generated from its exportentities type.
+// Properties are named after the yaml tags as CDS files are written in yaml.
+func GetSchema(kind string) (*Schema, error) {
+	e, ok := schemaEntities[kind]
+	if !ok {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unknown schema %s, available schemas are %s", kind, strings.Join(SchemaKinds(), ", "))
+	}
+
+	g := schemaGenerator{definitions: map[string]*Schema{}, types: map[string]reflect.Type{}}
+	s := g.structSchema(reflect.TypeOf(e.entity))
+	s.Schema = "http://json-schema.org/draft-04/schema#"
+	s.ID = "https://github.com/ovh/cds/schemas/" + kind + ".schema.json"
+	s.Title = "CDS " + kind
+	s.Required = e.required
+	if len(g.definitions) > 0 {
+		s.Definitions = g.definitions
+	}
+	return s, nil
+}
+
+// ValidateSchema checks a CDS file against the JSON Schema of its kind and returns the violations
+func ValidateSchema(kind string, btes []byte, f Format) ([]string, error) {
+	s, err := GetSchema(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc interface{}
+	if err := Unmarshal(btes, f, &doc); err != nil {
+		return []string{err.Error()}, nil
+	}
+
+	res, err := gojsonschema.Validate(gojsonschema.NewGoLoader(s), gojsonschema.NewGoLoader(jsonCompatible(doc)))
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to validate %s", kind)
+	}
+
+	violations := make([]string, 0, len(res.Errors()))
+	for _, e := range res.Errors() {
+		violations = append(violations, fmt.Sprintf("%s: %s", e.Field(), e.Description()))
+	}
+	return violations, nil
+}
+
+// jsonCompatible converts the maps decoded from yaml to maps with string keys
+func jsonCompatible(i interface{}) interface{} {
+	switch v := i.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			m[fmt.Sprintf("%v", k)] = jsonCompatible(val)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			m[k] = jsonCompatible(val)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i := range v {
+			s[i] = jsonCompatible(v[i])
+		}
+		return s
+	}
+	return i
+}
+
+type schemaGenerator struct {
+	definitions map[string]*Schema
+	types       map[string]reflect.Type
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func (g *schemaGenerator) schema(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return g.schema(t.Elem())
+	case reflect.String:
+		// yaml scalars are decoded as strings in string fields
+		return &Schema{Type: []string{"string", "number", "boolean"}}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string"}
+		}
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		s := &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+		// a step is a map with a single action
+		if t == reflect.TypeOf(Step{}) {
+			s.MinProperties = 1
+		}
+		return s
+	case reflect.Struct:
+		if t == timeType {
+			return &Schema{Type: "string", Format: "date-time"}
+		}
+		return g.ref(t)
+	}
+	// interface{}: any value
+	return &Schema{}
+}
+
+// ref returns a reference to the definition of a struct type, the definition is generated once
+// so recursive types are supported
+func (g *schemaGenerator) ref(t reflect.Type) *Schema {
+	name := t.Name()
+	if known, ok := g.types[name]; ok && known != t {
+		name = t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:] + "." + name
+	}
+	if _, ok := g.types[name]; !ok {
+		g.types[name] = t
+		g.definitions[name] = g.structSchema(t)
+	}
+	return &Schema{Ref: "#/definitions/" + name}
+}
+
+func (g *schemaGenerator) structSchema(t reflect.Type) *Schema {
+	s := &Schema{
+		Type:                 "object",
+		Properties:           map[string]*Schema{},
+		AdditionalProperties: false,
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(f.Tag.Get("yaml"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		// yaml.v2 default field name
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		s.Properties[name] = g.schema(f.Type)
+	}
+	return s
+}
@@ -28,6 +28,13 @@ type (
 		Description  string `json:"description,omitempty" yaml:"description,omitempty"`
 		Advanced     *bool  `json:"advanced,omitempty" yaml:"advanced,omitempty"`
 	}
+
+	// OutputValue is a struct to export an output of a job or of an action defined in a repository
+	OutputValue struct {
+		Type        string `json:"type,omitempty" yaml:"type,omitempty"`
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		Value       string `json:"value" yaml:"value"`
+	}
 )
 
 //All the consts
@@ -43,6 +43,7 @@ type Workflow struct {
 	Permissions            map[string]int                 `json:"permissions,omitempty" yaml:"permissions,omitempty"`
 	Metadata               map[string]string              `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 	PurgeTags              []string                       `json:"purge_tags,omitempty" yaml:"purge_tags,omitempty"`
+	Concurrency            *sdk.WorkflowConcurrency       `json:"concurrency,omitempty" yaml:"concurrency,omitempty"`
 	HistoryLength          *int64                         `json:"history_length,omitempty" yaml:"history_length,omitempty"`
 	Notifications          []NotificationEntry            `json:"notify,omitempty" yaml:"notify,omitempty"`               // This is used when the workflow have only one pipeline
 	MapNotifications       map[string][]NotificationEntry `json:"notifications,omitempty" yaml:"notifications,omitempty"` // This is used when the workflow have more than one pipeline
@@ -64,20 +65,22 @@ type WorkflowPulledItem struct {
 
 // NodeEntry represents a node as code
 type NodeEntry struct {
-	ID                     int64                       `json:"-" yaml:"-"`
-	DependsOn              []string                    `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
-	Conditions             *sdk.WorkflowNodeConditions `json:"conditions,omitempty" yaml:"conditions,omitempty"`
-	When                   []string                    `json:"when,omitempty" yaml:"when,omitempty"` //This is used only for manual and success condition
-	PipelineName           string                      `json:"pipeline,omitempty" yaml:"pipeline,omitempty"`
-	ApplicationName        string                      `json:"application,omitempty" yaml:"application,omitempty"`
-	EnvironmentName        string                      `json:"environment,omitempty" yaml:"environment,omitempty"`
-	ProjectIntegrationName string                      `json:"integration,omitempty" yaml:"integration,omitempty"`
-	OneAtATime             *bool                       `json:"one_at_a_time,omitempty" yaml:"one_at_a_time,omitempty"`
-	Payload                map[string]interface{}      `json:"payload,omitempty" yaml:"payload,omitempty"`
-	Parameters             map[string]string           `json:"parameters,omitempty" yaml:"parameters,omitempty"`
-	OutgoingHookModelName  string                      `json:"trigger,omitempty" yaml:"trigger,omitempty"`
-	OutgoingHookConfig     map[string]string           `json:"config,omitempty" yaml:"config,omitempty"`
-	Permissions            map[string]int              `json:"permissions,omitempty" yaml:"permissions,omitempty"`
+	ID                     int64                         `json:"-" yaml:"-"`
+	DependsOn              []string                      `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
+	Conditions             *sdk.WorkflowNodeConditions   `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+	When                   []string                      `json:"when,omitempty" yaml:"when,omitempty"` //This is used only for manual and success condition
+	PipelineName           string                        `json:"pipeline,omitempty" yaml:"pipeline,omitempty"`
+	ApplicationName        string                        `json:"application,omitempty" yaml:"application,omitempty"`
+	EnvironmentName        string                        `json:"environment,omitempty" yaml:"environment,omitempty"`
+	ProjectIntegrationName string                        `json:"integration,omitempty" yaml:"integration,omitempty"`
+	OneAtATime             *bool                         `json:"one_at_a_time,omitempty" yaml:"one_at_a_time,omitempty"`
+	EphemeralEnvironment   *sdk.NodeEphemeralEnvironment `json:"ephemeral_environment,omitempty" yaml:"ephemeral_environment,omitempty"`
+	ApprovalGate           *sdk.NodeApprovalGate         `json:"approval_gate,omitempty" yaml:"approval_gate,omitempty"`
+	Payload                map[string]interface{}        `json:"payload,omitempty" yaml:"payload,omitempty"`
+	Parameters             map[string]string             `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	OutgoingHookModelName  string                        `json:"trigger,omitempty" yaml:"trigger,omitempty"`
+	OutgoingHookConfig     map[string]string             `json:"config,omitempty" yaml:"config,omitempty"`
+	Permissions            map[string]int                `json:"permissions,omitempty" yaml:"permissions,omitempty"`
 }
 
 // HookEntry represents a hook as code
@@ -172,6 +175,14 @@ func craftNodeEntry(w sdk.Workflow, n sdk.Node) (NodeEntry, error) {
 			entry.OneAtATime = &n.Context.Mutex
 		}
 
+		if n.Context.EphemeralEnvironment != nil {
+			entry.EphemeralEnvironment = n.Context.EphemeralEnvironment
+		}
+
+		if n.Context.ApprovalGate != nil {
+			entry.ApprovalGate = n.Context.ApprovalGate
+		}
+
 		if n.Context.HasDefaultPayload() {
 			enc := dump.NewDefaultEncoder()
 			enc.ExtraFields.DetailedMap = false
@@ -302,6 +313,7 @@ func NewWorkflow(w sdk.Workflow, opts ...WorkflowOptions) (Workflow, error) {
 	}
 
 	exportedWorkflow.PurgeTags = w.PurgeTags
+	exportedWorkflow.Concurrency = w.Concurrency
 
 	nodes := w.WorkflowData.Array()
 
@@ -509,6 +521,7 @@ func (w Workflow) GetWorkflow() (*sdk.Workflow, error) {
 		return nil, sdk.WrapError(err, "Unable to check dependencies")
 	}
 	wf.PurgeTags = w.PurgeTags
+	wf.Concurrency = w.Concurrency
 	if len(w.Metadata) > 0 {
 		wf.Metadata = make(map[string]string, len(w.Metadata))
 		for k, v := range w.Metadata {
@@ -647,6 +660,14 @@ func (e *NodeEntry) getNode(name string, w *sdk.Workflow) (*sdk.Node, error) {
 		node.Context.Mutex = *e.OneAtATime
 	}
 
+	if e.EphemeralEnvironment != nil {
+		node.Context.EphemeralEnvironment = e.EphemeralEnvironment
+	}
+
+	if e.ApprovalGate != nil {
+		node.Context.ApprovalGate = e.ApprovalGate
+	}
+
 	if e.OutgoingHookModelName != "" {
 		node.Type = sdk.NodeTypeOutGoingHook
 		config := sdk.WorkflowNodeHookConfig{}
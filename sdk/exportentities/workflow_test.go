@@ -216,6 +216,7 @@ func TestWorkflow_GetWorkflow(t *testing.T) {
 		PipelineHooks          []HookEntry
 		Permissions            map[string]int
 		HistoryLength          int64
+		Concurrency            *sdk.WorkflowConcurrency
 	}
 	tsts := []struct {
 		name    string
@@ -314,6 +315,112 @@ func TestWorkflow_GetWorkflow(t *testing.T) {
 			},
 		},
 		// root(pipeline-root) -> child(pipeline-child)
+		{
+			name: "Complexe workflow with an ephemeral environment should not raise an error",
+			fields: fields{
+				Workflow: map[string]NodeEntry{
+					"root": {
+						PipelineName: "pipeline-root",
+					},
+					"child": {
+						PipelineName:         "pipeline-child",
+						DependsOn:            []string{"root"},
+						EphemeralEnvironment: &sdk.NodeEphemeralEnvironment{Name: "preview-{{.git.branch}}", TTL: 120},
+					},
+				},
+			},
+			wantErr: false,
+			want: sdk.Workflow{
+				HistoryLength: sdk.DefaultHistoryLength,
+				WorkflowData: &sdk.WorkflowData{
+					Node: sdk.Node{
+						Name: "root",
+						Type: "pipeline",
+						Context: &sdk.NodeContext{
+							PipelineName: "pipeline-root",
+						},
+						Triggers: []sdk.NodeTrigger{
+							{
+								ChildNode: sdk.Node{
+									Name: "child",
+									Ref:  "child",
+									Type: "pipeline",
+									Context: &sdk.NodeContext{
+										PipelineName:         "pipeline-child",
+										EphemeralEnvironment: &sdk.NodeEphemeralEnvironment{Name: "preview-{{.git.branch}}", TTL: 120},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		// root(pipeline-root) -> child(pipeline-child)
+		{
+			name: "Complexe workflow with an approval gate should not raise an error",
+			fields: fields{
+				Workflow: map[string]NodeEntry{
+					"root": {
+						PipelineName: "pipeline-root",
+					},
+					"child": {
+						PipelineName: "pipeline-child",
+						DependsOn:    []string{"root"},
+						ApprovalGate: &sdk.NodeApprovalGate{Groups: []string{"ops"}, Count: 2, FourEyes: true, Expiry: 60},
+					},
+				},
+			},
+			wantErr: false,
+			want: sdk.Workflow{
+				HistoryLength: sdk.DefaultHistoryLength,
+				WorkflowData: &sdk.WorkflowData{
+					Node: sdk.Node{
+						Name: "root",
+						Type: "pipeline",
+						Context: &sdk.NodeContext{
+							PipelineName: "pipeline-root",
+						},
+						Triggers: []sdk.NodeTrigger{
+							{
+								ChildNode: sdk.Node{
+									Name: "child",
+									Ref:  "child",
+									Type: "pipeline",
+									Context: &sdk.NodeContext{
+										PipelineName: "pipeline-child",
+										ApprovalGate: &sdk.NodeApprovalGate{Groups: []string{"ops"}, Count: 2, FourEyes: true, Expiry: 60},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		// pipeline with a concurrency group
+		{
+			name: "Simple workflow with a concurrency group should not raise an error",
+			fields: fields{
+				PipelineName: "pipeline",
+				Concurrency:  &sdk.WorkflowConcurrency{Key: "{{.git.branch}}", Policy: sdk.ConcurrencyPolicyQueue},
+			},
+			wantErr: false,
+			want: sdk.Workflow{
+				HistoryLength: sdk.DefaultHistoryLength,
+				Concurrency:   &sdk.WorkflowConcurrency{Key: "{{.git.branch}}", Policy: sdk.ConcurrencyPolicyQueue},
+				WorkflowData: &sdk.WorkflowData{
+					Node: sdk.Node{
+						Name: "pipeline",
+						Type: "pipeline",
+						Context: &sdk.NodeContext{
+							PipelineName: "pipeline",
+						},
+					},
+				},
+			},
+		},
+		// root(pipeline-root) -> child(pipeline-child)
 		{
 			name: "Complexe workflow without joins with a default payload on a non root node should raise an error",
 			fields: fields{
@@ -692,6 +799,7 @@ func TestWorkflow_GetWorkflow(t *testing.T) {
 				PipelineHooks:          tt.fields.PipelineHooks,
 				Permissions:            tt.fields.Permissions,
 				HistoryLength:          &tt.fields.HistoryLength,
+				Concurrency:            tt.fields.Concurrency,
 			}
 			got, err := w.GetWorkflow()
 			if (err != nil) != tt.wantErr {
@@ -0,0 +1,56 @@
+package sdk
+
+import (
+	"fmt"
+	"hash/crc32"
+	"time"
+)
+
+// Feature is a feature flag of the CDS database feature store. A feature is active for a project if it is enabled
+// and the project matches one of its rules: its key, one of its groups or the percentage rollout
+type Feature struct {
+	ID           int64     `json:"id" yaml:"-"`
+	Name         string    `json:"name" yaml:"name" cli:"name,key"`
+	Enabled      bool      `json:"enabled" yaml:"enabled" cli:"enabled"`
+	ProjectKeys  []string  `json:"project_keys,omitempty" yaml:"project_keys,omitempty" cli:"project_keys"`
+	Groups       []string  `json:"groups,omitempty" yaml:"groups,omitempty" cli:"groups"`
+	Percentage   int       `json:"percentage" yaml:"percentage" cli:"percentage"`
+	LastModified time.Time `json:"last_modified" yaml:"-" cli:"last_modified"`
+}
+
+// IsValid returns an error if the feature is not valid
+func (f Feature) IsValid() error {
+	if f.Name == "" {
+		return NewErrorFrom(ErrWrongRequest, "invalid feature name")
+	}
+	if f.Percentage < 0 || f.Percentage > 100 {
+		return NewErrorFrom(ErrWrongRequest, "percentage must be between 0 and 100")
+	}
+	return nil
+}
+
+// IsActive returns true if the feature is active for the project with given groups. The percentage rollout
+// is computed on a hash of the feature and project key, a project stays in the rollout when the percentage grows
+func (f Feature) IsActive(projectKey string, groups []string) bool {
+	if !f.Enabled {
+		return false
+	}
+	for _, k := range f.ProjectKeys {
+		if k == projectKey {
+			return true
+		}
+	}
+	for _, g := range f.Groups {
+		for _, pg := range groups {
+			if g == pg {
+				return true
+			}
+		}
+	}
+	return f.Percentage > 0 && FeatureBucket(f.Name, projectKey) < f.Percentage
+}
+
+// FeatureBucket returns the rollout bucket (between 0 and 99) of a project for a feature
+func FeatureBucket(name, projectKey string) int {
+	return int(crc32.ChecksumIEEE([]byte(fmt.Sprintf("%s:%s", name, projectKey))) % 100)
+}
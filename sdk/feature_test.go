@@ -0,0 +1,45 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeatureIsActive(t *testing.T) {
+	f := Feature{
+		Name:        "cds:tracing",
+		Enabled:     true,
+		ProjectKeys: []string{"PROJ1"},
+		Groups:      []string{"team-a"},
+	}
+	assert.True(t, f.IsActive("PROJ1", nil))
+	assert.True(t, f.IsActive("PROJ2", []string{"team-b", "team-a"}))
+	assert.False(t, f.IsActive("PROJ2", []string{"team-b"}))
+
+	f.Enabled = false
+	assert.False(t, f.IsActive("PROJ1", []string{"team-a"}))
+
+	// A project in the rollout stays in it when the percentage grows
+	f = Feature{Name: "cds:wnode", Enabled: true, Percentage: 30}
+	var actives []string
+	for _, key := range []string{"A", "B", "C", "D", "E", "F", "G", "H", "I", "J"} {
+		if f.IsActive(key, nil) {
+			actives = append(actives, key)
+		}
+	}
+	f.Percentage = 80
+	for _, key := range actives {
+		assert.True(t, f.IsActive(key, nil), "project %s should still be in the rollout", key)
+	}
+	f.Percentage = 100
+	assert.True(t, f.IsActive("Z", nil))
+	f.Percentage = 0
+	assert.False(t, f.IsActive("Z", nil))
+}
+
+func TestFeatureIsValid(t *testing.T) {
+	assert.NoError(t, Feature{Name: "cds:wnode", Percentage: 100}.IsValid())
+	assert.Error(t, Feature{Percentage: 10}.IsValid())
+	assert.Error(t, Feature{Name: "cds:wnode", Percentage: 101}.IsValid())
+}
@@ -0,0 +1,129 @@
+package sdk
+
+import (
+	"time"
+
+	"github.com/gorhill/cronexpr"
+)
+
+// FreezeWindow blocks the workflow nodes bound to an environment during a period. A window is either
+// recurrent (a cron expression starting the window and a duration) or an explicit range.
+// A window without environment applies to all the environments of the project.
+type FreezeWindow struct {
+	ID              int64     `json:"id" cli:"-"`
+	ProjectID       int64     `json:"project_id" cli:"-"`
+	EnvironmentID   int64     `json:"environment_id,omitempty" cli:"-"`
+	EnvironmentName string    `json:"environment_name,omitempty" cli:"environment"`
+	Name            string    `json:"name" cli:"name,key"`
+	Reason          string    `json:"reason,omitempty" cli:"reason"`
+	Cron            string    `json:"cron,omitempty" cli:"cron"`
+	Duration        int64     `json:"duration,omitempty" cli:"duration"` // in minutes
+	Timezone        string    `json:"timezone,omitempty" cli:"timezone"`
+	Start           time.Time `json:"start,omitempty" cli:"start"`
+	End             time.Time `json:"end,omitempty" cli:"end"`
+	OverrideGroups  []string  `json:"override_groups,omitempty" cli:"override_groups"`
+	Created         time.Time `json:"created" cli:"-"`
+}
+
+// IsValid checks the recurrence or the range of the window
+func (f FreezeWindow) IsValid() error {
+	if !NamePatternRegex.MatchString(f.Name) {
+		return NewErrorFrom(ErrInvalidFreezeWindow, "name %s do not respect pattern %s", f.Name, NamePattern)
+	}
+	if _, err := time.LoadLocation(f.Timezone); err != nil {
+		return NewErrorFrom(ErrInvalidFreezeWindow, "unknown timezone %s", f.Timezone)
+	}
+	if f.Cron != "" {
+		if _, err := cronexpr.Parse(f.Cron); err != nil {
+			return NewErrorFrom(ErrInvalidFreezeWindow, "unable to parse cron expression %s: %v", f.Cron, err)
+		}
+		if f.Duration <= 0 {
+			return NewErrorFrom(ErrInvalidFreezeWindow, "duration is mandatory with a cron expression")
+		}
+		return nil
+	}
+	if f.Start.IsZero() || !f.End.After(f.Start) {
+		return NewErrorFrom(ErrInvalidFreezeWindow, "a cron expression or a range is mandatory")
+	}
+	return nil
+}
+
+// IsActive returns true if the window is open at t
+func (f FreezeWindow) IsActive(t time.Time) (bool, error) {
+	if f.Cron == "" {
+		return !t.Before(f.Start) && t.Before(f.End), nil
+	}
+
+	loc, err := time.LoadLocation(f.Timezone)
+	if err != nil {
+		return false, NewErrorFrom(ErrInvalidFreezeWindow, "unknown timezone %s", f.Timezone)
+	}
+	expr, err := cronexpr.Parse(f.Cron)
+	if err != nil {
+		return false, NewErrorFrom(ErrInvalidFreezeWindow, "unable to parse cron expression %s: %v", f.Cron, err)
+	}
+
+	// The window is open if it started less than Duration minutes ago
+	t = t.In(loc)
+	next := expr.Next(t.Add(-time.Duration(f.Duration) * time.Minute))
+	return !next.IsZero() && !next.After(t), nil
+}
+
+// CanOverride returns true if the user is allowed to run nodes during the window
+func (f FreezeWindow) CanOverride(u *User) bool {
+	if u == nil {
+		return false
+	}
+	if u.Admin {
+		return true
+	}
+	for _, g := range u.Groups {
+		if IsInArray(g.Name, f.OverrideGroups) {
+			return true
+		}
+	}
+	return false
+}
+
+// FreezePeriod is an occurrence of a freeze window, used to display the change calendar of a project
+type FreezePeriod struct {
+	Name            string    `json:"name" cli:"name"`
+	EnvironmentName string    `json:"environment_name,omitempty" cli:"environment"`
+	Reason          string    `json:"reason,omitempty" cli:"reason"`
+	Start           time.Time `json:"start" cli:"start"`
+	End             time.Time `json:"end" cli:"end"`
+}
+
+// maxFreezePeriods limits the number of occurrences computed for a recurrent window
+const maxFreezePeriods = 500
+
+// Periods returns the occurrences of the window overlapping [from, to)
+func (f FreezeWindow) Periods(from, to time.Time) ([]FreezePeriod, error) {
+	newPeriod := func(start, end time.Time) FreezePeriod {
+		return FreezePeriod{Name: f.Name, EnvironmentName: f.EnvironmentName, Reason: f.Reason, Start: start, End: end}
+	}
+
+	if f.Cron == "" {
+		if f.End.After(from) && f.Start.Before(to) {
+			return []FreezePeriod{newPeriod(f.Start, f.End)}, nil
+		}
+		return nil, nil
+	}
+
+	loc, err := time.LoadLocation(f.Timezone)
+	if err != nil {
+		return nil, NewErrorFrom(ErrInvalidFreezeWindow, "unknown timezone %s", f.Timezone)
+	}
+	expr, err := cronexpr.Parse(f.Cron)
+	if err != nil {
+		return nil, NewErrorFrom(ErrInvalidFreezeWindow, "unable to parse cron expression %s: %v", f.Cron, err)
+	}
+
+	duration := time.Duration(f.Duration) * time.Minute
+	var ps []FreezePeriod
+	// Start from the occurrence which may still be open at from
+	for start := expr.Next(from.In(loc).Add(-duration)); !start.IsZero() && start.Before(to) && len(ps) < maxFreezePeriods; start = expr.Next(start) {
+		ps = append(ps, newPeriod(start, start.Add(duration)))
+	}
+	return ps, nil
+}
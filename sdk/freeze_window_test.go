@@ -0,0 +1,68 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreezeWindowIsActive(t *testing.T) {
+	// Every weekday from 18:00 for 14 hours, Paris time
+	f := FreezeWindow{Name: "nights", Cron: "0 18 * * 1-5", Duration: 14 * 60, Timezone: "Europe/Paris"}
+	assert.NoError(t, f.IsValid())
+
+	// Monday 2018-12-03 at 19:30 Paris time
+	active, err := f.IsActive(time.Date(2018, 12, 3, 18, 30, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.True(t, active)
+
+	// Tuesday 2018-12-04 at 07:30 Paris time
+	active, _ = f.IsActive(time.Date(2018, 12, 4, 6, 30, 0, 0, time.UTC))
+	assert.True(t, active)
+
+	// Tuesday 2018-12-04 at 10:00 Paris time
+	active, _ = f.IsActive(time.Date(2018, 12, 4, 9, 0, 0, 0, time.UTC))
+	assert.False(t, active)
+
+	// An explicit range
+	f = FreezeWindow{Name: "holidays", Timezone: "UTC", Start: time.Date(2018, 12, 24, 0, 0, 0, 0, time.UTC), End: time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC)}
+	assert.NoError(t, f.IsValid())
+	active, _ = f.IsActive(time.Date(2018, 12, 25, 12, 0, 0, 0, time.UTC))
+	assert.True(t, active)
+	active, _ = f.IsActive(time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC))
+	assert.False(t, active)
+}
+
+func TestFreezeWindowIsValid(t *testing.T) {
+	assert.Error(t, FreezeWindow{Name: "foo", Timezone: "UTC"}.IsValid())
+	assert.Error(t, FreezeWindow{Name: "foo", Timezone: "UTC", Cron: "0 18 * * *"}.IsValid())
+	assert.Error(t, FreezeWindow{Name: "foo", Timezone: "Mars/Olympus", Cron: "0 18 * * *", Duration: 60}.IsValid())
+	assert.Error(t, FreezeWindow{Name: "foo", Timezone: "UTC", Cron: "foo", Duration: 60}.IsValid())
+}
+
+func TestFreezeWindowCanOverride(t *testing.T) {
+	f := FreezeWindow{OverrideGroups: []string{"ops"}}
+	assert.True(t, f.CanOverride(&User{Groups: []Group{{Name: "ops"}}}))
+	assert.True(t, f.CanOverride(&User{Admin: true}))
+	assert.False(t, f.CanOverride(&User{Groups: []Group{{Name: "dev"}}}))
+	assert.False(t, f.CanOverride(nil))
+}
+
+func TestFreezeWindowPeriods(t *testing.T) {
+	f := FreezeWindow{Name: "nights", Cron: "0 18 * * 1-5", Duration: 14 * 60, Timezone: "UTC"}
+
+	// From Monday 2018-12-03 at 20:00 to Thursday 2018-12-06 at 00:00
+	ps, err := f.Periods(time.Date(2018, 12, 3, 20, 0, 0, 0, time.UTC), time.Date(2018, 12, 6, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Len(t, ps, 3)
+	assert.Equal(t, time.Date(2018, 12, 3, 18, 0, 0, 0, time.UTC), ps[0].Start.UTC())
+	assert.Equal(t, time.Date(2018, 12, 4, 8, 0, 0, 0, time.UTC), ps[0].End.UTC())
+	assert.Equal(t, time.Date(2018, 12, 5, 18, 0, 0, 0, time.UTC), ps[2].Start.UTC())
+
+	f = FreezeWindow{Name: "holidays", Start: time.Date(2018, 12, 24, 0, 0, 0, 0, time.UTC), End: time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC)}
+	ps, _ = f.Periods(time.Date(2018, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2018, 12, 31, 0, 0, 0, 0, time.UTC))
+	assert.Len(t, ps, 1)
+	ps, _ = f.Periods(time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC))
+	assert.Len(t, ps, 0)
+}
@@ -41,6 +41,13 @@ func (e *ExamplePlugin) DeployStatus(ctx context.Context, q *integrationplugin.D
 	}, nil
 }
 
+func (e *ExamplePlugin) Teardown(ctx context.Context, q *integrationplugin.TeardownQuery) (*integrationplugin.DeployResult, error) {
+	return &integrationplugin.DeployResult{
+		Details: "none",
+		Status:  "success",
+	}, nil
+}
+
 func main() {
 	if os.Args[1:][0] == "serve" {
 		e := ExamplePlugin{}
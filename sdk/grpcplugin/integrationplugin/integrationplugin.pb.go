@@ -129,6 +129,7 @@ func (m *DeployQuery) GetOptions() map[string]string {
 type DeployResult struct {
 	Status               string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
 	Details              string   `protobuf:"bytes,2,opt,name=details,proto3" json:"details,omitempty"`
+	Url                  string   `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -173,6 +174,13 @@ func (m *DeployResult) GetDetails() string {
 	return ""
 }
 
+func (m *DeployResult) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
 type DeployStatusQuery struct {
 	ID                   string   `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -212,44 +220,88 @@ func (m *DeployStatusQuery) GetID() string {
 	return ""
 }
 
+type TeardownQuery struct {
+	Options              map[string]string `protobuf:"bytes,1,rep,name=options,proto3" json:"options,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *TeardownQuery) Reset()         { *m = TeardownQuery{} }
+func (m *TeardownQuery) String() string { return proto.CompactTextString(m) }
+func (*TeardownQuery) ProtoMessage()    {}
+func (*TeardownQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ad20155c873eed76, []int{4}
+}
+
+func (m *TeardownQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TeardownQuery.Unmarshal(m, b)
+}
+func (m *TeardownQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TeardownQuery.Marshal(b, m, deterministic)
+}
+func (m *TeardownQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TeardownQuery.Merge(m, src)
+}
+func (m *TeardownQuery) XXX_Size() int {
+	return xxx_messageInfo_TeardownQuery.Size(m)
+}
+func (m *TeardownQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_TeardownQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TeardownQuery proto.InternalMessageInfo
+
+func (m *TeardownQuery) GetOptions() map[string]string {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*IntegrationPluginManifest)(nil), "integrationplugin.IntegrationPluginManifest")
 	proto.RegisterType((*DeployQuery)(nil), "integrationplugin.DeployQuery")
 	proto.RegisterMapType((map[string]string)(nil), "integrationplugin.DeployQuery.OptionsEntry")
 	proto.RegisterType((*DeployResult)(nil), "integrationplugin.DeployResult")
 	proto.RegisterType((*DeployStatusQuery)(nil), "integrationplugin.DeployStatusQuery")
+	proto.RegisterType((*TeardownQuery)(nil), "integrationplugin.TeardownQuery")
+	proto.RegisterMapType((map[string]string)(nil), "integrationplugin.TeardownQuery.OptionsEntry")
 }
 
 func init() { proto.RegisterFile("integrationplugin.proto", fileDescriptor_ad20155c873eed76) }
 
 var fileDescriptor_ad20155c873eed76 = []byte{
-	// 415 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0x4f, 0x8b, 0xda, 0x40,
-	0x1c, 0x35, 0xd1, 0x6a, 0xfb, 0x53, 0x4a, 0x1d, 0x8a, 0x4d, 0x53, 0x68, 0x25, 0xed, 0x41, 0xa8,
-	0x8c, 0x60, 0x2f, 0xe2, 0xa9, 0x88, 0x1e, 0xa4, 0x94, 0xda, 0x78, 0x28, 0xb4, 0xa7, 0x98, 0x8c,
-	0x31, 0x18, 0x33, 0x61, 0x66, 0x22, 0xe4, 0xdc, 0x2f, 0xb0, 0xdf, 0x6a, 0xbf, 0xd6, 0x92, 0x99,
-	0xc9, 0x6e, 0x20, 0x9b, 0x65, 0x6f, 0xf9, 0xfd, 0x79, 0xef, 0x37, 0xef, 0x3d, 0x02, 0xef, 0xa2,
-	0x44, 0x90, 0x90, 0x79, 0x22, 0xa2, 0x49, 0x1a, 0x67, 0x61, 0x94, 0xe0, 0x94, 0x51, 0x41, 0xd1,
-	0xb0, 0x36, 0xb0, 0x3f, 0x84, 0x94, 0x86, 0x31, 0x99, 0xc9, 0x85, 0x43, 0x76, 0x9c, 0x91, 0x4b,
-	0x2a, 0x72, 0xb5, 0xef, 0xfc, 0x37, 0xe0, 0xfd, 0xf6, 0x01, 0xb2, 0x93, 0x90, 0x9f, 0x5e, 0x12,
-	0x1d, 0x09, 0x17, 0x08, 0x41, 0x27, 0xf1, 0x2e, 0xc4, 0x32, 0xc6, 0xc6, 0xe4, 0x95, 0x2b, 0xbf,
-	0x91, 0x05, 0xbd, 0x2b, 0x61, 0x3c, 0xa2, 0x89, 0x65, 0xca, 0x76, 0x59, 0xa2, 0x31, 0xf4, 0x03,
-	0xc2, 0x7d, 0x16, 0xa5, 0x05, 0x95, 0xd5, 0x96, 0xd3, 0x6a, 0x0b, 0x8d, 0xa0, 0xeb, 0x65, 0xe2,
-	0x44, 0x99, 0xd5, 0x91, 0x43, 0x5d, 0x39, 0x37, 0x06, 0xf4, 0xd7, 0x24, 0x8d, 0x69, 0xfe, 0x3b,
-	0x23, 0x2c, 0x47, 0x1b, 0xe8, 0x51, 0x89, 0xe0, 0x96, 0x31, 0x6e, 0x4f, 0xfa, 0xf3, 0xaf, 0xb8,
-	0x2e, 0xb8, 0x02, 0xc0, 0xbf, 0xd4, 0xf6, 0x26, 0x11, 0x2c, 0x77, 0x4b, 0xac, 0xbd, 0x84, 0x41,
-	0x75, 0x80, 0xde, 0x40, 0xfb, 0x4c, 0x72, 0xad, 0xa6, 0xf8, 0x44, 0x6f, 0xe1, 0xc5, 0xd5, 0x8b,
-	0x33, 0xa2, 0xa5, 0xa8, 0x62, 0x69, 0x2e, 0x0c, 0xe7, 0x3b, 0x0c, 0xd4, 0x01, 0x97, 0xf0, 0x2c,
-	0x16, 0xc5, 0xd3, 0xb9, 0xf0, 0x44, 0xc6, 0x35, 0x5c, 0x57, 0x85, 0x1d, 0x01, 0x11, 0x5e, 0x14,
-	0xf3, 0xd2, 0x0e, 0x5d, 0x3a, 0x9f, 0x61, 0xa8, 0x18, 0xf6, 0x72, 0x53, 0x29, 0x7b, 0x0d, 0xe6,
-	0x76, 0xad, 0x29, 0xcc, 0xed, 0x7a, 0x7e, 0x6b, 0xc2, 0xb0, 0xe6, 0x3f, 0x72, 0xe1, 0xe5, 0x7d,
-	0x06, 0x23, 0xac, 0xf2, 0xc3, 0x65, 0x7e, 0x78, 0x53, 0xe4, 0x67, 0x4f, 0x1f, 0xb1, 0xa4, 0x31,
-	0x49, 0xa7, 0x85, 0x7e, 0x40, 0x57, 0x3d, 0x07, 0x7d, 0x7c, 0xda, 0x4c, 0xfb, 0x53, 0xe3, 0x5c,
-	0x79, 0xe1, 0xb4, 0xd0, 0x9f, 0xd2, 0x1d, 0xa5, 0x0d, 0x7d, 0x69, 0x84, 0x54, 0xc4, 0x3f, 0x87,
-	0x78, 0x01, 0x9d, 0xbd, 0xa0, 0x69, 0xa3, 0xea, 0x86, 0xbe, 0xd3, 0x5a, 0xfd, 0x83, 0xa9, 0x4f,
-	0x2f, 0x98, 0x5e, 0x4f, 0xd8, 0x0f, 0x38, 0xe6, 0xc1, 0x19, 0x87, 0x2c, 0xf5, 0xf5, 0x99, 0xda,
-	0xe1, 0xd5, 0xa8, 0x66, 0xd6, 0xae, 0xa0, 0xdc, 0x19, 0x7f, 0xeb, 0xff, 0xd0, 0xa1, 0x2b, 0xcf,
-	0x7d, 0xbb, 0x0b, 0x00, 0x00, 0xff, 0xff, 0x04, 0xbd, 0x83, 0x33, 0x78, 0x03, 0x00, 0x00,
+	// 459 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0x8d, 0x93, 0x90, 0x96, 0x49, 0x40, 0x64, 0x85, 0x82, 0x09, 0x12, 0x44, 0x86, 0x43, 0x25,
+	0xca, 0x56, 0x2a, 0x97, 0xaa, 0xc7, 0x2a, 0x11, 0x8a, 0x10, 0x6a, 0x70, 0x91, 0x90, 0xe0, 0xb4,
+	0x8d, 0xb7, 0xae, 0x55, 0x67, 0xd7, 0xda, 0x8f, 0x20, 0x9f, 0xf9, 0x03, 0x1c, 0x38, 0xf0, 0x73,
+	0xd1, 0x7e, 0x18, 0x0c, 0xae, 0xa1, 0x87, 0xde, 0x76, 0x3e, 0xde, 0x1b, 0xcf, 0x9b, 0x27, 0xc3,
+	0xa3, 0x8c, 0x29, 0x9a, 0x0a, 0xa2, 0x32, 0xce, 0x8a, 0x5c, 0xa7, 0x19, 0xc3, 0x85, 0xe0, 0x8a,
+	0xa3, 0x71, 0xa3, 0x30, 0x7d, 0x92, 0x72, 0x9e, 0xe6, 0xf4, 0xc0, 0x36, 0x9c, 0xeb, 0x8b, 0x03,
+	0xba, 0x29, 0x54, 0xe9, 0xfa, 0xa3, 0xaf, 0x01, 0x3c, 0x5e, 0xfe, 0x86, 0xac, 0x2c, 0xe4, 0x1d,
+	0x61, 0xd9, 0x05, 0x95, 0x0a, 0x21, 0xe8, 0x33, 0xb2, 0xa1, 0x61, 0x30, 0x0b, 0xf6, 0xee, 0xc6,
+	0xf6, 0x8d, 0x42, 0xd8, 0xd9, 0x52, 0x21, 0x33, 0xce, 0xc2, 0xae, 0x4d, 0x57, 0x21, 0x9a, 0xc1,
+	0x30, 0xa1, 0x72, 0x2d, 0xb2, 0xc2, 0x50, 0x85, 0x3d, 0x5b, 0xad, 0xa7, 0xd0, 0x04, 0x06, 0x44,
+	0xab, 0x4b, 0x2e, 0xc2, 0xbe, 0x2d, 0xfa, 0x28, 0xfa, 0x16, 0xc0, 0x70, 0x4e, 0x8b, 0x9c, 0x97,
+	0xef, 0x35, 0x15, 0x25, 0x5a, 0xc0, 0x0e, 0xb7, 0x08, 0x19, 0x06, 0xb3, 0xde, 0xde, 0xf0, 0xf0,
+	0x25, 0x6e, 0x2e, 0x5c, 0x03, 0xe0, 0x53, 0xd7, 0xbd, 0x60, 0x4a, 0x94, 0x71, 0x85, 0x9d, 0x1e,
+	0xc3, 0xa8, 0x5e, 0x40, 0x0f, 0xa0, 0x77, 0x45, 0x4b, 0xbf, 0x8d, 0x79, 0xa2, 0x87, 0x70, 0x67,
+	0x4b, 0x72, 0x4d, 0xfd, 0x2a, 0x2e, 0x38, 0xee, 0x1e, 0x05, 0x51, 0x0c, 0x23, 0x37, 0x20, 0xa6,
+	0x52, 0xe7, 0xca, 0x7c, 0xba, 0x54, 0x44, 0x69, 0xe9, 0xe1, 0x3e, 0x32, 0x72, 0x24, 0x54, 0x91,
+	0x2c, 0x97, 0x95, 0x1c, 0x3e, 0x34, 0xd3, 0xb4, 0xc8, 0xbd, 0x0c, 0xe6, 0x19, 0x3d, 0x87, 0xb1,
+	0xe3, 0x3c, 0xb3, 0x58, 0xb7, 0xeb, 0x7d, 0xe8, 0x2e, 0xe7, 0x9e, 0xb4, 0xbb, 0x9c, 0x47, 0xdf,
+	0x03, 0xb8, 0xf7, 0x81, 0x12, 0x91, 0xf0, 0x2f, 0xcc, 0x75, 0xbc, 0xf9, 0x5b, 0x8d, 0x57, 0xd7,
+	0xa8, 0xf1, 0x07, 0xe4, 0xf6, 0xf5, 0x38, 0xfc, 0xd1, 0x83, 0x71, 0xc3, 0x28, 0x28, 0x86, 0xdd,
+	0x5f, 0x66, 0x99, 0x60, 0x67, 0x34, 0x5c, 0x19, 0x0d, 0x2f, 0x8c, 0xd1, 0xa6, 0xfb, 0xd7, 0x7c,
+	0x6d, 0xab, 0xe5, 0xa2, 0x0e, 0x7a, 0x0b, 0x03, 0xa7, 0x12, 0x7a, 0xfa, 0xef, 0xab, 0x4f, 0x9f,
+	0xb5, 0xd6, 0xdd, 0xd1, 0xa2, 0x0e, 0xfa, 0x08, 0xa3, 0xba, 0xe4, 0xe8, 0x45, 0x2b, 0xa4, 0x76,
+	0x93, 0x9b, 0x10, 0x9f, 0xc2, 0x6e, 0x25, 0x39, 0x9a, 0xfd, 0xef, 0x1e, 0x37, 0x21, 0x3c, 0x82,
+	0xfe, 0x99, 0xe2, 0x45, 0xab, 0x8c, 0x2d, 0xf9, 0xa8, 0x73, 0xf2, 0x19, 0xf6, 0xd7, 0x7c, 0x83,
+	0xf9, 0xf6, 0x12, 0xaf, 0x13, 0x89, 0x65, 0x72, 0x85, 0x53, 0x51, 0xac, 0xfd, 0x98, 0xc6, 0xe0,
+	0x93, 0x49, 0x43, 0xfd, 0x95, 0xa1, 0x5c, 0x05, 0x9f, 0x9a, 0x7f, 0x8f, 0xf3, 0x81, 0x1d, 0xf7,
+	0xfa, 0xe7, 0x00, 0xb9, 0x9f, 0x9f, 0xbc, 0x72, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -267,6 +319,7 @@ type IntegrationPluginClient interface {
 	Manifest(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*IntegrationPluginManifest, error)
 	Deploy(ctx context.Context, in *DeployQuery, opts ...grpc.CallOption) (*DeployResult, error)
 	DeployStatus(ctx context.Context, in *DeployStatusQuery, opts ...grpc.CallOption) (*DeployResult, error)
+	Teardown(ctx context.Context, in *TeardownQuery, opts ...grpc.CallOption) (*DeployResult, error)
 	Stop(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
 }
 
@@ -305,6 +358,15 @@ func (c *integrationPluginClient) DeployStatus(ctx context.Context, in *DeploySt
 	return out, nil
 }
 
+func (c *integrationPluginClient) Teardown(ctx context.Context, in *TeardownQuery, opts ...grpc.CallOption) (*DeployResult, error) {
+	out := new(DeployResult)
+	err := c.cc.Invoke(ctx, "/integrationplugin.IntegrationPlugin/Teardown", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *integrationPluginClient) Stop(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
 	err := c.cc.Invoke(ctx, "/integrationplugin.IntegrationPlugin/Stop", in, out, opts...)
@@ -319,6 +381,7 @@ type IntegrationPluginServer interface {
 	Manifest(context.Context, *empty.Empty) (*IntegrationPluginManifest, error)
 	Deploy(context.Context, *DeployQuery) (*DeployResult, error)
 	DeployStatus(context.Context, *DeployStatusQuery) (*DeployResult, error)
+	Teardown(context.Context, *TeardownQuery) (*DeployResult, error)
 	Stop(context.Context, *empty.Empty) (*empty.Empty, error)
 }
 
@@ -380,6 +443,24 @@ func _IntegrationPlugin_DeployStatus_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _IntegrationPlugin_Teardown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TeardownQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IntegrationPluginServer).Teardown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/integrationplugin.IntegrationPlugin/Teardown",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IntegrationPluginServer).Teardown(ctx, req.(*TeardownQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _IntegrationPlugin_Stop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(empty.Empty)
 	if err := dec(in); err != nil {
@@ -414,6 +495,10 @@ var _IntegrationPlugin_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeployStatus",
 			Handler:    _IntegrationPlugin_DeployStatus_Handler,
 		},
+		{
+			MethodName: "Teardown",
+			Handler:    _IntegrationPlugin_Teardown_Handler,
+		},
 		{
 			MethodName: "Stop",
 			Handler:    _IntegrationPlugin_Stop_Handler,
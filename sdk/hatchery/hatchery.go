@@ -6,7 +6,6 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
-	"strings"
 	"syscall"
 	"time"
 
@@ -30,11 +29,13 @@ var (
 	// Opencensus tags
 	TagHatchery     tag.Key
 	TagHatcheryName tag.Key
+	TagWorkerModel  tag.Key
 )
 
 func init() {
 	TagHatchery, _ = tag.NewKey("hatchery")
 	TagHatcheryName, _ = tag.NewKey("hatchery_name")
+	TagWorkerModel, _ = tag.NewKey("worker_model")
 }
 
 // WithTags returns a context with opencenstus tags
@@ -73,6 +74,11 @@ func Create(ctx context.Context, h Interface) error {
 		return fmt.Errorf("Create> Init error: %v", err)
 	}
 
+	pools, err := newWarmPools(h.Configuration().Provision.WarmPools)
+	if err != nil {
+		return fmt.Errorf("Create> %v", err)
+	}
+
 	// Call WorkerModel Enabled first
 	var errwm error
 	models, errwm = h.WorkerModelsEnabled()
@@ -225,6 +231,27 @@ func Create(ctx context.Context, h Interface) error {
 				stats.Record(currentCtx, h.Metrics().JobsSSE.M(1))
 			}
 
+			workerRequest := workerStarterRequest{
+				ctx:               currentCtx,
+				cancel:            endTrace,
+				id:                j.ID,
+				execGroups:        j.ExecGroups,
+				requirements:      j.Job.Action.Requirements,
+				hostname:          hostname,
+				timestamp:         time.Now().Unix(),
+				spawnAttempts:     j.SpawnAttempts,
+				workflowNodeRunID: j.WorkflowNodeRunID,
+			}
+
+			//The job is a demand for the warm pool of the first model able to run it, even if
+			//it is booked or if the hatchery has no capacity to start a worker now
+			for i := range models {
+				if canRunJob(h, workerRequest, models[i]) {
+					pools.observeJob(models[i].Name, j.ID, j.Queued, time.Now())
+					break
+				}
+			}
+
 			//Check if the jobs is concerned by a pending worker creation
 			if _, exist := spawnIDs.Get(strconv.FormatInt(j.ID, 10)); exist {
 				log.Debug("job %d already spawned in previous routine", j.ID)
@@ -249,18 +276,6 @@ func Create(ctx context.Context, h Interface) error {
 				continue
 			}
 
-			workerRequest := workerStarterRequest{
-				ctx:               currentCtx,
-				cancel:            endTrace,
-				id:                j.ID,
-				execGroups:        j.ExecGroups,
-				requirements:      j.Job.Action.Requirements,
-				hostname:          hostname,
-				timestamp:         time.Now().Unix(),
-				spawnAttempts:     j.SpawnAttempts,
-				workflowNodeRunID: j.WorkflowNodeRunID,
-			}
-
 			// Check at least one worker model can match
 			var chosenModel *sdk.Model
 			for i := range models {
@@ -282,15 +297,26 @@ func Create(ctx context.Context, h Interface) error {
 				continue
 			}
 
-			//We got a model, let's start a worker
-			workerRequest.model = *chosenModel
+			//We got a model, let's start a worker with the version of the model for this job
+			versionedModel, err := modelVersionForJob(h, *chosenModel, workerRequest)
+			if err != nil {
+				log.Warning("hatchery> unable to get version of worker model %s for job %d: %v", chosenModel.Name, j.ID, err)
+				workerStartResultChan <- workerStarterResult{
+					request:      workerRequest,
+					isRun:        false,
+					temptToSpawn: true,
+				}
+				endTrace("no model version")
+				continue
+			}
+			workerRequest.model = versionedModel
 
 			//Ask to start
 			log.Debug("hatchery> Request a worker for job %d (%.3f seconds elapsed)", j.ID, time.Since(t0).Seconds())
 			workersStartChan <- workerRequest
 
 		case <-tickerProvision.C:
-			provisioning(h, models)
+			provisioning(h, models, pools)
 
 		case <-tickerRegister.C:
 			if err := workerRegister(ctx, h, workersStartChan); err != nil {
@@ -354,10 +380,9 @@ func canRunJob(h Interface, j workerStarterRequest, model sdk.Model) bool {
 	// Common check
 	for _, r := range j.requirements {
 		// If requirement is a Model requirement, it's easy. It's either can or can't run
-		// r.Value could be: theModelName --port=8888:9999, so we take strings.Split(r.Value, " ")[0] to compare
-		// only modelName
-		if r.Type == sdk.ModelRequirement && strings.Split(r.Value, " ")[0] != model.Name {
-			log.Debug("canRunJob> %d - job %d - model requirement r.Value(%s) != model.Name(%s)", j.timestamp, j.id, strings.Split(r.Value, " ")[0], model.Name)
+		// r.Value could be: theModelName@version --port=8888:9999, so we only compare the modelName
+		if modelName, _ := sdk.ParseModelRequirement(r.Value); r.Type == sdk.ModelRequirement && modelName != model.Name {
+			log.Debug("canRunJob> %d - job %d - model requirement r.Value(%s) != model.Name(%s)", j.timestamp, j.id, modelName, model.Name)
 			return false
 		}
 
@@ -406,6 +431,32 @@ func canRunJob(h Interface, j workerStarterRequest, model sdk.Model) bool {
 	return h.CanSpawn(&model, j.id, j.requirements)
 }
 
+// modelVersionForJob returns the version of a worker model to spawn for a job: the version pinned by the model requirement
+// of the job, the canary version for a part of the jobs, or the current version of the model
+func modelVersionForJob(h Interface, model sdk.Model, j workerStarterRequest) (sdk.Model, error) {
+	for _, r := range j.requirements {
+		if r.Type != sdk.ModelRequirement {
+			continue
+		}
+		_, version := sdk.ParseModelRequirement(r.Value)
+		switch {
+		case version == 0:
+		case version == model.Version:
+			model.Canary = nil
+			return model, nil
+		case model.Canary != nil && version == model.Canary.Version:
+			return model.WithVersion(*model.Canary), nil
+		default:
+			v, err := h.CDSClient().WorkerModelVersion(model.ID, version)
+			if err != nil {
+				return model, err
+			}
+			return model.WithVersion(*v), nil
+		}
+	}
+	return model.ForJob(j.id), nil
+}
+
 // SendSpawnInfo sends a spawnInfo
 func SendSpawnInfo(ctx context.Context, h Interface, jobID int64, spawnMsg sdk.SpawnMsg) {
 	infos := []sdk.SpawnInfo{{RemoteTime: time.Now(), Message: spawnMsg}}
@@ -59,7 +59,7 @@ func checkCapacities(ctx context.Context, h Interface) bool {
 	return true
 }
 
-func provisioning(h Interface, models []sdk.Model) {
+func provisioning(h Interface, models []sdk.Model, pools *warmPools) {
 	if h.Configuration().Provision.Disabled {
 		log.Debug("provisioning> disabled on this hatchery")
 		return
@@ -75,9 +75,22 @@ func provisioning(h Interface, models []sdk.Model) {
 		}
 		if models[k].Type == h.ModelType() {
 			existing := h.WorkersStartedByModel(&models[k])
-			for i := existing; i < int(models[k].Provision); i++ {
+			target := int(models[k].Provision)
+			// the warm pool of the model can only raise the provision of the model, idle workers
+			// above the target are disabled when the pool scales down
+			if poolTarget, demand, has := pools.computeTarget(models[k].Name, time.Now()); has {
+				recordWarmPool(h, models[k], poolTarget, existing, demand)
+				if poolTarget > target {
+					target = poolTarget
+				}
+				if existing > target {
+					scaleDownWarmPool(context.Background(), h, pools, models[k], existing-target)
+				}
+			}
+			for i := existing; i < target; i++ {
 				go func(m sdk.Model) {
-					if name, errSpawn := h.SpawnWorker(context.Background(), SpawnArguments{Model: m, JobID: 0, Requirements: nil, LogInfo: "spawn for provision"}); errSpawn != nil {
+					name, errSpawn := h.SpawnWorker(context.Background(), SpawnArguments{Model: m, JobID: 0, Requirements: nil, LogInfo: "spawn for provision"})
+					if errSpawn != nil {
 						log.Warning("provisioning> cannot spawn worker %s with model %s for provisioning: %s", name, m.Name, errSpawn)
 						var spawnError = sdk.SpawnErrorForm{
 							Error: fmt.Sprintf("hatchery %s cannot spawn worker %s for provisioning", h.Service().Name, m.Name),
@@ -86,7 +99,9 @@ func provisioning(h Interface, models []sdk.Model) {
 						if err := h.CDSClient().WorkerModelSpawnError(m.ID, spawnError); err != nil {
 							log.Error("provisioning> cannot client.WorkerModelSpawnError for worker %s with model %s for provisioning: %s", name, m.Name, errSpawn)
 						}
+						return
 					}
+					pools.addWorker(m.Name, name)
 				}(models[k])
 			}
 		}
@@ -46,6 +46,7 @@ type CommonConfiguration struct {
 				ExtraValue string `toml:"extraValue" comment:"value for extraKey field. For many keys: valueaaa,valuebbb" json:"-"`
 			} `toml:"graylog" json:"graylog"`
 		} `toml:"workerLogsOptions" comment:"Worker Log Configuration" json:"workerLogsOptions"`
+		WarmPools []WarmPoolConfiguration `toml:"warmPools" comment:"Warm pools of pre-provisioned workers per worker model" json:"warmPools"`
 	} `toml:"provision" json:"provision"`
 	LogOptions struct {
 		SpawnOptions struct {
@@ -55,6 +56,27 @@ type CommonConfiguration struct {
 	} `toml:"logOptions" comment:"Hatchery Log Configuration" json:"logOptions"`
 }
 
+// WarmPoolConfiguration describes the autoscaling rules of the warm pool of a worker model.
+// The pool grows when jobs wait in queue longer than ScaleUpWaitingTime and goes back
+// to its floor after ScaleDownIdleTime without any demand.
+type WarmPoolConfiguration struct {
+	Model              string            `toml:"model" comment:"Worker model name" json:"model"`
+	Min                int               `toml:"min" default:"0" comment:"Minimum number of warm workers" json:"min"`
+	Max                int               `toml:"max" default:"10" comment:"Maximum number of warm workers" json:"max"`
+	ScaleUpWaitingTime int               `toml:"scaleUpWaitingTime" default:"30" comment:"Scale up the pool when jobs wait in queue more than n seconds" json:"scaleUpWaitingTime"`
+	ScaleDownIdleTime  int               `toml:"scaleDownIdleTime" default:"10" comment:"Scale down the pool after n minutes without demand" json:"scaleDownIdleTime"`
+	Profiles           []WarmPoolProfile `toml:"profiles" comment:"Scheduled profiles raising the minimum of the pool" json:"profiles"`
+}
+
+// WarmPoolProfile raises the minimum size of a warm pool during a scheduled window
+type WarmPoolProfile struct {
+	Name     string `toml:"name" comment:"Name of the profile, example: weekday-mornings" json:"name"`
+	Cron     string `toml:"cron" comment:"Cron expression starting the window, example: 0 8 * * 1-5" json:"cron"`
+	Duration int    `toml:"duration" comment:"Duration of the window in minutes" json:"duration"`
+	Timezone string `toml:"timezone" default:"UTC" comment:"Timezone of the cron expression" json:"timezone"`
+	Min      int    `toml:"min" comment:"Minimum number of warm workers during the window" json:"min"`
+}
+
 // SpawnArguments contains arguments to func SpawnWorker
 type SpawnArguments struct {
 	Model        sdk.Model
@@ -107,4 +129,7 @@ type Metrics struct {
 	WaitingWorkers     *stats.Int64Measure
 	BuildingWorkers    *stats.Int64Measure
 	DisabledWorkers    *stats.Int64Measure
+	WarmPoolTarget     *stats.Int64Measure
+	WarmPoolSize       *stats.Int64Measure
+	WarmPoolDemand     *stats.Int64Measure
 }
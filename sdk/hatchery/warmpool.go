@@ -0,0 +1,210 @@
+package hatchery
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorhill/cronexpr"
+	defaults "github.com/mcuadros/go-defaults"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+type warmPoolProfile struct {
+	WarmPoolProfile
+	expr     *cronexpr.Expression
+	location *time.Location
+}
+
+// active returns true if the window started by the cron expression is still open at t
+func (p warmPoolProfile) active(t time.Time) bool {
+	t = t.In(p.location)
+	start := t.Add(-time.Duration(p.Duration) * time.Minute)
+	next := p.expr.Next(start)
+	return !next.IsZero() && !next.After(t)
+}
+
+type warmPool struct {
+	WarmPoolConfiguration
+	profiles   []warmPoolProfile
+	target     int
+	lastDemand time.Time
+	waiting    map[int64]struct{}
+	demand     map[int64]struct{}
+	// names of the workers spawned by this hatchery for provisioning, they are the only ones removed on scale down
+	workers map[string]struct{}
+}
+
+// floor returns the minimum size of the pool at t, according to the scheduled profiles
+func (p *warmPool) floor(t time.Time) int {
+	min := p.Min
+	for _, prof := range p.profiles {
+		if prof.Min > min && prof.active(t) {
+			min = prof.Min
+		}
+	}
+	if min > p.Max {
+		min = p.Max
+	}
+	return min
+}
+
+// warmPools keeps the warm pool state of each worker model configured on the hatchery
+type warmPools struct {
+	mutex sync.Mutex
+	pools map[string]*warmPool
+}
+
+func newWarmPools(cfgs []WarmPoolConfiguration) (*warmPools, error) {
+	w := &warmPools{pools: make(map[string]*warmPool, len(cfgs))}
+	for _, cfg := range cfgs {
+		// default values are not applied by the configuration loader on the elements of a slice
+		defaults.SetDefaults(&cfg)
+		if cfg.Model == "" {
+			return nil, fmt.Errorf("invalid warm pool configuration: model is mandatory")
+		}
+		if cfg.Max < cfg.Min {
+			return nil, fmt.Errorf("invalid warm pool configuration for model %s: max (%d) is lower than min (%d)", cfg.Model, cfg.Max, cfg.Min)
+		}
+		p := &warmPool{
+			WarmPoolConfiguration: cfg,
+			target:                cfg.Min,
+			waiting:               map[int64]struct{}{},
+			demand:                map[int64]struct{}{},
+			workers:               map[string]struct{}{},
+		}
+		for _, prof := range cfg.Profiles {
+			defaults.SetDefaults(&prof)
+			expr, err := cronexpr.Parse(prof.Cron)
+			if err != nil {
+				return nil, fmt.Errorf("invalid warm pool profile %s for model %s: unable to parse cron expression %s: %v", prof.Name, cfg.Model, prof.Cron, err)
+			}
+			loc, err := time.LoadLocation(prof.Timezone)
+			if err != nil {
+				return nil, fmt.Errorf("invalid warm pool profile %s for model %s: unable to load timezone %s: %v", prof.Name, cfg.Model, prof.Timezone, err)
+			}
+			p.profiles = append(p.profiles, warmPoolProfile{WarmPoolProfile: prof, expr: expr, location: loc})
+		}
+		w.pools[cfg.Model] = p
+	}
+	return w, nil
+}
+
+// observeJob records a job in queue which can be run by the given model
+func (w *warmPools) observeJob(model string, jobID int64, queued time.Time, now time.Time) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	p, has := w.pools[model]
+	if !has {
+		return
+	}
+	p.lastDemand = now
+	p.demand[jobID] = struct{}{}
+	if now.Sub(queued) > time.Duration(p.ScaleUpWaitingTime)*time.Second {
+		p.waiting[jobID] = struct{}{}
+	}
+}
+
+// computeTarget computes the new size of the warm pool of the given model. It scales up
+// by the number of jobs waiting for too long, scales down to the floor when the model has
+// been idle for too long, and resets the demand observed since the last call.
+func (w *warmPools) computeTarget(model string, now time.Time) (target, demand int, has bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	p, has := w.pools[model]
+	if !has {
+		return 0, 0, false
+	}
+
+	floor := p.floor(now)
+	switch {
+	case len(p.waiting) > 0:
+		p.target += len(p.waiting)
+	case now.Sub(p.lastDemand) > time.Duration(p.ScaleDownIdleTime)*time.Minute:
+		p.target = floor
+	}
+	if p.target < floor {
+		p.target = floor
+	}
+	if p.target > p.Max {
+		p.target = p.Max
+	}
+
+	demand = len(p.demand)
+	p.waiting = map[int64]struct{}{}
+	p.demand = map[int64]struct{}{}
+	return p.target, demand, true
+}
+
+// addWorker records a worker spawned for the provisioning of the given model
+func (w *warmPools) addWorker(model, name string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if p, has := w.pools[model]; has {
+		p.workers[name] = struct{}{}
+	}
+}
+
+// idleWorkers returns at most n workers of the pool of the model which are waiting without job, the workers of the
+// pool that are not in the given list anymore are forgotten
+func (w *warmPools) idleWorkers(workers []sdk.Worker, hatcheryName string, model sdk.Model, n int) []sdk.Worker {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	p, has := w.pools[model.Name]
+	if !has {
+		return nil
+	}
+
+	names := make(map[string]struct{}, len(workers))
+	var res []sdk.Worker
+	for _, wk := range workers {
+		names[wk.Name] = struct{}{}
+		if _, ok := p.workers[wk.Name]; !ok || len(res) >= n {
+			continue
+		}
+		if wk.HatcheryName != hatcheryName || wk.ModelID != model.ID || wk.Status != sdk.StatusWaiting || wk.ActionBuildID != 0 {
+			continue
+		}
+		res = append(res, wk)
+	}
+	for name := range p.workers {
+		if _, ok := names[name]; !ok {
+			delete(p.workers, name)
+		}
+	}
+	return res
+}
+
+// scaleDownWarmPool disables at most n idle workers spawned by the hatchery for the provisioning of the model,
+// disabled workers are killed by the hatchery
+func scaleDownWarmPool(ctx context.Context, h Interface, pools *warmPools, model sdk.Model, n int) {
+	workers, err := WorkerPool(ctx, h)
+	if err != nil {
+		log.Error("scaleDownWarmPool> unable to get worker pool: %v", err)
+		return
+	}
+	for _, w := range pools.idleWorkers(workers, h.Service().Name, model, n) {
+		log.Info("scaleDownWarmPool> disabling idle worker %s of model %s", w.Name, model.Name)
+		if err := h.CDSClient().WorkerDisable(ctx, w.ID); err != nil {
+			log.Warning("scaleDownWarmPool> unable to disable worker %s: %v", w.Name, err)
+		}
+	}
+}
+
+func recordWarmPool(h Interface, model sdk.Model, target, size, demand int) {
+	ctx, err := tag.New(WithTags(context.Background(), h), tag.Upsert(TagWorkerModel, model.Name))
+	if err != nil {
+		log.Error("recordWarmPool> unable to tag context: %v", err)
+		return
+	}
+	stats.Record(ctx,
+		h.Metrics().WarmPoolTarget.M(int64(target)),
+		h.Metrics().WarmPoolSize.M(int64(size)),
+		h.Metrics().WarmPoolDemand.M(int64(demand)),
+	)
+}
@@ -0,0 +1,142 @@
+package hatchery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestWarmPoolsComputeTarget(t *testing.T) {
+	pools, err := newWarmPools([]WarmPoolConfiguration{
+		{Model: "my-model", Min: 1, Max: 4, ScaleUpWaitingTime: 30, ScaleDownIdleTime: 10},
+	})
+	assert.NoError(t, err)
+
+	now := time.Now()
+	target, demand, has := pools.computeTarget("my-model", now)
+	assert.True(t, has)
+	assert.Equal(t, 1, target)
+	assert.Equal(t, 0, demand)
+
+	_, _, has = pools.computeTarget("unknown", now)
+	assert.False(t, has)
+
+	// A job queued recently does not scale up the pool
+	pools.observeJob("my-model", 1, now.Add(-5*time.Second), now)
+	target, demand, _ = pools.computeTarget("my-model", now)
+	assert.Equal(t, 1, target)
+	assert.Equal(t, 1, demand)
+
+	// Jobs waiting for too long scale up the pool, the same job is counted once
+	pools.observeJob("my-model", 2, now.Add(-time.Minute), now)
+	pools.observeJob("my-model", 2, now.Add(-time.Minute), now)
+	pools.observeJob("my-model", 3, now.Add(-time.Minute), now)
+	target, demand, _ = pools.computeTarget("my-model", now)
+	assert.Equal(t, 3, target)
+	assert.Equal(t, 2, demand)
+
+	// The pool can't exceed its max
+	for i := int64(4); i < 10; i++ {
+		pools.observeJob("my-model", i, now.Add(-time.Minute), now)
+	}
+	target, _, _ = pools.computeTarget("my-model", now)
+	assert.Equal(t, 4, target)
+
+	// Without demand the pool keeps its size until the idle time is reached
+	target, _, _ = pools.computeTarget("my-model", now.Add(5*time.Minute))
+	assert.Equal(t, 4, target)
+	target, _, _ = pools.computeTarget("my-model", now.Add(11*time.Minute))
+	assert.Equal(t, 1, target)
+}
+
+func TestWarmPoolsProfiles(t *testing.T) {
+	pools, err := newWarmPools([]WarmPoolConfiguration{
+		{
+			Model: "my-model", Min: 0, Max: 10, ScaleUpWaitingTime: 30, ScaleDownIdleTime: 10,
+			Profiles: []WarmPoolProfile{
+				{Name: "weekday-mornings", Cron: "0 8 * * 1-5", Duration: 240, Timezone: "UTC", Min: 5},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	// Monday 2018-12-03 at 09:30 UTC
+	monday := time.Date(2018, 12, 3, 9, 30, 0, 0, time.UTC)
+	target, _, _ := pools.computeTarget("my-model", monday)
+	assert.Equal(t, 5, target)
+
+	// Monday 2018-12-03 at 13:30 UTC, the window is closed
+	target, _, _ = pools.computeTarget("my-model", monday.Add(4*time.Hour))
+	assert.Equal(t, 0, target)
+
+	// Sunday 2018-12-02 at 09:30 UTC
+	target, _, _ = pools.computeTarget("my-model", monday.Add(-24*time.Hour))
+	assert.Equal(t, 0, target)
+}
+
+func TestNewWarmPoolsInvalid(t *testing.T) {
+	_, err := newWarmPools([]WarmPoolConfiguration{{Min: 1, Max: 2}})
+	assert.Error(t, err)
+
+	_, err = newWarmPools([]WarmPoolConfiguration{{Model: "my-model", Min: 3, Max: 2}})
+	assert.Error(t, err)
+
+	_, err = newWarmPools([]WarmPoolConfiguration{{Model: "my-model", Max: 2, Profiles: []WarmPoolProfile{{Cron: "foo", Timezone: "UTC"}}}})
+	assert.Error(t, err)
+}
+
+func TestNewWarmPoolsDefaults(t *testing.T) {
+	pools, err := newWarmPools([]WarmPoolConfiguration{
+		{
+			Model: "my-model",
+			Profiles: []WarmPoolProfile{
+				{Name: "weekday-mornings", Cron: "0 8 * * 1-5", Duration: 240, Min: 5},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	p := pools.pools["my-model"]
+	assert.Equal(t, 0, p.Min)
+	assert.Equal(t, 10, p.Max)
+	assert.Equal(t, 30, p.ScaleUpWaitingTime)
+	assert.Equal(t, 10, p.ScaleDownIdleTime)
+	assert.Equal(t, "UTC", p.profiles[0].Timezone)
+	assert.Equal(t, time.UTC, p.profiles[0].location)
+}
+
+func TestWarmPoolsIdleWorkers(t *testing.T) {
+	pools, err := newWarmPools([]WarmPoolConfiguration{{Model: "my-model", Max: 5}})
+	assert.NoError(t, err)
+	model := sdk.Model{ID: 1, Name: "my-model"}
+
+	pools.addWorker("my-model", "idle-1")
+	pools.addWorker("my-model", "idle-2")
+	pools.addWorker("my-model", "building")
+	pools.addWorker("my-model", "gone")
+
+	workers := []sdk.Worker{
+		{Name: "idle-1", HatcheryName: "my-hatchery", ModelID: 1, Status: sdk.StatusWaiting},
+		{Name: "building", HatcheryName: "my-hatchery", ModelID: 1, Status: sdk.StatusBuilding, ActionBuildID: 42},
+		// spawned by this hatchery for a job in queue
+		{Name: "job-bound", HatcheryName: "my-hatchery", ModelID: 1, Status: sdk.StatusWaiting},
+		// spawned by another hatchery
+		{Name: "foreign", HatcheryName: "other-hatchery", ModelID: 1, Status: sdk.StatusWaiting},
+		{Name: "idle-2", HatcheryName: "my-hatchery", ModelID: 1, Status: sdk.StatusWaiting},
+	}
+
+	res := pools.idleWorkers(workers, "my-hatchery", model, 5)
+	assert.Len(t, res, 2)
+	assert.Equal(t, "idle-1", res[0].Name)
+	assert.Equal(t, "idle-2", res[1].Name)
+
+	res = pools.idleWorkers(workers, "my-hatchery", model, 1)
+	assert.Len(t, res, 1)
+
+	// workers which are not in the pool anymore are forgotten
+	_, has := pools.pools["my-model"].workers["gone"]
+	assert.False(t, has)
+}
@@ -2,11 +2,12 @@ package sdk
 
 // This is the buitin integration model
 const (
-	KafkaIntegrationModel         = "Kafka"
-	RabbitMQIntegrationModel      = "RabbitMQ"
-	OpenstackIntegrationModel     = "Openstack"
-	AWSIntegrationModel           = "AWS"
-	DefaultStorageIntegrationName = "shared.infra"
+	KafkaIntegrationModel          = "Kafka"
+	RabbitMQIntegrationModel       = "RabbitMQ"
+	OpenstackIntegrationModel      = "Openstack"
+	AWSIntegrationModel            = "AWS"
+	DockerRegistryIntegrationModel = "DockerRegistry"
+	DefaultStorageIntegrationName  = "shared.infra"
 )
 
 // Here are the default plateform models
@@ -16,6 +17,7 @@ var (
 		&RabbitMQIntegration,
 		&OpenstackIntegration,
 		&AWSIntegration,
+		&DockerRegistryIntegration,
 	}
 	// KafkaIntegration represents a kafka integration
 	KafkaIntegration = IntegrationModel{
@@ -120,6 +122,27 @@ var (
 		Disabled: false,
 		Hook:     false,
 	}
+	// DockerRegistryIntegration represents a docker registry used by docker worker models
+	DockerRegistryIntegration = IntegrationModel{
+		Name:       DockerRegistryIntegrationModel,
+		Author:     "CDS",
+		Identifier: "github.com/ovh/cds/integration/builtin/docker-registry",
+		Icon:       "",
+		DefaultConfig: IntegrationConfig{
+			"url": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "URL of the registry, ie: https://registry-1.docker.io",
+			},
+			"username": IntegrationConfigValue{
+				Type: IntegrationConfigTypeString,
+			},
+			"password": IntegrationConfigValue{
+				Type: IntegrationConfigTypePassword,
+			},
+		},
+		Disabled: false,
+		Hook:     false,
+	}
 )
 
 // DefaultIfEmptyStorage return sdk.DefaultStorageIntegrationName if integrationName is empty
@@ -6,6 +6,7 @@ import (
 
 	"github.com/yuin/gluare"
 	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/parse"
 )
 
 // Check is a type which helps to call a lua script with variables to check something.
@@ -97,3 +98,32 @@ func (c *Check) Perform(script string) error {
 	c.Result = ok
 	return nil
 }
+
+// CheckExpression performs given lua expression with given variables then returns its result. The expression doesn't
+// need to start with return, e.g. `git_branch == "master" and cds_status == "Success"`.
+func CheckExpression(expression string, vars map[string]string) (bool, error) {
+	c, err := NewCheck()
+	if err != nil {
+		return false, err
+	}
+	defer c.state.Close()
+	c.SetVariables(vars)
+	if err := c.Perform(expressionScript(expression)); err != nil {
+		return false, err
+	}
+	return c.Result, nil
+}
+
+// CheckExpressionSyntax returns an error if given lua expression can't be parsed.
+func CheckExpressionSyntax(expression string) error {
+	_, err := parse.Parse(strings.NewReader(expressionScript(expression)), "<expression>")
+	return err
+}
+
+func expressionScript(expression string) string {
+	expression = strings.TrimSpace(expression)
+	if strings.HasPrefix(expression, "return ") {
+		return expression
+	}
+	return "return " + expression
+}
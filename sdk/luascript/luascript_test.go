@@ -3,61 +3,60 @@ package luascript
 import (
 	"testing"
 
-	"github.com/ovh/cds/engine/api/test"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestLuaCheck(t *testing.T) {
 	l, err := NewCheck()
-	test.NoError(t, err)
+	assert.NoError(t, err)
 	l.SetVariables(map[string]string{
 		"cds.application": "mon-appli",
 	})
-	test.NoError(t, l.Perform("return cds_application == \"mon-appli\""))
+	assert.NoError(t, l.Perform("return cds_application == \"mon-appli\""))
 	assert.False(t, l.IsError)
 	assert.True(t, l.Result)
 }
 
 func TestLuaCheckStrings(t *testing.T) {
 	l, err := NewCheck()
-	test.NoError(t, err)
+	assert.NoError(t, err)
 	l.SetVariables(map[string]string{
 		"cds.application": "mon-appli",
 	})
-	test.NoError(t, l.Perform("return string.match(\"abcdefg\", \"b..\") == \"bcd\""))
+	assert.NoError(t, l.Perform("return string.match(\"abcdefg\", \"b..\") == \"bcd\""))
 	assert.False(t, l.IsError)
 	assert.True(t, l.Result)
 }
 
 func TestLuaCheckStringsFind(t *testing.T) {
 	l, err := NewCheck()
-	test.NoError(t, err)
+	assert.NoError(t, err)
 	l.SetVariables(map[string]string{
 		"git_branch": "release/foo",
 	})
-	test.NoError(t, l.Perform(`return git_branch:find("^release/") ~= nil`))
+	assert.NoError(t, l.Perform(`return git_branch:find("^release/") ~= nil`))
 	assert.False(t, l.IsError)
 	assert.True(t, l.Result)
 }
 
 func TestLuaCheckWeekOfDay(t *testing.T) {
 	l, err := NewCheck()
-	test.NoError(t, err)
+	assert.NoError(t, err)
 	l.SetVariables(map[string]string{
 		"cds.application": "mon-appli",
 	})
-	test.NoError(t, l.Perform(`return os.date("%w") < "8"`))
+	assert.NoError(t, l.Perform(`return os.date("%w") < "8"`))
 	assert.False(t, l.IsError)
 	assert.True(t, l.Result)
 }
 
 func TestLuaCheckRegularExpression(t *testing.T) {
 	l, err := NewCheck()
-	test.NoError(t, err)
+	assert.NoError(t, err)
 	l.SetVariables(map[string]string{
 		"cds.application": "mon-appli",
 	})
-	test.NoError(t, l.Perform(`
+	assert.NoError(t, l.Perform(`
 		local re = require("re")
 
 		return re.match("abcdefg", "abc.*") == "abcdefg"
@@ -65,7 +64,7 @@ func TestLuaCheckRegularExpression(t *testing.T) {
 	assert.False(t, l.IsError)
 	assert.True(t, l.Result)
 
-	test.NoError(t, l.Perform(`
+	assert.NoError(t, l.Perform(`
 		local re = require("re")
 
 		return re.match("abcdefg", "zzz.*") == ""
@@ -74,3 +73,23 @@ func TestLuaCheckRegularExpression(t *testing.T) {
 	assert.False(t, l.Result)
 
 }
+
+func TestCheckExpression(t *testing.T) {
+	vars := map[string]string{
+		"git.branch": "master",
+		"cds.status": "Fail",
+	}
+	ok, err := CheckExpression(`git_branch == "master" and cds_status == "Fail"`, vars)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = CheckExpression(`return git_branch:find("^release/") ~= nil`, vars)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = CheckExpression(`git_branch ==`, vars)
+	assert.Error(t, err)
+
+	assert.NoError(t, CheckExpressionSyntax(`cds_status == "Success"`))
+	assert.Error(t, CheckExpressionSyntax(`cds_status = "Success"`))
+}
@@ -47,6 +47,7 @@ var (
 	MsgEnvironmentKeyCreated               = &Message{"MsgEnvironmentKeyCreated", trad{FR: "La clé %s %s a été créée sur l'environnement %s", EN: "%s key %s created on environment %s"}, nil}
 	MsgJobNotValidActionNotFound           = &Message{"MsgJobNotValidActionNotFound", trad{FR: "Erreur de validation du Job %s : L'action %s à l'étape %d n'a pas été trouvée", EN: "Job %s validation Failure: Unknown action %s on step #%d"}, nil}
 	MsgJobNotValidInvalidActionParameter   = &Message{"MsgJobNotValidInvalidActionParameter", trad{FR: "Erreur de validation du Job %s : Le paramètre %s de l'étape %d - %s est invalide", EN: "Job %s validation Failure: Invalid parameter %s on step #%d %s"}, nil}
+	MsgJobNotValidActionParameterType      = &Message{"MsgJobNotValidActionParameterType", trad{FR: "Erreur de validation du Job %s : Le paramètre %s de l'étape %d - %s doit être de type %s", EN: "Job %s validation Failure: Parameter %s on step #%d %s should be a %s"}, nil}
 	MsgPipelineGroupUpdated                = &Message{"MsgPipelineGroupUpdated", trad{FR: "Les permissions du groupe %s sur le pipeline %s on été mises à jour", EN: "Permission for group %s on pipeline %s has been updated"}, nil}
 	MsgPipelineGroupAdded                  = &Message{"MsgPipelineGroupAdded", trad{FR: "Les permissions du groupe %s sur le pipeline %s on été ajoutées", EN: "Permission for group %s on pipeline %s has been added"}, nil}
 	MsgPipelineGroupDeleted                = &Message{"MsgPipelineGroupDeleted", trad{FR: "Les permissions du groupe %s sur le pipeline %s on été supprimées", EN: "Permission for group %s on pipeline %s has been deleted"}, nil}
@@ -79,12 +80,22 @@ var (
 	MsgWorkflowNodeStop                    = &Message{"MsgWorkflowNodeStop", trad{FR: "Le pipeline a été arrété par %s", EN: "The pipeline has been stopped by %s"}, nil}
 	MsgWorkflowNodeMutex                   = &Message{"MsgWorkflowNodeMutex", trad{FR: "Le pipeline %s est mis en attente tant qu'il est en cours sur un autre run", EN: "The pipeline %s is waiting while it's running on another run"}, nil}
 	MsgWorkflowNodeMutexRelease            = &Message{"MsgWorkflowNodeMutexRelease", trad{FR: "Lancement du pipeline %s", EN: "Triggering pipeline %s"}, nil}
+	MsgWorkflowNodeApprovalWaiting         = &Message{"MsgWorkflowNodeApprovalWaiting", trad{FR: "Le pipeline %s est en attente de %d approbation(s)", EN: "The pipeline %s is waiting for %d approval(s)"}, nil}
+	MsgWorkflowNodeApproved                = &Message{"MsgWorkflowNodeApproved", trad{FR: "Le pipeline %s a été approuvé par %s", EN: "The pipeline %s has been approved by %s"}, nil}
+	MsgWorkflowNodeRejected                = &Message{"MsgWorkflowNodeRejected", trad{FR: "Le pipeline %s a été rejeté par %s", EN: "The pipeline %s has been rejected by %s"}, nil}
+	MsgWorkflowNodeApprovalExpired         = &Message{"MsgWorkflowNodeApprovalExpired", trad{FR: "L'approbation du pipeline %s a expiré", EN: "The approval of pipeline %s has expired"}, nil}
+	MsgWorkflowNodeBlockedByFreeze         = &Message{"MsgWorkflowNodeBlockedByFreeze", trad{FR: "Le pipeline %s est bloqué par la période de gel %s: %s", EN: "Pipeline %s is blocked by freeze window %s: %s"}, nil}
+	MsgWorkflowNodeFreezeOverridden        = &Message{"MsgWorkflowNodeFreezeOverridden", trad{FR: "La période de gel %s a été outrepassée par %s pour le pipeline %s", EN: "Freeze window %s has been overridden by %s for pipeline %s"}, nil}
+	MsgWorkflowRunSupersededBy             = &Message{"MsgWorkflowRunSupersededBy", trad{FR: "Cette exécution a été remplacée par #%d", EN: "This workflow run has been superseded by #%d"}, nil}
+	MsgWorkflowRunQueued                   = &Message{"MsgWorkflowRunQueued", trad{FR: "Exécution en attente de la fin de #%d dans le groupe de concurrence %s", EN: "Workflow run queued behind #%d in concurrency group %s"}, nil}
+	MsgWorkflowRunDequeued                 = &Message{"MsgWorkflowRunDequeued", trad{FR: "Exécution démarrée après la fin de #%d dans le groupe de concurrence %s", EN: "Workflow run started after the end of #%d in concurrency group %s"}, nil}
 	MsgWorkflowImportedUpdated             = &Message{"MsgWorkflowImportedUpdated", trad{FR: "Le workflow %s a été mis à jour", EN: "Workflow %s has been updated"}, nil}
 	MsgWorkflowImportedInserted            = &Message{"MsgWorkflowImportedInserted", trad{FR: "Le workflow %s a été créé", EN: "Workflow %s has been created"}, nil}
 	MsgSpawnInfoHatcheryCannotStartJob     = &Message{"MsgSpawnInfoHatcheryCannotStart", trad{FR: "Aucune hatchery n'a pu démarrer de worker respectant vos pré-requis de job, merci de les vérifier.", EN: "No hatchery can spawn a worker corresponding your job's requirements. Please check your job's requirements."}, nil}
 	MsgWorkflowRunBranchDeleted            = &Message{"MsgWorkflowRunBranchDeleted", trad{FR: "La branche %s  a été supprimée", EN: "Branch %s has been deleted"}, nil}
 	MsgWorkflowTemplateImportedInserted    = &Message{"MsgWorkflowTemplateImportedInserted", trad{FR: "Le template de workflow %s/%s a été créé", EN: "Workflow template %s/%s has been created"}, nil}
 	MsgWorkflowTemplateImportedUpdated     = &Message{"MsgWorkflowTemplateImportedUpdated", trad{FR: "Le template de workflow %s/%s a été mis à jour", EN: "Workflow template %s/%s has been updated"}, nil}
+	MsgSpawnInfoSecurityPolicyViolation    = &Message{"MsgSpawnInfoSecurityPolicyViolation", trad{FR: "Politique de sécurité non respectée : %s", EN: "Security policy violation: %s"}, nil}
 )
 
 // Messages contains all sdk Messages
@@ -116,6 +127,7 @@ var Messages = map[string]*Message{
 	MsgEnvironmentKeyCreated.ID:               MsgEnvironmentKeyCreated,
 	MsgJobNotValidActionNotFound.ID:           MsgJobNotValidActionNotFound,
 	MsgJobNotValidInvalidActionParameter.ID:   MsgJobNotValidInvalidActionParameter,
+	MsgJobNotValidActionParameterType.ID:      MsgJobNotValidActionParameterType,
 	MsgPipelineGroupUpdated.ID:                MsgPipelineGroupUpdated,
 	MsgPipelineGroupAdded.ID:                  MsgPipelineGroupAdded,
 	MsgPipelineGroupDeleted.ID:                MsgPipelineGroupDeleted,
@@ -148,12 +160,22 @@ var Messages = map[string]*Message{
 	MsgWorkflowNodeStop.ID:                    MsgWorkflowNodeStop,
 	MsgWorkflowNodeMutex.ID:                   MsgWorkflowNodeMutex,
 	MsgWorkflowNodeMutexRelease.ID:            MsgWorkflowNodeMutexRelease,
+	MsgWorkflowNodeApprovalWaiting.ID:         MsgWorkflowNodeApprovalWaiting,
+	MsgWorkflowNodeApproved.ID:                MsgWorkflowNodeApproved,
+	MsgWorkflowNodeRejected.ID:                MsgWorkflowNodeRejected,
+	MsgWorkflowNodeApprovalExpired.ID:         MsgWorkflowNodeApprovalExpired,
+	MsgWorkflowNodeBlockedByFreeze.ID:         MsgWorkflowNodeBlockedByFreeze,
+	MsgWorkflowNodeFreezeOverridden.ID:        MsgWorkflowNodeFreezeOverridden,
+	MsgWorkflowRunSupersededBy.ID:             MsgWorkflowRunSupersededBy,
+	MsgWorkflowRunQueued.ID:                   MsgWorkflowRunQueued,
+	MsgWorkflowRunDequeued.ID:                 MsgWorkflowRunDequeued,
 	MsgWorkflowImportedUpdated.ID:             MsgWorkflowImportedUpdated,
 	MsgWorkflowImportedInserted.ID:            MsgWorkflowImportedInserted,
 	MsgSpawnInfoHatcheryCannotStartJob.ID:     MsgSpawnInfoHatcheryCannotStartJob,
 	MsgWorkflowRunBranchDeleted.ID:            MsgWorkflowRunBranchDeleted,
 	MsgWorkflowTemplateImportedInserted.ID:    MsgWorkflowTemplateImportedInserted,
 	MsgWorkflowTemplateImportedUpdated.ID:     MsgWorkflowTemplateImportedUpdated,
+	MsgSpawnInfoSecurityPolicyViolation.ID:    MsgSpawnInfoSecurityPolicyViolation,
 }
 
 //Message represent a struc format translated messages
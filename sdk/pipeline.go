@@ -1,6 +1,8 @@
 package sdk
 
 import (
+	"regexp"
+	"strings"
 	"time"
 )
 
@@ -47,3 +49,73 @@ type PipelineAction struct {
 	Args            []Parameter `json:"args"`
 	PipelineStageID int64       `json:"pipeline_stage_id"`
 }
+
+var jobOutputReferenceRegexp = regexp.MustCompile(`cds\.output\.[a-zA-Z0-9._-]+`)
+
+// CheckJobOutputs returns an error if a job output is invalid, or if a job or a stage condition uses an output
+// that is not declared by a job of a previous stage. Jobs of a stage run in parallel, so they can't use the outputs
+// of each other.
+func (p Pipeline) CheckJobOutputs() error {
+	// build order of the stage that declares each output
+	declared := map[string]int{}
+	for _, s := range p.Stages {
+		for _, j := range s.Jobs {
+			if len(j.Action.Outputs) == 0 {
+				continue
+			}
+			if !NamePatternRegex.MatchString(j.Action.Name) {
+				return NewErrorFrom(ErrWrongRequest, "job %s declares outputs, its name should match %s", j.Action.Name, NamePattern)
+			}
+			for _, o := range j.Action.Outputs {
+				if err := o.IsValid(); err != nil {
+					return err
+				}
+				declared[JobOutputVariableName(j.Action.Name, o.Name)] = s.BuildOrder
+			}
+		}
+	}
+
+	check := func(s Stage, where, value string) error {
+		for _, ref := range jobOutputReferenceRegexp.FindAllString(value, -1) {
+			ref = strings.TrimRight(ref, ".")
+			buildOrder, ok := declared[ref]
+			if !ok {
+				return NewErrorFrom(ErrWrongRequest, "%s uses %s which is not declared as a job output", where, ref)
+			}
+			if buildOrder >= s.BuildOrder {
+				return NewErrorFrom(ErrWrongRequest, "%s uses %s which is the output of a job that does not run in a previous stage", where, ref)
+			}
+		}
+		return nil
+	}
+
+	for _, s := range p.Stages {
+		for _, c := range s.Prerequisites {
+			if err := check(s, "condition of stage "+s.Name, c.Parameter+" "+c.ExpectedValue); err != nil {
+				return err
+			}
+		}
+		for _, j := range s.Jobs {
+			where := "job " + j.Action.Name
+			for _, r := range j.Action.Requirements {
+				if err := check(s, where, r.Value); err != nil {
+					return err
+				}
+			}
+			for _, o := range j.Action.Outputs {
+				if err := check(s, where, o.Value); err != nil {
+					return err
+				}
+			}
+			for _, step := range j.Action.Actions {
+				for _, param := range step.Parameters {
+					if err := check(s, where, param.Value); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
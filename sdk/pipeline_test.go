@@ -0,0 +1,77 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipelineCheckJobOutputs(t *testing.T) {
+	newPipeline := func(value string, buildOrder int) Pipeline {
+		return Pipeline{
+			Stages: []Stage{
+				{
+					Name:       "build",
+					BuildOrder: 1,
+					Jobs: []Job{{Action: Action{
+						Name:    "compile",
+						Outputs: ActionOutputs{{Name: "version", Type: StringParameter, Value: "{{.cds.build.version}}"}},
+					}}},
+				},
+				{
+					Name:       "deploy",
+					BuildOrder: buildOrder,
+					Jobs: []Job{{Action: Action{
+						Name: "deploy",
+						Actions: []Action{{
+							Name:       ScriptAction,
+							Parameters: []Parameter{{Name: "script", Value: value}},
+						}},
+					}}},
+				},
+			},
+		}
+	}
+
+	assert.NoError(t, newPipeline("echo {{.cds.output.compile.version}}", 2).CheckJobOutputs())
+	assert.NoError(t, newPipeline("echo {{.cds.build.version}}", 1).CheckJobOutputs())
+	// unknown output
+	assert.Error(t, newPipeline("echo {{.cds.output.compile.unknown}}", 2).CheckJobOutputs())
+	// output of a job of the same stage
+	assert.Error(t, newPipeline("echo {{.cds.output.compile.version}}", 1).CheckJobOutputs())
+
+	p := newPipeline("", 2)
+	p.Stages[1].Prerequisites = []Prerequisite{{Parameter: "cds.output.compile.version", ExpectedValue: "1.0.0"}}
+	assert.NoError(t, p.CheckJobOutputs())
+	p.Stages[1].BuildOrder = 1
+	assert.Error(t, p.CheckJobOutputs())
+
+	p = newPipeline("", 2)
+	p.Stages[0].Jobs[0].Action.Name = "compile all"
+	assert.Error(t, p.CheckJobOutputs())
+}
+
+func TestStageConditionsJobOutputs(t *testing.T) {
+	s := Stage{
+		Prerequisites: []Prerequisite{
+			{Parameter: "cds.output.compile.version", ExpectedValue: "1.0.0"},
+			{Parameter: "env", ExpectedValue: "prod"},
+		},
+	}
+	conditions := s.Conditions()
+	assert.Equal(t, "cds.output.compile.version", conditions[0].Variable)
+	assert.Equal(t, "cds.pip.env", conditions[1].Variable)
+
+	params := []Parameter{
+		{Name: "cds.output.compile.version", Type: StringParameter, Value: "1.0.0"},
+		{Name: "cds.pip.env", Type: StringParameter, Value: "prod"},
+	}
+	ok, err := WorkflowCheckConditions(conditions, params)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	params[0].Value = "2.0.0"
+	ok, err = WorkflowCheckConditions(conditions, params)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
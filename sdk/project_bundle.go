@@ -0,0 +1,26 @@
+package sdk
+
+import "strings"
+
+// ProjectBundleImportReport is the result of the import of a project bundle.
+// Conflicts are entities which already exist in the target project, they are overridden with force.
+// Errors are problems which prevent the import, like a missing group or integration model.
+type ProjectBundleImportReport struct {
+	DryRun    bool     `json:"dry_run" cli:"dry_run"`
+	Imported  []string `json:"imported" cli:"imported"`
+	Conflicts []string `json:"conflicts" cli:"conflicts"`
+	Errors    []string `json:"errors" cli:"errors"`
+}
+
+// ProjectBundleGroupMapping parses group mappings given as old=new and returns them as a map
+func ProjectBundleGroupMapping(mappings []string) (map[string]string, error) {
+	m := make(map[string]string, len(mappings))
+	for _, s := range mappings {
+		t := strings.SplitN(s, "=", 2)
+		if len(t) != 2 || t[0] == "" || t[1] == "" {
+			return nil, NewErrorFrom(ErrWrongRequest, "invalid group mapping %s, expected old=new", s)
+		}
+		m[t[0]] = t[1]
+	}
+	return m, nil
+}
@@ -0,0 +1,86 @@
+package sdk
+
+import (
+	"fmt"
+	"strings"
+)
+
+var severityLevels = map[string]int{
+	SeverityUnknown:    0,
+	SeverityNegligible: 1,
+	SeverityLow:        2,
+	SeverityMedium:     3,
+	SeverityHigh:       4,
+	SeverityCritical:   5,
+	SeverityDefcon1:    6,
+}
+
+// ProjectSecurityPolicy is checked on each vulnerability report and SBOM sent by a worker
+// for the applications of the project
+type ProjectSecurityPolicy struct {
+	ProjectID          int64    `json:"project_id" db:"project_id"`
+	MaxSeverity        string   `json:"max_severity" db:"max_severity"`
+	ForbiddenLicenses  []string `json:"forbidden_licenses" db:"-"`
+	FailNode           bool     `json:"fail_node" db:"fail_node"`
+	CommentPullRequest bool     `json:"comment_pull_request" db:"comment_pull_request"`
+}
+
+// IsValid returns an error if a forbidden license is empty or if the policy max severity is unknown
+func (p ProjectSecurityPolicy) IsValid() error {
+	for _, l := range p.ForbiddenLicenses {
+		if strings.TrimSpace(l) == "" {
+			return NewErrorFrom(ErrWrongRequest, "invalid empty forbidden license")
+		}
+	}
+	if p.MaxSeverity == "" {
+		return nil
+	}
+	if _, ok := severityLevels[p.MaxSeverity]; !ok || p.MaxSeverity == SeverityUnknown {
+		return NewErrorFrom(ErrWrongRequest, "invalid max severity %s", p.MaxSeverity)
+	}
+	return nil
+}
+
+// CheckVulnerabilities returns a violation for each not ignored vulnerability above the max severity
+func (p ProjectSecurityPolicy) CheckVulnerabilities(vs []Vulnerability) []string {
+	if p.MaxSeverity == "" {
+		return nil
+	}
+	max := severityLevels[p.MaxSeverity]
+	var violations []string
+	for _, v := range vs {
+		if v.Ignored || severityLevels[v.Severity] <= max {
+			continue
+		}
+		name := v.CVE
+		if name == "" {
+			name = v.Title
+		}
+		violations = append(violations, fmt.Sprintf("%s vulnerability %s on %s exceeds max severity %s", v.Severity, name, v.Component, p.MaxSeverity))
+	}
+	return violations
+}
+
+// CheckComponents returns a violation for each component with a forbidden license
+func (p ProjectSecurityPolicy) CheckComponents(cs []Component) []string {
+	if len(p.ForbiddenLicenses) == 0 {
+		return nil
+	}
+	var violations []string
+	for _, c := range cs {
+		for _, l := range c.Licenses {
+			for _, f := range p.ForbiddenLicenses {
+				if strings.EqualFold(l, f) {
+					violations = append(violations, fmt.Sprintf("component %s %s uses forbidden license %s", c.Name, c.Version, l))
+				}
+			}
+		}
+	}
+	return violations
+}
+
+// SecurityPolicyReport is returned to the worker after a vulnerability report or a SBOM was sent
+type SecurityPolicyReport struct {
+	Violations []string `json:"violations"`
+	FailNode   bool     `json:"fail_node"`
+}
@@ -1,7 +1,9 @@
 package sdk
 
 import (
+	"crypto/sha1"
 	"encoding/base64"
+	"encoding/hex"
 	"time"
 )
 
@@ -25,6 +27,13 @@ type Operation struct {
 type OperationSetup struct {
 	Checkout OperationCheckout `json:"checkout,omitempty"`
 	Push     OperationPush     `json:"push,omitempty"`
+	Mirror   OperationMirror   `json:"mirror,omitempty"`
+}
+
+// OperationMirror creates or refreshes the bare mirror of the repository
+type OperationMirror struct {
+	// ID of the mirror, given by RepositoryMirrorID
+	ID string `json:"id,omitempty"`
 }
 
 // OperationRepositoryInfo represents global information about the repository
@@ -44,6 +53,8 @@ type OperationLoadFiles struct {
 type OperationCheckout struct {
 	Branch string `json:"branch,omitempty"`
 	Commit string `json:"commit,omitempty"`
+	// Tag checkouts a tag instead of a branch, the commit of the tag is returned in Commit
+	Tag string `json:"tag,omitempty"`
 }
 
 // OperationPush represents information about push operation
@@ -76,3 +87,22 @@ type OperationRepo struct {
 func (r OperationRepo) ID() string {
 	return base64.StdEncoding.EncodeToString([]byte(r.URL))
 }
+
+// RepositoryMirrorID returns the ID of the mirror of a repository of a project, mirrors are not shared
+// between projects as their repositories are cloned with the credentials of the project
+func RepositoryMirrorID(projectKey, vcsServer, repoFullname string) string {
+	h := sha1.Sum([]byte(projectKey + "/" + vcsServer + "/" + repoFullname))
+	return hex.EncodeToString(h[:])
+}
+
+// RepositoryCloneURL returns the clone URL of the repository of the application from the parameters of a run,
+// according to the connection type of the application
+func RepositoryCloneURL(params []Parameter) string {
+	switch ParameterValue(params, "git.connection.type") {
+	case "ssh":
+		return ParameterValue(params, "git.url")
+	case "https":
+		return ParameterValue(params, "git.http_url")
+	}
+	return ""
+}
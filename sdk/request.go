@@ -47,6 +47,8 @@ var (
 	ResponseWorkflowIDHeader = "X-Api-Workflow-Id"
 	// WorkflowAsCodeHeader is used as HTTP header
 	WorkflowAsCodeHeader = "X-Api-Workflow-As-Code"
+	// ProjectBundlePassphraseHeader is used as HTTP header to give the passphrase of a project bundle
+	ProjectBundlePassphraseHeader = "X-Api-Bundle-Passphrase"
 
 	// ResponseTemplateGroupNameHeader is used as HTTP header
 	ResponseTemplateGroupNameHeader = "X-Api-Template-Group-Name"
@@ -38,6 +38,8 @@ func easyjson48f3ebbbDecodeGithubComOvhCdsSdk(in *jlexer.Lexer, out *Requirement
 		switch key {
 		case "id":
 			out.ID = int64(in.Int64())
+		case "action_id":
+			out.ActionID = int64(in.Int64())
 		case "name":
 			out.Name = string(in.String())
 		case "type":
@@ -68,6 +70,16 @@ func easyjson48f3ebbbEncodeGithubComOvhCdsSdk(out *jwriter.Writer, in Requiremen
 		}
 		out.Int64(int64(in.ID))
 	}
+	{
+		const prefix string = ",\"action_id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.ActionID))
+	}
 	{
 		const prefix string = ",\"name\":"
 		if first {
@@ -0,0 +1,186 @@
+package sdk
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Resource usage suggestions on the memory requirement of a job
+const (
+	ResourceSuggestionIncreaseMemory = "increase_memory"
+	ResourceSuggestionDecreaseMemory = "decrease_memory"
+	ResourceSuggestionSetMemory      = "set_memory"
+)
+
+// ResourceUsageMinRuns is the number of runs needed before suggesting a memory requirement
+const ResourceUsageMinRuns = 5
+
+// StepResourceUsage is the resource usage of the process tree of a step, sampled by the worker
+type StepResourceUsage struct {
+	StepOrder  int     `json:"step_order"`
+	CPUSeconds float64 `json:"cpu_seconds"`
+	// MaxMemory is the peak resident memory of the process tree in bytes
+	MaxMemory int64 `json:"max_memory"`
+	DiskRead  int64 `json:"disk_read"`
+	DiskWrite int64 `json:"disk_write"`
+	NetworkRx int64 `json:"network_rx"`
+	NetworkTx int64 `json:"network_tx"`
+}
+
+// JobResourceUsage is the resource usage of a job run, sent by the worker with the job result
+type JobResourceUsage struct {
+	ID                   int64               `json:"id"`
+	WorkflowID           int64               `json:"workflow_id"`
+	WorkflowRunID        int64               `json:"workflow_run_id"`
+	WorkflowNodeRunID    int64               `json:"workflow_node_run_id"`
+	WorkflowNodeJobRunID int64               `json:"workflow_node_job_run_id"`
+	WorkflowNodeName     string              `json:"workflow_node_name"`
+	JobName              string              `json:"job_name"`
+	Num                  int64               `json:"num"`
+	RequestedMemory      int64               `json:"requested_memory"`
+	Created              time.Time           `json:"created"`
+	Steps                []StepResourceUsage `json:"steps"`
+	Total                StepResourceUsage   `json:"total"`
+}
+
+// Summarize computes the usage of the job from the usage of its steps
+func (u *JobResourceUsage) Summarize() {
+	u.Total = StepResourceUsage{StepOrder: -1}
+	for _, s := range u.Steps {
+		u.Total.CPUSeconds += s.CPUSeconds
+		u.Total.DiskRead += s.DiskRead
+		u.Total.DiskWrite += s.DiskWrite
+		u.Total.NetworkRx += s.NetworkRx
+		u.Total.NetworkTx += s.NetworkTx
+		if s.MaxMemory > u.Total.MaxMemory {
+			u.Total.MaxMemory = s.MaxMemory
+		}
+	}
+}
+
+// RequestedMemory returns the memory requirement (MB) of a job, 0 if there is no valid one
+func RequestedMemory(reqs []Requirement) int64 {
+	for _, r := range reqs {
+		if r.Type == MemoryRequirement {
+			m, err := strconv.ParseInt(r.Value, 10, 64)
+			if err == nil {
+				return m
+			}
+		}
+	}
+	return 0
+}
+
+// ResourcePercentiles are percentiles of a resource across job runs
+type ResourcePercentiles struct {
+	P50 float64 `json:"p50"`
+	P90 float64 `json:"p90"`
+	P99 float64 `json:"p99"`
+	Max float64 `json:"max"`
+}
+
+// NewResourcePercentiles computes percentiles of given values
+func NewResourcePercentiles(values []float64) ResourcePercentiles {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	return ResourcePercentiles{
+		P50: Percentile(sorted, 50),
+		P90: Percentile(sorted, 90),
+		P99: Percentile(sorted, 99),
+		Max: Percentile(sorted, 100),
+	}
+}
+
+// Percentile returns the nearest-rank percentile p of sorted values
+func Percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+// JobResourceUsageStats is the resource usage of a pipeline job across workflow runs
+type JobResourceUsageStats struct {
+	WorkflowNodeName string              `json:"workflow_node_name"`
+	JobName          string              `json:"job_name"`
+	Runs             int                 `json:"runs"`
+	CPUSeconds       ResourcePercentiles `json:"cpu_seconds"`
+	// MaxMemory percentiles are in bytes
+	MaxMemory ResourcePercentiles `json:"max_memory"`
+	Disk      ResourcePercentiles `json:"disk"`
+	Network   ResourcePercentiles `json:"network"`
+	// RequestedMemory and SuggestedMemory are in MB, as the memory requirement
+	RequestedMemory int64  `json:"requested_memory"`
+	SuggestedMemory int64  `json:"suggested_memory,omitempty"`
+	Suggestion      string `json:"suggestion,omitempty"`
+}
+
+// NewJobResourceUsageStats computes the usage stats of each job from job runs usages, usages must
+// be sorted from the most recent to the oldest
+func NewJobResourceUsageStats(usages []JobResourceUsage) []JobResourceUsageStats {
+	type key struct{ node, job string }
+	var keys []key
+	byJob := map[key][]JobResourceUsage{}
+	for _, u := range usages {
+		k := key{u.WorkflowNodeName, u.JobName}
+		if _, has := byJob[k]; !has {
+			keys = append(keys, k)
+		}
+		byJob[k] = append(byJob[k], u)
+	}
+
+	stats := make([]JobResourceUsageStats, 0, len(keys))
+	for _, k := range keys {
+		us := byJob[k]
+		var cpu, mem, disk, net []float64
+		for _, u := range us {
+			cpu = append(cpu, u.Total.CPUSeconds)
+			mem = append(mem, float64(u.Total.MaxMemory))
+			disk = append(disk, float64(u.Total.DiskRead+u.Total.DiskWrite))
+			net = append(net, float64(u.Total.NetworkRx+u.Total.NetworkTx))
+		}
+		s := JobResourceUsageStats{
+			WorkflowNodeName: k.node,
+			JobName:          k.job,
+			Runs:             len(us),
+			CPUSeconds:       NewResourcePercentiles(cpu),
+			MaxMemory:        NewResourcePercentiles(mem),
+			Disk:             NewResourcePercentiles(disk),
+			Network:          NewResourcePercentiles(net),
+			RequestedMemory:  us[0].RequestedMemory,
+		}
+		s.Suggestion, s.SuggestedMemory = suggestMemory(s.RequestedMemory, s.MaxMemory.P99, s.Runs)
+		stats = append(stats, s)
+	}
+	return stats
+}
+
+// suggestMemory compares the requested memory (MB) with the p99 of the peak memory (bytes) used by a job,
+// the suggested memory gives 25% of headroom and is rounded up to 64MB
+func suggestMemory(requested int64, p99 float64, runs int) (string, int64) {
+	if runs < ResourceUsageMinRuns || p99 <= 0 {
+		return "", 0
+	}
+	usedMB := p99 / (1024 * 1024)
+	suggested := int64(math.Ceil(usedMB*1.25/64)) * 64
+
+	switch {
+	case requested == 0:
+		return ResourceSuggestionSetMemory, suggested
+	case usedMB > 0.9*float64(requested):
+		return ResourceSuggestionIncreaseMemory, suggested
+	case usedMB < 0.5*float64(requested) && suggested < requested:
+		return ResourceSuggestionDecreaseMemory, suggested
+	}
+	return "", 0
+}
@@ -0,0 +1,65 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPercentile(t *testing.T) {
+	values := []float64{15, 20, 35, 40, 50}
+	assert.Equal(t, float64(15), Percentile(values, 0))
+	assert.Equal(t, float64(20), Percentile(values, 30))
+	assert.Equal(t, float64(35), Percentile(values, 50))
+	assert.Equal(t, float64(50), Percentile(values, 99))
+	assert.Equal(t, float64(0), Percentile(nil, 50))
+}
+
+func TestNewJobResourceUsageStats(t *testing.T) {
+	const mb = 1024 * 1024
+	var usages []JobResourceUsage
+	for i := 0; i < ResourceUsageMinRuns; i++ {
+		build := JobResourceUsage{
+			WorkflowNodeName: "build",
+			JobName:          "compile",
+			RequestedMemory:  4096,
+			Steps: []StepResourceUsage{
+				{StepOrder: 0, CPUSeconds: 2, MaxMemory: int64(100+i) * mb, DiskWrite: 10},
+				{StepOrder: 1, CPUSeconds: 3, MaxMemory: int64(200+i) * mb, NetworkRx: 5},
+			},
+		}
+		build.Summarize()
+		deploy := JobResourceUsage{
+			WorkflowNodeName: "deploy",
+			JobName:          "push",
+			RequestedMemory:  512,
+			Steps:            []StepResourceUsage{{StepOrder: 0, MaxMemory: 500 * mb}},
+		}
+		deploy.Summarize()
+		usages = append(usages, build, deploy)
+	}
+
+	stats := NewJobResourceUsageStats(usages)
+	assert.Len(t, stats, 2)
+
+	assert.Equal(t, "build", stats[0].WorkflowNodeName)
+	assert.Equal(t, ResourceUsageMinRuns, stats[0].Runs)
+	assert.Equal(t, float64(5), stats[0].CPUSeconds.P50)
+	assert.Equal(t, float64(204*mb), stats[0].MaxMemory.Max)
+	assert.Equal(t, float64(15), stats[0].Disk.P99+stats[0].Network.P99)
+	assert.Equal(t, ResourceSuggestionDecreaseMemory, stats[0].Suggestion)
+	assert.Equal(t, int64(256), stats[0].SuggestedMemory)
+
+	assert.Equal(t, "deploy", stats[1].WorkflowNodeName)
+	assert.Equal(t, ResourceSuggestionIncreaseMemory, stats[1].Suggestion)
+	assert.Equal(t, int64(640), stats[1].SuggestedMemory)
+
+	// Not enough runs to suggest anything
+	stats = NewJobResourceUsageStats(usages[:2])
+	assert.Equal(t, "", stats[0].Suggestion)
+}
+
+func TestRequestedMemory(t *testing.T) {
+	assert.Equal(t, int64(1024), RequestedMemory([]Requirement{{Type: BinaryRequirement, Value: "git"}, {Type: MemoryRequirement, Value: "1024"}}))
+	assert.Equal(t, int64(0), RequestedMemory([]Requirement{{Type: MemoryRequirement, Value: "foo"}}))
+}
@@ -0,0 +1,156 @@
+package sdk
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// VulnerabilityTypeSARIF is the type of the vulnerabilities read from a SARIF report
+const VulnerabilityTypeSARIF = "sarif"
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	FullDescription  sarifMessage `json:"fullDescription"`
+	HelpURI          string       `json:"helpUri"`
+	Properties       struct {
+		SecuritySeverity string `json:"security-severity"`
+	} `json:"properties"`
+	DefaultConfiguration struct {
+		Level string `json:"level"`
+	} `json:"defaultConfiguration"`
+}
+
+type sarifLog struct {
+	Runs []struct {
+		Tool struct {
+			Driver struct {
+				Name  string      `json:"name"`
+				Rules []sarifRule `json:"rules"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Results []struct {
+			RuleID    string       `json:"ruleId"`
+			RuleIndex *int         `json:"ruleIndex"`
+			Level     string       `json:"level"`
+			Message   sarifMessage `json:"message"`
+			Locations []struct {
+				PhysicalLocation struct {
+					ArtifactLocation struct {
+						URI string `json:"uri"`
+					} `json:"artifactLocation"`
+					Region struct {
+						StartLine int `json:"startLine"`
+					} `json:"region"`
+				} `json:"physicalLocation"`
+			} `json:"locations"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
+// ParseSARIF converts a SARIF static analysis report to a vulnerability report
+func ParseSARIF(btes []byte) (VulnerabilityWorkerReport, error) {
+	var log sarifLog
+	if err := json.Unmarshal(btes, &log); err != nil {
+		return VulnerabilityWorkerReport{}, NewErrorFrom(ErrWrongRequest, "invalid SARIF report: %v", err)
+	}
+
+	report := VulnerabilityWorkerReport{
+		Type:            VulnerabilityTypeSARIF,
+		Summary:         make(map[string]int64),
+		Vulnerabilities: []Vulnerability{},
+	}
+	for _, run := range log.Runs {
+		rules := make(map[string]sarifRule, len(run.Tool.Driver.Rules))
+		for _, r := range run.Tool.Driver.Rules {
+			rules[r.ID] = r
+		}
+
+		for _, res := range run.Results {
+			var rule sarifRule
+			if res.RuleIndex != nil && *res.RuleIndex >= 0 && *res.RuleIndex < len(run.Tool.Driver.Rules) {
+				rule = run.Tool.Driver.Rules[*res.RuleIndex]
+			} else {
+				rule = rules[res.RuleID]
+			}
+			ruleID := res.RuleID
+			if ruleID == "" {
+				ruleID = rule.ID
+			}
+
+			level := res.Level
+			if level == "" {
+				level = rule.DefaultConfiguration.Level
+			}
+
+			title := rule.ShortDescription.Text
+			if title == "" {
+				title = rule.Name
+			}
+			if title == "" {
+				title = ruleID
+			}
+
+			v := Vulnerability{
+				Title:       truncate(title, 100),
+				Description: res.Message.Text,
+				CVE:         truncate(ruleID, 100),
+				Link:        rule.HelpURI,
+				Origin:      run.Tool.Driver.Name,
+				Severity:    sarifSeverity(level, rule.Properties.SecuritySeverity),
+				Type:        VulnerabilityTypeSARIF,
+			}
+			if len(res.Locations) > 0 {
+				loc := res.Locations[0].PhysicalLocation
+				v.Component = loc.ArtifactLocation.URI
+				if loc.Region.StartLine > 0 {
+					v.Version = "line " + strconv.Itoa(loc.Region.StartLine)
+				}
+			}
+			report.Vulnerabilities = append(report.Vulnerabilities, v)
+			report.Summary[v.Severity]++
+		}
+	}
+	return report, nil
+}
+
+// sarifSeverity uses the CVSS security-severity property when set, the SARIF level otherwise
+func sarifSeverity(level, securitySeverity string) string {
+	if score, err := strconv.ParseFloat(securitySeverity, 64); err == nil {
+		switch {
+		case score >= 9:
+			return SeverityCritical
+		case score >= 7:
+			return SeverityHigh
+		case score >= 4:
+			return SeverityMedium
+		case score > 0:
+			return SeverityLow
+		}
+		return SeverityNegligible
+	}
+
+	switch level {
+	case "error":
+		return SeverityHigh
+	case "warning", "":
+		return SeverityMedium
+	case "note":
+		return SeverityLow
+	case "none":
+		return SeverityNegligible
+	}
+	return SeverityUnknown
+}
+
+func truncate(s string, size int) string {
+	if len(s) <= size {
+		return s
+	}
+	return s[:size]
+}
@@ -17,8 +17,17 @@ type Stage struct {
 	Jobs          []Job                  `json:"jobs"`
 	Status        Status                 `json:"status"`
 	Warnings      []PipelineBuildWarning `json:"warnings"`
+	// Generation is 0 for the stages of a pipeline, and n+1 for the stages generated at run time by a job of a
+	// stage of generation n
+	Generation int `json:"generation,omitempty"`
 }
 
+// Limits of the stages generated at run time by jobs of a node run
+const (
+	MaxStageGeneration = 3
+	MaxGeneratedJobs   = 50
+)
+
 // StageSummary is a light representation of stage for CDS event
 type StageSummary struct {
 	ID             int64                       `json:"id"`
@@ -51,7 +60,7 @@ func (s Stage) ToSummary() StageSummary {
 func (s *Stage) Conditions() []WorkflowNodeCondition {
 	res := make([]WorkflowNodeCondition, len(s.Prerequisites))
 	for i, p := range s.Prerequisites {
-		if !strings.HasPrefix(p.Parameter, "workflow.") && !strings.HasPrefix(p.Parameter, "git.") {
+		if !strings.HasPrefix(p.Parameter, "workflow.") && !strings.HasPrefix(p.Parameter, "git.") && !strings.HasPrefix(p.Parameter, JobOutputVariablePrefix) {
 			p.Parameter = "cds.pip." + p.Parameter
 		}
 		res[i] = WorkflowNodeCondition{
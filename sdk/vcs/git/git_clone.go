@@ -2,6 +2,7 @@ package git
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -19,6 +20,19 @@ type CloneOpts struct {
 	Quiet                   bool
 	CheckoutCommit          string
 	NoStrictHostKeyChecking bool
+	// Filter makes a partial clone, for example blob:none to fetch the blobs on demand
+	Filter string
+	// SparseCheckout are the directories checked out in cone mode, the whole tree is checked out if empty
+	SparseCheckout []string
+	// LFS fetches the Git LFS objects matching LFSInclude and not matching LFSExclude after the checkout
+	LFS        bool
+	LFSInclude []string
+	LFSExclude []string
+	// MirrorURL is the smart HTTP URL of a mirror of the repository to clone from, the repository is
+	// cloned from its URL if the mirror is unavailable
+	MirrorURL       string
+	MirrorUserAgent string
+	MirrorHeaders   map[string]string
 }
 
 // Clone make a git clone
@@ -39,6 +53,20 @@ func Clone(repo string, path string, auth *AuthOpts, opts *CloneOpts, output *Ou
 
 	var userLogCommand string
 	userLogCommand, commands = prepareGitCloneCommands(repoURL, path, opts)
+
+	// The clone from the mirror is the first command, next ones use the repository as origin
+	if opts != nil && opts.MirrorURL != "" {
+		if err := runGitCommands(repo, commands[:1], auth, output); err != nil {
+			LogFunc("Unable to clone from mirror %s: %v", opts.MirrorURL, err)
+			noMirror := *opts
+			noMirror.MirrorURL = ""
+			userLogCommand, commands = prepareGitCloneCommands(repoURL, path, &noMirror)
+			userLogCommand = "Mirror " + opts.MirrorURL + " unavailable, cloning from repository\n\r" + userLogCommand
+			return userLogCommand, runGitCommands(repo, commands, auth, output)
+		}
+		return userLogCommand, runGitCommands(repo, commands[1:], auth, output)
+	}
+
 	return userLogCommand, runGitCommands(repo, commands, auth, output)
 }
 
@@ -73,10 +101,29 @@ func prepareGitCloneCommands(repo string, path string, opts *CloneOpts) (string,
 		if opts.Recursive {
 			gitcmd.args = append(gitcmd.args, "--recursive")
 		}
+
+		if opts.Filter != "" {
+			gitcmd.args = append(gitcmd.args, "--filter="+opts.Filter)
+		}
+
+		if len(opts.SparseCheckout) > 0 {
+			gitcmd.args = append(gitcmd.args, "--sparse")
+		}
+
+		// LFS objects are fetched once the checkout is done, to apply the include and exclude patterns
+		if opts.LFS {
+			gitcmd.env = append(gitcmd.env, "GIT_LFS_SKIP_SMUDGE=1")
+		}
 	}
 
 	userLogCommand := "Executing: git " + strings.Join(gitcmd.args, " ") + " ...  "
-	gitcmd.args = append(gitcmd.args, repo)
+	if opts != nil && opts.MirrorURL != "" {
+		userLogCommand = "Executing: git " + strings.Join(gitcmd.args, " ") + " " + opts.MirrorURL + " ...  "
+		gitcmd.args = append(gitcmd.args, opts.MirrorURL)
+		gitcmd.env = append(gitcmd.env, mirrorGitConfig(opts))
+	} else {
+		gitcmd.args = append(gitcmd.args, repo)
+	}
 
 	if path != "" {
 		gitcmd.args = append(gitcmd.args, path)
@@ -84,23 +131,75 @@ func prepareGitCloneCommands(repo string, path string, opts *CloneOpts) (string,
 
 	allCmd = append(allCmd, gitcmd)
 
+	// next commands are run in the cloned directory
+	dir := path
+	if dir == "" {
+		t := strings.Split(repo, "/")
+		dir = strings.TrimSuffix(t[len(t)-1], ".git")
+	}
+
+	// the mirror is only used for the clone, then fetch the latest changes from the repository
+	if opts != nil && opts.MirrorURL != "" {
+		setURLCmd := cmd{
+			cmd:  "git",
+			args: []string{"remote", "set-url", "origin", repo},
+			dir:  dir,
+		}
+		allCmd = append(allCmd, setURLCmd)
+		userLogCommand += "\n\rExecuting: git remote set-url origin ..."
+
+		// the mirror can be late, when no commit is given update the branch from the repository
+		if opts.CheckoutCommit == "" && opts.Branch != "" && (opts.Tag == "" || opts.Tag == sdk.DefaultGitCloneParameterTagValue) {
+			fetchCmd := cmd{
+				cmd:  "git",
+				args: []string{"fetch", "origin", opts.Branch},
+				dir:  dir,
+			}
+			if opts.Depth != 0 {
+				fetchCmd.args = append(fetchCmd.args, "--depth", fmt.Sprintf("%d", opts.Depth))
+			}
+			resetCmd := cmd{
+				cmd:  "git",
+				args: []string{"reset", "--hard", "FETCH_HEAD"},
+				dir:  dir,
+			}
+			if opts.LFS {
+				fetchCmd.env = append(fetchCmd.env, "GIT_LFS_SKIP_SMUDGE=1")
+				resetCmd.env = append(resetCmd.env, "GIT_LFS_SKIP_SMUDGE=1")
+			}
+			userLogCommand += "\n\rExecuting: git " + strings.Join(fetchCmd.args, " ")
+			userLogCommand += "\n\rExecuting: git " + strings.Join(resetCmd.args, " ")
+			allCmd = append(allCmd, fetchCmd, resetCmd)
+		}
+	}
+
+	// the clone checked out the files at the root of the repository only, add the given directories
+	if opts != nil && len(opts.SparseCheckout) > 0 {
+		sparseCmd := cmd{
+			cmd:  "git",
+			args: append([]string{"sparse-checkout", "set"}, opts.SparseCheckout...),
+			dir:  dir,
+		}
+		userLogCommand += "\n\rExecuting: git " + strings.Join(sparseCmd.args, " ")
+		allCmd = append(allCmd, sparseCmd)
+	}
+
 	// if a specific commit hash is given, try to reset current repo to this commit
 	// when a tag is given the commit hash is ignored
 	if opts != nil && opts.CheckoutCommit != "" && opts.Tag == "" {
 		// if no branch was given, this means that we cloned the repo on the default branch, we need to fetch the target commit hash
 		// fetching a specific commit hash will not work for old git version (1.7 for example)
-		if opts.Branch == "" {
+		// the commit can also be missing in a late mirror
+		if opts.Branch == "" || opts.MirrorURL != "" {
 			fetchCmd := cmd{
 				cmd:  "git",
 				args: []string{"fetch", "origin", opts.CheckoutCommit},
 			}
 			userLogCommand += "\n\rExecuting: git " + strings.Join(fetchCmd.args, " ")
 			//Locate the git reset cmd to the right directory
-			if path == "" {
-				t := strings.Split(repo, "/")
-				fetchCmd.dir = strings.TrimSuffix(t[len(t)-1], ".git")
-			} else {
-				fetchCmd.dir = path
+			fetchCmd.dir = dir
+			if opts.LFS {
+				fetchCmd.env = append(fetchCmd.env, "GIT_LFS_SKIP_SMUDGE=1")
 			}
 
 			allCmd = append(allCmd, fetchCmd)
@@ -113,15 +212,47 @@ func prepareGitCloneCommands(repo string, path string, opts *CloneOpts) (string,
 		}
 		userLogCommand += "\n\rExecuting: git " + strings.Join(resetCmd.args, " ")
 		// locate the git reset cmd to the right directory
-		if path == "" {
-			t := strings.Split(repo, "/")
-			resetCmd.dir = strings.TrimSuffix(t[len(t)-1], ".git")
-		} else {
-			resetCmd.dir = path
+		resetCmd.dir = dir
+		if opts.LFS {
+			resetCmd.env = append(resetCmd.env, "GIT_LFS_SKIP_SMUDGE=1")
 		}
 
 		allCmd = append(allCmd, resetCmd)
 	}
 
+	if opts != nil && opts.LFS {
+		lfsCmd := cmd{
+			cmd:  "git",
+			args: []string{"lfs", "pull"},
+			dir:  dir,
+		}
+		if len(opts.LFSInclude) > 0 {
+			lfsCmd.args = append(lfsCmd.args, "--include="+strings.Join(opts.LFSInclude, ","))
+		}
+		if len(opts.LFSExclude) > 0 {
+			lfsCmd.args = append(lfsCmd.args, "--exclude="+strings.Join(opts.LFSExclude, ","))
+		}
+		userLogCommand += "\n\rExecuting: git " + strings.Join(lfsCmd.args, " ")
+		allCmd = append(allCmd, lfsCmd)
+	}
+
 	return userLogCommand, cmds(allCmd)
 }
+
+// mirrorGitConfig returns the environment variable that sets the http configuration of git to authenticate on the mirror,
+// it is not written in the repository configuration nor visible in the process list
+func mirrorGitConfig(opts *CloneOpts) string {
+	var params []string
+	if opts.MirrorUserAgent != "" {
+		params = append(params, "'http.useragent="+opts.MirrorUserAgent+"'")
+	}
+	keys := make([]string, 0, len(opts.MirrorHeaders))
+	for k := range opts.MirrorHeaders {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		params = append(params, "'http.extraheader="+k+": "+opts.MirrorHeaders[k]+"'")
+	}
+	return "GIT_CONFIG_PARAMETERS=" + strings.Join(params, " ")
+}
@@ -0,0 +1,33 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Mirror creates a bare mirror of the repository in path, or fetches all its refs if the mirror already exists
+func Mirror(repo string, path string, auth *AuthOpts, output *OutputOpts) error {
+	repoURL, err := getRepoURL(repo, auth)
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Stat(filepath.Join(path, "HEAD"))
+	exists := err == nil
+	return runGitCommands(repo, prepareGitMirrorCommands(repoURL, path, exists), auth, output)
+}
+
+func prepareGitMirrorCommands(repo string, path string, exists bool) cmds {
+	if !exists {
+		return cmds{
+			{cmd: "git", args: []string{"clone", "--mirror", repo, path}},
+			// allow partial clones from the mirror
+			{cmd: "git", args: []string{"config", "uploadpack.allowFilter", "true"}, dir: path},
+		}
+	}
+	return cmds{
+		// credentials may have changed since the creation of the mirror
+		{cmd: "git", args: []string{"remote", "set-url", "origin", repo}, dir: path},
+		{cmd: "git", args: []string{"remote", "update", "--prune"}, dir: path},
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/ovh/cds/engine/api/test"
 	"github.com/ovh/cds/sdk/vcs"
 )
@@ -157,6 +159,25 @@ func Test_gitCloneOverSSH(t *testing.T) {
 	}
 }
 
+func Test_mirrorGitConfig(t *testing.T) {
+	env := mirrorGitConfig(&CloneOpts{
+		MirrorUserAgent: "CDS/worker",
+		MirrorHeaders:   map[string]string{"X_AUTH_HEADER": "c2VjcmV0", "X-Requested-Name": "worker1"},
+	})
+	assert.Equal(t, "GIT_CONFIG_PARAMETERS='http.useragent=CDS/worker' 'http.extraheader=X-Requested-Name: worker1' 'http.extraheader=X_AUTH_HEADER: c2VjcmV0'", env)
+}
+
+func Test_gitMirrorCommand(t *testing.T) {
+	assert.Equal(t, []string{
+		"git clone --mirror https://github.com/ovh/cds.git /tmp/mirror",
+		"git config uploadpack.allowFilter true",
+	}, prepareGitMirrorCommands("https://github.com/ovh/cds.git", "/tmp/mirror", false).Strings())
+	assert.Equal(t, []string{
+		"git remote set-url origin https://github.com/ovh/cds.git",
+		"git remote update --prune",
+	}, prepareGitMirrorCommands("https://github.com/ovh/cds.git", "/tmp/mirror", true).Strings())
+}
+
 func Test_gitCommand(t *testing.T) {
 	type args struct {
 		repo string
@@ -226,6 +247,77 @@ func Test_gitCommand(t *testing.T) {
 				"git reset --hard eb8b87a",
 			},
 		},
+		{
+			name: "Partial clone with sparse checkout",
+			args: args{
+				repo: "https://github.com/ovh/cds.git",
+				path: "/tmp/Test_gitCommand-4",
+				opts: &CloneOpts{
+					Branch:         "master",
+					Filter:         "blob:none",
+					SparseCheckout: []string{"sdk", "engine/api"},
+				},
+			},
+			want: []string{
+				"git clone --branch master --filter=blob:none --sparse https://github.com/ovh/cds.git /tmp/Test_gitCommand-4",
+				"git sparse-checkout set sdk engine/api",
+			},
+		},
+		{
+			name: "Clone with LFS and checkout commit",
+			args: args{
+				repo: "https://github.com/ovh/cds.git",
+				opts: &CloneOpts{
+					Branch:         "master",
+					CheckoutCommit: "eb8b87a",
+					LFS:            true,
+					LFSInclude:     []string{"assets/**", "*.bin"},
+					LFSExclude:     []string{"assets/videos/**"},
+				},
+			},
+			want: []string{
+				"git clone --branch master https://github.com/ovh/cds.git",
+				"git reset --hard eb8b87a",
+				"git lfs pull --include=assets/**,*.bin --exclude=assets/videos/**",
+			},
+		},
+		{
+			name: "Clone from mirror and update branch from repository",
+			args: args{
+				repo: "https://github.com/ovh/cds.git",
+				path: "/tmp/Test_gitCommand-5",
+				opts: &CloneOpts{
+					Branch:        "master",
+					Depth:         50,
+					MirrorURL:     "http://localhost:8081/queue/workflows/1/mirror",
+					MirrorHeaders: map[string]string{"X_AUTH_HEADER": "c2VjcmV0"},
+				},
+			},
+			want: []string{
+				"git clone --depth 50 --branch master http://localhost:8081/queue/workflows/1/mirror /tmp/Test_gitCommand-5",
+				"git remote set-url origin https://github.com/ovh/cds.git",
+				"git fetch origin master --depth 50",
+				"git reset --hard FETCH_HEAD",
+			},
+		},
+		{
+			name: "Clone from mirror and fetch commit from repository",
+			args: args{
+				repo: "https://github.com/ovh/cds.git",
+				path: "/tmp/Test_gitCommand-6",
+				opts: &CloneOpts{
+					Branch:         "master",
+					CheckoutCommit: "eb8b87a",
+					MirrorURL:      "http://localhost:8081/queue/workflows/1/mirror",
+				},
+			},
+			want: []string{
+				"git clone --branch master http://localhost:8081/queue/workflows/1/mirror /tmp/Test_gitCommand-6",
+				"git remote set-url origin https://github.com/ovh/cds.git",
+				"git fetch origin eb8b87a",
+				"git reset --hard eb8b87a",
+			},
+		},
 	}
 	for _, tt := range tests {
 		os.RemoveAll(tt.args.path)
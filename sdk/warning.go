@@ -34,6 +34,7 @@ const (
 	WarningUnusedEnvironmentKey                    = "UNUSED_ENVIRONMENT_KEY"
 	WarningMissingPipelineParameter                = "MISSING_PIPELINE_PARAMETER"
 	WarningUnusedPipelineParameter                 = "UNUSED_PIPELINE_PARAMETER"
+	WarningWorkerModelImageUpdated                 = "WORKER_MODEL_IMAGE_UPDATED"
 )
 
 // Warning Represents warning database structure
@@ -79,6 +80,7 @@ var MessageAmericanEnglish = map[string]string{
 	WarningUnusedEnvironmentKey:                    `Unused key {{index . "KeyName"}} on project/environment {{index . "ProjectKey"}}/{{index . "EnvironmentName"}}.`,
 	WarningMissingPipelineParameter:                `Parameter {{index . "ParamName"}} is used but does not exist on project/pipeline {{index . "ProjectKey"}}/{{index . "PipelineName"}}`,
 	WarningUnusedPipelineParameter:                 `Unused parameter {{index . "ParamName"}} on project/pipeline {{index . "ProjectKey"}}/{{index . "PipelineName"}}.`,
+	WarningWorkerModelImageUpdated:                 `Image {{index . "Image"}} of worker model {{index . "ModelName"}} has been updated on registry {{index . "IntegrationName"}}: the model is pinned to {{index . "Digest"}}, save the model to use {{index . "NewDigest"}}.`,
 }
 
 var MessageFrench = map[string]string{
@@ -107,6 +109,7 @@ var MessageFrench = map[string]string{
 	WarningUnusedEnvironmentKey:                    `La clé {{index . "KeyName"}} est inutilisée dans l'environnement {{index . "ProjectKey"}}/{{index . "EnvironmentName"}}.`,
 	WarningMissingPipelineParameter:                `Le paramètre {{index . "ParamName"}} est utilisé mais n'existe pas dans le pipeline {{index . "ProjectKey"}}/{{index . "PipelineName"}}`,
 	WarningUnusedPipelineParameter:                 `Le paramètre {{index . "ParamName"}} est inutilisé dans le pipeline {{index . "ProjectKey"}}/{{index . "PipelineName"}}.`,
+	WarningWorkerModelImageUpdated:                 `L'image {{index . "Image"}} du modèle de worker {{index . "ModelName"}} a été mise à jour sur le registre {{index . "IntegrationName"}} : le modèle utilise {{index . "Digest"}}, sauvegardez le modèle pour utiliser {{index . "NewDigest"}}.`,
 }
 
 func (w *Warning) ComputeMessage(language string) {
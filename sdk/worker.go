@@ -13,6 +13,7 @@ type Worker struct {
 	LastBeat      time.Time `json:"lastbeat" cli:"lastbeat"`
 	GroupID       int64     `json:"group_id" cli:"-"`
 	ModelID       int64     `json:"model_id" cli:"-"`
+	ModelVersion  int64     `json:"model_version,omitempty" cli:"-"`
 	ActionBuildID int64     `json:"action_build_id" cli:"-"`
 	Model         *Model    `json:"model" cli:"-"`
 	HatcheryName  string    `json:"hatchery_name" cli:"-"`
@@ -27,6 +28,7 @@ type WorkerRegistrationForm struct {
 	Name               string
 	Token              string
 	ModelID            int64
+	ModelVersion       int64
 	HatcheryName       string
 	BinaryCapabilities []string
 	Version            string
@@ -123,6 +125,10 @@ type Model struct {
 	IsDeprecated           bool                `json:"is_deprecated" db:"is_deprecated" cli:"deprecated"`
 	IsOfficial             bool                `json:"is_official" db:"-" cli:"official"`
 	PatternName            string              `json:"pattern_name,omitempty" db:"-" cli:"-"`
+	Version                int64               `json:"version" db:"version" cli:"version"`
+	CanaryVersion          int64               `json:"canary_version,omitempty" db:"canary_version" cli:"canary_version"`
+	CanaryPercent          int64               `json:"canary_percent,omitempty" db:"canary_percent" cli:"canary_percent"`
+	Canary                 *ModelVersion       `json:"canary,omitempty" db:"-" cli:"-"`
 }
 
 // ModelVirtualMachine for openstack or vsphere
@@ -141,6 +147,14 @@ type ModelDocker struct {
 	Envs   map[string]string `json:"envs,omitempty"`
 	Shell  string            `json:"shell,omitempty"`
 	Cmd    string            `json:"cmd,omitempty"`
+	// Registry integration of a project, the image is pinned to its digest when the model is saved
+	RegistryProjectKey  string `json:"registry_project_key,omitempty"`
+	RegistryIntegration string `json:"registry_integration,omitempty"`
+	Digest              string `json:"digest,omitempty"`
+	// Registry credentials, only sent to hatcheries
+	Registry string `json:"registry,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
 }
 
 // ModelPattern represent patterns for users and admin when creating a worker model
@@ -168,6 +182,7 @@ type WorkerArgs struct {
 	BaseDir         string `json:"base_dir"`
 	HTTPInsecure    bool   `json:"http_insecure"`
 	Model           int64  `json:"model"`
+	ModelVersion    int64  `json:"model_version"`
 	HatcheryName    string `json:"hatchery_name"`
 	WorkflowJobID   int64  `json:"workflow_job_id"`
 	TTL             int    `json:"ttl"`
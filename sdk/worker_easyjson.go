@@ -74,7 +74,9 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk(in *jlexer.Lexer, out *Model) {
 				}
 				for !in.IsDelim(']') {
 					var v1 Requirement
-					(v1).UnmarshalEasyJSON(in)
+					if data := in.Raw(); in.Ok() {
+						in.AddError((v1).UnmarshalJSON(data))
+					}
 					out.RegisteredCapabilities = append(out.RegisteredCapabilities, v1)
 					in.WantComma()
 				}
@@ -136,6 +138,22 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk(in *jlexer.Lexer, out *Model) {
 			out.IsOfficial = bool(in.Bool())
 		case "pattern_name":
 			out.PatternName = string(in.String())
+		case "version":
+			out.Version = int64(in.Int64())
+		case "canary_version":
+			out.CanaryVersion = int64(in.Int64())
+		case "canary_percent":
+			out.CanaryPercent = int64(in.Int64())
+		case "canary":
+			if in.IsNull() {
+				in.Skip()
+				out.Canary = nil
+			} else {
+				if out.Canary == nil {
+					out.Canary = new(ModelVersion)
+				}
+				easyjson82a45abeDecodeGithubComOvhCdsSdk5(in, out.Canary)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -266,7 +284,7 @@ func easyjson82a45abeEncodeGithubComOvhCdsSdk(out *jwriter.Writer, in Model) {
 				if v2 > 0 {
 					out.RawByte(',')
 				}
-				(v3).MarshalEasyJSON(out)
+				out.Raw((v3).MarshalJSON())
 			}
 			out.RawByte(']')
 		}
@@ -449,6 +467,46 @@ func easyjson82a45abeEncodeGithubComOvhCdsSdk(out *jwriter.Writer, in Model) {
 		}
 		out.String(string(in.PatternName))
 	}
+	{
+		const prefix string = ",\"version\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Version))
+	}
+	if in.CanaryVersion != 0 {
+		const prefix string = ",\"canary_version\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.CanaryVersion))
+	}
+	if in.CanaryPercent != 0 {
+		const prefix string = ",\"canary_percent\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.CanaryPercent))
+	}
+	if in.Canary != nil {
+		const prefix string = ",\"canary\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		easyjson82a45abeEncodeGithubComOvhCdsSdk5(out, *in.Canary)
+	}
 	out.RawByte('}')
 }
 
@@ -475,6 +533,175 @@ func (v *Model) UnmarshalJSON(data []byte) error {
 func (v *Model) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson82a45abeDecodeGithubComOvhCdsSdk(l, v)
 }
+func easyjson82a45abeDecodeGithubComOvhCdsSdk5(in *jlexer.Lexer, out *ModelVersion) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int64(in.Int64())
+		case "worker_model_id":
+			out.ModelID = int64(in.Int64())
+		case "version":
+			out.Version = int64(in.Int64())
+		case "type":
+			out.Type = string(in.String())
+		case "model_docker":
+			easyjson82a45abeDecodeGithubComOvhCdsSdk2(in, &out.ModelDocker)
+		case "model_virtual_machine":
+			easyjson82a45abeDecodeGithubComOvhCdsSdk1(in, &out.ModelVirtualMachine)
+		case "created":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Created).UnmarshalJSON(data))
+			}
+		case "created_by":
+			out.CreatedBy = string(in.String())
+		case "image":
+			out.Image = string(in.String())
+		case "current":
+			out.Current = bool(in.Bool())
+		case "canary":
+			out.Canary = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson82a45abeEncodeGithubComOvhCdsSdk5(out *jwriter.Writer, in ModelVersion) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.ID))
+	}
+	{
+		const prefix string = ",\"worker_model_id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.ModelID))
+	}
+	{
+		const prefix string = ",\"version\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Version))
+	}
+	{
+		const prefix string = ",\"type\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Type))
+	}
+	if true {
+		const prefix string = ",\"model_docker\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		easyjson82a45abeEncodeGithubComOvhCdsSdk2(out, in.ModelDocker)
+	}
+	if true {
+		const prefix string = ",\"model_virtual_machine\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		easyjson82a45abeEncodeGithubComOvhCdsSdk1(out, in.ModelVirtualMachine)
+	}
+	{
+		const prefix string = ",\"created\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.Created).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"created_by\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.CreatedBy))
+	}
+	{
+		const prefix string = ",\"image\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Image))
+	}
+	{
+		const prefix string = ",\"current\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.Current))
+	}
+	{
+		const prefix string = ",\"canary\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.Canary))
+	}
+	out.RawByte('}')
+}
 func easyjson82a45abeDecodeGithubComOvhCdsSdk4(in *jlexer.Lexer, out *Group) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
@@ -561,7 +788,7 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk4(in *jlexer.Lexer, out *Group) {
 				}
 				for !in.IsDelim(']') {
 					var v6 Token
-					easyjson82a45abeDecodeGithubComOvhCdsSdk5(in, &v6)
+					easyjson82a45abeDecodeGithubComOvhCdsSdk6(in, &v6)
 					out.Tokens = append(out.Tokens, v6)
 					in.WantComma()
 				}
@@ -653,14 +880,14 @@ func easyjson82a45abeEncodeGithubComOvhCdsSdk4(out *jwriter.Writer, in Group) {
 				if v11 > 0 {
 					out.RawByte(',')
 				}
-				easyjson82a45abeEncodeGithubComOvhCdsSdk5(out, v12)
+				easyjson82a45abeEncodeGithubComOvhCdsSdk6(out, v12)
 			}
 			out.RawByte(']')
 		}
 	}
 	out.RawByte('}')
 }
-func easyjson82a45abeDecodeGithubComOvhCdsSdk5(in *jlexer.Lexer, out *Token) {
+func easyjson82a45abeDecodeGithubComOvhCdsSdk6(in *jlexer.Lexer, out *Token) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -707,7 +934,7 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk5(in *jlexer.Lexer, out *Token) {
 		in.Consumed()
 	}
 }
-func easyjson82a45abeEncodeGithubComOvhCdsSdk5(out *jwriter.Writer, in Token) {
+func easyjson82a45abeEncodeGithubComOvhCdsSdk6(out *jwriter.Writer, in Token) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -864,14 +1091,16 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk3(in *jlexer.Lexer, out *User) {
 				}
 				for !in.IsDelim(']') {
 					var v14 Favorite
-					easyjson82a45abeDecodeGithubComOvhCdsSdk6(in, &v14)
+					easyjson82a45abeDecodeGithubComOvhCdsSdk7(in, &v14)
 					out.Favorites = append(out.Favorites, v14)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
 		case "permissions":
-			(out.Permissions).UnmarshalEasyJSON(in)
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Permissions).UnmarshalJSON(data))
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -981,7 +1210,7 @@ func easyjson82a45abeEncodeGithubComOvhCdsSdk3(out *jwriter.Writer, in User) {
 				if v17 > 0 {
 					out.RawByte(',')
 				}
-				easyjson82a45abeEncodeGithubComOvhCdsSdk6(out, v18)
+				easyjson82a45abeEncodeGithubComOvhCdsSdk7(out, v18)
 			}
 			out.RawByte(']')
 		}
@@ -994,11 +1223,11 @@ func easyjson82a45abeEncodeGithubComOvhCdsSdk3(out *jwriter.Writer, in User) {
 		} else {
 			out.RawString(prefix)
 		}
-		(in.Permissions).MarshalEasyJSON(out)
+		out.Raw((in.Permissions).MarshalJSON())
 	}
 	out.RawByte('}')
 }
-func easyjson82a45abeDecodeGithubComOvhCdsSdk6(in *jlexer.Lexer, out *Favorite) {
+func easyjson82a45abeDecodeGithubComOvhCdsSdk7(in *jlexer.Lexer, out *Favorite) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1073,7 +1302,7 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk6(in *jlexer.Lexer, out *Favorite)
 		in.Consumed()
 	}
 }
-func easyjson82a45abeEncodeGithubComOvhCdsSdk6(out *jwriter.Writer, in Favorite) {
+func easyjson82a45abeEncodeGithubComOvhCdsSdk7(out *jwriter.Writer, in Favorite) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1168,6 +1397,18 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk2(in *jlexer.Lexer, out *ModelDocke
 			out.Shell = string(in.String())
 		case "cmd":
 			out.Cmd = string(in.String())
+		case "registry_project_key":
+			out.RegistryProjectKey = string(in.String())
+		case "registry_integration":
+			out.RegistryIntegration = string(in.String())
+		case "digest":
+			out.Digest = string(in.String())
+		case "registry":
+			out.Registry = string(in.String())
+		case "username":
+			out.Username = string(in.String())
+		case "password":
+			out.Password = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -1246,6 +1487,66 @@ func easyjson82a45abeEncodeGithubComOvhCdsSdk2(out *jwriter.Writer, in ModelDock
 		}
 		out.String(string(in.Cmd))
 	}
+	if in.RegistryProjectKey != "" {
+		const prefix string = ",\"registry_project_key\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.RegistryProjectKey))
+	}
+	if in.RegistryIntegration != "" {
+		const prefix string = ",\"registry_integration\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.RegistryIntegration))
+	}
+	if in.Digest != "" {
+		const prefix string = ",\"digest\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Digest))
+	}
+	if in.Registry != "" {
+		const prefix string = ",\"registry\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Registry))
+	}
+	if in.Username != "" {
+		const prefix string = ",\"username\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Username))
+	}
+	if in.Password != "" {
+		const prefix string = ",\"password\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Password))
+	}
 	out.RawByte('}')
 }
 func easyjson82a45abeDecodeGithubComOvhCdsSdk1(in *jlexer.Lexer, out *ModelVirtualMachine) {
@@ -0,0 +1,125 @@
+package sdk
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ModelVersion is an immutable version of the definition of a worker model, a new version is created each time
+// the image or the commands of the model are updated
+type ModelVersion struct {
+	ID                  int64               `json:"id" db:"id" cli:"-"`
+	ModelID             int64               `json:"worker_model_id" db:"worker_model_id" cli:"-"`
+	Version             int64               `json:"version" db:"version" cli:"version,key"`
+	Type                string              `json:"type" db:"type" cli:"type"`
+	ModelDocker         ModelDocker         `json:"model_docker,omitempty" db:"-" cli:"-"`
+	ModelVirtualMachine ModelVirtualMachine `json:"model_virtual_machine,omitempty" db:"-" cli:"-"`
+	Created             time.Time           `json:"created" db:"created" cli:"created"`
+	CreatedBy           string              `json:"created_by" db:"created_by" cli:"created_by"`
+	Image               string              `json:"image" db:"-" cli:"image"`
+	Current             bool                `json:"current" db:"-" cli:"current"`
+	Canary              bool                `json:"canary" db:"-" cli:"canary"`
+}
+
+// ModelCanary is the canary rollout of a version of a worker model, the given percentage of the jobs is sent to this version
+type ModelCanary struct {
+	Version int64 `json:"version"`
+	Percent int64 `json:"percent"`
+}
+
+// ModelRollback is the version to restore as current version of a worker model
+type ModelRollback struct {
+	Version int64 `json:"version"`
+}
+
+// IsValid returns an error if the canary rollout is invalid
+func (c ModelCanary) IsValid() error {
+	if c.Version <= 0 {
+		return NewErrorFrom(ErrWrongRequest, "invalid canary version %d", c.Version)
+	}
+	if c.Percent <= 0 || c.Percent >= 100 {
+		return NewErrorFrom(ErrWrongRequest, "canary percentage must be between 1 and 99")
+	}
+	return nil
+}
+
+// NewModelVersion returns the definition of a worker model as a version
+func NewModelVersion(m Model) ModelVersion {
+	v := ModelVersion{
+		ModelID: m.ID,
+		Version: m.Version,
+		Type:    m.Type,
+	}
+	if m.Type == Docker {
+		v.ModelDocker = m.ModelDocker
+		v.ModelDocker.Registry = ""
+		v.ModelDocker.Username = ""
+		v.ModelDocker.Password = ""
+	} else {
+		v.ModelVirtualMachine = m.ModelVirtualMachine
+	}
+	return v
+}
+
+// SameDefinition returns true if two versions have the same image and commands
+func (v ModelVersion) SameDefinition(other ModelVersion) bool {
+	if v.Type != other.Type {
+		return false
+	}
+	if v.Type != Docker {
+		return v.ModelVirtualMachine == other.ModelVirtualMachine
+	}
+	a, b := v.ModelDocker, other.ModelDocker
+	a.Registry, a.Username, a.Password = "", "", ""
+	b.Registry, b.Username, b.Password = "", "", ""
+	if len(a.Envs) == 0 && len(b.Envs) == 0 {
+		a.Envs, b.Envs = nil, nil
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// WithVersion returns the worker model with the definition of the given version,
+// registry credentials of the model are kept
+func (m Model) WithVersion(v ModelVersion) Model {
+	res := m
+	res.Version = v.Version
+	res.Canary = nil
+	if v.Type == Docker {
+		res.ModelDocker = v.ModelDocker
+		res.ModelDocker.Registry = m.ModelDocker.Registry
+		res.ModelDocker.Username = m.ModelDocker.Username
+		res.ModelDocker.Password = m.ModelDocker.Password
+	} else {
+		res.ModelVirtualMachine = v.ModelVirtualMachine
+	}
+	return res
+}
+
+// ForJob returns the version of the worker model to spawn for a job, the canary version is used
+// for the given percentage of the jobs
+func (m Model) ForJob(jobID int64) Model {
+	if m.Canary == nil || m.CanaryPercent <= 0 || jobID <= 0 {
+		return m
+	}
+	if jobID%100 < m.CanaryPercent {
+		return m.WithVersion(*m.Canary)
+	}
+	return m
+}
+
+// ParseModelRequirement returns the worker model name and the pinned version of a model requirement value.
+// A model requirement can be pinned to a version with name@version, ie: go-build@3 --port=8080:8080
+func ParseModelRequirement(value string) (string, int64) {
+	name := strings.Split(value, " ")[0]
+	i := strings.LastIndex(name, "@")
+	if i <= 0 {
+		return name, 0
+	}
+	version, err := strconv.ParseInt(name[i+1:], 10, 64)
+	if err != nil || version <= 0 {
+		return name, 0
+	}
+	return name[:i], version
+}
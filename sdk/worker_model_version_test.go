@@ -0,0 +1,65 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseModelRequirement(t *testing.T) {
+	tests := []struct {
+		value   string
+		name    string
+		version int64
+	}{
+		{"go-build", "go-build", 0},
+		{"go-build@3", "go-build", 3},
+		{"go-build@3 --port=8080:8080", "go-build", 3},
+		{"shared.infra/go-build@12", "shared.infra/go-build", 12},
+		{"go-build@latest", "go-build@latest", 0},
+		{"go-build --port=8080:8080", "go-build", 0},
+	}
+	for _, tt := range tests {
+		name, version := ParseModelRequirement(tt.value)
+		assert.Equal(t, tt.name, name, tt.value)
+		assert.Equal(t, tt.version, version, tt.value)
+	}
+}
+
+func TestModelForJob(t *testing.T) {
+	m := Model{
+		Name:          "go-build",
+		Type:          Docker,
+		Version:       3,
+		ModelDocker:   ModelDocker{Image: "golang:1.11", Username: "foo"},
+		CanaryVersion: 4,
+		CanaryPercent: 10,
+		Canary:        &ModelVersion{Version: 4, Type: Docker, ModelDocker: ModelDocker{Image: "golang:1.12"}},
+	}
+
+	var canary int
+	for id := int64(1); id <= 1000; id++ {
+		res := m.ForJob(id)
+		if res.Version == 4 {
+			canary++
+			assert.Equal(t, "golang:1.12", res.ModelDocker.Image)
+			assert.Equal(t, "foo", res.ModelDocker.Username)
+			assert.Nil(t, res.Canary)
+		} else {
+			assert.Equal(t, "golang:1.11", res.ModelDocker.Image)
+		}
+	}
+	assert.Equal(t, 100, canary)
+
+	m.CanaryPercent = 0
+	assert.Equal(t, int64(3), m.ForJob(5).Version)
+}
+
+func TestModelVersionSameDefinition(t *testing.T) {
+	a := ModelVersion{Type: Docker, ModelDocker: ModelDocker{Image: "golang:1.11", Envs: map[string]string{"A": "1"}}}
+	b := ModelVersion{Type: Docker, ModelDocker: ModelDocker{Image: "golang:1.11", Envs: map[string]string{"A": "1"}, Password: "secret"}}
+	assert.True(t, a.SameDefinition(b))
+	b.ModelDocker.Envs["A"] = "2"
+	assert.False(t, a.SameDefinition(b))
+	assert.False(t, a.SameDefinition(ModelVersion{Type: Openstack}))
+}
@@ -0,0 +1,165 @@
+package sdk
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Workflow node run approval statuses
+const (
+	ApprovalStatusPending  = "Pending"
+	ApprovalStatusApproved = "Approved"
+	ApprovalStatusRejected = "Rejected"
+	ApprovalStatusExpired  = "Expired"
+)
+
+// NodeApprovalGate is the configuration of a workflow node which has to be approved
+// before being executed.
+type NodeApprovalGate struct {
+	// Groups allowed to approve, if empty every user with execute permission on the node can approve
+	Groups []string `json:"groups,omitempty" yaml:"groups,omitempty"`
+	// Count is the number of required approvals
+	Count int `json:"count,omitempty" yaml:"count,omitempty"`
+	// FourEyes forbids the committer to approve
+	FourEyes bool `json:"four_eyes,omitempty" yaml:"four_eyes,omitempty"`
+	// Expiry in minutes, the node run fails if it has not been approved in time. 0 means no expiry
+	Expiry int64 `json:"expiry,omitempty" yaml:"expiry,omitempty"`
+}
+
+// RequiredApprovals returns the number of approvals needed to run the node
+func (g NodeApprovalGate) RequiredApprovals() int {
+	if g.Count < 1 {
+		return 1
+	}
+	return g.Count
+}
+
+// WorkflowNodeRunApproval is the approval request of a workflow node run
+type WorkflowNodeRunApproval struct {
+	ID                int64                          `json:"id" db:"id"`
+	WorkflowID        int64                          `json:"workflow_id" db:"workflow_id"`
+	WorkflowRunID     int64                          `json:"workflow_run_id" db:"workflow_run_id"`
+	WorkflowNodeRunID int64                          `json:"workflow_node_run_id" db:"workflow_node_run_id"`
+	WorkflowNodeName  string                         `json:"workflow_node_name" db:"workflow_node_name"`
+	Status            string                         `json:"status" db:"status"`
+	Committer         string                         `json:"committer" db:"committer"`
+	Token             string                         `json:"-" db:"token"`
+	Created           time.Time                      `json:"created" db:"created"`
+	Expire            time.Time                      `json:"expire,omitempty" db:"expire"`
+	Reviews           WorkflowNodeRunApprovalReviews `json:"reviews" db:"reviews"`
+	Gate              NodeApprovalGate               `json:"gate" db:"-"`
+	CallbackURL       string                         `json:"callback_url,omitempty" db:"-"`
+}
+
+// WorkflowNodeRunApprovalReview is the decision of a reviewer on an approval request
+type WorkflowNodeRunApprovalReview struct {
+	Username string    `json:"username"`
+	Fullname string    `json:"fullname"`
+	Approved bool      `json:"approved"`
+	Comment  string    `json:"comment,omitempty"`
+	Created  time.Time `json:"created"`
+}
+
+// WorkflowNodeRunApprovalReviews is a list of reviews stored as JSON
+type WorkflowNodeRunApprovalReviews []WorkflowNodeRunApprovalReview
+
+// Value returns driver.Value from workflow node run approval reviews.
+func (r WorkflowNodeRunApprovalReviews) Value() (driver.Value, error) {
+	j, err := json.Marshal(r)
+	return j, WrapError(err, "cannot marshal WorkflowNodeRunApprovalReviews")
+}
+
+// Scan workflow node run approval reviews.
+func (r *WorkflowNodeRunApprovalReviews) Scan(src interface{}) error {
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(errors.New("type assertion .([]byte) failed"))
+	}
+	return WrapError(json.Unmarshal(source, r), "cannot unmarshal WorkflowNodeRunApprovalReviews")
+}
+
+// WorkflowNodeRunApprovalRequest is the body sent to approve or reject a workflow node run
+type WorkflowNodeRunApprovalRequest struct {
+	Approved bool   `json:"approved"`
+	Comment  string `json:"comment"`
+}
+
+// IsExpired returns true if the approval has an expiry and has expired
+func (a WorkflowNodeRunApproval) IsExpired(t time.Time) bool {
+	return !a.Expire.IsZero() && a.Expire.Before(t)
+}
+
+// Approvals returns the number of distinct users who approved
+func (a WorkflowNodeRunApproval) Approvals() int {
+	var n int
+	for _, r := range a.Reviews {
+		if r.Approved {
+			n++
+		}
+	}
+	return n
+}
+
+// AddReview checks that the user is allowed to review the approval request, then records
+// the review and computes the new status of the request.
+func (a *WorkflowNodeRunApproval) AddReview(u *User, approved bool, comment string, t time.Time) error {
+	if a.Status != ApprovalStatusPending {
+		return NewErrorFrom(ErrWorkflowNodeRunApprovalNotPending, "approval of %s is %s", a.WorkflowNodeName, strings.ToLower(a.Status))
+	}
+	if a.IsExpired(t) {
+		return NewErrorFrom(ErrWorkflowNodeRunApprovalNotPending, "approval of %s has expired", a.WorkflowNodeName)
+	}
+
+	if len(a.Gate.Groups) > 0 {
+		var inGroup bool
+		for _, g := range u.Groups {
+			if IsInArray(g.Name, a.Gate.Groups) {
+				inGroup = true
+				break
+			}
+		}
+		if !inGroup {
+			return NewErrorFrom(ErrWorkflowNodeRunApprovalForbidden, "user %s is not a member of groups %s", u.Username, strings.Join(a.Gate.Groups, ","))
+		}
+	}
+
+	if a.Gate.FourEyes && a.Committer != "" {
+		for _, id := range []string{u.Username, u.Fullname, u.Email} {
+			if id != "" && strings.EqualFold(id, a.Committer) {
+				return NewErrorFrom(ErrWorkflowNodeRunApprovalForbidden, "the committer %s cannot approve", a.Committer)
+			}
+		}
+	}
+
+	for _, r := range a.Reviews {
+		if r.Username == u.Username {
+			return NewErrorFrom(ErrWorkflowNodeRunApprovalForbidden, "user %s has already reviewed", u.Username)
+		}
+	}
+
+	a.Reviews = append(a.Reviews, WorkflowNodeRunApprovalReview{
+		Username: u.Username,
+		Fullname: u.Fullname,
+		Approved: approved,
+		Comment:  comment,
+		Created:  t,
+	})
+
+	switch {
+	case !approved:
+		a.Status = ApprovalStatusRejected
+	case a.Approvals() >= a.Gate.RequiredApprovals():
+		a.Status = ApprovalStatusApproved
+	}
+	return nil
+}
+
+// String returns a human readable summary of the approval
+func (a WorkflowNodeRunApproval) String() string {
+	return fmt.Sprintf("%s: %s (%d/%d)", a.WorkflowNodeName, a.Status, a.Approvals(), a.Gate.RequiredApprovals())
+}
@@ -0,0 +1,60 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowNodeRunApprovalAddReview(t *testing.T) {
+	now := time.Now()
+	a := WorkflowNodeRunApproval{
+		WorkflowNodeName: "deploy",
+		Status:           ApprovalStatusPending,
+		Committer:        "john.doe",
+		Expire:           now.Add(time.Hour),
+		Gate:             NodeApprovalGate{Groups: []string{"ops"}, Count: 2, FourEyes: true},
+	}
+
+	ops := Group{Name: "ops"}
+	john := &User{Username: "john.doe", Groups: []Group{ops}}
+	jane := &User{Username: "jane", Groups: []Group{ops}}
+	bob := &User{Username: "bob", Groups: []Group{ops}}
+	dev := &User{Username: "dev", Groups: []Group{{Name: "dev"}}}
+
+	// Reviewers must be members of the groups of the gate
+	assert.True(t, ErrorIs(a.AddReview(dev, true, "", now), ErrWorkflowNodeRunApprovalForbidden))
+	// The committer cannot approve with the four-eyes rule
+	assert.True(t, ErrorIs(a.AddReview(john, true, "", now), ErrWorkflowNodeRunApprovalForbidden))
+
+	assert.NoError(t, a.AddReview(jane, true, "lgtm", now))
+	assert.Equal(t, ApprovalStatusPending, a.Status)
+	// A user can't review twice
+	assert.True(t, ErrorIs(a.AddReview(jane, true, "", now), ErrWorkflowNodeRunApprovalForbidden))
+
+	assert.NoError(t, a.AddReview(bob, true, "", now))
+	assert.Equal(t, ApprovalStatusApproved, a.Status)
+	assert.Equal(t, 2, a.Approvals())
+	assert.Len(t, a.Reviews, 2)
+	assert.Equal(t, "lgtm", a.Reviews[0].Comment)
+
+	// The approval is over
+	assert.True(t, ErrorIs(a.AddReview(dev, false, "", now), ErrWorkflowNodeRunApprovalNotPending))
+}
+
+func TestWorkflowNodeRunApprovalReject(t *testing.T) {
+	now := time.Now()
+	a := WorkflowNodeRunApproval{
+		WorkflowNodeName: "deploy",
+		Status:           ApprovalStatusPending,
+		Expire:           now.Add(time.Minute),
+		Gate:             NodeApprovalGate{Count: 2},
+	}
+
+	// Expired approvals can't be reviewed
+	assert.True(t, ErrorIs(a.AddReview(&User{Username: "jane"}, true, "", now.Add(time.Hour)), ErrWorkflowNodeRunApprovalNotPending))
+
+	assert.NoError(t, a.AddReview(&User{Username: "jane"}, false, "not during the sales", now))
+	assert.Equal(t, ApprovalStatusRejected, a.Status)
+}
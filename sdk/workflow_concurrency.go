@@ -0,0 +1,37 @@
+package sdk
+
+// Workflow concurrency policies, applied when a run starts while another run of the same concurrency group is in progress
+const (
+	// ConcurrencyPolicyCancelInProgress stops the runs in progress
+	ConcurrencyPolicyCancelInProgress = "cancel-in-progress"
+	// ConcurrencyPolicyQueue starts the new run once the runs in progress are over
+	ConcurrencyPolicyQueue = "queue"
+	// ConcurrencyPolicySkipIfNewerPending queues the new run, only the newest queued run is started
+	ConcurrencyPolicySkipIfNewerPending = "skip-if-newer-pending"
+)
+
+// Workflow run tags set by concurrency groups
+const (
+	WorkflowRunTagConcurrency       = "concurrency"
+	WorkflowRunTagConcurrencyQueued = "concurrency.queued"
+	WorkflowRunTagSupersededBy      = "superseded_by"
+)
+
+// WorkflowConcurrency defines the concurrency group of the runs of a workflow. The key is
+// interpolated with the build parameters of the root node, ie. {{.git.branch}}
+type WorkflowConcurrency struct {
+	Key    string `json:"key" yaml:"key"`
+	Policy string `json:"policy" yaml:"policy"`
+}
+
+// IsValid checks the policy of the concurrency group
+func (c WorkflowConcurrency) IsValid() error {
+	if c.Key == "" {
+		return NewErrorFrom(ErrWorkflowInvalid, "concurrency key is mandatory")
+	}
+	switch c.Policy {
+	case ConcurrencyPolicyCancelInProgress, ConcurrencyPolicyQueue, ConcurrencyPolicySkipIfNewerPending:
+		return nil
+	}
+	return NewErrorFrom(ErrWorkflowInvalid, "invalid concurrency policy %s", c.Policy)
+}
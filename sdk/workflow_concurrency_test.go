@@ -0,0 +1,15 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowConcurrencyIsValid(t *testing.T) {
+	assert.NoError(t, WorkflowConcurrency{Key: "{{.git.branch}}", Policy: ConcurrencyPolicyCancelInProgress}.IsValid())
+	assert.NoError(t, WorkflowConcurrency{Key: "{{.git.branch}}", Policy: ConcurrencyPolicyQueue}.IsValid())
+	assert.NoError(t, WorkflowConcurrency{Key: "deploy", Policy: ConcurrencyPolicySkipIfNewerPending}.IsValid())
+	assert.Error(t, WorkflowConcurrency{Policy: ConcurrencyPolicyQueue}.IsValid())
+	assert.Error(t, WorkflowConcurrency{Key: "{{.git.branch}}", Policy: "unknown"}.IsValid())
+}
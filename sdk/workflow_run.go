@@ -143,6 +143,17 @@ func (r *WorkflowRun) TagExists(tag string) bool {
 	return false
 }
 
+// RemoveTag removes a tag and all its values
+func (r *WorkflowRun) RemoveTag(tag string) {
+	tags := r.Tags[:0]
+	for _, t := range r.Tags {
+		if t.Tag != tag {
+			tags = append(tags, t)
+		}
+	}
+	r.Tags = tags
+}
+
 // TODO remove old struct
 func (r *WorkflowRun) RootRun() *WorkflowNodeRun {
 	rootNodeRuns, has := r.WorkflowNodeRuns[r.Workflow.WorkflowData.Node.ID]
@@ -207,6 +218,7 @@ type WorkflowNodeRun struct {
 	WorkflowRunID          int64                                `json:"workflow_run_id"`
 	WorkflowID             int64                                `json:"workflow_id"`
 	ApplicationID          int64                                `json:"application_id"`
+	EnvironmentID          int64                                `json:"environment_id,omitempty"`
 	ID                     int64                                `json:"id"`
 	WorkflowNodeID         int64                                `json:"workflow_node_id"`
 	WorkflowNodeName       string                               `json:"workflow_node_name"`
@@ -436,6 +448,7 @@ type WorkflowNodeRunManual struct {
 	Payload            interface{} `json:"payload" db:"-"`
 	PipelineParameters []Parameter `json:"pipeline_parameter" db:"-"`
 	User               User        `json:"user" db:"-"`
+	OverrideFreeze     bool        `json:"override_freeze,omitempty" db:"-"`
 }
 
 //GetName returns the name the artifact
@@ -0,0 +1,16 @@
+package sdk
+
+// Debug session message types
+const (
+	DebugSessionMessageData   = "data"
+	DebugSessionMessageResize = "resize"
+	DebugSessionMessageClose  = "close"
+)
+
+// DebugSessionMessage is exchanged through the API between a user and a worker kept alive after a job failure.
+type DebugSessionMessage struct {
+	Type string `json:"type"`
+	Data []byte `json:"data,omitempty"`
+	Cols uint16 `json:"cols,omitempty"`
+	Rows uint16 `json:"rows,omitempty"`
+}
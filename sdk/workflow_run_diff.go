@@ -0,0 +1,246 @@
+package sdk
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ovh/venom"
+)
+
+// WorkflowRunDiff is the comparison of two runs of a workflow
+type WorkflowRunDiff struct {
+	FromNumber int64                      `json:"from_number"`
+	ToNumber   int64                      `json:"to_number"`
+	FromHash   string                     `json:"from_hash"`
+	ToHash     string                     `json:"to_hash"`
+	Parameters []WorkflowRunParameterDiff `json:"parameters"`
+	Nodes      []WorkflowRunNodeDiff      `json:"nodes"`
+	Tests      []WorkflowRunTestDiff      `json:"tests"`
+	Commits    []VCSCommit                `json:"commits"`
+}
+
+// WorkflowRunParameterDiff is a build parameter with a different value in the two runs
+type WorkflowRunParameterDiff struct {
+	Name string `json:"name"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// WorkflowRunNodeDiff compares the runs of a node, durations are in seconds
+type WorkflowRunNodeDiff struct {
+	Name             string   `json:"name"`
+	FromStatus       string   `json:"from_status"`
+	ToStatus         string   `json:"to_status"`
+	FromDuration     int64    `json:"from_duration"`
+	ToDuration       int64    `json:"to_duration"`
+	FromWorkerModels []string `json:"from_worker_models,omitempty"`
+	ToWorkerModels   []string `json:"to_worker_models,omitempty"`
+}
+
+// WorkflowRunTestDiff is a test case which changed status between the two runs
+type WorkflowRunTestDiff struct {
+	Node       string `json:"node"`
+	Suite      string `json:"suite"`
+	Name       string `json:"name"`
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+}
+
+// Test case status used in runs comparison
+const (
+	TestStatusSuccess = "success"
+	TestStatusFail    = "fail"
+	TestStatusSkipped = "skipped"
+	TestStatusMissing = "missing"
+)
+
+// DiffWorkflowRuns compares two runs of a workflow, commits are not computed
+func DiffWorkflowRuns(from, to WorkflowRun) WorkflowRunDiff {
+	diff := WorkflowRunDiff{
+		FromNumber: from.Number,
+		ToNumber:   to.Number,
+		Parameters: []WorkflowRunParameterDiff{},
+		Nodes:      []WorkflowRunNodeDiff{},
+		Tests:      []WorkflowRunTestDiff{},
+		Commits:    []VCSCommit{},
+	}
+
+	fromNodes := lastNodeRunsByName(from)
+	toNodes := lastNodeRunsByName(to)
+
+	if r := from.RootRun(); r != nil {
+		diff.FromHash = r.VCSHash
+	}
+	if r := to.RootRun(); r != nil {
+		diff.ToHash = r.VCSHash
+	}
+
+	// Build parameters of the root node runs
+	var fromParams, toParams []Parameter
+	if r := from.RootRun(); r != nil {
+		fromParams = r.BuildParameters
+	}
+	if r := to.RootRun(); r != nil {
+		toParams = r.BuildParameters
+	}
+	diff.Parameters = diffParameters(fromParams, toParams)
+
+	names := make(map[string]struct{}, len(fromNodes)+len(toNodes))
+	for n := range fromNodes {
+		names[n] = struct{}{}
+	}
+	for n := range toNodes {
+		names[n] = struct{}{}
+	}
+	sortedNames := make([]string, 0, len(names))
+	for n := range names {
+		sortedNames = append(sortedNames, n)
+	}
+	sort.Strings(sortedNames)
+
+	for _, name := range sortedNames {
+		f, hasFrom := fromNodes[name]
+		t, hasTo := toNodes[name]
+		nodeDiff := WorkflowRunNodeDiff{Name: name}
+		if hasFrom {
+			nodeDiff.FromStatus = f.Status
+			nodeDiff.FromDuration = nodeRunDuration(f)
+			nodeDiff.FromWorkerModels = nodeRunWorkerModels(f)
+		}
+		if hasTo {
+			nodeDiff.ToStatus = t.Status
+			nodeDiff.ToDuration = nodeRunDuration(t)
+			nodeDiff.ToWorkerModels = nodeRunWorkerModels(t)
+		}
+		diff.Nodes = append(diff.Nodes, nodeDiff)
+
+		var fromTests, toTests map[string]string
+		if hasFrom {
+			fromTests = testCasesStatus(f.Tests)
+		}
+		if hasTo {
+			toTests = testCasesStatus(t.Tests)
+		}
+		diff.Tests = append(diff.Tests, diffTestCases(name, fromTests, toTests)...)
+	}
+
+	return diff
+}
+
+// lastNodeRunsByName returns the last sub run of each node of a workflow run
+func lastNodeRunsByName(r WorkflowRun) map[string]WorkflowNodeRun {
+	res := make(map[string]WorkflowNodeRun, len(r.WorkflowNodeRuns))
+	for _, nrs := range r.WorkflowNodeRuns {
+		if len(nrs) == 0 {
+			continue
+		}
+		nr := nrs[0]
+		name := nr.WorkflowNodeName
+		if name == "" {
+			if n := r.Workflow.WorkflowData.NodeByID(nr.WorkflowNodeID); n != nil {
+				name = n.Name
+			}
+		}
+		res[name] = nr
+	}
+	return res
+}
+
+func nodeRunDuration(nr WorkflowNodeRun) int64 {
+	if nr.Start.IsZero() || nr.Done.IsZero() || nr.Done.Before(nr.Start) {
+		return 0
+	}
+	return int64(nr.Done.Sub(nr.Start).Seconds())
+}
+
+func nodeRunWorkerModels(nr WorkflowNodeRun) []string {
+	var models []string
+	for _, s := range nr.Stages {
+		for _, rj := range s.RunJobs {
+			if rj.Model != "" && !IsInArray(rj.Model, models) {
+				models = append(models, rj.Model)
+			}
+		}
+	}
+	sort.Strings(models)
+	return models
+}
+
+func diffParameters(from, to []Parameter) []WorkflowRunParameterDiff {
+	fromValues := make(map[string]string, len(from))
+	for _, p := range from {
+		fromValues[p.Name] = p.Value
+	}
+	toValues := make(map[string]string, len(to))
+	for _, p := range to {
+		toValues[p.Name] = p.Value
+	}
+
+	res := []WorkflowRunParameterDiff{}
+	for name, f := range fromValues {
+		if t, ok := toValues[name]; !ok || t != f {
+			res = append(res, WorkflowRunParameterDiff{Name: name, From: f, To: toValues[name]})
+		}
+	}
+	for name, t := range toValues {
+		if _, ok := fromValues[name]; !ok {
+			res = append(res, WorkflowRunParameterDiff{Name: name, To: t})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+// testCasesStatus returns the status of each test case, indexed by suite and test name
+func testCasesStatus(tests *venom.Tests) map[string]string {
+	res := map[string]string{}
+	if tests == nil {
+		return res
+	}
+	for _, ts := range tests.TestSuites {
+		for _, tc := range ts.TestCases {
+			status := TestStatusSuccess
+			switch {
+			case len(tc.Failures) > 0 || len(tc.Errors) > 0:
+				status = TestStatusFail
+			case len(tc.Skipped) > 0:
+				status = TestStatusSkipped
+			}
+			res[ts.Name+"\x00"+tc.Name] = status
+		}
+	}
+	return res
+}
+
+func diffTestCases(node string, from, to map[string]string) []WorkflowRunTestDiff {
+	res := []WorkflowRunTestDiff{}
+	keys := map[string]struct{}{}
+	for k := range from {
+		keys[k] = struct{}{}
+	}
+	for k := range to {
+		keys[k] = struct{}{}
+	}
+	for k := range keys {
+		f, ok := from[k]
+		if !ok {
+			f = TestStatusMissing
+		}
+		t, ok := to[k]
+		if !ok {
+			t = TestStatusMissing
+		}
+		if f == t {
+			continue
+		}
+		suiteAndName := strings.SplitN(k, "\x00", 2)
+		res = append(res, WorkflowRunTestDiff{Node: node, Suite: suiteAndName[0], Name: suiteAndName[1], FromStatus: f, ToStatus: t})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Suite != res[j].Suite {
+			return res[i].Suite < res[j].Suite
+		}
+		return res[i].Name < res[j].Name
+	})
+	return res
+}
@@ -0,0 +1,63 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ovh/venom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffWorkflowRuns(t *testing.T) {
+	start := time.Now()
+	newRun := func(number int64, hash, status string, duration time.Duration, tests []venom.TestCase) WorkflowRun {
+		return WorkflowRun{
+			Number:   number,
+			Workflow: Workflow{WorkflowData: &WorkflowData{Node: Node{ID: 1, Name: "build"}}},
+			WorkflowNodeRuns: map[int64][]WorkflowNodeRun{
+				1: {{
+					WorkflowNodeID:   1,
+					WorkflowNodeName: "build",
+					Status:           status,
+					Start:            start,
+					Done:             start.Add(duration),
+					VCSHash:          hash,
+					BuildParameters: []Parameter{
+						{Name: "git.hash", Value: hash},
+						{Name: "cds.version", Value: "1"},
+					},
+					Stages: []Stage{{RunJobs: []WorkflowNodeJobRun{{Model: "go-" + hash}}}},
+					Tests:  &venom.Tests{TestSuites: []venom.TestSuite{{Name: "suite", TestCases: tests}}},
+				}},
+			},
+		}
+	}
+
+	from := newRun(1, "aaa", StatusSuccess.String(), time.Minute, []venom.TestCase{
+		{Name: "TestA"},
+		{Name: "TestB"},
+	})
+	to := newRun(2, "bbb", StatusFail.String(), 2*time.Minute, []venom.TestCase{
+		{Name: "TestA"},
+		{Name: "TestB", Failures: []venom.Failure{{Value: "boom"}}},
+		{Name: "TestC"},
+	})
+
+	diff := DiffWorkflowRuns(from, to)
+	assert.Equal(t, "aaa", diff.FromHash)
+	assert.Equal(t, "bbb", diff.ToHash)
+	assert.Equal(t, []WorkflowRunParameterDiff{{Name: "git.hash", From: "aaa", To: "bbb"}}, diff.Parameters)
+	assert.Equal(t, []WorkflowRunNodeDiff{{
+		Name:             "build",
+		FromStatus:       StatusSuccess.String(),
+		ToStatus:         StatusFail.String(),
+		FromDuration:     60,
+		ToDuration:       120,
+		FromWorkerModels: []string{"go-aaa"},
+		ToWorkerModels:   []string{"go-bbb"},
+	}}, diff.Nodes)
+	assert.Equal(t, []WorkflowRunTestDiff{
+		{Node: "build", Suite: "suite", Name: "TestB", FromStatus: TestStatusSuccess, ToStatus: TestStatusFail},
+		{Node: "build", Suite: "suite", Name: "TestC", FromStatus: TestStatusMissing, ToStatus: TestStatusSuccess},
+	}, diff.Tests)
+}
@@ -2264,6 +2264,8 @@ func easyjsonD7860c2dDecodeGithubComOvhCdsSdk1(in *jlexer.Lexer, out *ExecutedJo
 			out.WorkerName = string(in.String())
 		case "worker_id":
 			out.WorkerID = string(in.String())
+		case "worker_model_version":
+			out.WorkerModelVersion = int64(in.Int64())
 		case "pipeline_action_id":
 			out.PipelineActionID = int64(in.Int64())
 		case "pipeline_stage_id":
@@ -2362,6 +2364,16 @@ func easyjsonD7860c2dEncodeGithubComOvhCdsSdk1(out *jwriter.Writer, in ExecutedJ
 		}
 		out.String(string(in.WorkerID))
 	}
+	if in.WorkerModelVersion != 0 {
+		const prefix string = ",\"worker_model_version\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.WorkerModelVersion))
+	}
 	{
 		const prefix string = ",\"pipeline_action_id\":"
 		if first {
@@ -2535,12 +2547,41 @@ func easyjsonD7860c2dDecodeGithubComOvhCdsSdk14(in *jlexer.Lexer, out *Action) {
 			out.Enabled = bool(in.Bool())
 		case "deprecated":
 			out.Deprecated = bool(in.Bool())
+		case "outputs":
+			if in.IsNull() {
+				in.Skip()
+				out.Outputs = nil
+			} else {
+				in.Delim('[')
+				if out.Outputs == nil {
+					if !in.IsDelim(']') {
+						out.Outputs = make(ActionOutputs, 0, 1)
+					} else {
+						out.Outputs = ActionOutputs{}
+					}
+				} else {
+					out.Outputs = (out.Outputs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v65 ActionOutput
+					easyjsonD7860c2dDecodeGithubComOvhCdsSdk16(in, &v65)
+					out.Outputs = append(out.Outputs, v65)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "condition":
+			out.Condition = string(in.String())
+		case "keep_on_failure":
+			out.KeepOnFailure = int(in.Int())
 		case "step_name":
 			out.StepName = string(in.String())
 		case "optional":
 			out.Optional = bool(in.Bool())
 		case "always_executed":
 			out.AlwaysExecuted = bool(in.Bool())
+		case "continue_on_error":
+			out.ContinueOnError = bool(in.Bool())
 		case "requirements":
 			if in.IsNull() {
 				in.Skip()
@@ -2557,11 +2598,11 @@ func easyjsonD7860c2dDecodeGithubComOvhCdsSdk14(in *jlexer.Lexer, out *Action) {
 					out.Requirements = (out.Requirements)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v65 Requirement
+					var v66 Requirement
 					if data := in.Raw(); in.Ok() {
-						in.AddError((v65).UnmarshalJSON(data))
+						in.AddError((v66).UnmarshalJSON(data))
 					}
-					out.Requirements = append(out.Requirements, v65)
+					out.Requirements = append(out.Requirements, v66)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2582,9 +2623,9 @@ func easyjsonD7860c2dDecodeGithubComOvhCdsSdk14(in *jlexer.Lexer, out *Action) {
 					out.Parameters = (out.Parameters)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v66 Parameter
-					easyjsonD7860c2dDecodeGithubComOvhCdsSdk2(in, &v66)
-					out.Parameters = append(out.Parameters, v66)
+					var v67 Parameter
+					easyjsonD7860c2dDecodeGithubComOvhCdsSdk2(in, &v67)
+					out.Parameters = append(out.Parameters, v67)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2605,9 +2646,9 @@ func easyjsonD7860c2dDecodeGithubComOvhCdsSdk14(in *jlexer.Lexer, out *Action) {
 					out.Actions = (out.Actions)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v67 Action
-					easyjsonD7860c2dDecodeGithubComOvhCdsSdk14(in, &v67)
-					out.Actions = append(out.Actions, v67)
+					var v68 Action
+					easyjsonD7860c2dDecodeGithubComOvhCdsSdk14(in, &v68)
+					out.Actions = append(out.Actions, v68)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2630,7 +2671,7 @@ func easyjsonD7860c2dDecodeGithubComOvhCdsSdk14(in *jlexer.Lexer, out *Action) {
 				if out.FirstAudit == nil {
 					out.FirstAudit = new(AuditAction)
 				}
-				easyjsonD7860c2dDecodeGithubComOvhCdsSdk16(in, out.FirstAudit)
+				easyjsonD7860c2dDecodeGithubComOvhCdsSdk17(in, out.FirstAudit)
 			}
 		case "last_audit":
 			if in.IsNull() {
@@ -2640,7 +2681,7 @@ func easyjsonD7860c2dDecodeGithubComOvhCdsSdk14(in *jlexer.Lexer, out *Action) {
 				if out.LastAudit == nil {
 					out.LastAudit = new(AuditAction)
 				}
-				easyjsonD7860c2dDecodeGithubComOvhCdsSdk16(in, out.LastAudit)
+				easyjsonD7860c2dDecodeGithubComOvhCdsSdk17(in, out.LastAudit)
 			}
 		case "editable":
 			out.Editable = bool(in.Bool())
@@ -2728,6 +2769,45 @@ func easyjsonD7860c2dEncodeGithubComOvhCdsSdk14(out *jwriter.Writer, in Action)
 		}
 		out.Bool(bool(in.Deprecated))
 	}
+	if len(in.Outputs) != 0 {
+		const prefix string = ",\"outputs\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v69, v70 := range in.Outputs {
+				if v69 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonD7860c2dEncodeGithubComOvhCdsSdk16(out, v70)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Condition != "" {
+		const prefix string = ",\"condition\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Condition))
+	}
+	if in.KeepOnFailure != 0 {
+		const prefix string = ",\"keep_on_failure\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.KeepOnFailure))
+	}
 	if in.StepName != "" {
 		const prefix string = ",\"step_name\":"
 		if first {
@@ -2758,6 +2838,16 @@ func easyjsonD7860c2dEncodeGithubComOvhCdsSdk14(out *jwriter.Writer, in Action)
 		}
 		out.Bool(bool(in.AlwaysExecuted))
 	}
+	if in.ContinueOnError {
+		const prefix string = ",\"continue_on_error\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.ContinueOnError))
+	}
 	{
 		const prefix string = ",\"requirements\":"
 		if first {
@@ -2770,11 +2860,11 @@ func easyjsonD7860c2dEncodeGithubComOvhCdsSdk14(out *jwriter.Writer, in Action)
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v68, v69 := range in.Requirements {
-				if v68 > 0 {
+			for v71, v72 := range in.Requirements {
+				if v71 > 0 {
 					out.RawByte(',')
 				}
-				out.Raw((v69).MarshalJSON())
+				out.Raw((v72).MarshalJSON())
 			}
 			out.RawByte(']')
 		}
@@ -2791,11 +2881,11 @@ func easyjsonD7860c2dEncodeGithubComOvhCdsSdk14(out *jwriter.Writer, in Action)
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v70, v71 := range in.Parameters {
-				if v70 > 0 {
+			for v73, v74 := range in.Parameters {
+				if v73 > 0 {
 					out.RawByte(',')
 				}
-				easyjsonD7860c2dEncodeGithubComOvhCdsSdk2(out, v71)
+				easyjsonD7860c2dEncodeGithubComOvhCdsSdk2(out, v74)
 			}
 			out.RawByte(']')
 		}
@@ -2810,11 +2900,11 @@ func easyjsonD7860c2dEncodeGithubComOvhCdsSdk14(out *jwriter.Writer, in Action)
 		}
 		{
 			out.RawByte('[')
-			for v72, v73 := range in.Actions {
-				if v72 > 0 {
+			for v75, v76 := range in.Actions {
+				if v75 > 0 {
 					out.RawByte(',')
 				}
-				easyjsonD7860c2dEncodeGithubComOvhCdsSdk14(out, v73)
+				easyjsonD7860c2dEncodeGithubComOvhCdsSdk14(out, v76)
 			}
 			out.RawByte(']')
 		}
@@ -2837,7 +2927,7 @@ func easyjsonD7860c2dEncodeGithubComOvhCdsSdk14(out *jwriter.Writer, in Action)
 		} else {
 			out.RawString(prefix)
 		}
-		easyjsonD7860c2dEncodeGithubComOvhCdsSdk16(out, *in.FirstAudit)
+		easyjsonD7860c2dEncodeGithubComOvhCdsSdk17(out, *in.FirstAudit)
 	}
 	if in.LastAudit != nil {
 		const prefix string = ",\"last_audit\":"
@@ -2847,7 +2937,7 @@ func easyjsonD7860c2dEncodeGithubComOvhCdsSdk14(out *jwriter.Writer, in Action)
 		} else {
 			out.RawString(prefix)
 		}
-		easyjsonD7860c2dEncodeGithubComOvhCdsSdk16(out, *in.LastAudit)
+		easyjsonD7860c2dEncodeGithubComOvhCdsSdk17(out, *in.LastAudit)
 	}
 	if in.Editable {
 		const prefix string = ",\"editable\":"
@@ -2861,7 +2951,7 @@ func easyjsonD7860c2dEncodeGithubComOvhCdsSdk14(out *jwriter.Writer, in Action)
 	}
 	out.RawByte('}')
 }
-func easyjsonD7860c2dDecodeGithubComOvhCdsSdk16(in *jlexer.Lexer, out *AuditAction) {
+func easyjsonD7860c2dDecodeGithubComOvhCdsSdk17(in *jlexer.Lexer, out *AuditAction) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2908,7 +2998,7 @@ func easyjsonD7860c2dDecodeGithubComOvhCdsSdk16(in *jlexer.Lexer, out *AuditActi
 		in.Consumed()
 	}
 }
-func easyjsonD7860c2dEncodeGithubComOvhCdsSdk16(out *jwriter.Writer, in AuditAction) {
+func easyjsonD7860c2dEncodeGithubComOvhCdsSdk17(out *jwriter.Writer, in AuditAction) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -2994,6 +3084,89 @@ func easyjsonD7860c2dEncodeGithubComOvhCdsSdk16(out *jwriter.Writer, in AuditAct
 	}
 	out.RawByte('}')
 }
+func easyjsonD7860c2dDecodeGithubComOvhCdsSdk16(in *jlexer.Lexer, out *ActionOutput) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "type":
+			out.Type = string(in.String())
+		case "description":
+			out.Description = string(in.String())
+		case "value":
+			out.Value = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD7860c2dEncodeGithubComOvhCdsSdk16(out *jwriter.Writer, in ActionOutput) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"type\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Type))
+	}
+	if in.Description != "" {
+		const prefix string = ",\"description\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Description))
+	}
+	{
+		const prefix string = ",\"value\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Value))
+	}
+	out.RawByte('}')
+}
 func easyjsonD7860c2dDecodeGithubComOvhCdsSdk13(in *jlexer.Lexer, out *StepStatus) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
@@ -15,6 +15,9 @@ export class Action {
     actions: Array<Action>;
     optional: boolean;
     always_executed: boolean;
+    continue_on_error: boolean;
+    condition: string;
+    keep_on_failure: number;
     enabled: boolean;
     deprecated: boolean;
     group: Group;
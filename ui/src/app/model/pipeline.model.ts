@@ -15,6 +15,7 @@ export class PipelineStatus {
     static NEVER_BUILT = 'Never Built';
     static STOPPED = 'Stopped';
     static PENDING = 'Pending';
+    static BLOCKED_BY_FREEZE = 'BlockedByFreeze';
 
     static neverRun(status: string) {
         return status === this.SKIPPED || status === this.NEVER_BUILT || status === this.SKIPPED || status === this.DISABLED;
@@ -25,7 +26,7 @@ export class PipelineStatus {
     }
 
     static isDone(status: string) {
-        return status === this.SUCCESS || status === this.STOPPED || status === this.FAIL;
+        return status === this.SUCCESS || status === this.STOPPED || status === this.FAIL || status === this.BLOCKED_BY_FREEZE;
     }
 }
 
@@ -5,6 +5,7 @@ import { Store } from '@ngxs/store';
 import { ProjectState, ProjectStateModel } from 'app/store/project.state';
 import { Subscription } from 'rxjs';
 import { filter, first } from 'rxjs/operators';
+import { Parameter } from '../../../../model/parameter.model';
 import { PipelineStatus } from '../../../../model/pipeline.model';
 import { Project } from '../../../../model/project.model';
 import { WNode, Workflow } from '../../../../model/workflow.model';
@@ -139,6 +140,14 @@ export class WorkflowNodeRunComponent {
             'node', this.nodeRun.id], navExtras);
     }
 
+    getOutputs(): Array<Parameter> {
+        if (!this.nodeRun || !this.nodeRun.build_parameters) {
+            return [];
+        }
+        // Job outputs are exported as build parameters named cds.output.<job name>.<output name>
+        return this.nodeRun.build_parameters.filter(p => p.name.startsWith('cds.output.'));
+    }
+
     updateTitle() {
         if (!this.workflowRun || !Array.isArray(this.workflowRun.tags)) {
             return;